@@ -1,5 +1,7 @@
 package appos
 
+import "sync/atomic"
+
 // AppOS holds properties and methods for determining the OS the app is running
 // on.
 type AppOS struct {
@@ -31,3 +33,28 @@ func (os *AppOS) IsMobile() bool {
 func (os *AppOS) IsDarwin() bool {
 	return os.isDarwin
 }
+
+// meteredConnectionHook reports whether the active network connection is
+// metered. Only set on platforms that can query it.
+var meteredConnectionHook atomic.Value // func() bool
+
+// SetMeteredConnectionHook sets the function reporting whether the active
+// network connection of the device is metered, e.g. cellular data. It is set
+// by the platform specific code that can query the connectivity of the OS.
+func SetMeteredConnectionHook(hook func() bool) {
+	meteredConnectionHook.Store(hook)
+}
+
+// CanDetectMeteredConnection returns true if the platform reports whether
+// the active network connection is metered.
+func (os *AppOS) CanDetectMeteredConnection() bool {
+	hook, _ := meteredConnectionHook.Load().(func() bool)
+	return hook != nil
+}
+
+// IsMeteredConnection returns true if the active network connection is
+// metered. It returns false if the platform can't tell.
+func (os *AppOS) IsMeteredConnection() bool {
+	hook, _ := meteredConnectionHook.Load().(func() bool)
+	return hook != nil && hook()
+}
@@ -35,6 +35,8 @@ type config struct {
 	Quiet            bool   `short:"q" long:"quiet" description:"Easy way to set debuglevel to error"`
 	SpendUnconfirmed bool   `long:"spendunconfirmed" description:"Allow the assetsManager to use transactions that have not been confirmed"`
 	Profile          int    `long:"profile" description:"Runs local web server for profiling"`
+	MetricsListen    string `long:"metricslisten" description:"Serve sync and app metrics in the Prometheus text format at /metrics on this address, e.g. 127.0.0.1:9465. Disabled by default."`
+	MetricsBalances  bool   `long:"metricsbalances" description:"Include the wallet balances in the metrics"`
 	DEXTestAddr      string `long:"dextestaddr" description:"If using the dextest network, set an address for the dex harness to be used as a persistant peer for all new wallets."`
 
 	net libutils.NetworkType
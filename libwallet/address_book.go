@@ -0,0 +1,352 @@
+package libwallet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// AddressBookFormat is the file format of an exported address book.
+type AddressBookFormat string
+
+const (
+	AddressBookJSON AddressBookFormat = "json"
+	AddressBookCSV  AddressBookFormat = "csv"
+)
+
+// addressBookCSVHeader is the first row of an address book exported as CSV.
+var addressBookCSVHeader = []string{"asset", "name", "address", "note", "last_used"}
+
+// Contact is a labeled address saved in the address book. Contacts are shared
+// by all the wallets of the same asset type.
+type Contact struct {
+	ID        int             `storm:"id,increment" json:"id"`
+	AssetType utils.AssetType `storm:"index" json:"asset"`
+	Name      string          `json:"name"`
+	Address   string          `storm:"index" json:"address"`
+	Note      string          `json:"note,omitempty"`
+	// LastUsed is the unix timestamp of the last transaction sent to the
+	// contact, it is zero if the contact was never used.
+	LastUsed int64 `json:"last_used,omitempty"`
+}
+
+// exportedContact is a contact as written to an exported address book, without
+// its database ID.
+type exportedContact struct {
+	AssetType utils.AssetType `json:"asset"`
+	Name      string          `json:"name"`
+	Address   string          `json:"address"`
+	Note      string          `json:"note,omitempty"`
+	LastUsed  int64           `json:"last_used,omitempty"`
+}
+
+// IsAddressValid returns true if the address is valid for the asset type on
+// the current network. The address is checked by a wallet of the asset type
+// if there is one.
+func (mgr *AssetsManager) IsAddressValid(assetType utils.AssetType, address string) bool {
+	if wallets := mgr.AssetWallets(assetType); len(wallets) > 0 {
+		return wallets[0].IsAddressValid(address)
+	}
+
+	driver, ok := assetDrivers[assetType]
+	if !ok {
+		return false
+	}
+	params, err := utils.GetChainParams(assetType, mgr.NetType())
+	if err != nil {
+		return false
+	}
+	return driver.ValidateAddress(address, params) == nil
+}
+
+func (mgr *AssetsManager) validateContact(contact *Contact) error {
+	contact.Name = strings.TrimSpace(contact.Name)
+	contact.Address = strings.TrimSpace(contact.Address)
+	contact.Note = strings.TrimSpace(contact.Note)
+	if contact.Name == "" {
+		return errors.New(utils.ErrInvalid)
+	}
+	if !mgr.IsAddressValid(contact.AssetType, contact.Address) {
+		return errors.New(utils.ErrInvalidAddress)
+	}
+	return nil
+}
+
+// AddContact saves a new contact to the address book. An address can only be
+// saved once per asset type.
+func (mgr *AssetsManager) AddContact(assetType utils.AssetType, name, address, note string) (*Contact, error) {
+	contact := &Contact{
+		AssetType: assetType,
+		Name:      name,
+		Address:   address,
+		Note:      note,
+	}
+	if err := mgr.validateContact(contact); err != nil {
+		return nil, err
+	}
+
+	if mgr.ContactForAddress(assetType, contact.Address) != nil {
+		return nil, errors.New(utils.ErrExist)
+	}
+
+	if err := mgr.params.DB.Save(contact); err != nil {
+		return nil, errors.Errorf("error saving contact: %v", err)
+	}
+	return contact, nil
+}
+
+// UpdateContact saves the changes made to a contact of the address book.
+func (mgr *AssetsManager) UpdateContact(contact *Contact) error {
+	if err := mgr.validateContact(contact); err != nil {
+		return err
+	}
+
+	if existing := mgr.ContactForAddress(contact.AssetType, contact.Address); existing != nil && existing.ID != contact.ID {
+		return errors.New(utils.ErrExist)
+	}
+
+	if err := mgr.params.DB.Update(contact); err != nil {
+		if err == storm.ErrNotFound {
+			return errors.New(utils.ErrNotExist)
+		}
+		return errors.Errorf("error updating contact: %v", err)
+	}
+	return nil
+}
+
+// DeleteContact removes a contact from the address book.
+func (mgr *AssetsManager) DeleteContact(id int) error {
+	err := mgr.params.DB.DeleteStruct(&Contact{ID: id})
+	if err == storm.ErrNotFound {
+		return errors.New(utils.ErrNotExist)
+	}
+	return err
+}
+
+// Contacts returns the contacts saved for the asset type, or all the contacts
+// if no asset type is provided. The most recently used contacts come first.
+func (mgr *AssetsManager) Contacts(assetTypes ...utils.AssetType) ([]*Contact, error) {
+	var contacts []*Contact
+	query := mgr.params.DB.Select()
+	if len(assetTypes) > 0 {
+		query = mgr.params.DB.Select(q.In("AssetType", assetTypes))
+	}
+	if err := query.Find(&contacts); err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	sort.SliceStable(contacts, func(i, j int) bool {
+		if contacts[i].LastUsed != contacts[j].LastUsed {
+			return contacts[i].LastUsed > contacts[j].LastUsed
+		}
+		return strings.ToLower(contacts[i].Name) < strings.ToLower(contacts[j].Name)
+	})
+	return contacts, nil
+}
+
+// SearchContacts returns the contacts of the asset type that match the query,
+// best matches first. A contact matches if its name contains the letters of
+// the query in order, or if its address or note contains the query.
+func (mgr *AssetsManager) SearchContacts(assetType utils.AssetType, query string) ([]*Contact, error) {
+	contacts, err := mgr.Contacts(assetType)
+	query = strings.ToLower(strings.TrimSpace(query))
+	if err != nil || query == "" {
+		return contacts, err
+	}
+
+	scores := make(map[int]int, len(contacts))
+	matches := contacts[:0]
+	for _, contact := range contacts {
+		if score := contactMatchScore(contact, query); score > 0 {
+			scores[contact.ID] = score
+			matches = append(matches, contact)
+		}
+	}
+
+	// Contacts are already sorted by last use, which breaks ties.
+	sort.SliceStable(matches, func(i, j int) bool {
+		return scores[matches[i].ID] > scores[matches[j].ID]
+	})
+	return matches, nil
+}
+
+// contactMatchScore ranks how well a contact matches the lower case query,
+// zero means that it does not match.
+func contactMatchScore(contact *Contact, query string) int {
+	name := strings.ToLower(contact.Name)
+	switch {
+	case strings.HasPrefix(name, query):
+		return 4
+	case strings.Contains(name, query):
+		return 3
+	case strings.Contains(strings.ToLower(contact.Address), query),
+		strings.Contains(strings.ToLower(contact.Note), query):
+		return 2
+	}
+
+	// Fuzzy match the letters of the query in order, e.g. "jdo" matches
+	// "John Doe".
+	next := 0
+	for _, r := range name {
+		if next < len(query) && rune(query[next]) == r {
+			next++
+		}
+	}
+	if next == len(query) {
+		return 1
+	}
+	return 0
+}
+
+// ContactForAddress returns the contact saved with the address, or nil if the
+// address is not in the address book.
+func (mgr *AssetsManager) ContactForAddress(assetType utils.AssetType, address string) *Contact {
+	var contact Contact
+	query := mgr.params.DB.Select(q.Eq("AssetType", assetType), q.Eq("Address", strings.TrimSpace(address)))
+	if err := query.First(&contact); err != nil {
+		if err != storm.ErrNotFound {
+			log.Errorf("Error reading contact: %v", err)
+		}
+		return nil
+	}
+	return &contact
+}
+
+// MarkContactUsed records that a transaction was sent to the address, if it
+// belongs to a contact.
+func (mgr *AssetsManager) MarkContactUsed(assetType utils.AssetType, address string) {
+	contact := mgr.ContactForAddress(assetType, address)
+	if contact == nil {
+		return
+	}
+
+	if err := mgr.params.DB.UpdateField(contact, "LastUsed", time.Now().Unix()); err != nil {
+		log.Errorf("Error updating contact: %v", err)
+	}
+}
+
+// ExportContacts writes every contact of the address book in the format.
+func (mgr *AssetsManager) ExportContacts(w io.Writer, format AddressBookFormat) error {
+	contacts, err := mgr.Contacts()
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case AddressBookJSON:
+		exported := make([]exportedContact, len(contacts))
+		for i, contact := range contacts {
+			exported[i] = exportedContact{
+				AssetType: contact.AssetType,
+				Name:      contact.Name,
+				Address:   contact.Address,
+				Note:      contact.Note,
+				LastUsed:  contact.LastUsed,
+			}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(exported)
+
+	case AddressBookCSV:
+		writer := csv.NewWriter(w)
+		if err = writer.Write(addressBookCSVHeader); err != nil {
+			return err
+		}
+		for _, contact := range contacts {
+			err = writer.Write([]string{
+				string(contact.AssetType),
+				contact.Name,
+				contact.Address,
+				contact.Note,
+				strconv.FormatInt(contact.LastUsed, 10),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+
+	return fmt.Errorf("unsupported address book format: %s", format)
+}
+
+// ImportContacts reads contacts exported by ExportContacts and saves them to
+// the address book. Nothing is saved unless every contact is valid, contacts
+// whose address is already saved are skipped. The number of contacts added is
+// returned.
+func (mgr *AssetsManager) ImportContacts(r io.Reader, format AddressBookFormat) (int, error) {
+	var contacts []*Contact
+	switch format {
+	case AddressBookJSON:
+		if err := json.NewDecoder(r).Decode(&contacts); err != nil {
+			return 0, fmt.Errorf("invalid address book: %v", err)
+		}
+
+	case AddressBookCSV:
+		records, err := csv.NewReader(r).ReadAll()
+		if err != nil {
+			return 0, fmt.Errorf("invalid address book: %v", err)
+		}
+		for i, record := range records {
+			if i == 0 && strings.EqualFold(record[0], addressBookCSVHeader[0]) {
+				continue
+			}
+			if len(record) < 3 {
+				return 0, fmt.Errorf("invalid address book: line %d has %d fields", i+1, len(record))
+			}
+
+			contact := &Contact{
+				AssetType: utils.AssetType(strings.ToUpper(strings.TrimSpace(record[0]))),
+				Name:      record[1],
+				Address:   record[2],
+			}
+			if len(record) > 3 {
+				contact.Note = record[3]
+			}
+			if len(record) > 4 && record[4] != "" {
+				if contact.LastUsed, err = strconv.ParseInt(record[4], 10, 64); err != nil {
+					return 0, fmt.Errorf("invalid address book: line %d: %v", i+1, err)
+				}
+			}
+			contacts = append(contacts, contact)
+		}
+
+	default:
+		return 0, fmt.Errorf("unsupported address book format: %s", format)
+	}
+
+	for _, contact := range contacts {
+		if err := mgr.validateContact(contact); err != nil {
+			return 0, fmt.Errorf("invalid contact %q: %w", contact.Name, err)
+		}
+	}
+
+	var added int
+	seen := make(map[string]bool, len(contacts))
+	for _, contact := range contacts {
+		key := string(contact.AssetType) + ":" + contact.Address
+		if seen[key] || mgr.ContactForAddress(contact.AssetType, contact.Address) != nil {
+			continue
+		}
+		seen[key] = true
+
+		contact.ID = 0
+		if err := mgr.params.DB.Save(contact); err != nil {
+			return added, errors.Errorf("error saving contact: %v", err)
+		}
+		added++
+	}
+	return added, nil
+}
@@ -0,0 +1,196 @@
+package libwallet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/ltcsuite/ltcd/ltcutil"
+)
+
+// testAddress returns a P2PKH address of the asset type on the network of the
+// assets manager, derived from the provided byte.
+func testAddress(t *testing.T, mgr *AssetsManager, assetType utils.AssetType, b byte) string {
+	t.Helper()
+	params, err := utils.GetChainParams(assetType, mgr.NetType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := bytes.Repeat([]byte{b}, 20)
+
+	var addr interface{ String() string }
+	switch assetType {
+	case utils.DCRWalletAsset:
+		addr, err = stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(hash, params.DCR)
+	case utils.BTCWalletAsset:
+		addr, err = btcutil.NewAddressPubKeyHash(hash, params.BTC)
+	case utils.LTCWalletAsset:
+		addr, err = ltcutil.NewAddressPubKeyHash(hash, params.LTC)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr.String()
+}
+
+func TestImportContacts(t *testing.T) {
+	mgr := newTestAssetsManager(t)
+	dcrAddr := testAddress(t, mgr, utils.DCRWalletAsset, 1)
+	btcAddr := testAddress(t, mgr, utils.BTCWalletAsset, 2)
+	ltcAddr := testAddress(t, mgr, utils.LTCWalletAsset, 3)
+	savedAddr := testAddress(t, mgr, utils.BTCWalletAsset, 4)
+	if _, err := mgr.AddContact(utils.BTCWalletAsset, "Saved", savedAddr, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		format AddressBookFormat
+		data   string
+		added  int
+		err    string
+	}{{
+		name:   "csv with header",
+		format: AddressBookCSV,
+		data:   "asset,name,address,note,last_used\nDCR,Alice,{dcr}, rent ,1700000000\nbtc, Bob ,{btc},,\n",
+		added:  2,
+	}, {
+		name:   "csv without header or optional fields",
+		format: AddressBookCSV,
+		data:   "LTC,Carol,{ltc}\n",
+		added:  1,
+	}, {
+		name:   "csv with saved and repeated addresses",
+		format: AddressBookCSV,
+		data:   "BTC,Saved again,{saved}\nLTC,Carol,{ltc}\nLTC,Carol again,{ltc}\n",
+		added:  1,
+	}, {
+		name:   "csv with an invalid address",
+		format: AddressBookCSV,
+		data:   "DCR,Alice,{dcr}\nBTC,Bob,{dcr}\n",
+		err:    utils.ErrInvalidAddress,
+	}, {
+		name:   "csv without a name",
+		format: AddressBookCSV,
+		data:   "DCR, ,{dcr}\n",
+		err:    utils.ErrInvalid,
+	}, {
+		name:   "csv with missing fields",
+		format: AddressBookCSV,
+		data:   "DCR,Alice\n",
+	}, {
+		name:   "csv with an invalid timestamp",
+		format: AddressBookCSV,
+		data:   "DCR,Alice,{dcr},,yesterday\n",
+	}, {
+		name:   "json with an address of another asset",
+		format: AddressBookJSON,
+		data:   `[{"asset":"LTC","name":"Carol","address":"{dcr}"}]`,
+		err:    utils.ErrInvalidAddress,
+	}, {
+		name:   "invalid json",
+		format: AddressBookJSON,
+		data:   `{"asset":"DCR"`,
+	}, {
+		name:   "unsupported format",
+		format: "xml",
+		data:   "<contacts/>",
+	}, {
+		name:   "json",
+		format: AddressBookJSON,
+		data: `[{"asset":"DCR","name":"Alice","address":"{dcr}","note":"rent","last_used":1700000000},` +
+			`{"asset":"BTC","name":"Bob","address":"{btc}"}]`,
+		added: 2,
+	}}
+
+	addresses := strings.NewReplacer("{dcr}", dcrAddr, "{btc}", btcAddr, "{ltc}", ltcAddr, "{saved}", savedAddr)
+	for _, tc := range tests {
+		// Contacts imported by a previous case are removed.
+		contacts, err := mgr.Contacts()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, contact := range contacts {
+			if contact.Address != savedAddr {
+				if err = mgr.DeleteContact(contact.ID); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+
+		added, err := mgr.ImportContacts(strings.NewReader(addresses.Replace(tc.data)), tc.format)
+		wantErr := tc.added == 0
+		switch {
+		case wantErr && err == nil:
+			t.Errorf("%s: expected an error", tc.name)
+			continue
+		case tc.err != "" && !strings.Contains(err.Error(), tc.err):
+			t.Errorf("%s: got error %v, want %v", tc.name, err, tc.err)
+			continue
+		case !wantErr && err != nil:
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if added != tc.added {
+			t.Errorf("%s: got %d contacts added, want %d", tc.name, added, tc.added)
+		}
+
+		// Nothing is saved from an invalid address book.
+		contacts, err = mgr.Contacts()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(contacts) != tc.added+1 {
+			t.Errorf("%s: got %d contacts, want %d", tc.name, len(contacts), tc.added+1)
+		}
+	}
+
+	// The contacts of the last case are saved with every field.
+	alice := mgr.ContactForAddress(utils.DCRWalletAsset, dcrAddr)
+	if alice == nil || alice.Name != "Alice" || alice.Note != "rent" || alice.LastUsed != 1700000000 {
+		t.Errorf("got imported contact %+v", alice)
+	}
+}
+
+func TestExportContacts(t *testing.T) {
+	mgr := newTestAssetsManager(t)
+	if _, err := mgr.AddContact(utils.DCRWalletAsset, "Alice", testAddress(t, mgr, utils.DCRWalletAsset, 1), "rent, monthly"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mgr.AddContact(utils.LTCWalletAsset, "Carol", testAddress(t, mgr, utils.LTCWalletAsset, 3), ""); err != nil {
+		t.Fatal(err)
+	}
+	want, err := mgr.Contacts()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []AddressBookFormat{AddressBookCSV, AddressBookJSON} {
+		var buf bytes.Buffer
+		if err := mgr.ExportContacts(&buf, format); err != nil {
+			t.Fatalf("%s: unexpected error: %v", format, err)
+		}
+
+		other := newTestAssetsManager(t)
+		if added, err := other.ImportContacts(&buf, format); err != nil || added != len(want) {
+			t.Fatalf("%s: got %d contacts imported (%v), want %d", format, added, err, len(want))
+		}
+		got, err := other.Contacts()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range want {
+			got[i].ID = want[i].ID
+			if *got[i] != *want[i] {
+				t.Errorf("%s: got contact %+v, want %+v", format, got[i], want[i])
+			}
+		}
+	}
+
+	if err := mgr.ExportContacts(new(bytes.Buffer), "xml"); err == nil {
+		t.Error("exported the address book in an unsupported format")
+	}
+}
@@ -0,0 +1,70 @@
+package libwallet
+
+import (
+	"fmt"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// AssetDriver creates and loads the wallets of an asset for the assets
+// manager. Drivers are registered with RegisterAssetDriver from an init
+// function, the asset must be registered with utils.RegisterAsset first.
+type AssetDriver struct {
+	Type utils.AssetType
+
+	LoadExisting          func(w *sharedW.Wallet, params *sharedW.InitParams) (sharedW.Asset, error)
+	CreateWallet          func(pass *sharedW.AuthInfo, params *sharedW.InitParams) (sharedW.Asset, error)
+	RestoreWallet         func(seedMnemonic string, pass *sharedW.AuthInfo, params *sharedW.InitParams) (sharedW.Asset, error)
+	CreateWatchOnlyWallet func(walletName, extendedPublicKey string, params *sharedW.InitParams) (sharedW.Asset, error)
+
+	// CreateMultisigWallet creates an m-of-n multisig wallet and
+	// MultisigCosignerKey returns the cosigner key of an opened wallet. They
+	// are nil if the asset has no multisig wallets.
+	CreateMultisigWallet func(walletName string, config *sharedW.MultisigConfig, params *sharedW.InitParams) (sharedW.Asset, error)
+	MultisigCosignerKey  func(wallet sharedW.Asset, privatePassphrase, seedPassphrase string) (string, error)
+
+	// WalletUsesSeed returns true if the opened wallet was created or
+	// restored from the seed.
+	WalletUsesSeed func(wallet sharedW.Asset, seedMnemonic, seedPassphrase string, wordSeedType sharedW.WordSeedType) (bool, error)
+	// WalletHasXPub returns true if the opened wallet has an account with
+	// the xpub.
+	WalletHasXPub func(wallet sharedW.Asset, xpub string) (bool, error)
+	// ValidateAddress returns an error if the address isn't valid for the
+	// chain parameters of the asset, it checks addresses when there is no
+	// wallet of the asset to do it.
+	ValidateAddress func(address string, params *utils.ChainsParams) error
+
+	// ExplorerTxURLs are the URLs of the block explorer transaction pages
+	// per network, the transaction hash is appended to them.
+	ExplorerTxURLs map[utils.NetworkType]string
+	// HDPrefixes are the HD paths of the accounts per network, the account
+	// number is appended to them.
+	HDPrefixes map[utils.NetworkType]string
+}
+
+var assetDrivers = make(map[utils.AssetType]*AssetDriver)
+
+// RegisterAssetDriver adds the driver of an asset to the assets manager. It
+// panics if the asset isn't registered or already has a driver.
+func RegisterAssetDriver(driver *AssetDriver) {
+	if utils.RegisteredAsset(driver.Type) == nil {
+		panic(fmt.Sprintf("asset %s isn't registered", driver.Type))
+	}
+	if _, ok := assetDrivers[driver.Type]; ok {
+		panic(fmt.Sprintf("asset %s already has a driver", driver.Type))
+	}
+	assetDrivers[driver.Type] = driver
+}
+
+// driverAssetTypes returns the types of the assets with a driver, in the order
+// the assets were registered.
+func driverAssetTypes() []utils.AssetType {
+	var assetTypes []utils.AssetType
+	for _, assetType := range utils.RegisteredAssetTypes() {
+		if _, ok := assetDrivers[assetType]; ok {
+			assetTypes = append(assetTypes, assetType)
+		}
+	}
+	return assetTypes
+}
@@ -1,16 +1,21 @@
 package btc
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"decred.org/dcrwallet/v4/errors"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/wallet"
+	"github.com/btcsuite/btcwallet/walletdb"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 )
@@ -52,8 +57,30 @@ func (asset *Asset) GetAccountsRaw() (*sharedW.Accounts, error) {
 		return nil, err
 	}
 
-	accounts := make([]*sharedW.Account, len(resp.Accounts))
-	for i, a := range resp.Accounts {
+	walletAccounts := resp.Accounts
+	if !asset.IsWatchingOnlyWallet() {
+		for _, s := range keyScopeAccounts.Scopes {
+			scope := waddrmgr.KeyScope(s.Scope)
+			scopeResp, err := asset.Internal().BTC.Accounts(scope)
+			if err != nil {
+				if waddrmgr.IsError(err, waddrmgr.ErrScopeNotFound) {
+					continue
+				}
+				return nil, err
+			}
+
+			for _, a := range scopeResp.Accounts {
+				if !asset.isScopeAccountListed(s, &a.AccountProperties) {
+					continue
+				}
+				a.AccountNumber = uint32(scopedAccountNumber(scope, a.AccountNumber))
+				walletAccounts = append(walletAccounts, a)
+			}
+		}
+	}
+
+	accounts := make([]*sharedW.Account, len(walletAccounts))
+	for i, a := range walletAccounts {
 		balance, err := asset.GetAccountBalance(int32(a.AccountNumber))
 		if err != nil {
 			return nil, err
@@ -104,7 +131,7 @@ func (asset *Asset) GetAccountBalance(accountNumber int32) (*sharedW.Balance, er
 		return nil, utils.ErrBTCNotInitialized
 	}
 
-	balance, err := asset.Internal().BTC.CalculateAccountBalances(uint32(accountNumber), asset.RequiredConfirmations())
+	balance, err := asset.calculateAccountBalances(accountNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +150,50 @@ func (asset *Asset) GetAccountBalance(accountNumber int32) (*sharedW.Balance, er
 	}, nil
 }
 
+// calculateAccountBalances sums the unspent outputs of the account like
+// btcwallet's CalculateAccountBalances, which only matches the account number
+// and would mix up the balances of accounts that have the same number in
+// different key scopes.
+func (asset *Asset) calculateAccountBalances(accountNumber int32) (wallet.Balances, error) {
+	var bals wallet.Balances
+	confirmations := asset.RequiredConfirmations()
+	w := asset.Internal().BTC
+
+	err := walletdb.View(w.Database(), func(dbtx walletdb.ReadTx) error {
+		addrmgrNs := dbtx.ReadBucket(wAddrMgrBkt)
+		txmgrNs := dbtx.ReadBucket(wTxMgrBkt)
+		syncHeight := w.Manager.SyncedTo().Height
+
+		unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
+		if err != nil {
+			return err
+		}
+
+		for i := range unspent {
+			output := &unspent[i]
+			_, addrs, _, err := txscript.ExtractPkScriptAddrs(output.PkScript, asset.chainParams)
+			if err != nil || len(addrs) == 0 {
+				continue
+			}
+
+			smgr, outputAcct, err := w.Manager.AddrAccount(addrmgrNs, addrs[0])
+			if err != nil || scopedAccountNumber(smgr.Scope(), outputAcct) != accountNumber {
+				continue
+			}
+
+			confs := confirms(output.Height, syncHeight)
+			bals.Total += output.Amount
+			if output.FromCoinBase && confs < int32(asset.chainParams.CoinbaseMaturity) {
+				bals.ImmatureReward += output.Amount
+			} else if confs >= confirmations {
+				bals.Spendable += output.Amount
+			}
+		}
+		return nil
+	})
+	return bals, err
+}
+
 // lockedAmount is the total value of locked outputs, as locked with
 // LockUnspent.
 func (asset *Asset) lockedAmount() (btcutil.Amount, error) {
@@ -144,7 +215,7 @@ func (asset *Asset) SpendableForAccount(account int32) (int64, error) {
 		return -1, utils.ErrBTCNotInitialized
 	}
 
-	bals, err := asset.Internal().BTC.CalculateAccountBalances(uint32(account), asset.RequiredConfirmations())
+	bals, err := asset.calculateAccountBalances(account)
 	if err != nil {
 		return 0, utils.TranslateError(err)
 	}
@@ -179,6 +250,23 @@ func (asset *Asset) UnspentOutputs(account int32) ([]*sharedW.UnspentOutput, err
 	resp := make([]*sharedW.UnspentOutput, 0, len(unspents))
 
 	for _, utxo := range unspents {
+		// Accounts are matched by name, which is only unique within a key
+		// scope.
+		pkScript, err := hex.DecodeString(utxo.ScriptPubKey)
+		if err != nil {
+			continue
+		}
+		if utxoAccount, err := asset.scriptAccount(pkScript); err != nil || utxoAccount != account {
+			continue
+		}
+
+		// btcwallet only flags P2PKH and P2WPKH outputs as spendable, the
+		// P2TR and P2SH-P2WPKH outputs of the wallet's own accounts are
+		// spendable too.
+		if !asset.IsWatchingOnlyWallet() {
+			utxo.Spendable = true
+		}
+
 		// error returned is ignored because the amount value is from upstream
 		// and doesn't require an extra layer of validation.
 		amount, _ := btcutil.NewAmount(utxo.Amount)
@@ -201,10 +289,12 @@ func (asset *Asset) UnspentOutputs(account int32) ([]*sharedW.UnspentOutput, err
 		})
 	}
 
+	asset.ApplyOutputLabels(resp...)
 	return resp, nil
 }
 
-// CreateNewAccount creates a new account with the provided account name.
+// CreateNewAccount creates a new account with the provided account name. See
+// CreateTaprootAccount for accounts with P2TR addresses.
 func (asset *Asset) CreateNewAccount(accountName, privPass string) (int32, error) {
 	err := asset.UnlockWallet(privPass)
 	if err != nil {
@@ -240,7 +330,8 @@ func (asset *Asset) RenameAccount(accountNumber int32, newName string) error {
 		return utils.ErrBTCNotInitialized
 	}
 
-	err := asset.Internal().BTC.RenameAccount(GetScope(), uint32(accountNumber), newName)
+	scope, account := accountScope(accountNumber)
+	err := asset.Internal().BTC.RenameAccount(scope, account, newName)
 	if err != nil {
 		return utils.TranslateError(err)
 	}
@@ -264,7 +355,8 @@ func (asset *Asset) AccountNameRaw(accountNumber uint32) (string, error) {
 		return "", utils.ErrBTCNotInitialized
 	}
 
-	return asset.Internal().BTC.AccountName(GetScope(), accountNumber)
+	scope, account := accountScope(int32(accountNumber))
+	return asset.Internal().BTC.AccountName(scope, account)
 }
 
 // AccountNumber returns the account number for the provided account name.
@@ -274,6 +366,15 @@ func (asset *Asset) AccountNumber(accountName string) (int32, error) {
 	}
 
 	accountNumber, err := asset.Internal().BTC.AccountNumber(GetScope(), accountName)
+	if err != nil && !asset.IsWatchingOnlyWallet() {
+		for _, s := range keyScopeAccounts.Scopes {
+			scope := waddrmgr.KeyScope(s.Scope)
+			scopeAccount, scopeErr := asset.Internal().BTC.AccountNumber(scope, accountName)
+			if scopeErr == nil {
+				return scopedAccountNumber(scope, scopeAccount), nil
+			}
+		}
+	}
 	return int32(accountNumber), utils.TranslateError(err)
 }
 
@@ -283,7 +384,7 @@ func (asset *Asset) HasAccount(accountName string) bool {
 		return false
 	}
 
-	_, err := asset.Internal().BTC.AccountNumber(GetScope(), accountName)
+	_, err := asset.AccountNumber(accountName)
 	return err == nil
 }
 
@@ -296,5 +397,11 @@ func (asset *Asset) HDPathForAccount(accountNumber int32) (string, error) {
 		hdPath = TestnetHDPath
 	}
 
-	return hdPath + strconv.Itoa(int(accountNumber)), nil
+	// Accounts of other key scopes use the purpose of their key scope.
+	scope, account := accountScope(accountNumber)
+	if scope != GetScope() {
+		hdPath = strings.Replace(hdPath, "84'", fmt.Sprintf("%d'", scope.Purpose), 1)
+	}
+
+	return hdPath + strconv.Itoa(int(account)), nil
 }
@@ -67,13 +67,13 @@ func (asset *Asset) AddressInfo(address string) (*AddressInfo, error) {
 	if isMine {
 		addressInfo.IsMine = isMine
 
-		accountNumber, err := asset.Internal().BTC.AccountOfAddress(addr)
+		accountNumber, err := asset.addressAccount(addr)
 		if err != nil {
 			return nil, err
 		}
-		addressInfo.AccountNumber = accountNumber
+		addressInfo.AccountNumber = uint32(accountNumber)
 
-		accountName, err := asset.AccountName(int32(accountNumber))
+		accountName, err := asset.AccountName(accountNumber)
 		if err != nil {
 			return nil, err
 		}
@@ -87,6 +87,15 @@ func (asset *Asset) AddressInfo(address string) (*AddressInfo, error) {
 // asset. If that address has already been used to receive funds, the next
 // chained address is returned.
 func (asset *Asset) CurrentAddress(account int32) (string, error) {
+	if asset.IsMultisig() {
+		addr, _, err := asset.multisigBranchAddress(multisigExternalBranch, false)
+		if err != nil {
+			log.Errorf("CurrentAddress error: %v", err)
+			return "", err
+		}
+		return addr.String(), nil
+	}
+
 	if asset.IsRestored && !asset.ContainsDiscoveredAccounts() {
 		return "", errors.E(utils.ErrAddressDiscoveryNotDone)
 	}
@@ -95,7 +104,8 @@ func (asset *Asset) CurrentAddress(account int32) (string, error) {
 		return "", utils.ErrBTCNotInitialized
 	}
 
-	addr, err := asset.Internal().BTC.CurrentAddress(uint32(account), GetScope())
+	scope, acct := accountScope(account)
+	addr, err := asset.Internal().BTC.CurrentAddress(acct, scope)
 	if err != nil {
 		log.Errorf("CurrentAddress error: %v", err)
 		return "", err
@@ -107,6 +117,15 @@ func (asset *Asset) CurrentAddress(account int32) (string, error) {
 // payment address. If that address has already been used to receive funds,
 // the next chained address is returned.
 func (asset *Asset) NextAddress(account int32) (string, error) {
+	if asset.IsMultisig() {
+		addr, _, err := asset.multisigBranchAddress(multisigExternalBranch, true)
+		if err != nil {
+			log.Errorf("NextAddress error: %v", err)
+			return "", err
+		}
+		return addr.String(), nil
+	}
+
 	if asset.IsRestored && !asset.ContainsDiscoveredAccounts() {
 		return "", errors.E(utils.ErrAddressDiscoveryNotDone)
 	}
@@ -116,7 +135,8 @@ func (asset *Asset) NextAddress(account int32) (string, error) {
 	}
 
 	// NewAddress returns the next external chained address for a wallet.
-	address, err := asset.Internal().BTC.NewAddress(uint32(account), GetScope())
+	scope, acct := accountScope(account)
+	address, err := asset.Internal().BTC.NewAddress(acct, scope)
 	if err != nil {
 		log.Errorf("NewExternalAddress error: %w", err)
 		return "", err
@@ -136,12 +156,12 @@ func (asset *Asset) AccountOfAddress(address string) (string, error) {
 		return "", utils.ErrBTCNotInitialized
 	}
 
-	accountNumber, err := asset.Internal().BTC.AccountOfAddress(addr)
+	accountNumber, err := asset.addressAccount(addr)
 	if err != nil {
 		return "", utils.TranslateError(err)
 	}
 
-	accountName, err := asset.AccountName(int32(accountNumber))
+	accountName, err := asset.AccountName(accountNumber)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,229 @@
+package btc
+
+import (
+	"fmt"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/chain"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// rpcPollingInterval is the interval at which the RPC backend polls bitcoind
+// for new blocks and transactions, bitcoind ZMQ notifications are not used
+// since they are not enabled by default.
+const rpcPollingInterval = 10 * time.Second
+
+// rpcPort returns the default port of the bitcoind RPC server on the network.
+func rpcPort(params *chaincfg.Params) string {
+	switch params.Net {
+	case wire.TestNet3:
+		return "18332"
+	case wire.TestNet: // regtest
+		return "18443"
+	case wire.SimNet:
+		return "18554"
+	default:
+		return "8332"
+	}
+}
+
+// connectRPCBackend connects to the bitcoind node set in the RPC config of the
+// wallet and sets the chain client syncing with it.
+func (asset *Asset) connectRPCBackend() error {
+	if asset.RPCPassLocked() {
+		return utils.ErrRPCPassLocked
+	}
+
+	cfg := asset.RPCConfig()
+	if cfg.CertPath != "" {
+		return utils.ErrRPCCertUnsupported
+	}
+	host, err := cfg.Address(rpcPort(asset.chainParams))
+	if err != nil {
+		return err
+	}
+
+	conn, err := chain.NewBitcoindConn(&chain.BitcoindConfig{
+		ChainParams: asset.chainParams,
+		Host:        host,
+		User:        cfg.User,
+		Pass:        cfg.Pass,
+		PollingConfig: &chain.PollingConfig{
+			BlockPollingInterval: rpcPollingInterval,
+			TxPollingInterval:    rpcPollingInterval,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't connect to bitcoind: %v", err)
+	}
+	if err := conn.Start(); err != nil {
+		conn.Stop()
+		return fmt.Errorf("couldn't connect to bitcoind: %v", err)
+	}
+
+	asset.rpcMu.Lock()
+	asset.rpcConn = conn
+	asset.rpcClient = conn.NewBitcoindClient()
+	asset.rpcMu.Unlock()
+	return nil
+}
+
+// setElectrumBackend sets the chain client syncing with the Electrum server
+// set in the config of the wallet, or with one of the default servers of the
+// network. The client connects to the server when started.
+func (asset *Asset) setElectrumBackend() error {
+	servers, err := asset.ElectrumConfig().Servers(defaultElectrumServers[asset.chainParams.Net])
+	if err != nil {
+		return err
+	}
+
+	asset.rpcMu.Lock()
+	asset.electrumConn = newElectrumClient(asset.chainParams, servers)
+	asset.rpcMu.Unlock()
+	return nil
+}
+
+// disconnectRemoteBackend closes the connection to the bitcoind node or the
+// Electrum server, if any.
+func (asset *Asset) disconnectRemoteBackend() {
+	asset.rpcMu.Lock()
+	conn, electrumConn := asset.rpcConn, asset.electrumConn
+	asset.rpcConn = nil
+	asset.rpcClient = nil
+	asset.electrumConn = nil
+	asset.rpcMu.Unlock()
+
+	if conn != nil {
+		conn.Stop()
+	}
+	if electrumConn != nil {
+		electrumConn.Stop()
+	}
+}
+
+// renewRemoteClient replaces the chain client of the RPC or Electrum backend,
+// neither bitcoind clients nor Electrum clients can be restarted once
+// stopped.
+func (asset *Asset) renewRemoteClient() {
+	asset.rpcMu.Lock()
+	defer asset.rpcMu.Unlock()
+
+	if asset.rpcConn != nil {
+		asset.rpcClient = asset.rpcConn.NewBitcoindClient()
+	}
+	if asset.electrumConn != nil {
+		asset.electrumConn = newElectrumClient(asset.chainParams, asset.electrumConn.servers())
+	}
+}
+
+// remoteChainClient is the chain client of a backend holding the full chain,
+// a bitcoind node or an Electrum server.
+type remoteChainClient interface {
+	chain.Interface
+	GetBlockHeight(*chainhash.Hash) (int32, error)
+}
+
+// remoteClient returns the chain client of the RPC or Electrum backend, nil
+// if the wallet is connected to neither.
+func (asset *Asset) remoteClient() remoteChainClient {
+	asset.rpcMu.RLock()
+	defer asset.rpcMu.RUnlock()
+
+	if asset.rpcClient != nil {
+		return asset.rpcClient
+	}
+	if asset.electrumConn != nil {
+		return asset.electrumConn
+	}
+	return nil
+}
+
+// chainSource returns the chain client the wallet syncs with, nil if neither
+// the neutrino client nor a remote client is set.
+func (asset *Asset) chainSource() chain.Interface {
+	if remoteClient := asset.remoteClient(); remoteClient != nil {
+		return remoteClient
+	}
+	if asset.chainClient != nil {
+		return asset.chainClient
+	}
+	return nil
+}
+
+// neutrinoService returns the neutrino chain service of the SPV backend, nil
+// if the wallet syncs with a bitcoind node or an Electrum server, or if the
+// chain service is not loaded.
+func (asset *Asset) neutrinoService() ExtraNeutrinoChainService {
+	if asset.remoteClient() != nil || asset.chainClient == nil {
+		return nil
+	}
+	chainService, _ := asset.chainClient.CS.(ExtraNeutrinoChainService)
+	return chainService
+}
+
+// bestBlock returns the best block of the chain backend. The RPC and Electrum
+// backends report the best block synced by the wallet since the node or
+// server it syncs with holds the full chain.
+func (asset *Asset) bestBlock() (*sharedW.BlockInfo, error) {
+	if asset.remoteClient() != nil || asset.chainClient == nil {
+		if !asset.WalletOpened() {
+			return nil, utils.ErrBTCNotInitialized
+		}
+		syncedTo := asset.Internal().BTC.Manager.SyncedTo()
+		return &sharedW.BlockInfo{
+			Height:    syncedTo.Height,
+			Timestamp: syncedTo.Timestamp.Unix(),
+		}, nil
+	}
+
+	block, err := asset.chainClient.CS.BestBlock()
+	if err != nil {
+		return nil, err
+	}
+	return &sharedW.BlockInfo{
+		Height:    block.Height,
+		Timestamp: block.Timestamp.Unix(),
+	}, nil
+}
+
+// isChainCurrent returns true if the chain backend considers its view of the
+// network as current. The RPC and Electrum backends also require the wallet
+// to have synced with the node or server.
+func (asset *Asset) isChainCurrent() bool {
+	if remoteClient := asset.remoteClient(); remoteClient != nil {
+		return remoteClient.IsCurrent() && asset.Internal().BTC.ChainSynced()
+	}
+	return asset.chainClient != nil && asset.chainClient.IsCurrent()
+}
+
+// blockHeight returns the height of the block with the given hash.
+func (asset *Asset) blockHeight(hash *chainhash.Hash) (int32, error) {
+	if remoteClient := asset.remoteClient(); remoteClient != nil {
+		return remoteClient.GetBlockHeight(hash)
+	}
+	if asset.chainClient == nil {
+		return -1, errors.New(utils.ErrNotConnected)
+	}
+	return asset.chainClient.GetBlockHeight(hash)
+}
+
+// SetChainBackend sets the backend the wallet syncs with. An active sync is
+// restarted with the new backend.
+func (asset *Asset) SetChainBackend(backend sharedW.ChainBackend, cfg *sharedW.RPCConfig, privatePassphrase string) error {
+	if err := asset.SaveChainBackend(backend, cfg, privatePassphrase); err != nil {
+		return err
+	}
+
+	go func() {
+		err := asset.reloadChainService()
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,182 @@
+package btc
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// testRPCConfig is the config of a bitcoind node nothing listens on.
+var testRPCConfig = &sharedW.RPCConfig{Host: "127.0.0.1:1", User: "user", Pass: "secret"}
+
+// reopenTestWallet shuts the wallet down and loads it again from the database.
+// It returns the wallet with the function shutting it down, like
+// createTestWallet.
+func reopenTestWallet(t *testing.T, asset *Asset, params *sharedW.InitParams, shutdown func()) (*Asset, func()) {
+	t.Helper()
+	shutdown()
+
+	w := new(sharedW.Wallet)
+	if err := params.DB.One("ID", asset.ID, w); err != nil {
+		t.Fatal(err)
+	}
+	w.SetNetType(params.NetType)
+	reopened, err := LoadExisting(w, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shutdown = sync.OnceFunc(reopened.Shutdown)
+	t.Cleanup(shutdown)
+	if err = reopened.OpenWallet(); err != nil {
+		t.Fatal(err)
+	}
+	return reopened.(*Asset), shutdown
+}
+
+func TestRPCBackendConfig(t *testing.T) {
+	asset := newTestWallet(t)
+
+	tests := []struct {
+		name string
+		cfg  *sharedW.RPCConfig
+		pass string
+		err  error
+	}{
+		{"no host", &sharedW.RPCConfig{User: "user"}, testPassphrase, nil},
+		{"certificate", &sharedW.RPCConfig{Host: "127.0.0.1", CertPath: "rpc.cert"}, testPassphrase, utils.ErrRPCCertUnsupported},
+		{"wrong passphrase", testRPCConfig, "wrong", nil},
+	}
+	for _, tc := range tests {
+		err := asset.SaveChainBackend(sharedW.RPCBackend, tc.cfg, tc.pass)
+		if err == nil || (tc.err != nil && !errors.Is(err, tc.err)) {
+			t.Errorf("%s: got error %v, want %v", tc.name, err, tc.err)
+		}
+		if asset.ChainBackend() != sharedW.SPVBackend {
+			t.Errorf("%s: the backend was saved", tc.name)
+		}
+	}
+}
+
+func TestRPCBackendPassword(t *testing.T) {
+	params := newTestParams(t)
+	asset, shutdown := createTestWallet(t, params)
+
+	if err := asset.SaveChainBackend(sharedW.RPCBackend, testRPCConfig, testPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	if asset.RPCPassLocked() || asset.RPCConfig().Pass != testRPCConfig.Pass {
+		t.Fatalf("got password %q, want %q", asset.RPCConfig().Pass, testRPCConfig.Pass)
+	}
+	var encryptedPass []byte
+	if err := asset.ReadUserConfigValue(sharedW.RPCPassConfigKey, &encryptedPass); err != nil || len(encryptedPass) == 0 {
+		t.Fatalf("the password is not saved: %v", err)
+	}
+	if bytes.Contains(encryptedPass, []byte(testRPCConfig.Pass)) {
+		t.Fatal("the password is saved in plain text")
+	}
+
+	// The password is only decrypted once the wallet is unlocked.
+	asset, shutdown = reopenTestWallet(t, asset, params, shutdown)
+	if !asset.RPCPassLocked() || asset.RPCConfig().Pass != "" {
+		t.Fatalf("got password %q before unlocking the wallet", asset.RPCConfig().Pass)
+	}
+	if err := asset.UnlockWallet(testPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	asset.LockWallet()
+	if asset.RPCPassLocked() || asset.RPCConfig().Pass != testRPCConfig.Pass {
+		t.Fatalf("got password %q after unlocking the wallet, want %q", asset.RPCConfig().Pass, testRPCConfig.Pass)
+	}
+
+	// Changing the private passphrase encrypts the password again.
+	const newPassphrase = "new passphrase"
+	if err := asset.ChangePrivatePassphraseForWallet(testPassphrase, newPassphrase, sharedW.PassphraseTypePass); err != nil {
+		t.Fatal(err)
+	}
+	asset, _ = reopenTestWallet(t, asset, params, shutdown)
+	if err := asset.UnlockWallet(newPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	asset.LockWallet()
+	if asset.RPCConfig().Pass != testRPCConfig.Pass {
+		t.Fatalf("got password %q after changing the passphrase, want %q", asset.RPCConfig().Pass, testRPCConfig.Pass)
+	}
+}
+
+func TestRPCBackendWithoutChainService(t *testing.T) {
+	params := newTestParams(t)
+	asset, shutdown := createTestWallet(t, params)
+	if err := asset.SaveChainBackend(sharedW.RPCBackend, testRPCConfig, testPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	asset, _ = reopenTestWallet(t, asset, params, shutdown)
+
+	// Syncing fails until the password is decrypted, without the neutrino
+	// chain service to stop.
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	asset.syncCtx, asset.cancelSync = ctx, cancel
+	if err := asset.startSync(); !errors.Is(err, utils.ErrRPCPassLocked) {
+		t.Fatalf("got error %v syncing with a locked password, want %v", err, utils.ErrRPCPassLocked)
+	}
+	if asset.chainClient != nil && asset.chainClient.CS != nil {
+		t.Fatal("the neutrino chain service was loaded for the RPC backend")
+	}
+
+	asset.bestServerPeerBlockHeight()
+	if peers := asset.ConnectedPeers(); peers > 0 {
+		t.Errorf("got %d connected peers", peers)
+	}
+}
+
+func TestRPCBackendWatchOnlyPassword(t *testing.T) {
+	xpub, err := newTestWallet(t).GetExtendedPubKey(DefaultAccountNum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := newTestParams(t)
+	w, err := CreateWatchOnlyWallet("watching only", xpub, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shutdown := sync.OnceFunc(w.Shutdown)
+	t.Cleanup(shutdown)
+	asset := w.(*Asset)
+
+	if err := asset.SaveChainBackend(sharedW.RPCBackend, testRPCConfig, ""); err != nil {
+		t.Fatal(err)
+	}
+	if asset.RPCPassLocked() || asset.RPCConfig().Pass != testRPCConfig.Pass {
+		t.Fatalf("got password %q, want %q", asset.RPCConfig().Pass, testRPCConfig.Pass)
+	}
+	if asset.ReadUserConfigValue(sharedW.RPCPassConfigKey, new([]byte)) == nil {
+		t.Fatal("the password of a watching only wallet was saved")
+	}
+
+	// The password isn't saved, it is asked for again once the wallet is
+	// loaded.
+	asset, _ = reopenTestWallet(t, asset, params, shutdown)
+	if !asset.RPCPassLocked() || asset.RPCConfig().Pass != "" {
+		t.Fatalf("got password %q, want it locked", asset.RPCConfig().Pass)
+	}
+	if err := asset.SetRPCPass(""); err == nil {
+		t.Fatal("an empty password was set")
+	}
+	if err := asset.SetRPCPass(testRPCConfig.Pass); err != nil {
+		t.Fatal(err)
+	}
+	if asset.RPCPassLocked() || asset.RPCConfig().Pass != testRPCConfig.Pass {
+		t.Fatalf("got password %q, want %q", asset.RPCConfig().Pass, testRPCConfig.Pass)
+	}
+
+	// A full node without a password doesn't ask for one.
+	if err := asset.SaveChainBackend(sharedW.RPCBackend, &sharedW.RPCConfig{Host: testRPCConfig.Host}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if asset.RPCPassLocked() {
+		t.Fatal("the password of a full node without one is locked")
+	}
+}
@@ -39,7 +39,7 @@ func (asset *Asset) decodeTxInputs(mtx *wire.MsgTx,
 		// override account details if this is wallet input
 		for _, walletInput := range walletInputs {
 			if int(walletInput.Index) == i {
-				input.AccountNumber = int32(walletInput.PreviousAccount)
+				input.AccountNumber = asset.inputAccountNumber(txIn, walletInput.PreviousAccount)
 				input.Amount = int64(walletInput.PreviousAmount)
 				break
 			}
@@ -84,6 +84,9 @@ func (asset *Asset) decodeTxOutputs(mtx *wire.MsgTx,
 			if int32(walletOutput.Index) == output.Index {
 				output.Internal = walletOutput.Internal
 				output.AccountNumber = int32(walletOutput.Account)
+				if account, err := asset.scriptAccount(txOut.PkScript); err == nil {
+					output.AccountNumber = account
+				}
 				break
 			}
 		}
@@ -97,3 +100,19 @@ func (asset *Asset) decodeTxOutputs(mtx *wire.MsgTx,
 
 	return
 }
+
+// inputAccountNumber returns the number of the account that the wallet output
+// spent by txIn belongs to. btcwallet only reports the account number within
+// the key scope of the output.
+func (asset *Asset) inputAccountNumber(txIn *wire.TxIn, account uint32) int32 {
+	_, prevOut, _, _, err := asset.Internal().BTC.FetchInputInfo(&txIn.PreviousOutPoint)
+	if err != nil {
+		return int32(account)
+	}
+
+	accountNumber, err := asset.scriptAccount(prevOut.PkScript)
+	if err != nil {
+		return int32(account)
+	}
+	return accountNumber
+}
@@ -43,10 +43,10 @@ var dexLogLevel = dex.LevelError
 
 // DEXWallet wraps *wallet.Wallet and implements dexbtc.Wallet.
 type DEXWallet struct {
-	w                 *wallet.Wallet
-	acctNum           int32
-	cl                *btcChainService
-	syncStatusChecker SyncStatusChecker
+	w       *wallet.Wallet
+	acctNum int32
+	cl      *btcChainService
+	helper  WalletHelper
 	*dexbtc.BlockFiltersScanner
 }
 
@@ -105,18 +105,26 @@ type SyncStatusChecker interface {
 	IsSynced() bool
 }
 
+// WalletHelper provides the wallet state that the DEX wallet needs.
+type WalletHelper interface {
+	SyncStatusChecker
+	// IsOutputFrozen returns true for outputs that the user froze, they
+	// are not offered to the DEX.
+	IsOutputFrozen(txHash string, index uint32) bool
+}
+
 var _ dexbtc.CustomWallet = (*DEXWallet)(nil)
 var _ dexbtc.BlockInfoReader = (*DEXWallet)(nil)
 
 // NewDEXWallet returns a new *DEXWallet.
-func NewDEXWallet(w *wallet.Wallet, acctNum int32, nc *chain.NeutrinoClient, syncStatusChecker SyncStatusChecker) *DEXWallet {
+func NewDEXWallet(w *wallet.Wallet, acctNum int32, nc *chain.NeutrinoClient, helper WalletHelper) *DEXWallet {
 	dw := &DEXWallet{
 		w:       w,
 		acctNum: acctNum,
 		cl: &btcChainService{
 			NeutrinoClient: nc,
 		},
-		syncStatusChecker: syncStatusChecker,
+		helper: helper,
 	}
 
 	dw.BlockFiltersScanner = dexbtc.NewBlockFiltersScanner(dw, dexLogger{Logger: log})
@@ -235,7 +243,7 @@ func (dw *DEXWallet) GetChainHeight() (int32, error) {
 
 // Part of dexbtc.Wallet interface.
 func (dw *DEXWallet) PeerCount() (uint32, error) {
-	if !dw.syncStatusChecker.IsSyncing() && !dw.syncStatusChecker.IsSynced() {
+	if !dw.helper.IsSyncing() && !dw.helper.IsSynced() {
 		return 0, nil // avoid expensive call to dw.cl.Peers()
 	}
 
@@ -244,7 +252,7 @@ func (dw *DEXWallet) PeerCount() (uint32, error) {
 
 // syncHeight is the best known sync height among peers.
 func (dw *DEXWallet) syncHeight() int32 {
-	if !dw.syncStatusChecker.IsSyncing() && !dw.syncStatusChecker.IsSynced() {
+	if !dw.helper.IsSyncing() && !dw.helper.IsSynced() {
 		return 0 // avoid expensive call to dw.cl.Peers()
 	}
 
@@ -269,7 +277,7 @@ func (dw *DEXWallet) SyncStatus() (*dexbtc.SyncStatus, error) {
 	return &dexbtc.SyncStatus{
 		Target:  dw.syncHeight(),
 		Height:  walletBlock.Height,
-		Syncing: dw.syncStatusChecker.IsSyncing(),
+		Syncing: dw.helper.IsSyncing(),
 	}, nil
 }
 
@@ -280,8 +288,12 @@ func (dw *DEXWallet) Balances() (*dexbtc.GetBalancesResult, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error listing unspent outputs: %w", err)
 	}
-	var trusted, untrusted btcutil.Amount
+	var trusted, untrusted, frozen btcutil.Amount
 	for _, txout := range unspents {
+		if dw.helper.IsOutputFrozen(txout.TxID, txout.Vout) {
+			frozen += btcutil.Amount(AmountSatoshi(txout.Amount))
+			continue
+		}
 		if txout.Confirmations > 0 || dw.ownsInputs(txout.TxID) {
 			trusted += btcutil.Amount(AmountSatoshi(txout.Amount))
 			continue
@@ -297,8 +309,9 @@ func (dw *DEXWallet) Balances() (*dexbtc.GetBalancesResult, error) {
 	log.Tracef("Bals: spendable = %v (%v trusted, %v untrusted, %v assumed locked), immature = %v",
 		bals.Spendable, trusted, untrusted, bals.Spendable-trusted-untrusted, bals.ImmatureReward)
 	// Locked outputs would be in wallet.Balances.Spendable. Assume they would
-	// be considered trusted and add them back in.
-	if all := trusted + untrusted; bals.Spendable > all {
+	// be considered trusted and add them back in. Frozen outputs are not
+	// available to the DEX.
+	if all := trusted + untrusted + frozen; bals.Spendable > all {
 		trusted += bals.Spendable - all
 	}
 
@@ -319,6 +332,10 @@ func (dw *DEXWallet) ListUnspent() ([]*dexbtc.ListUnspentResult, error) {
 	}
 	res := make([]*dexbtc.ListUnspentResult, 0, len(unspents))
 	for _, utxo := range unspents {
+		if dw.helper.IsOutputFrozen(utxo.TxID, utxo.Vout) {
+			continue
+		}
+
 		// If the utxo is unconfirmed, we should determine whether it's "safe"
 		// by seeing if we control the inputs of its transaction.
 		safe := utxo.Confirmations > 0 || dw.ownsInputs(utxo.TxID)
@@ -0,0 +1,410 @@
+package btc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/chain"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/crypto-power/cryptopower/libwallet/internal/electrum"
+)
+
+// electrumBackEnd is the name the Electrum chain client reports as its
+// backend.
+const electrumBackEnd = "electrum"
+
+// defaultElectrumServers are the public servers picked from when the user
+// sets none.
+var defaultElectrumServers = map[wire.BitcoinNet][]string{
+	wire.MainNet: {
+		"ssl://electrum.blockstream.info:50002",
+		"ssl://electrum.emzy.de:50002",
+		"ssl://electrum.bitaroo.net:50002",
+	},
+	wire.TestNet3: {
+		"ssl://electrum.blockstream.info:60002",
+		"ssl://testnet.aranguren.org:51002",
+	},
+}
+
+// errNoBlocks is returned by GetBlock, Electrum servers don't serve blocks.
+var errNoBlocks = errors.New("blocks are not served by electrum servers")
+
+// electrumClient is a chain.Interface syncing the wallet with an Electrum
+// server through an electrum.Chain, which verifies the headers against the
+// proof of work and the checkpoints of the network.
+type electrumClient struct {
+	params *chaincfg.Params
+	chain  *electrum.Chain[*wire.MsgTx]
+	ntfns  *electrum.Queue[interface{}]
+}
+
+// Compile time check to ensure electrumClient satisfies chain.Interface.
+var _ chain.Interface = (*electrumClient)(nil)
+
+func newElectrumClient(params *chaincfg.Params, servers []*electrum.Server) *electrumClient {
+	c := &electrumClient{
+		params: params,
+		ntfns:  electrum.NewQueue[interface{}](),
+	}
+	c.chain = electrum.NewChain(&electrum.ChainConfig[*wire.MsgTx]{
+		Params:   electrumChainParams(params),
+		Servers:  servers,
+		DecodeTx: decodeElectrumTx,
+		Notifier: (*electrumNotifier)(c),
+		Log:      log,
+	})
+	return c
+}
+
+// electrumChainParams returns the consensus rules the headers served are
+// verified against. Bitcoin proof of work is on the block hash.
+func electrumChainParams(params *chaincfg.Params) *electrum.ChainParams {
+	checkpoints := make([]electrum.Checkpoint, len(params.Checkpoints))
+	for i, checkpoint := range params.Checkpoints {
+		checkpoints[i] = electrum.Checkpoint{Height: checkpoint.Height, Hash: electrum.Hash(*checkpoint.Hash)}
+	}
+	return &electrum.ChainParams{
+		Checkpoints:              checkpoints,
+		PowLimit:                 params.PowLimit,
+		RetargetInterval:         int32(params.TargetTimespan / params.TargetTimePerBlock),
+		RetargetAdjustmentFactor: params.RetargetAdjustmentFactor,
+		ReduceMinDifficulty:      params.ReduceMinDifficulty,
+		PowHash:                  electrum.DoubleHash,
+	}
+}
+
+func decodeElectrumTx(raw []byte) (*wire.MsgTx, electrum.Hash, error) {
+	tx := new(wire.MsgTx)
+	if err := tx.Deserialize(bytes.NewReader(raw)); err != nil {
+		return nil, electrum.Hash{}, err
+	}
+	return tx, electrum.Hash(tx.TxHash()), nil
+}
+
+// decodeElectrumHeader decodes a header verified by the electrum chain.
+func decodeElectrumHeader(header *electrum.Header) (*wire.BlockHeader, error) {
+	blockHeader := new(wire.BlockHeader)
+	if err := blockHeader.Deserialize(bytes.NewReader(header.Raw)); err != nil {
+		return nil, err
+	}
+	return blockHeader, nil
+}
+
+// electrumNotifier turns the notifications of the electrum chain into those
+// of the wallet.
+type electrumNotifier electrumClient
+
+func (n *electrumNotifier) Connected() {
+	n.ntfns.Push(chain.ClientConnected{})
+}
+
+func (n *electrumNotifier) BlockConnected(block *electrum.Block) {
+	n.ntfns.Push(chain.BlockConnected{
+		Block: wtxmgr.Block{Hash: chainhash.Hash(block.Hash), Height: block.Height},
+		Time:  block.Time,
+	})
+}
+
+func (n *electrumNotifier) BlockDisconnected(block *electrum.Block) {
+	n.ntfns.Push(chain.BlockDisconnected{
+		Block: wtxmgr.Block{Hash: chainhash.Hash(block.Hash), Height: block.Height},
+	})
+}
+
+func (n *electrumNotifier) RelevantTx(rtx *electrum.RelevantTx[*wire.MsgTx]) {
+	received := time.Now()
+	var block *wtxmgr.BlockMeta
+	if rtx.Block != nil {
+		received = rtx.Block.Time
+		block = &wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{Hash: chainhash.Hash(rtx.Block.Hash), Height: rtx.Block.Height},
+			Time:  rtx.Block.Time,
+		}
+	}
+	rec, err := wtxmgr.NewTxRecordFromMsgTx(rtx.Tx, received)
+	if err != nil {
+		log.Errorf("Unable to record transaction %v: %v", rtx.Hash, err)
+		return
+	}
+	n.ntfns.Push(chain.RelevantTx{TxRecord: rec, Block: block})
+}
+
+func (n *electrumNotifier) RescanFinished(tip *electrum.Block) {
+	hash := chainhash.Hash(tip.Hash)
+	n.ntfns.Push(&chain.RescanFinished{
+		Hash:   &hash,
+		Height: tip.Height,
+		Time:   tip.Time,
+	})
+}
+
+// pkScripts returns the output scripts paying to the addresses.
+func pkScripts(addrs []btcutil.Address) ([][]byte, error) {
+	scripts := make([][]byte, len(addrs))
+	for i, addr := range addrs {
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return nil, err
+		}
+		scripts[i] = pkScript
+	}
+	return scripts, nil
+}
+
+// Start connects to the first reachable server. The client can't be
+// restarted once stopped.
+func (c *electrumClient) Start() error {
+	return c.chain.Start()
+}
+
+// Stop disconnects from the server and closes the notifications channel.
+func (c *electrumClient) Stop() {
+	c.chain.Stop()
+	c.ntfns.Close()
+}
+
+// WaitForShutdown blocks until the client is stopped.
+func (c *electrumClient) WaitForShutdown() {
+	c.chain.WaitForShutdown()
+}
+
+// servers returns the servers of the client, to renew it.
+func (c *electrumClient) servers() []*electrum.Server {
+	return c.chain.Servers()
+}
+
+// serverInfo returns the address and software of the server the client is
+// connected to, empty if disconnected.
+func (c *electrumClient) serverInfo() (addr, software string) {
+	return c.chain.ServerInfo()
+}
+
+// GetBestBlock returns the hash and height of the tip of the server.
+func (c *electrumClient) GetBestBlock() (*chainhash.Hash, int32, error) {
+	tip, err := c.BlockStamp()
+	if err != nil {
+		return nil, 0, err
+	}
+	return &tip.Hash, tip.Height, nil
+}
+
+// BlockStamp returns the tip of the server.
+func (c *electrumClient) BlockStamp() (*waddrmgr.BlockStamp, error) {
+	tip, err := c.chain.Tip()
+	if err != nil {
+		return nil, err
+	}
+	return &waddrmgr.BlockStamp{
+		Height:    tip.Height,
+		Hash:      chainhash.Hash(tip.Hash),
+		Timestamp: tip.Time,
+	}, nil
+}
+
+// GetBlock is not supported, Electrum servers don't serve blocks.
+func (c *electrumClient) GetBlock(*chainhash.Hash) (*wire.MsgBlock, error) {
+	return nil, errNoBlocks
+}
+
+// GetBlockHash returns the hash of the block at the height.
+func (c *electrumClient) GetBlockHash(height int64) (*chainhash.Hash, error) {
+	if height > math.MaxInt32 {
+		return nil, fmt.Errorf("no block at height %d", height)
+	}
+	header, err := c.chain.Header(int32(height))
+	if err != nil {
+		return nil, err
+	}
+	hash := chainhash.Hash(header.Hash)
+	return &hash, nil
+}
+
+// GetBlockHeight returns the height of the block with the hash. Only recent
+// blocks and blocks of recently fetched headers are known.
+func (c *electrumClient) GetBlockHeight(hash *chainhash.Hash) (int32, error) {
+	return c.chain.BlockHeight(electrum.Hash(*hash))
+}
+
+// GetBlockHeader returns the header of the block with the hash.
+func (c *electrumClient) GetBlockHeader(hash *chainhash.Hash) (*wire.BlockHeader, error) {
+	height, err := c.GetBlockHeight(hash)
+	if err != nil {
+		return nil, err
+	}
+	header, err := c.chain.Header(height)
+	if err != nil {
+		return nil, err
+	}
+	return decodeElectrumHeader(header)
+}
+
+// IsCurrent returns true if the tip of the server is recent.
+func (c *electrumClient) IsCurrent() bool {
+	return c.chain.IsCurrent()
+}
+
+// FilterBlocks returns the transactions of the first block of the request
+// paying to or spending from the addresses of the request, found in the
+// history of the addresses. Nil is returned if none of the blocks holds a
+// transaction of the addresses.
+func (c *electrumClient) FilterBlocks(req *chain.FilterBlocksRequest) (*chain.FilterBlocksResponse, error) {
+	blocks := make([]*electrum.Block, len(req.Blocks))
+	for i, block := range req.Blocks {
+		blocks[i] = &electrum.Block{Hash: electrum.Hash(block.Hash), Height: block.Height}
+	}
+
+	externalAddrs := make(map[string]waddrmgr.ScopedIndex, len(req.ExternalAddrs))
+	internalAddrs := make(map[string]waddrmgr.ScopedIndex, len(req.InternalAddrs))
+	watchedAddrs := make(map[string]btcutil.Address)
+	addrs := make([]btcutil.Address, 0, len(req.ExternalAddrs)+len(req.InternalAddrs)+len(req.WatchedOutPoints))
+	for index, addr := range req.ExternalAddrs {
+		externalAddrs[addr.EncodeAddress()] = index
+		addrs = append(addrs, addr)
+	}
+	for index, addr := range req.InternalAddrs {
+		internalAddrs[addr.EncodeAddress()] = index
+		addrs = append(addrs, addr)
+	}
+	for _, addr := range req.WatchedOutPoints {
+		watchedAddrs[addr.EncodeAddress()] = addr
+		addrs = append(addrs, addr)
+	}
+
+	scripts, err := pkScripts(addrs)
+	if err != nil {
+		return nil, err
+	}
+	batchIndex, txs, err := c.chain.FilterBlocks(blocks, scripts)
+	if err != nil || batchIndex < 0 {
+		return nil, err
+	}
+
+	resp := &chain.FilterBlocksResponse{
+		BatchIndex:         uint32(batchIndex),
+		BlockMeta:          req.Blocks[batchIndex],
+		FoundExternalAddrs: make(map[waddrmgr.KeyScope]map[uint32]struct{}),
+		FoundInternalAddrs: make(map[waddrmgr.KeyScope]map[uint32]struct{}),
+		FoundOutPoints:     make(map[wire.OutPoint]btcutil.Address),
+	}
+	foundAddr := func(found map[waddrmgr.KeyScope]map[uint32]struct{}, index waddrmgr.ScopedIndex) {
+		if found[index.Scope] == nil {
+			found[index.Scope] = make(map[uint32]struct{})
+		}
+		found[index.Scope][index.Index] = struct{}{}
+	}
+	for _, rtx := range txs {
+		resp.RelevantTxns = append(resp.RelevantTxns, rtx.Tx)
+		txHash := chainhash.Hash(rtx.Hash)
+		for i, out := range rtx.Tx.TxOut {
+			_, outAddrs, _, err := txscript.ExtractPkScriptAddrs(out.PkScript, c.params)
+			if err != nil {
+				continue
+			}
+			for _, addr := range outAddrs {
+				encoded := addr.EncodeAddress()
+				outPoint := wire.OutPoint{Hash: txHash, Index: uint32(i)}
+				if index, ok := externalAddrs[encoded]; ok {
+					foundAddr(resp.FoundExternalAddrs, index)
+					resp.FoundOutPoints[outPoint] = addr
+				} else if index, ok := internalAddrs[encoded]; ok {
+					foundAddr(resp.FoundInternalAddrs, index)
+					resp.FoundOutPoints[outPoint] = addr
+				} else if _, ok := watchedAddrs[encoded]; ok {
+					resp.FoundOutPoints[outPoint] = addr
+				}
+			}
+		}
+	}
+	return resp, nil
+}
+
+// SendRawTransaction broadcasts the transaction through the server.
+func (c *electrumClient) SendRawTransaction(tx *wire.MsgTx, _ bool) (*chainhash.Hash, error) {
+	var buf bytes.Buffer
+	buf.Grow(tx.SerializeSize())
+	if err := tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	txHash, err := c.chain.Broadcast(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	hash := chainhash.Hash(txHash)
+	return &hash, nil
+}
+
+// Rescan notifies the transactions of the addresses mined from the start
+// block or in the mempool, and watches the addresses for new transactions.
+// The history of the addresses is queried from the server, no block is
+// scanned.
+func (c *electrumClient) Rescan(startHash *chainhash.Hash, addrs []btcutil.Address, outPoints map[wire.OutPoint]btcutil.Address) error {
+	for _, addr := range outPoints {
+		addrs = append(addrs, addr)
+	}
+	scripts, err := pkScripts(addrs)
+	if err != nil {
+		return err
+	}
+	return c.chain.Rescan(electrum.Hash(*startHash), scripts)
+}
+
+// NotifyReceived watches the addresses for new transactions.
+func (c *electrumClient) NotifyReceived(addrs []btcutil.Address) error {
+	scripts, err := pkScripts(addrs)
+	if err != nil {
+		return err
+	}
+	return c.chain.NotifyReceived(scripts)
+}
+
+// NotifyBlocks starts sending notifications of the blocks connected and
+// disconnected.
+func (c *electrumClient) NotifyBlocks() error {
+	c.chain.NotifyBlocks()
+	return nil
+}
+
+// Notifications returns the channel the chain notifications are sent on.
+func (c *electrumClient) Notifications() <-chan interface{} {
+	return c.ntfns.Out()
+}
+
+// BackEnd returns the name of the backend.
+func (c *electrumClient) BackEnd() string {
+	return electrumBackEnd
+}
+
+// TestMempoolAccept is not supported by the Electrum protocol.
+func (c *electrumClient) TestMempoolAccept([]*wire.MsgTx, float64) ([]*btcjson.TestMempoolAcceptResult, error) {
+	return nil, chain.ErrUnimplemented
+}
+
+// MapRPCErr maps the errors relayed by the server from its bitcoind node.
+func (c *electrumClient) MapRPCErr(rpcErr error) error {
+	var serverErr *electrum.RPCError
+	if !errors.As(rpcErr, &serverErr) {
+		return rpcErr
+	}
+
+	// bitcoind reject reasons are dash separated, the chain errors are
+	// space separated.
+	reason := strings.ToLower(strings.ReplaceAll(serverErr.Message, "-", " "))
+	for rpcErrCode := chain.RPCErr(0); rpcErrCode <= chain.ErrNonMandatoryScriptVerifyFlag; rpcErrCode++ {
+		if strings.Contains(reason, strings.ToLower(strings.ReplaceAll(rpcErrCode.Error(), "-", " "))) {
+			return rpcErrCode
+		}
+	}
+	return fmt.Errorf("%w: %v", chain.ErrUndefined, rpcErr)
+}
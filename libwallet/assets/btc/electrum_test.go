@@ -0,0 +1,399 @@
+package btc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/chain"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/wtxmgr"
+	"github.com/crypto-power/cryptopower/libwallet/internal/electrum"
+	"github.com/crypto-power/cryptopower/libwallet/internal/electrum/electrumtest"
+)
+
+// fakeChain is a regtest chain served by a fake Electrum server, with a
+// single transaction paying to a watched address.
+type fakeChain struct {
+	mu      sync.Mutex
+	headers []*wire.BlockHeader
+	addr    btcutil.Address
+	tx      *wire.MsgTx
+	txBlock int32
+	// sibling is the other transaction of the block of tx, first in the
+	// block.
+	sibling chainhash.Hash
+}
+
+// mineHeader returns a header extending prev that meets the regtest proof of
+// work.
+func mineHeader(t *testing.T, prev *wire.BlockHeader, merkleRoot chainhash.Hash) *wire.BlockHeader {
+	t.Helper()
+	params := &chaincfg.RegressionNetParams
+	header := &wire.BlockHeader{
+		Version:    4,
+		PrevBlock:  prev.BlockHash(),
+		MerkleRoot: merkleRoot,
+		Timestamp:  prev.Timestamp.Add(10 * time.Minute),
+		Bits:       params.PowLimitBits,
+	}
+	target := blockchain.CompactToBig(header.Bits)
+	for nonce := uint32(0); ; nonce++ {
+		header.Nonce = nonce
+		hash := header.BlockHash()
+		if blockchain.HashToBig(&hash).Cmp(target) <= 0 {
+			return header
+		}
+	}
+}
+
+func newFakeChain(t *testing.T, height int32) *fakeChain {
+	t.Helper()
+	params := &chaincfg.RegressionNetParams
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(bytes.Repeat([]byte{1}, 20), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{1}}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(1e8, pkScript))
+
+	fc := &fakeChain{
+		headers: []*wire.BlockHeader{&params.GenesisBlock.Header},
+		addr:    addr,
+		tx:      tx,
+		txBlock: 3,
+		sibling: chainhash.Hash{2},
+	}
+	fc.extend(t, height)
+	return fc
+}
+
+// extend mines blocks until the chain reaches the height.
+func (fc *fakeChain) extend(t *testing.T, height int32) {
+	t.Helper()
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	for h := int32(len(fc.headers)); h <= height; h++ {
+		// Blocks mined after a reorg get a different merkle root, changing
+		// their hash.
+		merkleRoot := chainhash.Hash{byte(h), byte(len(fc.headers))}
+		if h == fc.txBlock {
+			txHash := fc.tx.TxHash()
+			merkleRoot = blockchain.HashMerkleBranches(&fc.sibling, &txHash)
+		}
+		fc.headers = append(fc.headers, mineHeader(t, fc.headers[h-1], merkleRoot))
+	}
+}
+
+// reorg replaces the blocks above the height.
+func (fc *fakeChain) reorg(t *testing.T, forkHeight, height int32) {
+	t.Helper()
+	fc.mu.Lock()
+	fc.headers = fc.headers[:forkHeight+1]
+	fc.headers[forkHeight] = mineHeader(t, fc.headers[forkHeight-1], chainhash.Hash{0xff})
+	fc.mu.Unlock()
+	fc.extend(t, height)
+}
+
+func serializeHeader(t *testing.T, header *wire.BlockHeader) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := header.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(buf.Bytes())
+}
+
+func (fc *fakeChain) tip(t *testing.T) *electrum.HeaderNotification {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	height := int32(len(fc.headers) - 1)
+	return &electrum.HeaderNotification{Height: height, Hex: serializeHeader(t, fc.headers[height])}
+}
+
+func (fc *fakeChain) serve(t *testing.T, srv *electrumtest.Server) {
+	pkScript, _ := txscript.PayToAddrScript(fc.addr)
+	scripthash := electrum.ScriptHash(pkScript)
+	txHash := fc.tx.TxHash()
+
+	srv.Handle("blockchain.headers.subscribe", func([]json.RawMessage) (interface{}, error) {
+		return fc.tip(t), nil
+	})
+	srv.Handle("blockchain.block.headers", func(params []json.RawMessage) (interface{}, error) {
+		var start, count int
+		if err := json.Unmarshal(params[0], &start); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(params[1], &count); err != nil {
+			return nil, err
+		}
+		fc.mu.Lock()
+		defer fc.mu.Unlock()
+		end := start + count
+		if end > len(fc.headers) {
+			end = len(fc.headers)
+		}
+		var headersHex string
+		for _, header := range fc.headers[start:end] {
+			headersHex += serializeHeader(t, header)
+		}
+		return map[string]interface{}{"count": end - start, "hex": headersHex, "max": 2016}, nil
+	})
+	srv.Handle("blockchain.scripthash.subscribe", func(params []json.RawMessage) (interface{}, error) {
+		var sh string
+		if err := json.Unmarshal(params[0], &sh); err != nil {
+			return nil, err
+		}
+		if sh == scripthash {
+			return "status", nil
+		}
+		return nil, nil
+	})
+	srv.Handle("blockchain.scripthash.get_history", func(params []json.RawMessage) (interface{}, error) {
+		var sh string
+		if err := json.Unmarshal(params[0], &sh); err != nil {
+			return nil, err
+		}
+		if sh != scripthash {
+			return []interface{}{}, nil
+		}
+		return []*electrum.HistoryItem{{Height: fc.txBlock, TxHash: txHash.String()}}, nil
+	})
+	srv.Handle("blockchain.transaction.get", func([]json.RawMessage) (interface{}, error) {
+		var buf bytes.Buffer
+		if err := fc.tx.Serialize(&buf); err != nil {
+			return nil, err
+		}
+		return hex.EncodeToString(buf.Bytes()), nil
+	})
+	srv.Handle("blockchain.transaction.get_merkle", func([]json.RawMessage) (interface{}, error) {
+		return &electrum.MerkleProof{
+			BlockHeight: fc.txBlock,
+			Merkle:      []string{fc.sibling.String()},
+			Pos:         1,
+		}, nil
+	})
+}
+
+func startTestElectrumClient(t *testing.T, fc *fakeChain) (*electrumtest.Server, *electrumClient) {
+	t.Helper()
+	srv := electrumtest.NewServer(t)
+	fc.serve(t, srv)
+
+	server, err := electrum.ParseServer("tcp://"+srv.Addr, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newElectrumClient(&chaincfg.RegressionNetParams, []*electrum.Server{server})
+	if err := c.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		c.Stop()
+		c.WaitForShutdown()
+	})
+
+	if _, ok := nextNotification(t, c).(chain.ClientConnected); !ok {
+		t.Fatal("expected a ClientConnected notification first")
+	}
+	return srv, c
+}
+
+func nextNotification(t *testing.T, c *electrumClient) interface{} {
+	t.Helper()
+	select {
+	case n := <-c.Notifications():
+		return n
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification not received")
+		return nil
+	}
+}
+
+func TestElectrumClientHeaders(t *testing.T) {
+	fc := newFakeChain(t, 5)
+	_, c := startTestElectrumClient(t, fc)
+
+	hash, height, err := c.GetBestBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 5 || *hash != fc.headers[5].BlockHash() {
+		t.Fatalf("unexpected best block %v at height %d", hash, height)
+	}
+
+	for h := int32(0); h <= 5; h++ {
+		hash, err := c.GetBlockHash(int64(h))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *hash != fc.headers[h].BlockHash() {
+			t.Fatalf("unexpected hash %v at height %d", hash, h)
+		}
+		blockHeight, err := c.GetBlockHeight(hash)
+		if err != nil || blockHeight != h {
+			t.Fatalf("expected height %d for block %v, got %d (%v)", h, hash, blockHeight, err)
+		}
+		header, err := c.GetBlockHeader(hash)
+		if err != nil || header.BlockHash() != *hash {
+			t.Fatalf("unexpected header for block %v (%v)", hash, err)
+		}
+	}
+
+	if _, err := c.GetBlock(hash); err == nil {
+		t.Fatal("expected blocks not to be served")
+	}
+}
+
+func TestElectrumClientRescan(t *testing.T) {
+	fc := newFakeChain(t, 5)
+	_, c := startTestElectrumClient(t, fc)
+
+	startHash := fc.headers[0].BlockHash()
+	if err := c.Rescan(&startHash, []btcutil.Address{fc.addr}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	relevant, ok := nextNotification(t, c).(chain.RelevantTx)
+	if !ok {
+		t.Fatal("expected a RelevantTx notification")
+	}
+	if relevant.TxRecord.Hash != fc.tx.TxHash() {
+		t.Fatalf("unexpected transaction %v", relevant.TxRecord.Hash)
+	}
+	if relevant.Block == nil || relevant.Block.Height != fc.txBlock || relevant.Block.Hash != fc.headers[fc.txBlock].BlockHash() {
+		t.Fatalf("unexpected block %+v", relevant.Block)
+	}
+
+	finished, ok := nextNotification(t, c).(*chain.RescanFinished)
+	if !ok {
+		t.Fatal("expected a RescanFinished notification")
+	}
+	if finished.Height != 5 {
+		t.Fatalf("rescan finished at height %d", finished.Height)
+	}
+
+	resp, err := c.FilterBlocks(&chain.FilterBlocksRequest{
+		Blocks: []wtxmgr.BlockMeta{
+			{Block: wtxmgr.Block{Hash: fc.headers[2].BlockHash(), Height: 2}},
+			{Block: wtxmgr.Block{Hash: fc.headers[3].BlockHash(), Height: 3}},
+		},
+		ExternalAddrs: map[waddrmgr.ScopedIndex]btcutil.Address{
+			{Scope: waddrmgr.KeyScopeBIP0084, Index: 7}: fc.addr,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp == nil || resp.BatchIndex != 1 || len(resp.RelevantTxns) != 1 {
+		t.Fatalf("unexpected filter response %+v", resp)
+	}
+	if _, ok := resp.FoundExternalAddrs[waddrmgr.KeyScopeBIP0084][7]; !ok {
+		t.Fatal("address of the transaction not found")
+	}
+}
+
+func TestElectrumClientReorg(t *testing.T) {
+	fc := newFakeChain(t, 5)
+	srv, c := startTestElectrumClient(t, fc)
+	if err := c.NotifyBlocks(); err != nil {
+		t.Fatal(err)
+	}
+
+	fc.extend(t, 6)
+	srv.Notify("blockchain.headers.subscribe", fc.tip(t))
+	connected, ok := nextNotification(t, c).(chain.BlockConnected)
+	if !ok || connected.Height != 6 || connected.Hash != fc.headers[6].BlockHash() {
+		t.Fatalf("unexpected notification %+v", connected)
+	}
+
+	staleHash := fc.headers[6].BlockHash()
+	fc.reorg(t, 6, 7)
+	srv.Notify("blockchain.headers.subscribe", fc.tip(t))
+
+	disconnected, ok := nextNotification(t, c).(chain.BlockDisconnected)
+	if !ok || disconnected.Height != 6 || disconnected.Hash != staleHash {
+		t.Fatalf("unexpected notification %+v", disconnected)
+	}
+	for h := int32(6); h <= 7; h++ {
+		connected, ok := nextNotification(t, c).(chain.BlockConnected)
+		if !ok || connected.Height != h || connected.Hash != fc.headers[h].BlockHash() {
+			t.Fatalf("unexpected notification %+v at height %d", connected, h)
+		}
+	}
+
+	_, height, err := c.GetBestBlock()
+	if err != nil || height != 7 {
+		t.Fatalf("unexpected best height %d (%v)", height, err)
+	}
+}
+
+// electrumHeaders decodes the headers as the electrum chain does.
+func electrumHeaders(t *testing.T, headers ...*wire.BlockHeader) []*electrum.Header {
+	t.Helper()
+	var buf bytes.Buffer
+	for _, header := range headers {
+		if err := header.Serialize(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	decoded, err := electrum.DecodeHeaders(buf.Bytes(), len(headers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+func TestVerifyHeaders(t *testing.T) {
+	params := electrumChainParams(&chaincfg.RegressionNetParams)
+	fc := newFakeChain(t, 3)
+	headers := electrumHeaders(t, fc.headers...)
+	for i, header := range headers {
+		if header.Hash != electrum.Hash(fc.headers[i].BlockHash()) {
+			t.Fatalf("header %d decoded with hash %v, want %v", i, header.Hash, fc.headers[i].BlockHash())
+		}
+	}
+	if err := params.VerifyHeaders(1, headers[0], headers[1:]); err != nil {
+		t.Fatal(err)
+	}
+
+	unlinked := *fc.headers[2]
+	unlinked.PrevBlock = chainhash.Hash{}
+	if err := params.VerifyHeaders(1, headers[0], electrumHeaders(t, fc.headers[1], &unlinked)); err == nil {
+		t.Fatal("expected an error for a header not extending the chain")
+	}
+
+	// An unmined header on mainnet misses its proof of work.
+	mainNetParams := electrumChainParams(&chaincfg.MainNetParams)
+	unmined := *fc.headers[1]
+	unmined.Bits = chaincfg.MainNetParams.PowLimitBits
+	if err := mainNetParams.VerifyHeaders(1, nil, electrumHeaders(t, &unmined)); err == nil {
+		t.Fatal("expected an error for a header without proof of work")
+	}
+
+	// Mainnet checkpoints are enforced.
+	checkpoint := chaincfg.MainNetParams.Checkpoints[0]
+	if err := mainNetParams.VerifyHeaders(checkpoint.Height, nil, electrumHeaders(t, &unmined)); err == nil {
+		t.Fatal("expected an error for a header not matching a checkpoint")
+	}
+
+	// The mainnet genesis block meets its proof of work.
+	if err := mainNetParams.VerifyHeaders(0, nil, electrumHeaders(t, &chaincfg.MainNetParams.GenesisBlock.Header)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,186 @@
+package btc
+
+import (
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/walletdb"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// btcwallet numbers the accounts of each key scope from zero. Accounts of key
+// scopes other than BIP-84 are offered with an offset added to their number
+// that identifies the key scope wherever an account number is used.
+const (
+	// TaprootAccountOffset is the offset of the BIP-86 (P2TR) accounts.
+	TaprootAccountOffset int32 = 1 << 24
+	// NestedSegwitAccountOffset is the offset of the BIP-49 (P2SH-P2WPKH)
+	// accounts.
+	NestedSegwitAccountOffset int32 = 2 << 24
+	// LegacyAccountOffset is the offset of the BIP-44 (P2PKH) accounts.
+	LegacyAccountOffset int32 = 3 << 24
+)
+
+// keyScopeAccounts are the accounts of other key scopes offered next to the
+// BIP-84 accounts, sorted by offset.
+var keyScopeAccounts = sharedW.AccountScopes{
+	Scopes: []*sharedW.ScopeAccounts{
+		{Scope: sharedW.KeyScope(waddrmgr.KeyScopeBIP0086), Offset: TaprootAccountOffset},
+		{
+			Scope:  sharedW.KeyScope(waddrmgr.KeyScopeBIP0049Plus),
+			Offset: NestedSegwitAccountOffset,
+			Legacy: true,
+			Name:   "BIP-49 nested segwit",
+		},
+		{
+			Scope:  sharedW.KeyScope(waddrmgr.KeyScopeBIP0044),
+			Offset: LegacyAccountOffset,
+			Legacy: true,
+			Name:   "BIP-44 legacy",
+		},
+	},
+	ImportedAccount: ImportedAccountNumber,
+}
+
+// accountScope returns the key scope and the btcwallet account number of the
+// provided account.
+func accountScope(accountNumber int32) (waddrmgr.KeyScope, uint32) {
+	scope, account := keyScopeAccounts.AccountScope(sharedW.KeyScope(GetScope()), accountNumber)
+	return waddrmgr.KeyScope(scope), account
+}
+
+// scopedAccountNumber returns the account number of a btcwallet account of the
+// provided key scope.
+func scopedAccountNumber(scope waddrmgr.KeyScope, account uint32) int32 {
+	return keyScopeAccounts.ScopedAccountNumber(sharedW.KeyScope(scope), account)
+}
+
+// addressAccount returns the number of the account that the wallet address
+// belongs to.
+func (asset *Asset) addressAccount(addr btcutil.Address) (int32, error) {
+	var scope waddrmgr.KeyScope
+	var account uint32
+	w := asset.Internal().BTC
+	err := walletdb.View(w.Database(), func(dbtx walletdb.ReadTx) error {
+		smgr, acct, err := w.Manager.AddrAccount(dbtx.ReadBucket(wAddrMgrBkt), addr)
+		if err != nil {
+			return err
+		}
+		scope, account = smgr.Scope(), acct
+		return nil
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return scopedAccountNumber(scope, account), nil
+}
+
+// scriptAccount returns the number of the account that the wallet output
+// with the provided pkScript belongs to.
+func (asset *Asset) scriptAccount(pkScript []byte) (int32, error) {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, asset.chainParams)
+	if err != nil {
+		return -1, err
+	}
+	if len(addrs) == 0 {
+		return -1, errors.New(utils.ErrNotExist)
+	}
+	return asset.addressAccount(addrs[0])
+}
+
+// SetScanLegacyKeyScopes sets whether the legacy key scopes used by other
+// wallets are imported as accounts once the address discovery of a restored
+// wallet completes, if they have any history.
+func (asset *Asset) SetScanLegacyKeyScopes(scan bool) {
+	asset.SetBoolConfigValueForKey(sharedW.ScanLegacyKeyScopesConfigKey, scan)
+}
+
+// LegacyKeyScopes reports which of the key scopes used by other wallets, BIP-44
+// (P2PKH) and BIP-49 (P2SH-P2WPKH), have history. btcwallet scans these key
+// scopes when a wallet is restored, the report is only complete once address
+// discovery is done.
+//
+// btcwallet's BIP-49 key scope derives P2WPKH change addresses, change that
+// another wallet sent to nested segwit change addresses is not found.
+func (asset *Asset) LegacyKeyScopes() ([]*sharedW.KeyScopeHistory, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrBTCNotInitialized
+	}
+
+	return asset.LegacyKeyScopeHistory(&keyScopeAccounts, scopeAccountStore{asset}, DefaultAccountNum)
+}
+
+// ImportLegacyKeyScopes imports the default account of every legacy key scope
+// with history as an account of the wallet, see LegacyKeyScopes. Outputs of
+// the imported accounts are spent like those of any other account.
+func (asset *Asset) ImportLegacyKeyScopes() ([]*sharedW.KeyScopeHistory, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrBTCNotInitialized
+	}
+	return asset.ImportLegacyScopeAccounts(&keyScopeAccounts, scopeAccountStore{asset}, DefaultAccountNum)
+}
+
+// scopeAccountStore gives the shared key scope code access to the btcwallet
+// accounts of the wallet.
+type scopeAccountStore struct {
+	asset *Asset
+}
+
+func (s scopeAccountStore) ScopeAccountUsed(scope sharedW.KeyScope, account uint32) (bool, bool, error) {
+	props, err := s.asset.Internal().BTC.AccountProperties(waddrmgr.KeyScope(scope), account)
+	if err != nil {
+		if waddrmgr.IsError(err, waddrmgr.ErrScopeNotFound) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	return props.ExternalKeyCount > 0 || props.InternalKeyCount > 0, true, nil
+}
+
+func (s scopeAccountStore) AccountName(scope sharedW.KeyScope, account uint32) (string, error) {
+	return s.asset.Internal().BTC.AccountName(waddrmgr.KeyScope(scope), account)
+}
+
+func (s scopeAccountStore) RenameAccount(scope sharedW.KeyScope, account uint32, name string) error {
+	return s.asset.Internal().BTC.RenameAccount(waddrmgr.KeyScope(scope), account, name)
+}
+
+func (s scopeAccountStore) HasAccount(name string) bool {
+	return s.asset.HasAccount(name)
+}
+
+// isScopeAccountListed returns true if the account of a key scope other than
+// BIP-84 is offered by GetAccountsRaw.
+func (asset *Asset) isScopeAccountListed(s *sharedW.ScopeAccounts, props *waddrmgr.AccountProperties) bool {
+	if props.AccountNumber == ImportedAccountNumber {
+		return false
+	}
+	if s.Legacy {
+		return props.AccountNumber == DefaultAccountNum && asset.IsKeyScopeImported(s.Scope)
+	}
+	return isTaprootAccountInUse(props)
+}
+
+// signP2PKHInput returns the signature script spending the P2PKH output of a
+// BIP-44 account, which btcwallet's ComputeInputScript would sign as a witness
+// input.
+func (asset *Asset) signP2PKHInput(msgTx *wire.MsgTx, index int, pkScript []byte) ([]byte, error) {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, errors.New(utils.ErrNotExist)
+	}
+
+	privKey, err := asset.Internal().BTC.PrivKeyForAddress(addrs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return txscript.SignatureScript(msgTx, index, pkScript, txscript.SigHashAll, privKey, true)
+}
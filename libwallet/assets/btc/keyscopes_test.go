@@ -0,0 +1,188 @@
+package btc
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/asdine/storm"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/walletdb"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const testPassphrase = "passphrase"
+
+// newTestWallet creates a regtest wallet in a temporary directory. The wallet
+// is shut down when the test ends.
+func newTestWallet(t *testing.T) *Asset {
+	t.Helper()
+	asset, _ := createTestWallet(t, newTestParams(t))
+	return asset
+}
+
+// createTestWallet creates a wallet with the init parameters and returns it
+// with the function shutting it down, which is called when the test ends if
+// it wasn't before.
+func createTestWallet(t *testing.T, params *sharedW.InitParams) (*Asset, func()) {
+	t.Helper()
+	pass := &sharedW.AuthInfo{
+		Name:            "test",
+		PrivatePass:     testPassphrase,
+		PrivatePassType: sharedW.PassphraseTypePass,
+		WordSeedType:    sharedW.WordSeed12,
+	}
+	asset, err := CreateNewWallet(pass, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shutdown := sync.OnceFunc(asset.Shutdown)
+	t.Cleanup(shutdown)
+	return asset.(*Asset), shutdown
+}
+
+// newTestParams returns the init parameters of regtest wallets in a temporary
+// directory.
+func newTestParams(t *testing.T) *sharedW.InitParams {
+	t.Helper()
+	dir := t.TempDir()
+	db, err := storm.Open(filepath.Join(dir, "wallets.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err = db.Init(&sharedW.Wallet{}); err != nil {
+		t.Fatal(err)
+	}
+
+	return &sharedW.InitParams{
+		RootDir:  dir,
+		NetType:  utils.Regression,
+		DB:       db,
+		DbDriver: "bdb",
+		LogDir:   dir,
+	}
+}
+
+func TestAccountScope(t *testing.T) {
+	tests := []struct {
+		accountNumber int32
+		scope         waddrmgr.KeyScope
+		account       uint32
+	}{
+		{DefaultAccountNum, waddrmgr.KeyScopeBIP0084, DefaultAccountNum},
+		{2, waddrmgr.KeyScopeBIP0084, 2},
+		{TaprootAccountOffset, waddrmgr.KeyScopeBIP0086, DefaultAccountNum},
+		{TaprootAccountOffset + 1, waddrmgr.KeyScopeBIP0086, 1},
+		{NestedSegwitAccountOffset, waddrmgr.KeyScopeBIP0049Plus, DefaultAccountNum},
+		{LegacyAccountOffset + 3, waddrmgr.KeyScopeBIP0044, 3},
+	}
+	for _, tc := range tests {
+		scope, account := accountScope(tc.accountNumber)
+		if scope != tc.scope || account != tc.account {
+			t.Errorf("account %d: got account %d of %s, want account %d of %s",
+				tc.accountNumber, account, scope, tc.account, tc.scope)
+			continue
+		}
+		if got := scopedAccountNumber(scope, account); got != tc.accountNumber {
+			t.Errorf("account %d of %s: got account number %d, want %d", account, scope, got, tc.accountNumber)
+		}
+	}
+
+	if !IsTaprootAccount(TaprootAccountOffset) || IsTaprootAccount(NestedSegwitAccountOffset) || IsTaprootAccount(DefaultAccountNum) {
+		t.Error("taproot accounts are not told apart from the other accounts")
+	}
+}
+
+// listedAccount returns the number of the account listed with the provided
+// name, or -1.
+func listedAccount(t *testing.T, asset *Asset, name string) int32 {
+	t.Helper()
+	accounts, err := asset.GetAccountsRaw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range accounts.Accounts {
+		if a.AccountName == name {
+			return int32(a.AccountNumber)
+		}
+	}
+	return -1
+}
+
+// deriveAddress derives the next external address of the default account of
+// the key scope, as done by btcwallet's address discovery.
+func deriveAddress(t *testing.T, asset *Asset, scope waddrmgr.KeyScope) btcutil.Address {
+	t.Helper()
+	w := asset.Internal().BTC
+	smgr, err := w.Manager.FetchScopedKeyManager(scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var addr btcutil.Address
+	err = walletdb.Update(w.Database(), func(dbtx walletdb.ReadWriteTx) error {
+		addrs, err := smgr.NextExternalAddresses(dbtx.ReadWriteBucket(wAddrMgrBkt), DefaultAccountNum, 1)
+		if err != nil {
+			return err
+		}
+		addr = addrs[0].Address()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr
+}
+
+// TestImportLegacyKeyScopes derives addresses of the BIP-44 key scope, as
+// done by btcwallet when address discovery of a restored wallet finds their
+// history, and imports the key scope as an account.
+func TestImportLegacyKeyScopes(t *testing.T) {
+	asset := newTestWallet(t)
+
+	report, err := asset.LegacyKeyScopes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report) != 2 {
+		t.Fatalf("got %d legacy key scopes, want 2", len(report))
+	}
+	for _, history := range report {
+		if history.HasHistory || history.Imported {
+			t.Errorf("the %s key scope of a new wallet has history %v and is imported %v",
+				history.Name, history.HasHistory, history.Imported)
+		}
+	}
+
+	addr := deriveAddress(t, asset, waddrmgr.KeyScopeBIP0044)
+	if listedAccount(t, asset, "BIP-44 legacy") != -1 {
+		t.Fatal("the BIP-44 account is listed before it is imported")
+	}
+
+	report, err = asset.ImportLegacyKeyScopes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, history := range report {
+		isLegacy := history.Purpose == waddrmgr.KeyScopeBIP0044.Purpose
+		if history.HasHistory != isLegacy || history.Imported != isLegacy {
+			t.Errorf("the %s key scope has history %v and is imported %v, want %v",
+				history.Name, history.HasHistory, history.Imported, isLegacy)
+		}
+	}
+
+	if got := listedAccount(t, asset, "BIP-44 legacy"); got != LegacyAccountOffset {
+		t.Fatalf("got BIP-44 account number %d, want %d", got, LegacyAccountOffset)
+	}
+	if listedAccount(t, asset, "BIP-49 nested segwit") != -1 {
+		t.Error("the unused BIP-49 account is listed")
+	}
+	if got, err := asset.AccountNumber("BIP-44 legacy"); err != nil || got != LegacyAccountOffset {
+		t.Errorf("got BIP-44 account number %d (%v), want %d", got, err, LegacyAccountOffset)
+	}
+	if got, err := asset.addressAccount(addr); err != nil || got != LegacyAccountOffset {
+		t.Errorf("got account number %d (%v) of the BIP-44 address, want %d", got, err, LegacyAccountOffset)
+	}
+}
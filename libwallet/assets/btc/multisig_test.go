@@ -0,0 +1,240 @@
+package btc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+// newTestMultisigWallets creates the seed wallets of n cosigners and the
+// m-of-n multisig wallet of their cosigner keys.
+func newTestMultisigWallets(t *testing.T, requiredSigs, n int) (*Asset, []*Asset) {
+	t.Helper()
+	cosigners := make([]*Asset, n)
+	config := &sharedW.MultisigConfig{RequiredSigs: requiredSigs}
+	for i := range cosigners {
+		cosigners[i] = newTestWallet(t)
+		key, err := cosigners[i].MultisigCosignerKey(testPassphrase, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		config.CosignerKeys = append(config.CosignerKeys, key)
+	}
+
+	w, err := CreateMultisigWallet("multisig", config, newTestParams(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(w.Shutdown)
+	return w.(*Asset), cosigners
+}
+
+// descriptorAddress derives the address at the index from a sortedmulti
+// descriptor, as another coordinator importing the descriptor does.
+func descriptorAddress(t *testing.T, params *chaincfg.Params, desc string, index uint32) string {
+	t.Helper()
+	desc, checksum, _ := strings.Cut(desc, "#")
+	if want, err := sharedW.DescriptorChecksum(desc); err != nil || checksum != want {
+		t.Fatalf("descriptor %s has checksum %s, want %s (%v)", desc, checksum, want, err)
+	}
+	body, hasPrefix := strings.CutPrefix(desc, "wsh(sortedmulti(")
+	body, hasSuffix := strings.CutSuffix(body, "))")
+	if !hasPrefix || !hasSuffix {
+		t.Fatalf("descriptor %s isn't a P2WSH sortedmulti descriptor", desc)
+	}
+	exprs := strings.Split(body, ",")
+	requiredSigs, err := strconv.Atoi(exprs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pubKeys [][]byte
+	for _, expr := range exprs[1:] {
+		keyExpr, ranged := strings.CutSuffix(expr, "/*")
+		i := strings.LastIndex(keyExpr, "/")
+		if !ranged || i < 0 {
+			t.Fatalf("key %s isn't ranged over a branch", expr)
+		}
+		branch, err := strconv.ParseUint(keyExpr[i+1:], 10, 31)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := sharedW.ParseCosignerKey(keyExpr[:i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		xpub, err := hdkeychain.NewKeyFromString(key.XPub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		child, err := deriveKeyPath(xpub, []uint32{uint32(branch), index})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubKey, err := child.ECPubKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubKeys = append(pubKeys, pubKey.SerializeCompressed())
+	}
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i], pubKeys[j]) < 0
+	})
+
+	builder := txscript.NewScriptBuilder().AddInt64(int64(requiredSigs))
+	for _, pubKey := range pubKeys {
+		builder.AddData(pubKey)
+	}
+	script, err := builder.AddInt64(int64(len(pubKeys))).AddOp(txscript.OP_CHECKMULTISIG).Script()
+	if err != nil {
+		t.Fatal(err)
+	}
+	scriptHash := sha256.Sum256(script)
+	addr, err := btcutil.NewAddressWitnessScriptHash(scriptHash[:], params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr.String()
+}
+
+func TestMultisigDescriptorExport(t *testing.T) {
+	asset, cosigners := newTestMultisigWallets(t, 2, 3)
+
+	receive, change, err := asset.MultisigDescriptors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cosigner := range cosigners {
+		key, err := cosigner.MultisigCosignerKey(testPassphrase, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The key origin is exported for the signers to find their keys.
+		if !strings.HasPrefix(key, "[") || !strings.Contains(receive, key+"/0/*") || !strings.Contains(change, key+"/1/*") {
+			t.Fatalf("cosigner key %s missing from the descriptors %s and %s", key, receive, change)
+		}
+	}
+
+	// Another coordinator importing the descriptors derives the addresses of
+	// the wallet.
+	addr, err := asset.CurrentAddress(DefaultAccountNum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := descriptorAddress(t, asset.chainParams, receive, 0); addr != want {
+		t.Fatalf("got receive address %s, the descriptor derives %s", addr, want)
+	}
+	changeAddr, _, err := asset.multisigBranchAddress(multisigInternalBranch, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := descriptorAddress(t, asset.chainParams, change, 0); changeAddr.String() != want {
+		t.Fatalf("got change address %s, the descriptor derives %s", changeAddr, want)
+	}
+
+	if _, _, err := cosigners[0].MultisigDescriptors(); err == nil {
+		t.Fatal("a seed wallet exported multisig descriptors")
+	}
+}
+
+// newTestMultisigPSBT returns a PSBT spending an output of the current
+// receive address of the multisig wallet.
+func newTestMultisigPSBT(t *testing.T, asset *Asset) (string, []byte) {
+	t.Helper()
+	addr, script, err := asset.multisigBranchAddress(multisigExternalBranch, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{1}}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(90000, pkScript))
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packet.Inputs[0].WitnessUtxo = wire.NewTxOut(100000, pkScript)
+	packet.Inputs[0].WitnessScript = script.script
+	packet.Inputs[0].Bip32Derivation = script.derivations
+	b64, err := packet.B64Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b64, script.script
+}
+
+func TestMultisigPSBTCombineAndFinalize(t *testing.T) {
+	asset, cosigners := newTestMultisigWallets(t, 2, 3)
+	unsigned, script := newTestMultisigPSBT(t, asset)
+
+	sign := func(cosigner int) string {
+		t.Helper()
+		signed, err := cosigners[cosigner].SignMultisigPSBT(unsigned, testPassphrase, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	signedBy0, signedBy2 := sign(0), sign(2)
+
+	if _, err := cosigners[1].SignMultisigPSBT(unsigned, "wrong", ""); err == nil {
+		t.Fatal("the PSBT was signed with a wrong passphrase")
+	}
+	if _, err := asset.FinalizeMultisigPSBT(signedBy0); err == nil {
+		t.Fatal("the PSBT signed by 1 of 2 cosigners was finalized")
+	}
+
+	combined, err := asset.CombineMultisigPSBTs([]string{signedBy0, signedBy2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := asset.MultisigPSBTStatus(combined)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Signatures != 2 || !status.Complete || status.Fee != 10000 {
+		t.Fatalf("got status %+v for the combined PSBT", status)
+	}
+
+	tx, err := asset.FinalizeMultisigPSBT(combined)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The witness holds the empty element consumed by CHECKMULTISIG, the
+	// signatures of cosigners 0 and 2 in the order of their keys in the
+	// script, then the script.
+	packet, err := sharedW.DecodePSBT(combined)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigs := packet.Inputs[0].PartialSigs
+	sort.Slice(sigs, func(i, j int) bool {
+		return bytes.Index(script, sigs[i].PubKey) < bytes.Index(script, sigs[j].PubKey)
+	})
+	witness := tx.TxIn[0].Witness
+	if len(witness) != 4 || len(witness[0]) != 0 || !bytes.Equal(witness[1], sigs[0].Signature) ||
+		!bytes.Equal(witness[2], sigs[1].Signature) || !bytes.Equal(witness[3], script) {
+		t.Fatalf("unexpected witness %x", witness)
+	}
+	for _, sig := range witness[1:3] {
+		if sig[len(sig)-1] != byte(txscript.SigHashAll) {
+			t.Fatalf("signature %x doesn't commit to the whole transaction", sig)
+		}
+	}
+}
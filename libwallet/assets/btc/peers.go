@@ -0,0 +1,122 @@
+package btc
+
+import (
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/internal/peers"
+	"github.com/lightninglabs/neutrino/banman"
+)
+
+// peerWallet is the wallet whose peers are managed. It implements
+// peers.Wallet.
+type peerWallet struct {
+	*Asset
+}
+
+// RemotePeer returns the bitcoind node or Electrum server of a remote
+// backend, nil if the wallet syncs with neutrino.
+func (w peerWallet) RemotePeer() *sharedW.PeerInfo {
+	remoteClient := w.remoteClient()
+	if remoteClient == nil {
+		return nil
+	}
+	info := &sharedW.PeerInfo{Addr: w.RPCConfig().Host}
+	if electrumConn, ok := remoteClient.(*electrumClient); ok {
+		info.Addr, info.SubVer = electrumConn.serverInfo()
+	}
+	if _, height, err := remoteClient.GetBestBlock(); err == nil {
+		info.StartingHeight, info.BestHeight = int64(height), int64(height)
+	}
+	return info
+}
+
+// ChainService returns the neutrino chain service, nil if it isn't created.
+func (w peerWallet) ChainService() peers.ChainService {
+	if cs := w.neutrinoService(); cs != nil {
+		return peerService{cs}
+	}
+	return nil
+}
+
+// DefaultPort returns the default peer port of the network.
+func (w peerWallet) DefaultPort() string {
+	return w.chainParams.DefaultPort
+}
+
+// peerService is the neutrino chain service of the wallet. It implements
+// peers.ChainService.
+type peerService struct {
+	cs ExtraNeutrinoChainService
+}
+
+// Peers returns the peers the chain service is connected to.
+func (s peerService) Peers() []sharedW.PeerInfo {
+	serverPeers := s.cs.Peers()
+	infos := make([]sharedW.PeerInfo, 0, len(serverPeers))
+	for _, sp := range serverPeers {
+		stats := sp.StatsSnapshot()
+		info := sharedW.PeerInfo{
+			ID:             stats.ID,
+			Addr:           stats.Addr,
+			Services:       stats.Services.String(),
+			Version:        stats.Version,
+			SubVer:         stats.UserAgent,
+			StartingHeight: int64(stats.StartingHeight),
+			BestHeight:     int64(stats.LastBlock),
+			PingMicros:     stats.LastPingMicros,
+			BytesSent:      stats.BytesSent,
+			BytesRecv:      stats.BytesRecv,
+			Inbound:        stats.Inbound,
+		}
+		if localAddr := sp.LocalAddr(); localAddr != nil {
+			info.AddrLocal = localAddr.String()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// HasPeer returns true if the chain service is connected to the peer.
+func (s peerService) HasPeer(addr string) bool {
+	return s.cs.PeerByAddr(addr) != nil
+}
+
+// DisconnectNodeByAddr disconnects the peer with the address.
+func (s peerService) DisconnectNodeByAddr(addr string) error {
+	return s.cs.DisconnectNodeByAddr(addr)
+}
+
+// BanPeer disconnects the peer and bans its host. Banman has no reason for
+// manual bans, the peer is treated as misbehaving.
+func (s peerService) BanPeer(addr string) error {
+	return s.cs.BanPeer(addr, banman.ExceededBanThreshold)
+}
+
+// ConnectNode connects to the peer, without reconnecting to it once it
+// disconnects.
+func (s peerService) ConnectNode(addr string) error {
+	return s.cs.ConnectNode(addr, false)
+}
+
+// PeerInfoRaw returns the peers the wallet is connected to.
+func (asset *Asset) PeerInfoRaw() ([]sharedW.PeerInfo, error) {
+	return peers.Info(peerWallet{asset})
+}
+
+// DisconnectPeer disconnects the peer with the address, neutrino may connect
+// to it again later.
+func (asset *Asset) DisconnectPeer(addr string) error {
+	return peers.Disconnect(peerWallet{asset}, addr)
+}
+
+// BanPeer disconnects the peer with the address and bans its host for
+// neutrino.BanDuration.
+func (asset *Asset) BanPeer(addr string) error {
+	return peers.Ban(peerWallet{asset}, addr)
+}
+
+// AddPeer connects to the peer until it disconnects or the wallet stops
+// syncing. Unlike SetSpecificPeer, the peer isn't saved and the other peers
+// stay connected.
+func (asset *Asset) AddPeer(addr string) error {
+	return peers.Add(peerWallet{asset}, addr)
+}
@@ -0,0 +1,48 @@
+package btc
+
+import (
+	"errors"
+	"testing"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+func TestPeerActions(t *testing.T) {
+	asset := newTestWallet(t)
+
+	tests := []struct {
+		backend sharedW.ChainBackend
+		cfg     *sharedW.RPCConfig
+		err     error
+	}{
+		// The neutrino chain service is not started before the wallet syncs.
+		{sharedW.SPVBackend, nil, errors.New(utils.ErrNotConnected)},
+		// The node or server of a remote backend can't be managed.
+		{sharedW.RPCBackend, testRPCConfig, utils.ErrPeerActionUnsupported},
+		{sharedW.ElectrumBackend, nil, utils.ErrPeerActionUnsupported},
+	}
+	for _, tc := range tests {
+		if err := asset.SaveChainBackend(tc.backend, tc.cfg, testPassphrase); err != nil {
+			t.Fatalf("%s: %v", tc.backend, err)
+		}
+
+		actions := map[string]func(string) error{
+			"disconnect": asset.DisconnectPeer,
+			"ban":        asset.BanPeer,
+			"add":        asset.AddPeer,
+		}
+		for name, action := range actions {
+			err := action("127.0.0.1:18444")
+			if err == nil || err.Error() != tc.err.Error() {
+				t.Errorf("%s %s: got error %v, want %v", tc.backend, name, err, tc.err)
+			}
+		}
+		if _, err := asset.PeerInfoRaw(); err == nil {
+			t.Errorf("%s: got the peers of a wallet that is not connected", tc.backend)
+		}
+		if peers := asset.ConnectedPeers(); peers > 0 {
+			t.Errorf("%s: got %d connected peers", tc.backend, peers)
+		}
+	}
+}
@@ -11,12 +11,13 @@ import (
 	w "github.com/btcsuite/btcwallet/wallet"
 	"github.com/btcsuite/btcwallet/walletdb"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/internal/rescan"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 )
 
 // SetBlocksRescanProgressListener sets the blocks rescan progress listener.
 func (asset *Asset) SetBlocksRescanProgressListener(blocksRescanProgressListener *sharedW.BlocksRescanProgressListener) {
-	asset.blocksRescanProgressListener = blocksRescanProgressListener
+	asset.rescanner.SetListener(blocksRescanProgressListener)
 }
 
 // RescanBlocks rescans the blockchain for all addresses in the wallet.
@@ -27,10 +28,120 @@ func (asset *Asset) RescanBlocks() error {
 // RescanBlocksFromHeight rescans the blockchain for all addresses in the wallet
 // starting from the provided block height.
 func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
-	return asset.rescanBlocks(startHeight, nil)
+	return asset.rescanBlocks(startHeight, nil, nil)
 }
 
-func (asset *Asset) rescanBlocks(startHeight int32, addrs []btcutil.Address) error {
+// RescanWithOptions rescans the blockchain from the block selected by opts.
+// If opts sets a gap limit or some accounts, only the addresses of those
+// accounts are rescanned, after deriving gap limit addresses past the last
+// used address of each of them.
+func (asset *Asset) RescanWithOptions(opts *sharedW.RescanOptions) error {
+	// Check before deriving any addresses, rescanBlocks checks it again.
+	if !asset.IsSynced() {
+		return errors.E(utils.ErrNotSynced)
+	}
+
+	estimate, err := asset.EstimateRescan(opts)
+	if err != nil {
+		return err
+	}
+
+	var addrs []btcutil.Address
+	var scope *sharedW.RescanScope
+	if opts.GapLimit > 0 || len(opts.Accounts) > 0 {
+		addrs, err = asset.rescanAddresses(opts.Accounts, opts.GapLimit)
+		if err != nil {
+			return err
+		}
+		scope = &sharedW.RescanScope{Accounts: opts.Accounts, GapLimit: opts.GapLimit}
+	}
+	return asset.rescanBlocks(estimate.StartHeight, addrs, scope)
+}
+
+// EstimateRescan returns the blocks the rescan described by opts covers and
+// how long it's expected to take.
+func (asset *Asset) EstimateRescan(opts *sharedW.RescanOptions) (*sharedW.RescanEstimate, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrBTCNotInitialized
+	}
+
+	return asset.EstimateRescanFromBlocks(opts, asset.GetBestBlockHeight(), func(height int32) (time.Time, error) {
+		bs, err := asset.getblockStamp(height)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return bs.Timestamp, nil
+	})
+}
+
+// rescanAddresses returns the addresses of the accounts, all the accounts if
+// none is given, deriving gapLimit addresses past the ones derived so far on
+// both branches of each account first.
+func (asset *Asset) rescanAddresses(accounts []int32, gapLimit uint32) ([]btcutil.Address, error) {
+	if len(accounts) == 0 {
+		walletAccounts, err := asset.GetAccountsRaw()
+		if err != nil {
+			return nil, err
+		}
+		for _, account := range walletAccounts.Accounts {
+			if account.Number != ImportedAccountNumber {
+				accounts = append(accounts, account.Number)
+			}
+		}
+	}
+
+	var addrs []btcutil.Address
+	err := walletdb.Update(asset.Internal().BTC.Database(), func(dbtx walletdb.ReadWriteTx) error {
+		ns := dbtx.ReadWriteBucket(wAddrMgrBkt)
+		for _, accountNumber := range accounts {
+			scope, account := accountScope(accountNumber)
+			if accountNumber == ImportedAccountNumber {
+				scope, account = GetScope(), ImportedAccountNumber
+			}
+			scopedMgr, err := asset.Internal().BTC.Manager.FetchScopedKeyManager(scope)
+			if err != nil {
+				return err
+			}
+
+			if gapLimit > 0 && account != ImportedAccountNumber {
+				props, err := scopedMgr.AccountProperties(ns, account)
+				if err != nil {
+					return err
+				}
+				err = scopedMgr.ExtendExternalAddresses(ns, account, props.ExternalKeyCount+gapLimit-1)
+				if err != nil {
+					return err
+				}
+				err = scopedMgr.ExtendInternalAddresses(ns, account, props.InternalKeyCount+gapLimit-1)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = scopedMgr.ForEachAccountAddress(ns, account, func(maddr waddrmgr.ManagedAddress) error {
+				addrs = append(addrs, maddr.Address())
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return addrs, err
+}
+
+// rescanBlocks rescans the blockchain from startHeight for the addresses, or
+// for all the addresses of the wallet if addrs is nil. scope records which
+// addresses those are for an interrupted rescan to look for the same ones.
+func (asset *Asset) rescanBlocks(startHeight int32, addrs []btcutil.Address, scope *sharedW.RescanScope) error {
+	return asset.resumeRescanBlocks(startHeight, startHeight, addrs, scope)
+}
+
+// resumeRescanBlocks rescans the blockchain from fromHeight. startHeight is
+// the height the rescan was first started from, it's before fromHeight if an
+// interrupted rescan is resumed.
+func (asset *Asset) resumeRescanBlocks(startHeight, fromHeight int32, addrs []btcutil.Address, scope *sharedW.RescanScope) error {
 	if !asset.IsConnectedToBitcoinNetwork() {
 		return errors.E(utils.ErrNotConnected)
 	}
@@ -47,7 +158,7 @@ func (asset *Asset) rescanBlocks(startHeight int32, addrs []btcutil.Address) err
 		return errors.E(utils.ErrSyncAlreadyInProgress)
 	}
 
-	bs, err := asset.getblockStamp(startHeight)
+	bs, err := asset.getblockStamp(fromHeight)
 	if err != nil {
 		return err
 	}
@@ -56,60 +167,109 @@ func (asset *Asset) rescanBlocks(startHeight int32, addrs []btcutil.Address) err
 		addrs = []btcutil.Address{}
 	}
 
-	asset.syncData.mu.Lock()
-	asset.syncData.isRescan = true
-	asset.syncData.rescanStartTime = time.Now()
-	asset.syncData.mu.Unlock()
+	job := &rescanJob{asset: asset, addrs: addrs, start: *bs}
+	if err := asset.rescanner.Rescan(asset.syncCtx, job, startHeight, fromHeight, scope); err != nil {
+		return err
+	}
 
-	job := &w.RescanJob{
-		Addrs:      addrs,
-		OutPoints:  nil,
-		BlockStamp: *bs,
+	// Attempt to start up the notifications handler.
+	if atomic.CompareAndSwapUint32(&asset.syncData.syncstarted, stop, start) {
+		go asset.handleNotifications()
 	}
 
-	// It submits a rescan job without blocking on finishing the rescan.
-	// The rescan success or failure is logged elsewhere, and the channel
-	// is not required to be read, so discard the return value.
-	errChan := asset.Internal().BTC.SubmitRescan(job)
+	return nil
+}
 
-	// Listen for the rescan finish event and update it.
-	go func() {
-		for err := range errChan {
-			if err != nil {
-				log.Errorf("rescan job failed: %v", err)
-			}
-		}
+// rescanJob is the rescan job of the wallet for the addresses from the start
+// block. It implements rescan.Chain.
+type rescanJob struct {
+	asset *Asset
+	addrs []btcutil.Address
+	start waddrmgr.BlockStamp
+	// syncedTo is the block the wallet was synced to before the rescan.
+	syncedTo waddrmgr.BlockStamp
+}
 
-		asset.syncData.mu.Lock()
-		asset.syncData.isRescan = false
-		asset.syncData.mu.Unlock()
-	}()
+// Submit marks the wallet synced to the start block, for the progress of the
+// rescan to be read from the height the wallet is synced to, and submits the
+// rescan job.
+func (job *rescanJob) Submit() (<-chan error, error) {
+	wallet := job.asset.Internal().BTC
+	job.syncedTo = wallet.Manager.SyncedTo()
+	if err := job.asset.setSyncedTo(&job.start); err != nil {
+		return nil, err
+	}
+	return wallet.SubmitRescan(&w.RescanJob{
+		Addrs:      job.addrs,
+		BlockStamp: job.start,
+	}), nil
+}
 
-	// Attempt to start up the notifications handler.
-	if atomic.CompareAndSwapUint32(&asset.syncData.syncstarted, stop, start) {
-		go asset.handleNotifications()
+// SyncedTo returns the height the wallet is synced to.
+func (job *rescanJob) SyncedTo() int32 {
+	return job.asset.Internal().BTC.Manager.SyncedTo().Height
+}
+
+// BestHeight returns the height of the best block of the chain.
+func (job *rescanJob) BestHeight() int32 {
+	return job.asset.GetBestBlockHeight()
+}
+
+// Stop restarts the wallet and its chain client, which drops the rescan job,
+// synced to the block it was synced to before the rescan. The blocks
+// connected since are synced once the wallet is started again.
+func (job *rescanJob) Stop() error {
+	asset := job.asset
+	wallet := asset.Internal().BTC
+	wallet.Stop() // stops Wallet and chainClient (not chainService)
+	wallet.WaitForShutdown()
+	if chainClient := asset.chainSource(); chainClient != nil {
+		chainClient.WaitForShutdown()
+	}
+
+	if err := asset.setSyncedTo(&job.syncedTo); err != nil {
+		log.Errorf("(%v) Unable to reset the block the wallet is synced to: %v", asset.GetWalletName(), err)
 	}
 
+	wallet.Start()
+
+	// Stopped bitcoind and Electrum clients can't be restarted, a new one is
+	// needed.
+	asset.renewRemoteClient()
+	chainClient := asset.chainSource()
+	if chainClient == nil {
+		return errors.New(utils.ErrNotConnected)
+	}
+	if err := chainClient.Start(); err != nil {
+		return fmt.Errorf("couldn't start %s client: %v", chainClient.BackEnd(), err)
+	}
+	wallet.SynchronizeRPC(chainClient)
 	return nil
 }
 
+// setSyncedTo marks the wallet synced to the block.
+func (asset *Asset) setSyncedTo(bs *waddrmgr.BlockStamp) error {
+	return walletdb.Update(asset.Internal().BTC.Database(), func(dbtx walletdb.ReadWriteTx) error {
+		return asset.Internal().BTC.Manager.SetSyncedTo(dbtx.ReadWriteBucket(wAddrMgrBkt), bs)
+	})
+}
+
 // IsRescanning returns true if the wallet is currently rescanning the blockchain.
 func (asset *Asset) IsRescanning() bool {
+	if asset.rescanner.IsRunning() {
+		return true
+	}
+
 	asset.syncData.mu.RLock()
 	defer asset.syncData.mu.RUnlock()
 
 	return asset.syncData.isRescan
 }
 
-// CancelRescan cancels the current rescan.
+// CancelRescan stops the current rescan and returns once it's stopped. A
+// canceled rescan isn't resumed on the next sync.
 func (asset *Asset) CancelRescan() {
-	asset.syncData.mu.Lock()
-	asset.syncData.isRescan = false
-	asset.syncData.mu.Unlock()
-
-	if asset.blocksRescanProgressListener != nil {
-		asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, nil)
-	}
+	asset.rescanner.Cancel()
 }
 
 // rescanAsync initiates a full wallet recovery (used address discovery
@@ -134,7 +294,9 @@ func (asset *Asset) rescanAsync() error {
 
 	asset.Internal().BTC.Stop() // stops Wallet and chainClient (not chainService)
 	asset.Internal().BTC.WaitForShutdown()
-	asset.chainClient.WaitForShutdown()
+	if chainClient := asset.chainSource(); chainClient != nil {
+		chainClient.WaitForShutdown()
+	}
 
 	// Attempt to drop the the tx history. See the btcwallet/cmd/dropwtxmgr app
 	// for more information. Because of how often a forces rescan will be triggered,
@@ -152,15 +314,62 @@ func (asset *Asset) rescanAsync() error {
 	log.Info("Starting wallet...")
 	asset.Internal().BTC.Start()
 
-	if err := asset.chainClient.Start(); err != nil {
-		return fmt.Errorf("couldn't start Neutrino client: %v", err)
+	// Stopped bitcoind and Electrum clients can't be restarted, a new one is
+	// needed.
+	asset.renewRemoteClient()
+	chainClient := asset.chainSource()
+	if chainClient == nil {
+		return errors.New(utils.ErrNotConnected)
+	}
+	if err := chainClient.Start(); err != nil {
+		return fmt.Errorf("couldn't start %s client: %v", chainClient.BackEnd(), err)
 	}
 
 	log.Infof("Synchronizing wallet (%s) with network...", asset.GetWalletName())
-	asset.Internal().BTC.SynchronizeRPC(asset.chainClient)
+	asset.Internal().BTC.SynchronizeRPC(chainClient)
 	return nil
 }
 
+// resumeRescan restarts a rescan that was interrupted before it finished from
+// the height recorded in the sync checkpoint.
+func (asset *Asset) resumeRescan() {
+	checkpoint := asset.SyncCheckpoint()
+	if !checkpoint.Rescanning || asset.IsRescanning() {
+		return
+	}
+
+	// The gap limit addresses were derived when the rescan started, only
+	// the addresses of the accounts are looked for again.
+	var addrs []btcutil.Address
+	if scope := checkpoint.RescanScope; scope != nil {
+		var err error
+		addrs, err = asset.rescanAddresses(scope.Accounts, 0)
+		if err != nil {
+			log.Errorf("(%v) Resuming the rescan failed: %v", asset.GetWalletName(), err)
+			return
+		}
+	}
+
+	log.Infof("(%v) Resuming the interrupted rescan from block %d", asset.GetWalletName(), checkpoint.RescanHeight)
+	if err := asset.resumeRescanBlocks(checkpoint.RescanStartHeight, checkpoint.RescanHeight, addrs, checkpoint.RescanScope); err != nil {
+		log.Errorf("(%v) Resuming the rescan failed: %v", asset.GetWalletName(), err)
+	}
+}
+
+// resumeRecovery resumes the address discovery of a restored wallet that was
+// interrupted. The upstream wallet keeps the addresses found so far and the
+// height it got to, so unlike forceRescan the "synced to" field is kept.
+func (asset *Asset) resumeRecovery() {
+	log.Infof("(%v) Resuming address discovery from block %d", asset.GetWalletName(),
+		asset.Internal().BTC.Manager.SyncedTo().Height)
+
+	asset.syncData.mu.Lock()
+	asset.syncData.isRescan = true
+	asset.syncData.mu.Unlock()
+
+	asset.handleSyncUIUpdate()
+}
+
 // forceRescan forces a full rescan with active address discovery on wallet
 // restart by setting the "synced to" field to nil.
 func (asset *Asset) forceRescan() {
@@ -334,40 +543,12 @@ func (asset *Asset) getBirthdayBlock() (int32, bool, error) {
 func (asset *Asset) updateRescanProgress(height int32) {
 	if asset.syncData.rescanStartHeight == nil {
 		asset.syncData.rescanStartHeight = &height
+		asset.syncData.rescanStartTime = time.Now()
 	}
 
-	headersFetchedSoFar := float64(height - *asset.syncData.rescanStartHeight)
-	if headersFetchedSoFar < 1 {
-		headersFetchedSoFar = 1
-	}
-
-	remainingHeaders := float64(asset.GetBestBlockHeight() - height)
-	if remainingHeaders < 1 {
-		remainingHeaders = 1
-	}
-
-	allHeadersToFetch := headersFetchedSoFar + remainingHeaders
-
-	rescanProgressReport := &sharedW.HeadersRescanProgressReport{
-		CurrentRescanHeight: height,
-		TotalHeadersToScan:  int32(allHeadersToFetch),
-		WalletID:            asset.ID,
-	}
-
-	elapsedRescanTime := time.Since(asset.syncData.rescanStartTime)
-	rescanRate := headersFetchedSoFar / float64(rescanProgressReport.TotalHeadersToScan)
-
-	rescanProgressReport.RescanProgress = int32((headersFetchedSoFar * 100) / allHeadersToFetch)
-	estimatedTotalRescanTime := float64(elapsedRescanTime) / rescanRate
-	rescanProgressReport.RescanTimeRemaining = secondsToDuration(estimatedTotalRescanTime) - elapsedRescanTime
-
-	rescanProgressReport.GeneralSyncProgress = &sharedW.GeneralSyncProgress{
-		TotalSyncProgress:  rescanProgressReport.RescanProgress,
-		TotalTimeRemaining: rescanProgressReport.RescanTimeRemaining,
-	}
-
-	if asset.blocksRescanProgressListener != nil {
-		asset.blocksRescanProgressListener.OnBlocksRescanProgress(rescanProgressReport)
+	if listener := asset.rescanner.Listener(); listener != nil {
+		listener.OnBlocksRescanProgress(rescan.ProgressReport(asset.ID, *asset.syncData.rescanStartHeight,
+			height, asset.GetBestBlockHeight(), asset.syncData.rescanStartTime))
 	}
 }
 
@@ -377,14 +558,20 @@ func (asset *Asset) getblockStamp(height int32) (*waddrmgr.BlockStamp, error) {
 		return nil, fmt.Errorf("invalid block height provided: Error: %v", err)
 	}
 
-	block, err := asset.chainClient.GetBlock(startHash)
+	chainClient := asset.chainSource()
+	if chainClient == nil {
+		return nil, errors.New(utils.ErrNotConnected)
+	}
+
+	// Only the header is needed, Electrum servers don't serve blocks.
+	header, err := chainClient.GetBlockHeader(startHash)
 	if err != nil {
 		return nil, fmt.Errorf("invalid block hash provided: Error: %v", err)
 	}
 
 	return &waddrmgr.BlockStamp{
-		Hash:      block.BlockHash(),
+		Hash:      header.BlockHash(),
 		Height:    height,
-		Timestamp: block.Header.Timestamp,
+		Timestamp: header.Timestamp,
 	}, nil
 }
@@ -0,0 +1,67 @@
+package btc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/walletdb"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// startRecovery runs the recovery steps of starting the sync of the wallet.
+// The sync itself fails since the RPC password is locked.
+func startRecovery(t *testing.T, asset *Asset) {
+	t.Helper()
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	asset.syncCtx, asset.cancelSync = ctx, cancel
+	if err := asset.startWallet(); !errors.Is(err, utils.ErrRPCPassLocked) {
+		t.Fatalf("got error %v starting the sync, want %v", err, utils.ErrRPCPassLocked)
+	}
+}
+
+func TestResumeRecovery(t *testing.T) {
+	params := newTestParams(t)
+	asset, shutdown := createTestWallet(t, params)
+	if err := asset.SaveChainBackend(sharedW.RPCBackend, testRPCConfig, testPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	asset, _ = reopenTestWallet(t, asset, params, shutdown)
+	asset.IsRestored = true
+	asset.HasDiscoveredAccounts = false
+
+	// The first sync of a restored wallet discovers its addresses from the
+	// genesis block.
+	startRecovery(t, asset)
+	if !asset.SyncCheckpoint().RecoveryStarted {
+		t.Fatal("the recovery wasn't recorded")
+	}
+	if height := asset.Internal().BTC.Manager.SyncedTo().Height; height != 0 {
+		t.Fatalf("the recovery starts from block %d, want the genesis block", height)
+	}
+
+	// The recovery got to block 5 before the sync stopped.
+	const syncedTo = 5
+	err := walletdb.Update(asset.Internal().BTC.Database(), func(dbtx walletdb.ReadWriteTx) error {
+		ns := dbtx.ReadWriteBucket(wAddrMgrBkt)
+		for height := int32(1); height <= syncedTo; height++ {
+			bs := &waddrmgr.BlockStamp{Height: height, Hash: chainhash.Hash{byte(height)}, Timestamp: time.Now()}
+			if err := asset.Internal().BTC.Manager.SetSyncedTo(ns, bs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The next sync resumes the recovery instead of starting over.
+	startRecovery(t, asset)
+	if height := asset.Internal().BTC.Manager.SyncedTo().Height; height != syncedTo {
+		t.Errorf("the recovery resumes from block %d, want %d", height, syncedTo)
+	}
+}
@@ -0,0 +1,123 @@
+package btc
+
+import (
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/wallet/txsizes"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// defaultAccountName is the name btcwallet gives the default account of every
+// key scope.
+const defaultAccountName = "default"
+
+// IsTaprootAccount returns true if the account number belongs to an account
+// of the BIP-86 key scope, whose addresses are P2TR (bc1p) addresses.
+func IsTaprootAccount(accountNumber int32) bool {
+	return accountNumber >= TaprootAccountOffset && accountNumber < NestedSegwitAccountOffset
+}
+
+// SupportsTaprootAccounts returns true if Taproot accounts can be created in
+// the wallet. Watch only wallets only hold the account they were created
+// from.
+func (asset *Asset) SupportsTaprootAccounts() bool {
+	if !asset.WalletOpened() || asset.IsWatchingOnlyWallet() {
+		return false
+	}
+	_, err := asset.Internal().BTC.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0086)
+	return err == nil
+}
+
+// CreateTaprootAccount creates a new account under the BIP-86 key scope with
+// the provided account name. The addresses of the account are P2TR (bc1p)
+// addresses. The first Taproot account takes the BIP-86 default account
+// (m/86'/coin'/0') so that it can be found by other Taproot wallets.
+func (asset *Asset) CreateTaprootAccount(accountName, privPass string) (int32, error) {
+	if !asset.WalletOpened() {
+		return -1, utils.ErrBTCNotInitialized
+	}
+
+	if !asset.SupportsTaprootAccounts() {
+		return -1, errors.New(utils.ErrWalletIsWatchOnly)
+	}
+
+	if asset.HasAccount(accountName) {
+		return -1, errors.New(utils.ErrExist)
+	}
+
+	err := asset.UnlockWallet(privPass)
+	if err != nil {
+		return -1, err
+	}
+	defer asset.LockWallet()
+
+	props, err := asset.Internal().BTC.AccountProperties(waddrmgr.KeyScopeBIP0086, DefaultAccountNum)
+	if err != nil {
+		return -1, err
+	}
+
+	if !isTaprootAccountInUse(props) {
+		err = asset.Internal().BTC.RenameAccount(waddrmgr.KeyScopeBIP0086, DefaultAccountNum, accountName)
+		if err != nil {
+			return -1, utils.TranslateError(err)
+		}
+		return scopedAccountNumber(waddrmgr.KeyScopeBIP0086, DefaultAccountNum), nil
+	}
+
+	accountNumber, err := asset.Internal().BTC.NextAccount(waddrmgr.KeyScopeBIP0086, accountName)
+	if err != nil {
+		return -1, utils.TranslateError(err)
+	}
+
+	return scopedAccountNumber(waddrmgr.KeyScopeBIP0086, accountNumber), nil
+}
+
+// isTaprootAccountInUse returns false for the default account of the BIP-86
+// key scope until it is claimed by CreateTaprootAccount or receives funds,
+// e.g. when a wallet restored from a seed used elsewhere discovers it.
+func isTaprootAccountInUse(props *waddrmgr.AccountProperties) bool {
+	if props.AccountNumber != DefaultAccountNum {
+		return true
+	}
+	return props.AccountName != defaultAccountName ||
+		props.ExternalKeyCount > 0 || props.InternalKeyCount > 0
+}
+
+// estimateTxVirtualSize returns the worst case virtual size of a signed
+// transaction spending outputs with the provided pkScripts and paying to
+// txOuts. A change output with a script of changeScriptSize bytes is included
+// if changeScriptSize is greater than zero.
+func estimateTxVirtualSize(prevScripts [][]byte, txOuts []*wire.TxOut, changeScriptSize int) int {
+	var numP2PKH, numP2TR, numP2WPKH, numNestedP2WPKH int
+	for _, pkScript := range prevScripts {
+		switch {
+		case txscript.IsPayToTaproot(pkScript):
+			numP2TR++
+		case txscript.IsPayToWitnessPubKeyHash(pkScript):
+			numP2WPKH++
+		case txscript.IsPayToScriptHash(pkScript):
+			numNestedP2WPKH++
+		default:
+			numP2PKH++
+		}
+	}
+
+	return txsizes.EstimateVirtualSize(numP2PKH, numP2TR, numP2WPKH, numNestedP2WPKH,
+		txOuts, changeScriptSize)
+}
+
+// changeScriptSize returns the size of the change output script for the
+// account. Change is sent back to an internal address of the account, which is
+// a P2WPKH address for BIP-49 accounts.
+func (asset *Asset) changeScriptSize(accountNumber int32) int {
+	switch scope, _ := accountScope(accountNumber); scope.Purpose {
+	case waddrmgr.KeyScopeBIP0086.Purpose:
+		return txsizes.P2TRPkScriptSize
+	case waddrmgr.KeyScopeBIP0044.Purpose:
+		return txsizes.P2PKHPkScriptSize
+	default:
+		return txsizes.P2WPKHPkScriptSize
+	}
+}
@@ -0,0 +1,91 @@
+package btc
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/btcsuite/btcwallet/wallet/txauthor"
+)
+
+// TestSignTransaction signs a transaction spending a P2PKH, a P2WPKH and a
+// P2TR output of the wallet.
+func TestSignTransaction(t *testing.T) {
+	asset := newTestWallet(t)
+
+	scopes := []waddrmgr.KeyScope{waddrmgr.KeyScopeBIP0044, waddrmgr.KeyScopeBIP0084, waddrmgr.KeyScopeBIP0086}
+	msgTx := wire.NewMsgTx(wire.TxVersion)
+	prevScripts := make([][]byte, len(scopes))
+	prevValues := make([]btcutil.Amount, len(scopes))
+	for i, scope := range scopes {
+		pkScript, err := txscript.PayToAddrScript(deriveAddress(t, asset, scope))
+		if err != nil {
+			t.Fatal(err)
+		}
+		prevScripts[i] = pkScript
+		prevValues[i] = btcutil.Amount(i+1) * 1e6
+		msgTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{byte(i + 1)}, uint32(i)), nil, nil))
+	}
+	payScript, err := txscript.PayToAddrScript(deriveAddress(t, asset, waddrmgr.KeyScopeBIP0084))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgTx.AddTxOut(wire.NewTxOut(5e6, payScript))
+
+	if err = asset.signTransaction(msgTx, prevScripts, prevValues); err == nil {
+		t.Fatal("signed a transaction with the wallet locked")
+	}
+
+	if err = asset.UnlockWallet(testPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	defer asset.LockWallet()
+	if err = asset.signTransaction(msgTx, prevScripts, prevValues); err != nil {
+		t.Fatal(err)
+	}
+
+	// The P2PKH input is signed with a signature script, the segwit inputs
+	// with a witness. The taproot key path spend is a single Schnorr
+	// signature followed by its SIGHASH_ALL type.
+	p2pkh, p2wpkh, p2tr := msgTx.TxIn[0], msgTx.TxIn[1], msgTx.TxIn[2]
+	if len(p2pkh.SignatureScript) == 0 || len(p2pkh.Witness) != 0 {
+		t.Errorf("got P2PKH signature script %x and witness %x", p2pkh.SignatureScript, p2pkh.Witness)
+	}
+	if len(p2wpkh.SignatureScript) != 0 || len(p2wpkh.Witness) != 2 {
+		t.Errorf("got P2WPKH signature script %x and witness %x", p2wpkh.SignatureScript, p2wpkh.Witness)
+	}
+	if len(p2tr.SignatureScript) != 0 || len(p2tr.Witness) != 1 || len(p2tr.Witness[0]) != 65 ||
+		txscript.SigHashType(p2tr.Witness[0][64]) != txscript.SigHashAll {
+		t.Errorf("got P2TR signature script %x and witness %x", p2tr.SignatureScript, p2tr.Witness)
+	}
+
+	// The taproot signature commits to the amounts of every input, unlike
+	// the P2WPKH signature, which only commits to its own amount.
+	verify := func(index int, prevValues []btcutil.Amount) error {
+		fetcher, err := txauthor.TXPrevOutFetcher(msgTx, prevScripts, prevValues)
+		if err != nil {
+			t.Fatal(err)
+		}
+		flags := txscript.StandardVerifyFlags
+		vm, err := txscript.NewEngine(prevScripts[index], msgTx, index, flags, nil,
+			txscript.NewTxSigHashes(msgTx, fetcher), int64(prevValues[index]), fetcher)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return vm.Execute()
+	}
+	otherValues := []btcutil.Amount{prevValues[0] + 1, prevValues[1], prevValues[2]}
+	if err = verify(1, otherValues); err != nil {
+		t.Errorf("the P2WPKH signature depends on the amount of another input: %v", err)
+	}
+	if err = verify(2, otherValues); err == nil {
+		t.Error("the P2TR signature does not commit to the amount of another input")
+	}
+
+	if err = asset.signTransaction(msgTx, prevScripts[:2], prevValues[:2]); err == nil {
+		t.Error("signed a transaction without the previous outputs of every input")
+	}
+}
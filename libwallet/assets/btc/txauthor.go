@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
-	"sort"
 	"sync"
 	"time"
 
@@ -38,6 +37,7 @@ type TxAuthor struct {
 	needsConstruct bool
 
 	selectedUXTOs []*sharedW.UnspentOutput
+	coinSelection sharedW.CoinSelectionStrategy
 
 	mu sync.RWMutex
 }
@@ -61,10 +61,26 @@ func (asset *Asset) NewUnsignedTx(sourceAccountNumber int32, utxos []*sharedW.Un
 		destinations:        make(map[int]*sharedW.TransactionDestination, 0),
 		needsConstruct:      true,
 		selectedUXTOs:       utxos,
+		coinSelection:       asset.DefaultCoinSelectionStrategy(),
 	}
 	return nil
 }
 
+// SetCoinSelectionStrategy sets the strategy used to select the outputs that
+// fund the transaction when they are not selected manually.
+func (asset *Asset) SetCoinSelectionStrategy(strategy sharedW.CoinSelectionStrategy) error {
+	if !strategy.IsValid() {
+		return errors.New(utils.ErrInvalid)
+	}
+
+	asset.TxAuthoredInfo.mu.Lock()
+	defer asset.TxAuthoredInfo.mu.Unlock()
+
+	asset.TxAuthoredInfo.coinSelection = strategy
+	asset.TxAuthoredInfo.needsConstruct = true
+	return nil
+}
+
 // GetUnsignedTx returns the unsigned transaction.
 func (asset *Asset) GetUnsignedTx() *TxAuthor {
 	return asset.TxAuthoredInfo
@@ -75,7 +91,8 @@ func (asset *Asset) IsUnsignedTxExist() bool {
 	return asset.TxAuthoredInfo != nil
 }
 
-// ComputeTxSizeEstimation computes the estimated size of the final raw transaction.
+// ComputeTxSizeEstimation computes the estimated virtual size of the final raw
+// transaction, accounting for the type of each input.
 func (asset *Asset) ComputeTxSizeEstimation(dstAddress string, utxos []*sharedW.UnspentOutput) (int, error) {
 	if len(utxos) == 0 {
 		return 0, nil
@@ -86,8 +103,15 @@ func (asset *Asset) ComputeTxSizeEstimation(dstAddress string, utxos []*sharedW.
 	}
 
 	var sendAmount int64
+	prevScripts := make([][]byte, 0, len(utxos))
 	for _, c := range utxos {
 		sendAmount += c.Amount.ToInt()
+
+		script, err := hex.DecodeString(c.ScriptPubKey)
+		if err != nil {
+			return -1, fmt.Errorf("invalid utxo pkScript: %v", err)
+		}
+		prevScripts = append(prevScripts, script)
 	}
 
 	output, err := txhelper.MakeBTCTxOutput(dstAddress, sendAmount, asset.chainParams)
@@ -95,7 +119,12 @@ func (asset *Asset) ComputeTxSizeEstimation(dstAddress string, utxos []*sharedW.
 		return -1, fmt.Errorf("computing utxo size failed: %v", err)
 	}
 
-	estimatedSize := txsizes.EstimateSerializeSize(len(utxos), []*wire.TxOut{output}, true)
+	changeSize := txsizes.P2WPKHPkScriptSize
+	if asset.TxAuthoredInfo != nil {
+		changeSize = asset.changeScriptSize(int32(asset.TxAuthoredInfo.sourceAccountNumber))
+	}
+
+	estimatedSize := estimateTxVirtualSize(prevScripts, []*wire.TxOut{output}, changeSize)
 	return estimatedSize, nil
 }
 
@@ -223,6 +252,10 @@ func (asset *Asset) EstimateFeeAndSize() (*sharedW.TxFeeAndSize, error) {
 	// Since the fee is already calculated when computing the change source out
 	// or single destination to send max amount, no need to repeat calculations again.
 	feeToSpend := asset.TxAuthoredInfo.txSpendAmount - sendAmount
+	if unsignedTx.ChangeIndex < 0 {
+		// Without a change output, the inputs not sent are paid as fee.
+		feeToSpend = unsignedTx.TotalInput - sendAmount
+	}
 	feeAmount := &sharedW.Amount{
 		UnitValue: int64(feeToSpend),
 		CoinValue: feeToSpend.ToBTC(),
@@ -237,11 +270,9 @@ func (asset *Asset) EstimateFeeAndSize() (*sharedW.TxFeeAndSize, error) {
 		}
 	}
 
-	// TODO: confirm if the size on UI needs to be in vB to B.
-	// This estimation returns size in Bytes (B).
-	estimatedSize := txsizes.EstimateSerializeSize(len(unsignedTx.Tx.TxIn), unsignedTx.Tx.TxOut, true)
-	// This estimation returns size in virtualBytes (vB).
-	// estimatedSize := feeToSpend.ToBTC() / fallBackFeeRate.ToBTC()
+	// This estimation returns size in virtualBytes (vB), the change output is
+	// already part of the unsigned tx outputs.
+	estimatedSize := estimateTxVirtualSize(unsignedTx.PrevScripts, unsignedTx.Tx.TxOut, 0)
 
 	return &sharedW.TxFeeAndSize{
 		FeeRate:             asset.GetUserFeeRate().ToInt(),
@@ -275,6 +306,59 @@ func (asset *Asset) EstimateMaxSendAmount() (*sharedW.Amount, error) {
 	}, nil
 }
 
+// signTransaction signs every input of msgTx, which spends outputs with the
+// provided pkScripts and values, and checks the signatures by executing the
+// script pairs. The wallet must be unlocked.
+func (asset *Asset) signTransaction(msgTx *wire.MsgTx, prevScripts [][]byte, prevValues []btcutil.Amount) error {
+	// Taproot signatures commit to the amounts and scripts of every input, the
+	// sighashes are computed from all the previous outputs.
+	prevOutFetcher, err := txauthor.TXPrevOutFetcher(msgTx, prevScripts, prevValues)
+	if err != nil {
+		log.Errorf("fetching previous outputs failed: %v", err)
+		return err
+	}
+	sigHashes := txscript.NewTxSigHashes(msgTx, prevOutFetcher)
+
+	for index, txIn := range msgTx.TxIn {
+		previousTXout := prevOutFetcher.FetchPrevOutput(txIn.PreviousOutPoint)
+
+		var witness wire.TxWitness
+		var signature []byte
+		if txscript.IsPayToPubKeyHash(previousTXout.PkScript) {
+			signature, err = asset.signP2PKHInput(msgTx, index, previousTXout.PkScript)
+		} else {
+			witness, signature, err = asset.Internal().BTC.ComputeInputScript(
+				msgTx, previousTXout, index, sigHashes, txscript.SigHashAll, nil,
+			)
+		}
+		if err != nil {
+			log.Errorf("generating input signatures failed: %v", err)
+			return err
+		}
+
+		msgTx.TxIn[index].Witness = witness
+		msgTx.TxIn[index].SignatureScript = signature
+
+		// Prove that the transaction has been validly signed by executing the
+		// script pair.
+		flags := txscript.ScriptBip16 | txscript.ScriptVerifyDERSignatures |
+			txscript.ScriptStrictMultiSig | txscript.ScriptDiscourageUpgradableNops |
+			txscript.ScriptVerifyWitness | txscript.ScriptVerifyTaproot
+		vm, err := txscript.NewEngine(previousTXout.PkScript, msgTx, index, flags, nil, sigHashes,
+			previousTXout.Value, prevOutFetcher)
+		if err != nil {
+			log.Errorf("creating validation engine failed: %v", err)
+			return err
+		}
+		if err := vm.Execute(); err != nil {
+			log.Errorf("executing the validation engine failed: %v", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Broadcast broadcasts the transaction to the network.
 func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byte, error) {
 	if !asset.WalletOpened() {
@@ -313,43 +397,9 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byt
 	// https://bitcoin.stackexchange.com/questions/48384/why-bitcoin-core-creates-time-locked-transactions-by-default
 	msgTx.LockTime = uint32(asset.GetBestBlockHeight())
 
-	for index, txIn := range msgTx.TxIn {
-		_, previousTXout, _, _, err := asset.Internal().BTC.FetchInputInfo(&txIn.PreviousOutPoint)
-		if err != nil {
-			log.Errorf("fetch previous outpoint txout failed: %v", err)
-			return nil, err
-		}
-
-		prevOutScript := unsignedTx.PrevScripts[index]
-		prevOutAmount := int64(asset.TxAuthoredInfo.inputValues[index])
-		prevOutFetcher := txscript.NewCannedPrevOutputFetcher(prevOutScript, prevOutAmount)
-		sigHashes := txscript.NewTxSigHashes(msgTx, prevOutFetcher)
-
-		witness, signature, err := asset.Internal().BTC.ComputeInputScript(
-			msgTx, previousTXout, index, sigHashes, txscript.SigHashAll, nil,
-		)
-		if err != nil {
-			log.Errorf("generating input signatures failed: %v", err)
-			return nil, err
-		}
-
-		msgTx.TxIn[index].Witness = witness
-		msgTx.TxIn[index].SignatureScript = signature
-
-		// Prove that the transaction has been validly signed by executing the
-		// script pair.
-		flags := txscript.ScriptBip16 | txscript.ScriptVerifyDERSignatures |
-			txscript.ScriptStrictMultiSig | txscript.ScriptDiscourageUpgradableNops
-		vm, err := txscript.NewEngine(previousTXout.PkScript, msgTx, 0, flags, nil, nil,
-			prevOutAmount, prevOutFetcher)
-		if err != nil {
-			log.Errorf("creating validation engine failed: %v", err)
-			return nil, err
-		}
-		if err := vm.Execute(); err != nil {
-			log.Errorf("executing the validation engine failed: %v", err)
-			return nil, err
-		}
+	err = asset.signTransaction(msgTx, unsignedTx.PrevScripts, unsignedTx.PrevInputValues)
+	if err != nil {
+		return nil, err
 	}
 
 	var serializedTransaction bytes.Buffer
@@ -368,7 +418,19 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byt
 	}
 
 	err = asset.Internal().BTC.PublishTransaction(msgTx, transactionLabel)
-	return nil, utils.TranslateError(err)
+	if err != nil {
+		return nil, utils.TranslateError(err)
+	}
+
+	txHash := msgTx.TxHash()
+	if transactionLabel != "" {
+		// The label is saved by the wallet as well, so that it can be
+		// edited later.
+		if err = asset.SetTransactionLabel(txHash.String(), transactionLabel); err != nil {
+			log.Errorf("error saving the transaction label: %v", err)
+		}
+	}
+	return txHash[:], nil
 }
 
 func (asset *Asset) unsignedTransaction() (*txauthor.AuthoredTx, error) {
@@ -385,6 +447,9 @@ func (asset *Asset) unsignedTransaction() (*txauthor.AuthoredTx, error) {
 	return asset.TxAuthoredInfo.unsignedTx, nil
 }
 
+// constructTransaction builds the unsigned transaction paying the
+// destinations. A change output that would be dust is left out, its value is
+// paid as fee.
 func (asset *Asset) constructTransaction() (*txauthor.AuthoredTx, error) {
 	var err error
 	outputs := make([]*wire.TxOut, 0)
@@ -442,34 +507,85 @@ func (asset *Asset) constructTransaction() (*txauthor.AuthoredTx, error) {
 	}
 
 	// if preset with a selected list of UTXOs exists, use them instead.
+	// The coin selection strategy only applies to the outputs selected by
+	// the wallet, the outputs selected manually are spent largest first.
+	strategy := sharedW.CoinSelectionLargestFirst
 	unspents := asset.TxAuthoredInfo.selectedUXTOs
 	if len(unspents) == 0 {
 		unspents, err = asset.UnspentOutputs(int32(asset.TxAuthoredInfo.sourceAccountNumber))
 		if err != nil {
 			return nil, err
 		}
+		unspents = sharedW.UnfrozenOutputs(unspents)
+		strategy = asset.TxAuthoredInfo.coinSelection
 	}
 
-	inputSource := asset.makeInputSource(unspents, sendMax)
+	selection := coinSelection(strategy, unspents, outputs, changeSource.ScriptSize, setFeeRate)
+	inputSource := asset.makeInputSource(unspents, sendMax, selection)
 	unsignedTx, err := txauthor.NewUnsignedTransaction(outputs, setFeeRate, inputSource, changeSource)
 	if err != nil {
 		return nil, fmt.Errorf("creating unsigned tx failed: %v", err)
 	}
 
 	if unsignedTx.ChangeIndex == -1 {
-		// The change amount is zero or the Txout is likely to be considered as dust
-		// if sent to the mempool the whole tx will be rejected.
-		return nil, errors.New("adding the change txOut or sendMax tx failed")
+		if sendMax {
+			// The change amount is zero or the Txout is likely to be considered as dust
+			// if sent to the mempool the whole tx will be rejected.
+			return nil, errors.New("adding the change txOut or sendMax tx failed")
+		}
+		// The change would be dust, txauthor leaves it out and it is paid as
+		// fee whatever the coin selection strategy.
+		return unsignedTx, nil
+	}
+
+	change := unsignedTx.Tx.TxOut[unsignedTx.ChangeIndex]
+	if !sendMax && selection.Changeless && change.Value <= selection.CostOfChange {
+		// The branch and bound search found outputs paying the target
+		// without change, the change costs more to create and spend than it
+		// is worth and is paid as fee instead. The change output is always
+		// the last one.
+		unsignedTx.Tx.TxOut = unsignedTx.Tx.TxOut[:unsignedTx.ChangeIndex]
+		unsignedTx.ChangeIndex = -1
+		return unsignedTx, nil
 	}
 
 	// Confirm that the change output is valid too.
-	if err = txrules.CheckOutput(unsignedTx.Tx.TxOut[unsignedTx.ChangeIndex], setFeeRate); err != nil {
+	if err = txrules.CheckOutput(change, setFeeRate); err != nil {
 		return nil, fmt.Errorf("change txOut validation failed %v", err)
 	}
 
 	return unsignedTx, nil
 }
 
+// coinSelection returns the values used by the coin selection strategy to
+// fund the outputs. The cost of an input is the fee of the largest input
+// that spends one of the unspent outputs.
+func coinSelection(strategy sharedW.CoinSelectionStrategy, unspents []*sharedW.UnspentOutput,
+	outputs []*wire.TxOut, changeScriptSize int, feeRate btcutil.Amount) *sharedW.CoinSelection {
+	var inputSize int
+	for _, unspent := range unspents {
+		script, err := hex.DecodeString(unspent.ScriptPubKey)
+		if err != nil {
+			continue
+		}
+		if size := txsizes.GetMinInputVirtualSize(script); size > inputSize {
+			inputSize = size
+		}
+	}
+
+	txSize := txsizes.EstimateVirtualSize(0, 0, 0, 0, outputs, changeScriptSize)
+	changeSize := txsizes.EstimateVirtualSize(0, 0, 0, 0, nil, changeScriptSize) -
+		txsizes.EstimateVirtualSize(0, 0, 0, 0, nil, 0)
+	inputCost := txrules.FeeForSerializeSize(feeRate, inputSize)
+
+	return &sharedW.CoinSelection{
+		Strategy:     strategy,
+		Target:       int64(txauthor.SumOutputValues(outputs) + txrules.FeeForSerializeSize(feeRate, txSize)),
+		InputCost:    int64(inputCost),
+		CostOfChange: int64(txrules.FeeForSerializeSize(feeRate, changeSize) + inputCost),
+	}
+}
+
 // changeSource derives an internal address from the source wallet and account
 // for this unsigned tx, if a change address had not been previously derived.
 // The derived (or previously derived) address is used to prepare a
@@ -477,7 +593,8 @@ func (asset *Asset) constructTransaction() (*txauthor.AuthoredTx, error) {
 func (asset *Asset) changeSource() (*txauthor.ChangeSource, error) {
 	if asset.TxAuthoredInfo.changeAddress == "" {
 		changeAccount := asset.TxAuthoredInfo.sourceAccountNumber
-		address, err := asset.Internal().BTC.NewChangeAddress(changeAccount, GetScope())
+		scope, acct := accountScope(int32(changeAccount))
+		address, err := asset.Internal().BTC.NewChangeAddress(acct, scope)
 		if err != nil {
 			return nil, fmt.Errorf("change address error: %v", err)
 		}
@@ -502,28 +619,22 @@ func (asset *Asset) validateSendAmount(sendMax bool, satoshiAmount int64) error
 }
 
 // makeInputSource creates an InputSource that creates inputs for every unspent
-// output with non-zero output values. The importsource aims to create the leanest
-// transaction possible. It plans not to spend all the utxos available when servicing
-// the current transaction spending amount if possible. The sendMax shows that
-// all utxos must be spent without any balance(unspent utxo) left in the account.
-func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bool) txauthor.InputSource {
+// output with non-zero output values. Unless sendMax is set, the inputs that
+// fund the transaction are picked by the coin selection strategy, which plans
+// not to spend all the utxos available when servicing the current transaction
+// spending amount if possible. The sendMax shows that all utxos must be spent
+// without any balance(unspent utxo) left in the account.
+func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bool, selection *sharedW.CoinSelection) txauthor.InputSource {
 	var (
 		sourceErr       error
 		totalInputValue btcutil.Amount
 
+		candidates  = make([]*sharedW.UnspentOutput, 0, len(outputs))
 		inputs      = make([]*wire.TxIn, 0, len(outputs))
 		inputValues = make([]btcutil.Amount, 0, len(outputs))
 		pkScripts   = make([][]byte, 0, len(outputs))
 	)
 
-	// sorting is only necessary when send max is false.
-	if !sendMax {
-		// Sorts the outputs in the descending order (utxo with largest amount start)
-		// This descending order helps in selecting the least number of utxos needed
-		// in the servicing the transaction to be made.
-		sort.Slice(outputs, func(i, j int) bool { return outputs[i].Amount.ToCoin() > outputs[j].Amount.ToCoin() })
-	}
-
 	// validates the utxo amounts and if an invalid amount is discovered an
 	// error is returned.
 	for _, output := range outputs {
@@ -554,12 +665,13 @@ func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bo
 		}
 
 		// Determine whether this transaction output is considered dust
-		if txrules.IsDustOutput(wire.NewTxOut(output.Amount.ToInt(), script), txrules.DefaultRelayFeePerKb) {
+		if txrules.IsDustOutput(wire.NewTxOut(output.Amount.ToInt(), script), MinFeeRatePerkvB) {
 			log.Errorf("transaction contains a dust output with value: %v", output.Amount.String())
 			continue
 		}
 
 		totalInputValue += btcutil.Amount(output.Amount.(Amount))
+		candidates = append(candidates, output)
 		pkScripts = append(pkScripts, script)
 		inputValues = append(inputValues, btcutil.Amount(output.Amount.(Amount)))
 		inputs = append(inputs, wire.NewTxIn(previousOutPoint, nil, nil))
@@ -589,22 +701,28 @@ func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bo
 			return totalInputValue, inputs, inputValues, pkScripts, nil
 		}
 
-		var index int
-		var totalUtxo btcutil.Amount
-
-		for _, utxoAmount := range inputValues {
-			if totalUtxo < target {
-				// Found some utxo(s) we can spend in the current tx.
-				index++
+		// The target already includes the fee of an input, the strategies
+		// add the cost of every input they select.
+		txSelection := *selection
+		if remaining := int64(target) - selection.InputCost; remaining > txSelection.Target {
+			txSelection.Target = remaining
+		}
 
-				totalUtxo += utxoAmount
-				continue
-			}
-			break
+		var totalUtxo btcutil.Amount
+		selected, changeless := sharedW.SelectCoins(&txSelection, candidates)
+		selection.Changeless = changeless
+		selectedInputs := make([]*wire.TxIn, 0, len(selected))
+		selectedValues := make([]btcutil.Amount, 0, len(selected))
+		selectedScripts := make([][]byte, 0, len(selected))
+		for _, i := range selected {
+			totalUtxo += inputValues[i]
+			selectedInputs = append(selectedInputs, inputs[i])
+			selectedValues = append(selectedValues, inputValues[i])
+			selectedScripts = append(selectedScripts, pkScripts[i])
 		}
-		asset.TxAuthoredInfo.inputs = inputs[:index]
-		asset.TxAuthoredInfo.inputValues = inputValues[:index]
-		return totalUtxo, inputs[:index], inputValues[:index], pkScripts[:index], nil
+		asset.TxAuthoredInfo.inputs = selectedInputs
+		asset.TxAuthoredInfo.inputValues = selectedValues
+		return totalUtxo, selectedInputs, selectedValues, selectedScripts, nil
 	}
 }
 
@@ -24,11 +24,13 @@ const (
 	MainnetHDPath = "m / 84' / 0' / "
 )
 
-var wAddrMgrBkt = []byte("waddrmgr")
+var (
+	wAddrMgrBkt = []byte("waddrmgr")
+	wTxMgrBkt   = []byte("wtxmgr")
+)
 
-// GetScope returns the key scope that will be used within the waddrmgr to
-// create an HD chain for deriving all of our required keys. A different
-// scope is used for each specific coin type.
+// GetScope returns the key scope of the accounts of the BTC wallets, the
+// wallets of other coins use the key scope of their coin, see Asset.Scope.
 func GetScope() waddrmgr.KeyScope {
 	// Construct the key scope that will be used within the waddrmgr to
 	// create an HD chain for deriving all of our required keys. A different
@@ -61,8 +63,8 @@ func hardenedKey(key uint32) uint32 {
 }
 
 // DeriveAccountXpub derives the xpub for the given account.
-func (asset *Asset) DeriveAccountXpub(seedMnemonic string, wordSeedType sharedW.WordSeedType, account uint32, params *chaincfg.Params) (xpub string, err error) {
-	seed, err := sharedW.DecodeSeedMnemonic(seedMnemonic, asset.Type, wordSeedType)
+func (asset *Asset) DeriveAccountXpub(seedMnemonic, seedPassphrase string, wordSeedType sharedW.WordSeedType, account uint32, params *chaincfg.Params) (xpub string, err error) {
+	seed, err := sharedW.DecodeSeedMnemonic(seedMnemonic, asset.Type, wordSeedType, seedPassphrase)
 	if err != nil {
 		return "", err
 	}
@@ -79,7 +81,8 @@ func (asset *Asset) DeriveAccountXpub(seedMnemonic string, wordSeedType sharedW.
 	}
 	defer masterNode.Zero()
 
-	path := []uint32{hardenedKey(GetScope().Purpose), hardenedKey(GetScope().Coin)}
+	scope := GetScope()
+	path := []uint32{hardenedKey(scope.Purpose), hardenedKey(scope.Coin)}
 	path = append(path, hardenedKey(account))
 
 	currentKey := masterNode
@@ -93,17 +96,19 @@ func (asset *Asset) DeriveAccountXpub(seedMnemonic string, wordSeedType sharedW.
 	pubVersionBytes := make([]byte, len(params.HDPublicKeyID))
 	copy(pubVersionBytes, params.HDPublicKeyID[:])
 
-	switch params.Name {
-	case chaincfg.TestNet3Params.Name:
+	// Other coins keep the version bytes of their chain parameters.
+	switch {
+	case scope != waddrmgr.KeyScopeBIP0084:
+	case params.Name == chaincfg.TestNet3Params.Name:
 		binary.BigEndian.PutUint32(pubVersionBytes, uint32(
 			waddrmgr.HDVersionTestNetBIP0084,
 		))
 
-	case chaincfg.MainNetParams.Name:
+	case params.Name == chaincfg.MainNetParams.Name:
 		binary.BigEndian.PutUint32(pubVersionBytes, uint32(
 			waddrmgr.HDVersionMainNetBIP0084,
 		))
-	case chaincfg.SimNetParams.Name:
+	case params.Name == chaincfg.SimNetParams.Name:
 		binary.BigEndian.PutUint32(pubVersionBytes, uint32(
 			waddrmgr.HDVersionSimNetBIP0044,
 		))
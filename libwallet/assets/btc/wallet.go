@@ -20,6 +20,7 @@ import (
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader/btc"
+	"github.com/crypto-power/cryptopower/libwallet/internal/rescan"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/lightninglabs/neutrino"
 	"github.com/lightninglabs/neutrino/headerfs"
@@ -40,6 +41,14 @@ type Asset struct {
 	chainParams    *chaincfg.Params
 	TxAuthoredInfo *TxAuthor
 
+	// rpcConn and rpcClient connect the wallet to a bitcoind node and
+	// electrumConn to an Electrum server, they are only set while syncing
+	// with the RPC or Electrum backend.
+	rpcMu        sync.RWMutex
+	rpcConn      *chain.BitcoindConn
+	rpcClient    *chain.BitcoindClient
+	electrumConn *electrumClient
+
 	cancelSync context.CancelFunc
 	syncCtx    context.Context
 
@@ -64,7 +73,12 @@ type Asset struct {
 
 	syncData                        *SyncData
 	txAndBlockNotificationListeners map[string]*sharedW.TxAndBlockNotificationListener
-	blocksRescanProgressListener    *sharedW.BlocksRescanProgressListener
+	rescanner                       *rescan.Rescanner
+
+	// multisigMu guards the address indexes of multisig wallets and
+	// multisigPaths, the paths of the multisig addresses derived so far.
+	multisigMu    sync.Mutex
+	multisigPaths map[string]multisigPath
 }
 
 const (
@@ -106,6 +120,7 @@ func CreateNewWallet(pass *sharedW.AuthInfo, params *sharedW.InitParams) (shared
 			syncProgressListeners: make(map[string]*sharedW.SyncProgressListener),
 		},
 		txAndBlockNotificationListeners: make(map[string]*sharedW.TxAndBlockNotificationListener),
+		rescanner:                       rescan.New(w, log),
 	}
 
 	if err := btcWallet.prepareChain(); err != nil {
@@ -164,6 +179,7 @@ func CreateWatchOnlyWallet(walletName, extendedPublicKey string, params *sharedW
 			syncProgressListeners: make(map[string]*sharedW.SyncProgressListener),
 		},
 		txAndBlockNotificationListeners: make(map[string]*sharedW.TxAndBlockNotificationListener),
+		rescanner:                       rescan.New(w, log),
 	}
 
 	if err := btcWallet.prepareChain(); err != nil {
@@ -201,6 +217,7 @@ func RestoreWallet(seedMnemonic string, pass *sharedW.AuthInfo, params *sharedW.
 			syncProgressListeners: make(map[string]*sharedW.SyncProgressListener),
 		},
 		txAndBlockNotificationListeners: make(map[string]*sharedW.TxAndBlockNotificationListener),
+		rescanner:                       rescan.New(w, log),
 	}
 
 	if err := btcWallet.prepareChain(); err != nil {
@@ -236,6 +253,7 @@ func LoadExisting(w *sharedW.Wallet, params *sharedW.InitParams) (sharedW.Asset,
 			syncProgressListeners: make(map[string]*sharedW.SyncProgressListener),
 		},
 		txAndBlockNotificationListeners: make(map[string]*sharedW.TxAndBlockNotificationListener),
+		rescanner:                       rescan.New(w, log),
 	}
 
 	// w.EncryptedMnemonic was previously deleted after verification. Existing
@@ -326,7 +344,15 @@ func (asset *Asset) ConnectedPeers() int32 {
 	if !asset.IsConnectedToNetwork() {
 		return -1
 	}
-	return asset.chainClient.CS.(ExtraNeutrinoChainService).ConnectedCount()
+	if asset.remoteClient() != nil {
+		// The bitcoind node or Electrum server is the only peer of the wallet.
+		return 1
+	}
+	chainService := asset.neutrinoService()
+	if chainService == nil {
+		return 0
+	}
+	return chainService.ConnectedCount()
 }
 
 // IsConnectedToNetwork returns true if the wallet is connected to the network.
@@ -336,16 +362,12 @@ func (asset *Asset) IsConnectedToNetwork() bool {
 
 // GetBestBlock returns the best block.
 func (asset *Asset) GetBestBlock() *sharedW.BlockInfo {
-	block, err := asset.chainClient.CS.BestBlock()
+	block, err := asset.bestBlock()
 	if err != nil {
 		log.Error("GetBestBlock hash for BTC failed, Err: ", err)
 		return sharedW.InvalidBlock
 	}
-
-	return &sharedW.BlockInfo{
-		Height:    block.Height,
-		Timestamp: block.Timestamp.Unix(),
-	}
+	return block
 }
 
 // GetBestBlockHeight returns the best block height.
@@ -360,7 +382,7 @@ func (asset *Asset) GetBestBlockTimeStamp() int64 {
 
 // GetBlockHeight returns the block height for the given block hash.
 func (asset *Asset) GetBlockHeight(hash chainhash.Hash) (int32, error) {
-	height, err := asset.chainClient.GetBlockHeight(&hash)
+	height, err := asset.blockHeight(&hash)
 	if err != nil {
 		log.Warn("GetBlockHeight for BTC failed, Err: %v", err)
 		return -1, err
@@ -370,7 +392,12 @@ func (asset *Asset) GetBlockHeight(hash chainhash.Hash) (int32, error) {
 
 // GetBlockHash returns the block hash for the given block height.
 func (asset *Asset) GetBlockHash(height int64) (*chainhash.Hash, error) {
-	blockhash, err := asset.chainClient.GetBlockHash(height)
+	chainClient := asset.chainSource()
+	if chainClient == nil {
+		return nil, errors.New(utils.ErrNotConnected)
+	}
+
+	blockhash, err := chainClient.GetBlockHash(height)
 	if err != nil {
 		log.Warn("GetBlockHash for BTC failed, Err: %v", err)
 		return nil, err
@@ -489,15 +516,16 @@ func (asset *Asset) SetSpecificPeer(addresses string) {
 }
 
 // GetExtendedPubKey returns the extended public key of the given account,
-// to do that it calls btcwallet's AccountProperties method, using KeyScopeBIP0084
-// and the account number. On failure it returns error.
+// to do that it calls btcwallet's AccountProperties method, using the key scope
+// of the account and the account number. On failure it returns error.
 func (asset *Asset) GetExtendedPubKey(account int32) (string, error) {
 	loadedAsset := asset.Internal().BTC
 	if loadedAsset == nil {
 		return "", utils.ErrBTCNotInitialized
 	}
 
-	extendedPublicKey, err := loadedAsset.AccountProperties(GetScope(), uint32(account))
+	scope, acct := accountScope(account)
+	extendedPublicKey, err := loadedAsset.AccountProperties(scope, acct)
 	if err != nil {
 		return "", err
 	}
@@ -507,7 +535,8 @@ func (asset *Asset) GetExtendedPubKey(account int32) (string, error) {
 // AccountXPubMatches checks if the xpub of the provided account matches the
 // provided xpub.
 func (asset *Asset) AccountXPubMatches(account uint32, xPub string) (bool, error) {
-	acctXPubKey, err := asset.Internal().BTC.AccountProperties(GetScope(), account)
+	scope, acct := accountScope(int32(account))
+	acctXPubKey, err := asset.Internal().BTC.AccountProperties(scope, acct)
 	if err != nil {
 		return false, err
 	}
@@ -6,12 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"decred.org/dcrwallet/v4/errors"
 	w "decred.org/dcrwallet/v4/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/addresshelper"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/chaincfg/v3"
 	"github.com/decred/dcrd/dcrutil/v4"
 )
@@ -178,7 +180,7 @@ func (asset *Asset) SpendableForAccount(account int32) (int64, error) {
 
 // UnspentOutputs returns unspent outputs that can be used for transactions.
 // Unspent outputs that are locked by the wallet are not returned as valid
-// unspent utxos.
+// unspent utxos, except for frozen outputs which are returned with Frozen set.
 func (asset *Asset) UnspentOutputs(account int32) ([]*sharedW.UnspentOutput, error) {
 	if !asset.WalletOpened() {
 		return nil, utils.ErrDCRNotInitialized
@@ -198,7 +200,8 @@ func (asset *Asset) UnspentOutputs(account int32) ([]*sharedW.UnspentOutput, err
 	unspentOutputs := make([]*sharedW.UnspentOutput, 0, len(unspents))
 	for _, utxo := range unspents {
 		hash := utxo.OutPoint.Hash
-		if asset.Internal().DCR.LockedOutpoint(&hash, utxo.OutPoint.Index) {
+		if asset.Internal().DCR.LockedOutpoint(&hash, utxo.OutPoint.Index) &&
+			!asset.IsOutputFrozen(hash.String(), utxo.OutPoint.Index) {
 			continue // utxo is locked.
 		}
 
@@ -228,9 +231,56 @@ func (asset *Asset) UnspentOutputs(account int32) ([]*sharedW.UnspentOutput, err
 		})
 	}
 
+	asset.ApplyOutputLabels(unspentOutputs...)
 	return unspentOutputs, nil
 }
 
+// SetOutputFrozen freezes or unfreezes an output of the wallet. Frozen outputs
+// are also locked in dcrwallet so that they are not spent by the account
+// mixer, ticket purchases or the DEX.
+func (asset *Asset) SetOutputFrozen(txHash string, index uint32, frozen bool) error {
+	if err := asset.Wallet.SetOutputFrozen(txHash, index, frozen); err != nil {
+		return err
+	}
+	if !asset.WalletOpened() {
+		return nil
+	}
+
+	hash, err := chainhash.NewHashFromStr(txHash)
+	if err != nil {
+		return err
+	}
+	if frozen {
+		asset.Internal().DCR.LockOutpoint(hash, index)
+	} else {
+		asset.Internal().DCR.UnlockOutpoint(hash, index)
+	}
+	return nil
+}
+
+// lockFrozenOutputs locks the frozen outputs in dcrwallet, which only keeps
+// locked outputs in memory.
+func (asset *Asset) lockFrozenOutputs() {
+	frozen, err := asset.FrozenOutputs()
+	if err != nil {
+		log.Errorf("Error reading frozen outputs: %v", err)
+		return
+	}
+
+	for _, ref := range frozen {
+		txHash, indexStr, _ := strings.Cut(ref, ":")
+		hash, err := chainhash.NewHashFromStr(txHash)
+		if err != nil {
+			continue
+		}
+		index, err := strconv.ParseUint(indexStr, 10, 32)
+		if err != nil {
+			continue
+		}
+		asset.Internal().DCR.LockOutpoint(hash, uint32(index))
+	}
+}
+
 func (asset *Asset) CreateNewAccount(accountName, privPass string) (int32, error) {
 	err := asset.UnlockWallet(privPass)
 	if err != nil {
@@ -0,0 +1,201 @@
+package dcr
+
+import (
+	"fmt"
+	"strconv"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/vspd/types/v2"
+)
+
+// abstainChoiceID is the choice assumed for agendas without a saved choice.
+const abstainChoiceID = "abstain"
+
+// TicketVoteHistory decodes the vote bits of every vote cast by the wallet's
+// tickets against the provided agendas, as returned by AllVoteAgendas. An
+// agenda is only decoded for votes of the same vote version that were cast
+// while the agenda was being voted on. Votes are returned newest first.
+func (asset *Asset) TicketVoteHistory(agendas []*Agenda) ([]*TicketVoteChoices, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	votes, err := asset.GetTransactionsRaw(0, 0, utils.TxFilterVoted, true, "")
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]*TicketVoteChoices, 0, len(votes))
+	for _, vote := range votes {
+		voteBits, err := strconv.ParseUint(vote.VoteBits, 0, 16)
+		if err != nil {
+			log.Errorf("[%d] Invalid vote bits %q in vote %s", asset.ID, vote.VoteBits, vote.Hash)
+			continue
+		}
+
+		history = append(history, &TicketVoteChoices{
+			TicketHash:  vote.TicketSpentHash,
+			VoteHash:    vote.Hash,
+			BlockHeight: vote.BlockHeight,
+			Timestamp:   vote.Timestamp,
+			VoteVersion: uint32(vote.VoteVersion),
+			VoteBits:    uint16(voteBits),
+			Choices:     decodeVoteChoices(agendas, uint32(vote.VoteVersion), uint16(voteBits), vote.Timestamp),
+		})
+	}
+
+	return history, nil
+}
+
+// AgendaVoteSummaries counts the yes, no and abstain votes cast by the wallet's
+// tickets on each of the provided agendas, see TicketVoteHistory. Choices other
+// than abstain and no are counted as yes.
+func (asset *Asset) AgendaVoteSummaries(agendas []*Agenda) ([]*AgendaVoteSummary, error) {
+	history, err := asset.TicketVoteHistory(agendas)
+	if err != nil {
+		return nil, err
+	}
+
+	return summarizeAgendaVotes(agendas, history), nil
+}
+
+// decodeVoteChoices returns the choice IDs, keyed by agenda ID, selected by the
+// vote bits of a vote of the provided vote version cast at timestamp. Agendas
+// of other vote versions or not being voted on at timestamp are skipped.
+func decodeVoteChoices(agendas []*Agenda, voteVersion uint32, voteBits uint16, timestamp int64) map[string]string {
+	choices := make(map[string]string)
+	for _, agenda := range agendas {
+		if agenda.VoteVersion != voteVersion ||
+			timestamp < agenda.StartTime || timestamp > agenda.ExpireTime {
+			continue
+		}
+
+		for _, choice := range agenda.Choices {
+			if uint32(voteBits)&agenda.Mask == uint32(choice.Bits) {
+				choices[agenda.AgendaID] = choice.Id
+				break
+			}
+		}
+	}
+	return choices
+}
+
+// summarizeAgendaVotes counts the choices of the decoded votes on each agenda.
+func summarizeAgendaVotes(agendas []*Agenda, history []*TicketVoteChoices) []*AgendaVoteSummary {
+	summaries := make([]*AgendaVoteSummary, len(agendas))
+	for i, agenda := range agendas {
+		summary := &AgendaVoteSummary{AgendaID: agenda.AgendaID}
+		for _, vote := range history {
+			choiceID, ok := vote.Choices[agenda.AgendaID]
+			if !ok {
+				continue
+			}
+
+			for _, choice := range agenda.Choices {
+				if choice.Id != choiceID {
+					continue
+				}
+
+				switch {
+				case choice.IsAbstain:
+					summary.Abstain++
+				case choice.IsNo:
+					summary.No++
+				default:
+					summary.Yes++
+				}
+				break
+			}
+		}
+		summaries[i] = summary
+	}
+	return summaries
+}
+
+// VoteChoiceMismatches compares the vote choices saved in the wallet for each
+// unspent, unexpired ticket with the choices registered with the VSP voting
+// the ticket and returns the agendas whose choices differ. Tickets that are
+// not registered with a VSP are skipped. The wallet is unlocked to sign the
+// VSP status requests.
+func (asset *Asset) VoteChoiceMismatches(passphrase string) ([]*TicketChoiceMismatch, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	err := asset.UnlockWallet(passphrase)
+	if err != nil {
+		return nil, utils.TranslateError(err)
+	}
+	defer asset.LockWallet()
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+
+	ticketHashes := make([]*chainhash.Hash, 0)
+	err = asset.Internal().DCR.ForUnspentUnexpiredTickets(ctx, func(hash *chainhash.Hash) error {
+		ticketHashes = append(ticketHashes, hash)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch hashes for all unspent, unexpired tickets: %v", err)
+	}
+
+	mismatches := make([]*TicketChoiceMismatch, 0)
+	for _, ticketHash := range ticketHashes {
+		vspTicket, err := asset.Internal().DCR.NewVSPTicket(ctx, ticketHash)
+		if err != nil {
+			// Ignore NotExist error, just means the ticket is not
+			// registered with a VSP, nothing more to do here.
+			if errors.Is(errors.NotExist, err) {
+				continue
+			}
+			return nil, err
+		}
+
+		vspTicketInfo, err := vspTicket.VSPTicketInfo(ctx)
+		if err != nil {
+			if errors.Is(errors.NotExist, err) {
+				continue
+			}
+			return nil, err
+		}
+
+		vspClient, err := asset.VSPClient(-1, vspTicketInfo.Host, vspTicketInfo.PubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		req := types.TicketStatusRequest{TicketHash: ticketHash.String()}
+		status, err := vspClient.TicketStatus(ctx, req, vspTicket.CommitmentAddr())
+		if err != nil {
+			log.Errorf("[%d] Unable to fetch the status of ticket %s from %s: %v",
+				asset.ID, ticketHash, vspTicketInfo.Host, err)
+			continue
+		}
+
+		localChoices, _, err := asset.Internal().DCR.AgendaChoices(ctx, ticketHash)
+		if err != nil {
+			return nil, err
+		}
+
+		for agendaID, localChoice := range localChoices {
+			vspChoice := status.VoteChoices[agendaID]
+			if vspChoice == "" {
+				vspChoice = abstainChoiceID
+			}
+
+			if localChoice != vspChoice {
+				mismatches = append(mismatches, &TicketChoiceMismatch{
+					TicketHash:  ticketHash.String(),
+					VSPHost:     vspTicketInfo.Host,
+					AgendaID:    agendaID,
+					LocalChoice: localChoice,
+					VSPChoice:   vspChoice,
+				})
+			}
+		}
+	}
+
+	return mismatches, nil
+}
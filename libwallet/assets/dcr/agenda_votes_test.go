@@ -0,0 +1,90 @@
+package dcr
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/v3"
+)
+
+// testAgendas returns two agendas voted on together with vote version 7 and
+// an agenda voted on later with vote version 8.
+func testAgendas() []*Agenda {
+	choices := func(shift uint) []chaincfg.Choice {
+		return []chaincfg.Choice{
+			{Id: "abstain", Bits: 0, IsAbstain: true},
+			{Id: "no", Bits: 1 << shift, IsNo: true},
+			{Id: "yes", Bits: 2 << shift},
+		}
+	}
+	return []*Agenda{
+		{AgendaID: "headercommitments", Mask: 0x06, VoteVersion: 7, Choices: choices(1), StartTime: 100, ExpireTime: 200},
+		{AgendaID: "treasury", Mask: 0x18, VoteVersion: 7, Choices: choices(3), StartTime: 100, ExpireTime: 200},
+		{AgendaID: "autorevocations", Mask: 0x06, VoteVersion: 8, Choices: choices(1), StartTime: 300, ExpireTime: 400},
+	}
+}
+
+func TestDecodeVoteChoices(t *testing.T) {
+	agendas := testAgendas()
+
+	tests := []struct {
+		name        string
+		voteVersion uint32
+		voteBits    uint16
+		timestamp   int64
+		want        map[string]string
+	}{
+		{"abstain on all", 7, 0x0001, 150, map[string]string{"headercommitments": "abstain", "treasury": "abstain"}},
+		{"yes and no", 7, 0x0001 | 0x04 | 0x08, 150, map[string]string{"headercommitments": "yes", "treasury": "no"}},
+		{"no and yes", 7, 0x0001 | 0x02 | 0x10, 150, map[string]string{"headercommitments": "no", "treasury": "yes"}},
+		// Both bits of a mask set do not select any choice.
+		{"invalid choice", 7, 0x0001 | 0x06 | 0x10, 150, map[string]string{"treasury": "yes"}},
+		{"at start time", 7, 0x04, 100, map[string]string{"headercommitments": "yes", "treasury": "abstain"}},
+		{"at expire time", 7, 0x04, 200, map[string]string{"headercommitments": "yes", "treasury": "abstain"}},
+		{"before the vote", 7, 0x04, 99, map[string]string{}},
+		{"after the vote", 7, 0x04, 201, map[string]string{}},
+		// The same mask selects the choices of a different agenda in a later
+		// vote version.
+		{"later vote version", 8, 0x04, 350, map[string]string{"autorevocations": "yes"}},
+		{"older vote version", 6, 0x04, 150, map[string]string{}},
+	}
+	for _, tc := range tests {
+		got := decodeVoteChoices(agendas, tc.voteVersion, tc.voteBits, tc.timestamp)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: got choices %v, want %v", tc.name, got, tc.want)
+			continue
+		}
+		for agendaID, choiceID := range tc.want {
+			if got[agendaID] != choiceID {
+				t.Errorf("%s: got choices %v, want %v", tc.name, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestSummarizeAgendaVotes(t *testing.T) {
+	agendas := testAgendas()
+	history := []*TicketVoteChoices{
+		{Choices: decodeVoteChoices(agendas, 7, 0x0001|0x04|0x08, 150)},
+		{Choices: decodeVoteChoices(agendas, 7, 0x0001|0x04, 150)},
+		{Choices: decodeVoteChoices(agendas, 7, 0x0001|0x02|0x10, 150)},
+		{Choices: decodeVoteChoices(agendas, 8, 0x02, 350)},
+		// An unknown choice is not counted.
+		{Choices: map[string]string{"treasury": "maybe"}},
+	}
+
+	want := []AgendaVoteSummary{
+		{AgendaID: "headercommitments", Yes: 2, No: 1},
+		{AgendaID: "treasury", Yes: 1, No: 1, Abstain: 1},
+		{AgendaID: "autorevocations", No: 1},
+	}
+	got := summarizeAgendaVotes(agendas, history)
+	if len(got) != len(want) {
+		t.Fatalf("got %d summaries, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if *got[i] != want[i] {
+			t.Errorf("got summary %+v, want %+v", *got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package dcr
+
+import (
+	"decred.org/dcrwallet/v4/chain"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/wire"
+)
+
+// rpcPort returns the default port of the dcrd RPC server on the network.
+func rpcPort(params *chaincfg.Params) string {
+	switch params.Net {
+	case wire.TestNet3:
+		return "19109"
+	case wire.SimNet:
+		return "19556"
+	case wire.RegNet:
+		return "18656"
+	default:
+		return "9109"
+	}
+}
+
+// rpcSyncer returns a syncer of the dcrd node set in the RPC config of the
+// wallet.
+func (asset *Asset) rpcSyncer() (*chain.Syncer, error) {
+	if asset.RPCPassLocked() {
+		return nil, utils.ErrRPCPassLocked
+	}
+
+	cfg := asset.RPCConfig()
+	cert, err := cfg.ReadCert()
+	if err != nil {
+		return nil, err
+	}
+
+	syncer := chain.NewSyncer(asset.Internal().DCR, &chain.RPCOptions{
+		Address:     cfg.Host,
+		DefaultPort: rpcPort(asset.chainParams),
+		User:        cfg.User,
+		Pass:        cfg.Pass,
+		CA:          cert,
+	})
+	syncer.SetCallbacks(asset.rpcSyncNotificationCallbacks())
+	return syncer, nil
+}
+
+// SetChainBackend sets the backend the wallet syncs with. An active sync is
+// restarted with the new backend.
+func (asset *Asset) SetChainBackend(backend sharedW.ChainBackend, cfg *sharedW.RPCConfig, privatePassphrase string) error {
+	if err := asset.SaveChainBackend(backend, cfg, privatePassphrase); err != nil {
+		return err
+	}
+
+	if asset.IsConnectedToNetwork() {
+		return asset.RestartSpvSync()
+	}
+	return nil
+}
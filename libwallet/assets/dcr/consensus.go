@@ -235,8 +235,12 @@ func AllVoteAgendas(chainParams *chaincfg.Params, newestFirst bool) ([]*Agenda,
 	// Check for all agendas from the intital stake version to the
 	// current stake version, in order to fetch legacy agendas.
 	deployments := make([]chaincfg.ConsensusDeployment, 0)
-	for _, v := range chainParams.Deployments {
+	voteVersions := make([]uint32, 0)
+	for version, v := range chainParams.Deployments {
 		deployments = append(deployments, v...)
+		for range v {
+			voteVersions = append(voteVersions, version)
+		}
 	}
 
 	// Fetch high level agenda detail form dcrdata api.
@@ -268,6 +272,7 @@ func AllVoteAgendas(chainParams *chaincfg.Params, newestFirst bool) ([]*Agenda,
 			AgendaID:         d.Vote.Id,
 			Description:      d.Vote.Description,
 			Mask:             uint32(d.Vote.Mask),
+			VoteVersion:      voteVersions[i],
 			Choices:          d.Vote.Choices,
 			VotingPreference: "", // this value can be updated after reading a selected wallet's preferences
 			StartTime:        int64(d.StartTime),
@@ -46,6 +46,7 @@ type WalletHelper interface {
 	IsAccountMixerActive() bool
 	UnmixedAccountNumber() int32
 	MixedAccountNumber() int32
+	IsOutputFrozen(txHash string, index uint32) bool
 }
 
 var _ dexdcr.Wallet = (*DEXWallet)(nil)
@@ -224,6 +225,10 @@ func (dw *DEXWallet) LockUnspent(_ context.Context, unlock bool, ops []*wire.Out
 		fun = dw.w.UnlockOutpoint
 	}
 	for _, op := range ops {
+		// Frozen outputs stay locked.
+		if unlock && dw.helper.IsOutputFrozen(op.Hash.String(), op.Index) {
+			continue
+		}
 		fun(&op.Hash, op.Index)
 	}
 	return nil
@@ -16,6 +16,46 @@ func (asset *Asset) RescanBlocks() error {
 }
 
 func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
+	return asset.rescanBlocks(startHeight, startHeight, 0)
+}
+
+// RescanWithOptions rescans the blockchain from the block selected by opts.
+// dcrwallet always rescans for the addresses of all the accounts, and the
+// address discovery run first for an extended gap limit covers all of them
+// too, so opts.Accounts is ignored.
+func (asset *Asset) RescanWithOptions(opts *sharedW.RescanOptions) error {
+	estimate, err := asset.EstimateRescan(opts)
+	if err != nil {
+		return err
+	}
+	return asset.rescanBlocks(estimate.StartHeight, estimate.StartHeight, opts.GapLimit)
+}
+
+// EstimateRescan returns the blocks the rescan described by opts covers and
+// how long it's expected to take.
+func (asset *Asset) EstimateRescan(opts *sharedW.RescanOptions) (*sharedW.RescanEstimate, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	defer cancel()
+
+	return asset.EstimateRescanFromBlocks(opts, asset.GetBestBlockHeight(), func(height int32) (time.Time, error) {
+		info, err := asset.Internal().DCR.BlockInfo(ctx, w.NewBlockIdentifierFromHeight(height))
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(info.Timestamp, 0), nil
+	})
+}
+
+// rescanBlocks rescans the blockchain from fromHeight. startHeight is the
+// height the rescan was first started from, it's before fromHeight if an
+// interrupted rescan is being resumed. The transactions are indexed from
+// startHeight once the rescan finishes. If gapLimit is set, address usage is
+// discovered from fromHeight with that gap limit before rescanning.
+func (asset *Asset) rescanBlocks(startHeight, fromHeight int32, gapLimit uint32) error {
 	netBackend, err := asset.Internal().DCR.NetworkBackend()
 	if err != nil {
 		return errors.E(utils.ErrNotConnected)
@@ -44,8 +84,30 @@ func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
 			asset.blocksRescanProgressListener.OnBlocksRescanStarted(asset.ID)
 		}
 
+		// Record the rescan so that it's resumed if the sync stops before the
+		// rescan finishes.
+		asset.SaveRescanCheckpoint(startHeight, fromHeight)
+
+		if gapLimit > 0 {
+			info, err := asset.Internal().DCR.BlockInfo(ctx, w.NewBlockIdentifierFromHeight(fromHeight))
+			if err == nil {
+				err = asset.Internal().DCR.DiscoverActiveAddresses(ctx, netBackend, &info.Hash, !asset.Internal().DCR.Locked(), gapLimit)
+			}
+			if err != nil {
+				if ctx.Err() == context.Canceled {
+					err = nil // canceled through CancelRescan
+				} else {
+					log.Errorf("address discovery before the rescan failed: %v", err)
+				}
+				if asset.blocksRescanProgressListener != nil {
+					asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, err)
+				}
+				return
+			}
+		}
+
 		progress := make(chan w.RescanProgress, 1)
-		go asset.Internal().DCR.RescanProgressFromHeight(ctx, netBackend, startHeight, progress)
+		go asset.Internal().DCR.RescanProgressFromHeight(ctx, netBackend, fromHeight, progress)
 
 		rescanStartTime := time.Now()
 
@@ -58,6 +120,8 @@ func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
 				return
 			}
 
+			asset.SaveRescanCheckpoint(startHeight, p.ScannedThrough)
+
 			rescanProgressReport := &sharedW.HeadersRescanProgressReport{
 				CurrentRescanHeight: p.ScannedThrough,
 				TotalHeadersToScan:  asset.GetBestBlockHeight(),
@@ -112,6 +176,10 @@ func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
 
 			err = asset.IndexTransactions()
 		}
+		if err == nil {
+			asset.ClearRescanCheckpoint()
+			asset.SaveRescanDuration(asset.GetBestBlockHeight()-fromHeight, time.Since(rescanStartTime))
+		}
 		if asset.blocksRescanProgressListener != nil {
 			asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, err)
 		}
@@ -127,10 +195,27 @@ func (asset *Asset) CancelRescan() {
 		asset.syncData.cancelRescan()
 		asset.syncData.cancelRescan = nil
 
+		// A canceled rescan isn't resumed on the next sync.
+		asset.ClearRescanCheckpoint()
+
 		log.Info("Rescan canceled.")
 	}
 }
 
+// resumeRescan restarts a rescan that was interrupted before it finished from
+// the height recorded in the sync checkpoint.
+func (asset *Asset) resumeRescan() {
+	checkpoint := asset.SyncCheckpoint()
+	if !checkpoint.Rescanning || asset.IsRescanning() {
+		return
+	}
+
+	log.Infof("(%v) Resuming the interrupted rescan from block %d", asset.GetWalletName(), checkpoint.RescanHeight)
+	if err := asset.rescanBlocks(checkpoint.RescanStartHeight, checkpoint.RescanHeight, 0); err != nil {
+		log.Errorf("(%v) Resuming the rescan failed: %v", asset.GetWalletName(), err)
+	}
+}
+
 func (asset *Asset) IsRescanning() bool {
 	asset.syncData.mu.RLock()
 	defer asset.syncData.mu.RUnlock()
@@ -0,0 +1,320 @@
+package dcr
+
+import (
+	"context"
+
+	"decred.org/dcrwallet/v4/errors"
+	"decred.org/dcrwallet/v4/spv"
+	w "decred.org/dcrwallet/v4/wallet"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+)
+
+// Solo voting lets a wallet hold the voting rights of its own tickets instead
+// of delegating them to a VSP. Tickets bought this way are only voted if the
+// wallet is online, unlocked and connected to a backend that announces the
+// winning tickets of each block when the tickets are selected. A ticket that
+// misses its vote is revoked and the ticket reward is lost.
+//
+// The SPV syncer cannot provide winning ticket notifications because the
+// lottery is drawn from the full live ticket pool, which SPV clients do not
+// track, and no P2P message announces the winners. Solo voting therefore needs
+// the dcrd RPC backend, see SetChainBackend: the RPC syncer subscribes to the
+// winning tickets of the node and the wallet votes those it owns as soon as
+// they are announced.
+
+// SetSoloVotingEnabled saves whether the wallet should vote its own tickets.
+// The setting is applied the next time the wallet is opened.
+func (asset *Asset) SetSoloVotingEnabled(enabled bool) {
+	asset.SetBoolConfigValueForKey(sharedW.SoloVotingConfigKey, enabled)
+	if enabled {
+		log.Warnf("[%d] Solo voting enabled: tickets will only be voted while "+
+			"the wallet is online and unlocked", asset.ID)
+	}
+}
+
+// IsSoloVotingEnabled returns true if the wallet is configured to vote its own
+// tickets.
+func (asset *Asset) IsSoloVotingEnabled() bool {
+	return asset.ReadBoolConfigValueForKey(sharedW.SoloVotingConfigKey, false)
+}
+
+// SoloVotingActive returns true if the opened wallet was loaded with voting
+// enabled. It differs from IsSoloVotingEnabled until the wallet is reopened
+// after the setting is changed.
+func (asset *Asset) SoloVotingActive() bool {
+	if !asset.WalletOpened() {
+		return false
+	}
+	return asset.Internal().DCR.VotingEnabled()
+}
+
+// SupportsSoloVoting returns true if the wallet syncs with a backend that
+// delivers the winning ticket notifications required to vote tickets without
+// a VSP. Only the dcrd RPC backend does.
+func (asset *Asset) SupportsSoloVoting() bool {
+	if asset.ChainBackend() != sharedW.RPCBackend {
+		return false
+	}
+
+	if !asset.WalletOpened() {
+		return true
+	}
+
+	// The backend is switched when the sync restarts, the SPV syncer may
+	// still be running.
+	n, err := asset.Internal().DCR.NetworkBackend()
+	if err != nil {
+		return true
+	}
+
+	_, isSPV := n.(*spv.Syncer)
+	return !isSPV
+}
+
+// PurchaseTicketsSolo purchases tickets whose voting rights are held by the
+// wallet itself, no VSP fee is paid. The tickets are only voted while the
+// solo voter is running, see StartSoloVoter.
+// Returns a slice of hashes for tickets purchased.
+func (asset *Asset) PurchaseTicketsSolo(account, numTickets int32, passphrase string) ([]*chainhash.Hash, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	if asset.IsWatchingOnlyWallet() {
+		return nil, errors.New(utils.ErrWalletIsWatchOnly)
+	}
+
+	if !asset.SoloVotingActive() {
+		return nil, utils.ErrSoloVotingDisabled
+	}
+
+	networkBackend, err := asset.Internal().DCR.NetworkBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	err = asset.UnlockWallet(passphrase)
+	if err != nil {
+		return nil, utils.TranslateError(err)
+	}
+	defer asset.LockWallet()
+
+	request := &w.PurchaseTicketsRequest{
+		Count:         int(numTickets),
+		SourceAccount: uint32(account),
+		MinConf:       asset.RequiredConfirmations(),
+
+		// VotingAccount used to derive addresses for specifying voting rights.
+		// It is used when VotingAddress == nil, or Mixing == true
+		VotingAccount: uint32(account),
+	}
+
+	if err = asset.setMixedSplitBuying(request); err != nil {
+		return nil, err
+	}
+
+	log.Warnf("[%d] Purchasing %d solo ticket(s): the wallet must stay online "+
+		"and unlocked to vote them", asset.ID, numTickets)
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	ticketsResponse, err := asset.Internal().DCR.PurchaseTickets(ctx, networkBackend, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return ticketsResponse.TicketHashes, nil
+}
+
+// StartSoloVoter keeps the wallet unlocked so that the wallet can create and
+// publish votes for its winning tickets when the RPC backend announces them.
+// The voter runs until StopSoloVoter is called, the wallet shuts down or the
+// wallet stops syncing with the RPC backend. The passphrase is held in memory
+// for as long as the voter runs.
+func (asset *Asset) StartSoloVoter(passphrase string) error {
+	if !asset.WalletOpened() {
+		return utils.ErrDCRNotInitialized
+	}
+
+	if asset.IsWatchingOnlyWallet() {
+		return errors.New(utils.ErrWalletIsWatchOnly)
+	}
+
+	if !asset.SoloVotingActive() {
+		return utils.ErrSoloVotingDisabled
+	}
+
+	if !asset.SupportsSoloVoting() {
+		return utils.ErrSoloVotingNotSupported
+	}
+
+	// The lock is held until the voter is recorded for a concurrent call to
+	// see it running. It's released before the listeners are notified, they
+	// may check whether the voter runs.
+	asset.cancelSoloVoterMu.Lock()
+	if asset.cancelSoloVoter != nil {
+		asset.cancelSoloVoterMu.Unlock()
+		return utils.ErrSoloVoterAlreadyRunning
+	}
+
+	// Validate the passphrase.
+	if err := asset.UnlockWallet(passphrase); err != nil {
+		asset.cancelSoloVoterMu.Unlock()
+		return utils.TranslateError(err)
+	}
+
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	asset.cancelSoloVoter = cancel
+	asset.cancelSoloVoterMu.Unlock()
+
+	log.Warnf("[%d] Solo voter started: the wallet stays unlocked until the "+
+		"voter is stopped", asset.ID)
+	asset.publishSoloVoterStarted()
+
+	// Subscribe before the voter runs, the wallet may be unloaded by then.
+	c := asset.Internal().DCR.NtfnServer.MainTipChangedNotifications()
+	go func() {
+		err := asset.runSoloVoter(ctx, c, passphrase)
+		if ctx.Err() != nil {
+			log.Infof("[%d] Solo voter instance canceled", asset.ID)
+			return
+		}
+
+		log.Errorf("[%d] Solo voter instance errored: %v", asset.ID, err)
+		if err := asset.stopSoloVoter(err); err != nil {
+			log.Errorf("[%d] Stopping solo voter errored: %v", asset.ID, err)
+		}
+	}()
+
+	return nil
+}
+
+// runSoloVoter unlocks the wallet again whenever a new block is attached and
+// the wallet was locked in the meantime, ensuring the wallet can sign the votes
+// of tickets selected by the next block. It exits with an errors.Passphrase
+// error if the passphrase ever becomes incorrect, and with
+// utils.ErrSoloVotingNotSupported if the wallet is switched to a backend that
+// cannot announce the winning tickets.
+func (asset *Asset) runSoloVoter(ctx context.Context, c w.MainTipChangedNotificationsClient, passphrase string) error {
+	defer c.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case n := <-c.C:
+			if len(n.AttachedBlocks) == 0 {
+				continue
+			}
+			if !asset.SupportsSoloVoting() {
+				return utils.ErrSoloVotingNotSupported
+			}
+			if !asset.IsLocked() {
+				continue
+			}
+
+			log.Debugf("[%d] Solo voter unlocking the wallet", asset.ID)
+			if err := asset.UnlockWallet(passphrase); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// IsSoloVoterRunning returns true if the solo voter is active.
+func (asset *Asset) IsSoloVoterRunning() bool {
+	asset.cancelSoloVoterMu.RLock()
+	defer asset.cancelSoloVoterMu.RUnlock()
+	return asset.cancelSoloVoter != nil
+}
+
+// StopSoloVoter stops the solo voter and locks the wallet. Winning tickets
+// will not be voted until the voter is restarted.
+func (asset *Asset) StopSoloVoter() error {
+	return asset.stopSoloVoter(nil)
+}
+
+// stopSoloVoter stops the solo voter, locks the wallet and notifies the
+// listeners with the error that stopped the voter, if any.
+func (asset *Asset) stopSoloVoter(err error) error {
+	asset.cancelSoloVoterMu.Lock()
+	if asset.cancelSoloVoter == nil {
+		asset.cancelSoloVoterMu.Unlock()
+		return errors.New(utils.ErrInvalid)
+	}
+
+	asset.cancelSoloVoter()
+	asset.cancelSoloVoter = nil
+	asset.cancelSoloVoterMu.Unlock()
+
+	asset.Wallet.LockWallet()
+	asset.publishSoloVoterStopped(err)
+	return nil
+}
+
+// LockWallet locks the wallet unless the solo voter is running, in which case
+// the wallet must remain unlocked to sign votes. The skipped lock is reported
+// to the solo voter listeners for the user to be warned that the wallet is
+// still unlocked.
+func (asset *Asset) LockWallet() {
+	if !asset.IsSoloVoterRunning() {
+		asset.Wallet.LockWallet()
+		return
+	}
+
+	log.Warnf("[%d] Wallet not locked: the solo voter is running and keeps "+
+		"the wallet unlocked", asset.ID)
+	asset.notificationListenersMu.RLock()
+	defer asset.notificationListenersMu.RUnlock()
+	for _, l := range asset.soloVoterNotificationListeners {
+		if l.OnWalletKeptUnlocked != nil {
+			l.OnWalletKeptUnlocked(asset.ID)
+		}
+	}
+}
+
+// AddSoloVoterNotificationListener registers a listener of the solo voter
+// state.
+func (asset *Asset) AddSoloVoterNotificationListener(listener *SoloVoterNotificationListener, uniqueIdentifier string) error {
+	asset.notificationListenersMu.Lock()
+	defer asset.notificationListenersMu.Unlock()
+
+	if _, ok := asset.soloVoterNotificationListeners[uniqueIdentifier]; ok {
+		return errors.New(utils.ErrListenerAlreadyExist)
+	}
+
+	asset.soloVoterNotificationListeners[uniqueIdentifier] = listener
+	return nil
+}
+
+// RemoveSoloVoterNotificationListener removes the listener registered with
+// the identifier.
+func (asset *Asset) RemoveSoloVoterNotificationListener(uniqueIdentifier string) {
+	asset.notificationListenersMu.Lock()
+	defer asset.notificationListenersMu.Unlock()
+
+	delete(asset.soloVoterNotificationListeners, uniqueIdentifier)
+}
+
+func (asset *Asset) publishSoloVoterStarted() {
+	asset.notificationListenersMu.RLock()
+	defer asset.notificationListenersMu.RUnlock()
+
+	for _, l := range asset.soloVoterNotificationListeners {
+		if l.OnSoloVoterStarted != nil {
+			l.OnSoloVoterStarted(asset.ID)
+		}
+	}
+}
+
+func (asset *Asset) publishSoloVoterStopped(err error) {
+	asset.notificationListenersMu.RLock()
+	defer asset.notificationListenersMu.RUnlock()
+
+	for _, l := range asset.soloVoterNotificationListeners {
+		if l.OnSoloVoterStopped != nil {
+			l.OnSoloVoterStopped(asset.ID, err)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+//go:build harness
+
+package dcr
+
+// The tests of this file run against a simnet harness, such as the dcr harness
+// of dcrdex, and are only built with the harness tag:
+//
+//	go test -tags harness -run Harness ./libwallet/assets/dcr
+//
+// The harness is located with these environment variables:
+//
+//	DCR_HARNESS_DCRD         RPC address of the dcrd node, 127.0.0.1:19561 by default
+//	DCR_HARNESS_DCRD_CERT    TLS certificate of the dcrd node
+//	DCR_HARNESS_WALLET       RPC address of a funded harness dcrwallet, 127.0.0.1:19567 by default
+//	DCR_HARNESS_WALLET_CERT  TLS certificate of the harness dcrwallet
+//	DCR_HARNESS_USER         RPC user of the node and wallet, "user" by default
+//	DCR_HARNESS_PASS         RPC password of the node and wallet, "pass" by default
+//
+// The node must be started with a mining address for blocks to be generated.
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	w "decred.org/dcrwallet/v4/wallet"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+// harnessRPC is a JSON-RPC client of a harness node or wallet.
+type harnessRPC struct {
+	url        string
+	user, pass string
+	client     *http.Client
+}
+
+func newHarnessRPC(t *testing.T, addrEnv, defaultAddr, certEnv string) *harnessRPC {
+	t.Helper()
+	certPath := os.Getenv(certEnv)
+	if certPath == "" {
+		t.Skipf("%s is not set", certEnv)
+	}
+	cert, err := os.ReadFile(certPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(cert) {
+		t.Fatalf("invalid certificate %s", certPath)
+	}
+
+	return &harnessRPC{
+		url:  "https://" + envOr(addrEnv, defaultAddr),
+		user: envOr("DCR_HARNESS_USER", "user"),
+		pass: envOr("DCR_HARNESS_PASS", "pass"),
+		client: &http.Client{
+			Timeout:   time.Minute,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+		},
+	}
+}
+
+func envOr(key, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
+
+func (rpc *harnessRPC) call(t *testing.T, result interface{}, method string, params ...interface{}) {
+	t.Helper()
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, rpc.url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth(rpc.user, rpc.pass)
+	resp, err := rpc.client.Do(req)
+	if err != nil {
+		t.Fatalf("%s: %v", method, err)
+	}
+	defer resp.Body.Close()
+
+	var reply struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		t.Fatalf("%s: %v", method, err)
+	}
+	if reply.Error != nil {
+		t.Fatalf("%s: %d %s", method, reply.Error.Code, reply.Error.Message)
+	}
+	if result != nil {
+		if err = json.Unmarshal(reply.Result, result); err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+	}
+}
+
+// waitFor polls cond until it holds or the timeout expires.
+func waitFor(t *testing.T, what string, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// TestHarnessSoloVoting buys a ticket held by the wallet and mines blocks
+// until the solo voter has voted it.
+func TestHarnessSoloVoting(t *testing.T) {
+	dcrdAddr := envOr("DCR_HARNESS_DCRD", "127.0.0.1:19561")
+	dcrd := newHarnessRPC(t, "DCR_HARNESS_DCRD", "127.0.0.1:19561", "DCR_HARNESS_DCRD_CERT")
+	harnessWallet := newHarnessRPC(t, "DCR_HARNESS_WALLET", "127.0.0.1:19567", "DCR_HARNESS_WALLET_CERT")
+
+	mine := func(n int) {
+		t.Helper()
+		dcrd.call(t, nil, "generate", n)
+	}
+
+	tw := newTestWallet(t)
+	tw.SetSoloVotingEnabled(true)
+	err := tw.SaveChainBackend(sharedW.RPCBackend, &sharedW.RPCConfig{
+		Host:     dcrdAddr,
+		User:     dcrd.user,
+		Pass:     dcrd.pass,
+		CertPath: os.Getenv("DCR_HARNESS_DCRD_CERT"),
+	}, testPassphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw.reopen(t)
+
+	// The RPC password is decrypted when the reopened wallet is unlocked.
+	if !tw.RPCPassLocked() {
+		t.Fatal("the RPC password is not locked after the wallet is reopened")
+	}
+	if err = tw.UnlockWallet(testPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	tw.LockWallet()
+
+	if err = tw.SpvSync(); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the wallet to sync", 2*time.Minute, tw.IsSynced)
+
+	addr, err := tw.CurrentAddress(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	harnessWallet.call(t, nil, "sendtoaddress", addr, 1000)
+	mine(1)
+	waitFor(t, "the funds", time.Minute, func() bool {
+		balance, err := tw.GetAccountBalance(0)
+		return err == nil && balance.Spendable.ToInt() > 0
+	})
+
+	if err = tw.UnlockWallet(testPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	// The harness has no mixing peers, the ticket is bought without the mixed
+	// split of PurchaseTicketsSolo. The voting rights are held by the wallet
+	// either way.
+	ctx := context.Background()
+	backend, err := tw.Internal().DCR.NetworkBackend()
+	if err != nil {
+		t.Fatal(err)
+	}
+	purchase, err := tw.Internal().DCR.PurchaseTickets(ctx, backend, &w.PurchaseTicketsRequest{
+		Count:         1,
+		SourceAccount: 0,
+		MinConf:       1,
+		VotingAccount: 0,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ticket := purchase.TicketHashes[0]
+
+	if err = tw.StartSoloVoter(testPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	// Locks of the wallet, as done after every spend, must not stop the voter
+	// from signing votes.
+	tw.LockWallet()
+
+	const maxBlocks = 1000
+	for i := 0; i < maxBlocks; i++ {
+		mine(1)
+		height := tw.GetBestBlockHeight()
+		waitFor(t, fmt.Sprintf("block %d", height+1), time.Minute, func() bool {
+			return tw.GetBestBlockHeight() > height
+		})
+
+		summary, _, err := tw.Internal().DCR.GetTicketInfo(ctx, ticket)
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch summary.Status {
+		case w.TicketStatusVoted:
+			return
+		case w.TicketStatusMissed, w.TicketStatusExpired, w.TicketStatusRevoked:
+			t.Fatalf("ticket %s was not voted: %s", ticket, summary.Status)
+		}
+	}
+	t.Fatalf("ticket %s not voted after %d blocks", ticket, maxBlocks)
+}
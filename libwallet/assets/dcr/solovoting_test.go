@@ -0,0 +1,212 @@
+package dcr
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/asdine/storm"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const testPassphrase = "passphrase"
+
+// testWallet is a simnet wallet created in a temporary directory.
+type testWallet struct {
+	*Asset
+	params *sharedW.InitParams
+	// shutdown shuts the wallet down once, it is called when the test ends.
+	shutdown func()
+}
+
+// newTestWallet creates a simnet wallet in a temporary directory. The wallet
+// is shut down when the test ends.
+func newTestWallet(t *testing.T) *testWallet {
+	t.Helper()
+	dir := t.TempDir()
+	db, err := storm.Open(filepath.Join(dir, "wallets.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err = db.Init(&sharedW.Wallet{}); err != nil {
+		t.Fatal(err)
+	}
+
+	params := &sharedW.InitParams{
+		RootDir:  dir,
+		NetType:  utils.Simulation,
+		DB:       db,
+		DbDriver: "bdb",
+		LogDir:   dir,
+	}
+	pass := &sharedW.AuthInfo{
+		Name:            "solo",
+		PrivatePass:     testPassphrase,
+		PrivatePassType: sharedW.PassphraseTypePass,
+		WordSeedType:    sharedW.WordSeed33,
+	}
+	asset, err := CreateNewWallet(pass, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw := &testWallet{
+		Asset:    asset.(*Asset),
+		params:   params,
+		shutdown: sync.OnceFunc(asset.Shutdown),
+	}
+	t.Cleanup(func() { tw.shutdown() })
+	return tw
+}
+
+// reopen shuts the wallet down and opens it again, applying the
+// settings only read when the wallet is opened.
+func (tw *testWallet) reopen(t *testing.T) {
+	t.Helper()
+	tw.shutdown()
+
+	w := new(sharedW.Wallet)
+	if err := tw.params.DB.One("ID", tw.ID, w); err != nil {
+		t.Fatal(err)
+	}
+	w.SetNetType(tw.params.NetType)
+	reopened, err := LoadExisting(w, tw.params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw.Asset = reopened.(*Asset)
+	tw.shutdown = sync.OnceFunc(reopened.Shutdown)
+	if err = tw.OpenWallet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSoloVoterRequiresRPCBackend(t *testing.T) {
+	asset := newTestWallet(t)
+
+	if err := asset.StartSoloVoter(testPassphrase); !errors.Is(err, utils.ErrSoloVotingDisabled) {
+		t.Fatalf("got error %v starting the voter with solo voting disabled, want %v", err, utils.ErrSoloVotingDisabled)
+	}
+
+	asset.SetSoloVotingEnabled(true)
+	if asset.SoloVotingActive() {
+		t.Fatal("solo voting is active before the wallet is reopened")
+	}
+	asset.reopen(t)
+	if !asset.SoloVotingActive() {
+		t.Fatal("solo voting is not active after the wallet is reopened")
+	}
+
+	if asset.SupportsSoloVoting() {
+		t.Fatal("solo voting is supported with the SPV backend")
+	}
+	if err := asset.StartSoloVoter(testPassphrase); !errors.Is(err, utils.ErrSoloVotingNotSupported) {
+		t.Fatalf("got error %v starting the voter with the SPV backend, want %v", err, utils.ErrSoloVotingNotSupported)
+	}
+
+	err := asset.SaveChainBackend(sharedW.RPCBackend, &sharedW.RPCConfig{Host: "127.0.0.1:19556"}, testPassphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !asset.SupportsSoloVoting() {
+		t.Fatal("solo voting is not supported with the RPC backend")
+	}
+}
+
+func TestSoloVoterKeepsWalletUnlocked(t *testing.T) {
+	asset := newTestWallet(t)
+	asset.SetSoloVotingEnabled(true)
+	err := asset.SaveChainBackend(sharedW.RPCBackend, &sharedW.RPCConfig{Host: "127.0.0.1:19556"}, testPassphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	asset.reopen(t)
+
+	var started, keptUnlocked, stopped int
+	var stopErr error
+	listener := &SoloVoterNotificationListener{
+		OnSoloVoterStarted:   func(int) { started++ },
+		OnWalletKeptUnlocked: func(int) { keptUnlocked++ },
+		OnSoloVoterStopped: func(_ int, err error) {
+			stopped++
+			stopErr = err
+		},
+	}
+	if err = asset.AddSoloVoterNotificationListener(listener, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = asset.StartSoloVoter("wrong passphrase"); err == nil {
+		t.Fatal("the voter started with a wrong passphrase")
+	}
+	if asset.IsSoloVoterRunning() {
+		t.Fatal("the voter is running after failing to start")
+	}
+
+	if err = asset.StartSoloVoter(testPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	if !asset.IsSoloVoterRunning() || started != 1 {
+		t.Fatalf("voter running %v, started notifications %d, want running with 1 notification",
+			asset.IsSoloVoterRunning(), started)
+	}
+	if err = asset.StartSoloVoter(testPassphrase); !errors.Is(err, utils.ErrSoloVoterAlreadyRunning) {
+		t.Fatalf("got error %v starting the voter twice, want %v", err, utils.ErrSoloVoterAlreadyRunning)
+	}
+
+	asset.LockWallet()
+	if asset.IsLocked() {
+		t.Fatal("the wallet was locked while the voter runs")
+	}
+	if keptUnlocked != 1 {
+		t.Fatalf("got %d kept unlocked notifications, want 1", keptUnlocked)
+	}
+
+	if err = asset.StopSoloVoter(); err != nil {
+		t.Fatal(err)
+	}
+	if !asset.IsLocked() {
+		t.Fatal("the wallet is unlocked after the voter stopped")
+	}
+	if stopped != 1 || stopErr != nil {
+		t.Fatalf("got %d stopped notifications with error %v, want 1 without error", stopped, stopErr)
+	}
+	if err = asset.StopSoloVoter(); err == nil {
+		t.Fatal("stopping a stopped voter succeeded")
+	}
+}
+
+func TestSoloVoterStartsOnce(t *testing.T) {
+	asset := newTestWallet(t)
+	asset.SetSoloVotingEnabled(true)
+	err := asset.SaveChainBackend(sharedW.RPCBackend, &sharedW.RPCConfig{Host: "127.0.0.1:19556"}, testPassphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	asset.reopen(t)
+
+	// Only one of the concurrent starts runs a voter.
+	const starts = 4
+	errs := make(chan error, starts)
+	for i := 0; i < starts; i++ {
+		go func() { errs <- asset.StartSoloVoter(testPassphrase) }()
+	}
+	var started int
+	for i := 0; i < starts; i++ {
+		err := <-errs
+		switch {
+		case err == nil:
+			started++
+		case !errors.Is(err, utils.ErrSoloVoterAlreadyRunning):
+			t.Fatalf("got error %v starting the voter, want %v", err, utils.ErrSoloVoterAlreadyRunning)
+		}
+	}
+	if started != 1 {
+		t.Fatalf("%d voters started, want 1", started)
+	}
+	if err = asset.StopSoloVoter(); err != nil {
+		t.Fatal(err)
+	}
+}
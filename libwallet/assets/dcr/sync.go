@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"decred.org/dcrwallet/v4/chain"
 	"decred.org/dcrwallet/v4/errors"
 	"decred.org/dcrwallet/v4/p2p"
 	"decred.org/dcrwallet/v4/spv"
@@ -16,6 +17,8 @@ import (
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/decred/dcrd/addrmgr/v2"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/wire"
 )
 
 // reading/writing of properties of this struct are protected by mutex.x
@@ -61,9 +64,17 @@ func (s *SyncData) generalSyncProgress() *sharedW.GeneralSyncProgress {
 	return s.genSyncProgress
 }
 
+// chainSyncer syncs the wallet with its chain backend, it is implemented by
+// the SPV syncer and the dcrd RPC syncer.
+type chainSyncer interface {
+	Run(ctx context.Context) error
+	Synced(ctx context.Context) (bool, int32)
+	Blocks(ctx context.Context, blockHashes []*chainhash.Hash) ([]*wire.MsgBlock, error)
+}
+
 // reading/writing of properties of this struct are protected by syncData.mu.
 type activeSyncData struct {
-	syncer    *spv.Syncer
+	syncer    chainSyncer
 	syncStage utils.SyncStage
 
 	addressDiscoveryCompletedOrCanceled chan bool
@@ -94,6 +105,15 @@ const (
 	HeadersRescanSyncStage    = utils.HeadersRescanSyncStage
 )
 
+// peerBanDuration is how long a peer banned by the user stays banned, as for
+// the neutrino peers of the BTC and LTC wallets.
+const peerBanDuration = 24 * time.Hour
+
+var (
+	errDisconnectedByUser = errors.New("peer disconnected by the user")
+	errBannedByUser       = errors.New("peer banned by the user")
+)
+
 func (asset *Asset) initActiveSyncData() {
 	asset.syncData.mu.Lock()
 	asset.syncData.activeSyncData = &activeSyncData{
@@ -179,14 +199,15 @@ func (asset *Asset) SpvSync() error {
 		return errors.New(utils.ErrSyncAlreadyInProgress)
 	}
 
-	peerAddresses := asset.ReadStringConfigValueForKey(sharedW.SpvPersistentPeerAddressesConfigKey, "")
-	validPeerAddresses, errs := sharedW.ParseWalletPeers(peerAddresses, asset.chainParams.DefaultPort)
-	for _, err := range errs { // Log errors if any
-		log.Error(err)
+	var syncer chainSyncer
+	var err error
+	if asset.ChainBackend() == sharedW.RPCBackend {
+		syncer, err = asset.rpcSyncer()
+	} else {
+		syncer, err = asset.spvSyncer()
 	}
-
-	if len(validPeerAddresses) == 0 && len(errs) > 0 {
-		return errors.New(utils.ErrInvalidPeers)
+	if err != nil {
+		return err
 	}
 
 	// init activeSyncData to be used to hold data used
@@ -196,20 +217,6 @@ func (asset *Asset) SpvSync() error {
 	asset.waitingForHeaders = true
 	asset.syncing = true
 
-	addr := &net.TCPAddr{IP: net.ParseIP("::1"), Port: 0}
-	addrManager := addrmgr.New(asset.DataDir(), net.LookupIP) // TODO: be mindful of tor
-	lp := p2p.NewLocalPeer(asset.chainParams, addr, addrManager)
-
-	// Set the node to only connect to remote peers whose advertised best block
-	// height is greater than the currently synced.
-	lp.RequirePeerHeight(asset.GetBestBlockHeight())
-
-	syncer := spv.NewSyncer(asset.Internal().DCR, lp)
-	syncer.SetNotifications(asset.spvSyncNotificationCallbacks())
-	if len(validPeerAddresses) > 0 {
-		syncer.SetPersistentPeers(validPeerAddresses)
-	}
-
 	ctx, cancel := asset.ShutdownContextWithCancel()
 
 	asset.syncData.mu.Lock()
@@ -242,6 +249,12 @@ func (asset *Asset) SpvSync() error {
 			}
 		}
 
+		if _, isRPC := syncer.(*chain.Syncer); isRPC {
+			// The RPC syncer has no peer notifications, the node is
+			// the only peer and it is disconnected once Run returns.
+			asset.handlePeerCountUpdate(0)
+		}
+
 		// Close the syncer channel after the syncer.Run stops.
 		close(asset.syncData.syncCanceled)
 		// reset sync variables
@@ -250,6 +263,45 @@ func (asset *Asset) SpvSync() error {
 	return nil
 }
 
+// spvSyncer returns a syncer of the P2P network, connecting to the peers set
+// in the wallet config if any.
+func (asset *Asset) spvSyncer() (*spv.Syncer, error) {
+	peerAddresses := asset.ReadStringConfigValueForKey(sharedW.SpvPersistentPeerAddressesConfigKey, "")
+	validPeerAddresses, errs := sharedW.ParseWalletPeers(peerAddresses, asset.chainParams.DefaultPort)
+	for _, err := range errs { // Log errors if any
+		log.Error(err)
+	}
+
+	if len(validPeerAddresses) == 0 && len(errs) > 0 {
+		return nil, errors.New(utils.ErrInvalidPeers)
+	}
+
+	addr := &net.TCPAddr{IP: net.ParseIP("::1"), Port: 0}
+	addrManager := addrmgr.New(asset.DataDir(), net.LookupIP) // TODO: be mindful of tor
+	lp := p2p.NewLocalPeer(asset.chainParams, addr, addrManager)
+
+	// Count the traffic of the peer connections towards the data usage.
+	var dialer net.Dialer
+	lp.SetDialFunc(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dialer.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		return asset.TrackDataUsage(conn), nil
+	})
+
+	// Set the node to only connect to remote peers whose advertised best block
+	// height is greater than the currently synced.
+	lp.RequirePeerHeight(asset.GetBestBlockHeight())
+
+	syncer := spv.NewSyncer(asset.Internal().DCR, lp)
+	syncer.SetNotifications(asset.spvSyncNotificationCallbacks())
+	if len(validPeerAddresses) > 0 {
+		syncer.SetPersistentPeers(validPeerAddresses)
+	}
+	return syncer, nil
+}
+
 func (asset *Asset) RestartSpvSync() error {
 	asset.syncData.mu.Lock()
 	asset.syncData.restartSyncRequested = true
@@ -358,13 +410,40 @@ func (asset *Asset) SyncData() *SyncData {
 	return asset.syncData
 }
 
+// activeSyncer returns the syncer of the running sync, nil if the wallet
+// isn't syncing.
+func (asset *Asset) activeSyncer() chainSyncer {
+	asset.syncData.mu.RLock()
+	defer asset.syncData.mu.RUnlock()
+	if asset.syncData.activeSyncData == nil {
+		return nil
+	}
+	return asset.syncData.syncer
+}
+
+// PeerInfoRaw returns the peers the wallet is connected to. The SPV syncer
+// doesn't track the ping times and traffic of its peers.
 func (asset *Asset) PeerInfoRaw() ([]sharedW.PeerInfo, error) {
 	if !asset.IsConnectedToDecredNetwork() {
 		return nil, errors.New(utils.ErrNotConnected)
 	}
 
-	syncer := asset.syncData.syncer
+	switch syncer := asset.activeSyncer().(type) {
+	case *spv.Syncer:
+		return spvPeerInfo(syncer), nil
+	case *chain.Syncer:
+		// The dcrd node is the only peer of the wallet.
+		addr, err := asset.RPCConfig().Address(rpcPort(asset.chainParams))
+		if err != nil {
+			return nil, err
+		}
+		_, height := syncer.Synced(context.Background())
+		return []sharedW.PeerInfo{{Addr: addr, StartingHeight: int64(height), BestHeight: int64(height)}}, nil
+	}
+	return nil, errors.New(utils.ErrNotConnected)
+}
 
+func spvPeerInfo(syncer *spv.Syncer) []sharedW.PeerInfo {
 	infos := make([]sharedW.PeerInfo, 0, len(syncer.GetRemotePeers()))
 	for _, rp := range syncer.GetRemotePeers() {
 		info := sharedW.PeerInfo{
@@ -375,6 +454,7 @@ func (asset *Asset) PeerInfoRaw() ([]sharedW.PeerInfo, error) {
 			Version:        rp.Pver(),
 			SubVer:         rp.UA(),
 			StartingHeight: int64(rp.InitialHeight()),
+			BestHeight:     int64(rp.LastHeight()),
 			BanScore:       int32(rp.BanScore()),
 		}
 
@@ -385,7 +465,96 @@ func (asset *Asset) PeerInfoRaw() ([]sharedW.PeerInfo, error) {
 		return infos[i].ID < infos[j].ID
 	})
 
-	return infos, nil
+	return infos
+}
+
+// remotePeer returns the connected SPV peer with the address.
+func (asset *Asset) remotePeer(addr string) (*p2p.RemotePeer, error) {
+	activeSyncer := asset.activeSyncer()
+	if activeSyncer == nil {
+		return nil, errors.New(utils.ErrNotConnected)
+	}
+	syncer, ok := activeSyncer.(*spv.Syncer)
+	if !ok {
+		// The dcrd node can't be dropped, the RPC backend has no other peer.
+		return nil, utils.ErrPeerActionUnsupported
+	}
+	rp, ok := syncer.GetRemotePeers()[addr]
+	if !ok {
+		return nil, utils.ErrPeerNotFound
+	}
+	return rp, nil
+}
+
+// DisconnectPeer disconnects the SPV peer with the address, the syncer may
+// connect to it again later.
+func (asset *Asset) DisconnectPeer(addr string) error {
+	rp, err := asset.remotePeer(addr)
+	if err != nil {
+		return err
+	}
+	rp.Disconnect(errDisconnectedByUser)
+	return nil
+}
+
+// BanPeer disconnects the SPV peer with the address and drops the
+// connections to its host for peerBanDuration. The bans aren't persisted.
+func (asset *Asset) BanPeer(addr string) error {
+	rp, err := asset.remotePeer(addr)
+	if err != nil {
+		return err
+	}
+
+	asset.bannedPeersMu.Lock()
+	if asset.bannedPeers == nil {
+		asset.bannedPeers = make(map[string]time.Time)
+	}
+	asset.bannedPeers[peerHost(addr)] = time.Now().Add(peerBanDuration)
+	asset.bannedPeersMu.Unlock()
+
+	log.Infof("Banning peer %s for %v", addr, peerBanDuration)
+	rp.Disconnect(errBannedByUser)
+	return nil
+}
+
+// AddPeer isn't supported, the SPV syncer only connects to the peers it
+// picks or to the persistent peers set with SetSpecificPeer.
+func (asset *Asset) AddPeer(string) error {
+	return utils.ErrPeerActionUnsupported
+}
+
+// isPeerBanned returns true if the host of the peer is banned.
+func (asset *Asset) isPeerBanned(addr string) bool {
+	asset.bannedPeersMu.Lock()
+	defer asset.bannedPeersMu.Unlock()
+
+	host := peerHost(addr)
+	expiry, ok := asset.bannedPeers[host]
+	if ok && time.Now().After(expiry) {
+		delete(asset.bannedPeers, host)
+		return false
+	}
+	return ok
+}
+
+// dropBannedPeer disconnects the peer that just connected if it is banned.
+func (asset *Asset) dropBannedPeer(addr string) {
+	if !asset.isPeerBanned(addr) {
+		return
+	}
+	if rp, err := asset.remotePeer(addr); err == nil {
+		log.Debugf("Dropping banned peer %s", addr)
+		rp.Disconnect(errBannedByUser)
+	}
+}
+
+// peerHost returns the host of the peer address, bans apply to all the ports
+// of a host.
+func peerHost(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
 }
 
 func (asset *Asset) PeerInfo() (string, error) {
@@ -0,0 +1,79 @@
+package dcr
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+func TestPeerHost(t *testing.T) {
+	tests := []struct {
+		addr, host string
+	}{
+		{"127.0.0.1:19108", "127.0.0.1"},
+		{"[::1]:19108", "::1"},
+		{"example.com:9108", "example.com"},
+		{"127.0.0.1", "127.0.0.1"},
+	}
+	for _, tc := range tests {
+		if host := peerHost(tc.addr); host != tc.host {
+			t.Errorf("%s: got host %q, want %q", tc.addr, host, tc.host)
+		}
+	}
+}
+
+func TestPeerBans(t *testing.T) {
+	asset := &Asset{bannedPeers: map[string]time.Time{
+		"10.0.0.1": time.Now().Add(time.Hour),
+		"10.0.0.2": time.Now().Add(-time.Second),
+	}}
+
+	tests := []struct {
+		addr   string
+		banned bool
+	}{
+		{"10.0.0.1:19108", true},
+		// Bans apply to all the ports of the host.
+		{"10.0.0.1:18555", true},
+		{"10.0.0.2:19108", false},
+		{"10.0.0.3:19108", false},
+	}
+	for _, tc := range tests {
+		if banned := asset.isPeerBanned(tc.addr); banned != tc.banned {
+			t.Errorf("%s: got banned %v, want %v", tc.addr, banned, tc.banned)
+		}
+	}
+	if _, ok := asset.bannedPeers["10.0.0.2"]; ok {
+		t.Error("the expired ban was kept")
+	}
+}
+
+func TestPeerActionsNotSyncing(t *testing.T) {
+	asset := newTestWallet(t)
+
+	if _, err := asset.PeerInfoRaw(); err == nil {
+		t.Error("got the peers of a wallet that is not syncing")
+	}
+
+	tests := []struct {
+		name   string
+		action func(string) error
+		err    error
+	}{
+		{"disconnect", asset.DisconnectPeer, errors.New(utils.ErrNotConnected)},
+		{"ban", asset.BanPeer, errors.New(utils.ErrNotConnected)},
+		// The SPV syncer only connects to the peers it picks.
+		{"add", asset.AddPeer, utils.ErrPeerActionUnsupported},
+	}
+	for _, tc := range tests {
+		err := tc.action("127.0.0.1:18555")
+		if err == nil || err.Error() != tc.err.Error() {
+			t.Errorf("%s: got error %v, want %v", tc.name, err, tc.err)
+		}
+	}
+	if len(asset.bannedPeers) != 0 {
+		t.Errorf("got banned peers %v while not syncing", asset.bannedPeers)
+	}
+}
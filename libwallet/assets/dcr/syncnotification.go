@@ -4,6 +4,7 @@ import (
 	"math"
 	"time"
 
+	"decred.org/dcrwallet/v4/chain"
 	"decred.org/dcrwallet/v4/spv"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"golang.org/x/sync/errgroup"
@@ -11,8 +12,9 @@ import (
 
 func (asset *Asset) spvSyncNotificationCallbacks() *spv.Notifications {
 	return &spv.Notifications{
-		PeerConnected: func(peerCount int32, _ string) {
+		PeerConnected: func(peerCount int32, addr string) {
 			asset.handlePeerCountUpdate(peerCount)
+			asset.dropBannedPeer(addr)
 		},
 		PeerDisconnected: func(peerCount int32, _ string) {
 			asset.handlePeerCountUpdate(peerCount)
@@ -32,6 +34,40 @@ func (asset *Asset) spvSyncNotificationCallbacks() *spv.Notifications {
 	}
 }
 
+func (asset *Asset) rpcSyncNotificationCallbacks() *chain.Callbacks {
+	// The RPC syncer reports the number of headers fetched by each batch
+	// rather than the height of the last header fetched.
+	var fetchedHeaders int32
+	return &chain.Callbacks{
+		Synced: asset.syncedWallet,
+		FetchHeadersStarted: func() {
+			fetchedHeaders = 0
+			asset.fetchHeadersStarted()
+		},
+		FetchHeadersProgress: func(fetchedHeadersCount int32, lastHeaderTime int64) {
+			fetchedHeaders += fetchedHeadersCount
+			asset.syncData.mu.RLock()
+			startHeight := asset.syncData.scanStartHeight
+			asset.syncData.mu.RUnlock()
+			asset.fetchHeadersProgress(startHeight+fetchedHeaders, lastHeaderTime)
+		},
+		FetchHeadersFinished: asset.fetchHeadersFinished,
+		FetchMissingCFiltersStarted: func() {
+			// Missing cfilters are fetched once connected to the node,
+			// which is the only peer of the wallet.
+			asset.handlePeerCountUpdate(1)
+			asset.fetchCFiltersStarted()
+		},
+		FetchMissingCFiltersProgress: asset.fetchCFiltersProgress,
+		FetchMissingCFiltersFinished: asset.fetchCFiltersEnded,
+		DiscoverAddressesStarted:     asset.discoverAddressesStarted,
+		DiscoverAddressesFinished:    asset.discoverAddressesFinished,
+		RescanStarted:                asset.rescanStarted,
+		RescanProgress:               asset.rescanProgress,
+		RescanFinished:               asset.rescanFinished,
+	}
+}
+
 func (asset *Asset) handlePeerCountUpdate(peerCount int32) {
 	asset.syncData.mu.Lock()
 	asset.syncData.numOfConnectedPeers = peerCount
@@ -230,6 +266,7 @@ func (asset *Asset) fetchHeadersProgress(lastFetchedHeaderHeight int32, _ int64)
 	if asset.waitingForHeaders {
 		asset.waitingForHeaders = asset.GetBestBlockHeight() > lastFetchedHeaderHeight
 	}
+	asset.SaveHeadersCheckpoint(lastFetchedHeaderHeight)
 
 	headersFetchedSoFar := float64(lastFetchedHeaderHeight - startHeight)
 	if headersFetchedSoFar < 1 {
@@ -651,6 +688,11 @@ func (asset *Asset) syncedWallet(synced bool) {
 					}
 				}
 			}
+
+			if synced {
+				// Pick up a rescan the previous sync didn't finish.
+				asset.resumeRescan()
+			}
 		}()
 	}
 
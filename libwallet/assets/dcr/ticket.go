@@ -134,18 +134,10 @@ func (asset *Asset) PurchaseTickets(account, numTickets int32, vspHost, passphra
 		VotingAccount: uint32(account),
 	}
 
-	csppCfg := asset.readCSPPConfig()
-	if csppCfg == nil {
-		return nil, utils.ErrStakingAccountsMissing
+	if err = asset.setMixedSplitBuying(request); err != nil {
+		return nil, err
 	}
 
-	// Mixed split buying through CoinShuffle++, if configured.
-	request.Mixing = csppCfg.Mixing
-	request.MixedAccount = csppCfg.MixedAccount
-	request.MixedAccountBranch = csppCfg.MixedAccountBranch
-	request.ChangeAccount = csppCfg.ChangeAccount
-	request.MixedSplitAccount = csppCfg.TicketSplitAccount
-
 	ctx, _ := asset.ShutdownContextWithCancel()
 	ticketsResponse, err := asset.Internal().DCR.PurchaseTickets(ctx, networkBackend, request)
 	if err != nil {
@@ -155,6 +147,22 @@ func (asset *Asset) PurchaseTickets(account, numTickets int32, vspHost, passphra
 	return ticketsResponse.TicketHashes, err
 }
 
+// setMixedSplitBuying configures the ticket purchase request to buy the split
+// transaction outputs through CoinShuffle++, if configured.
+func (asset *Asset) setMixedSplitBuying(request *w.PurchaseTicketsRequest) error {
+	csppCfg := asset.readCSPPConfig()
+	if csppCfg == nil {
+		return utils.ErrStakingAccountsMissing
+	}
+
+	request.Mixing = csppCfg.Mixing
+	request.MixedAccount = csppCfg.MixedAccount
+	request.MixedAccountBranch = csppCfg.MixedAccountBranch
+	request.ChangeAccount = csppCfg.ChangeAccount
+	request.MixedSplitAccount = csppCfg.TicketSplitAccount
+	return nil
+}
+
 // VSPTicketInfo returns vsp-related info for a given ticket. Returns an error
 // if the ticket is not yet assigned to a VSP.
 func (asset *Asset) VSPTicketInfo(hash string) (*VSPTicketInfo, error) {
@@ -239,7 +247,8 @@ func (asset *Asset) StartTicketBuyer(passphrase string) error {
 	}
 
 	cfg := asset.AutoTicketsBuyerConfig()
-	if cfg.VspHost == "" {
+	soloVoting := cfg.VspHost == ""
+	if soloVoting && !asset.SoloVotingActive() {
 		return errors.New("ticket buyer config not set for this wallet")
 	}
 	if cfg.BalanceToMaintain < 0 {
@@ -263,22 +272,27 @@ func (asset *Asset) StartTicketBuyer(passphrase string) error {
 	asset.cancelAutoTicketBuyer = cancel
 	asset.cancelAutoTicketBuyerMu.Unlock()
 
-	// Check the VSP.
-	vspInfo, err := vspInfo(cfg.VspHost)
-	if err != nil {
-		return fmt.Errorf("error setting up vsp client: %v", err)
-	}
+	if soloVoting {
+		log.Warnf("[%d] Ticket buyer running without a VSP: tickets will only be "+
+			"voted while the solo voter is running", asset.ID)
+	} else {
+		// Check the VSP.
+		vspInfo, err := vspInfo(cfg.VspHost)
+		if err != nil {
+			return fmt.Errorf("error setting up vsp client: %v", err)
+		}
 
-	cfg.VspClient, err = asset.VSPClient(cfg.PurchaseAccount, cfg.VspHost, vspInfo.PubKey)
-	if err != nil {
-		log.Errorf("[%d] VSP Client instance failed error: %v", asset.ID, err)
-		return errors.New("VSP Client failed to start due to incorrect configuration")
+		cfg.VspClient, err = asset.VSPClient(cfg.PurchaseAccount, cfg.VspHost, vspInfo.PubKey)
+		if err != nil {
+			log.Errorf("[%d] VSP Client instance failed error: %v", asset.ID, err)
+			return errors.New("VSP Client failed to start due to incorrect configuration")
+		}
 	}
 
 	go func() {
 		log.Infof("[%d] Running ticket buyer", asset.ID)
 
-		if err = asset.runTicketBuyer(ctx, passphrase, cfg); err != nil {
+		if err := asset.runTicketBuyer(ctx, passphrase, cfg); err != nil {
 			if ctx.Err() != nil {
 				log.Errorf("[%d] Ticket buyer instance canceled", asset.ID)
 			} else {
@@ -286,7 +300,7 @@ func (asset *Asset) StartTicketBuyer(passphrase string) error {
 			}
 		}
 
-		if err = asset.StopAutoTicketsPurchase(); err != nil {
+		if err := asset.StopAutoTicketsPurchase(); err != nil {
 			log.Errorf("[%d] Stopping auto ticket purchase errored: %v", asset.ID, err)
 		}
 	}()
@@ -464,30 +478,27 @@ func (asset *Asset) buyTicket(ctx context.Context, passphrase string, sdiff dcru
 	// Count is 1 to prevent combining multiple split outputs in one tx,
 	// which can be used to link the tickets eventually purchased with the
 	// split outputs.
+	// VSP fees are not paid when the ticket buyer runs without a VSP, the
+	// wallet then votes the tickets itself.
 	request := &w.PurchaseTicketsRequest{
-		Count:                1,
-		SourceAccount:        uint32(cfg.PurchaseAccount),
-		Expiry:               expiry,
-		MinConf:              asset.RequiredConfirmations(),
-		VSPFeePercent:        cfg.VspClient.FeePercentage,
-		VSPFeePaymentProcess: cfg.VspClient.Process,
+		Count:         1,
+		SourceAccount: uint32(cfg.PurchaseAccount),
+		Expiry:        expiry,
+		MinConf:       asset.RequiredConfirmations(),
 
 		// VotingAccount used to derive addresses for specifying voting rights.
 		// It is used when VotingAddress == nil, or Mixing == true
 		VotingAccount: uint32(cfg.PurchaseAccount),
 	}
 
-	csppCfg := asset.readCSPPConfig()
-	if csppCfg == nil {
-		return utils.ErrStakingAccountsMissing
+	if cfg.VspClient != nil {
+		request.VSPFeePercent = cfg.VspClient.FeePercentage
+		request.VSPFeePaymentProcess = cfg.VspClient.Process
 	}
 
-	// Mixed split buying through CoinShuffle++, if configured.
-	request.Mixing = csppCfg.Mixing
-	request.MixedAccount = csppCfg.MixedAccount
-	request.MixedAccountBranch = csppCfg.MixedAccountBranch
-	request.ChangeAccount = csppCfg.ChangeAccount
-	request.MixedSplitAccount = csppCfg.TicketSplitAccount
+	if err = asset.setMixedSplitBuying(request); err != nil {
+		return err
+	}
 
 	tix, err := asset.Internal().DCR.PurchaseTickets(ctx, networkBackend, request)
 	if tix != nil {
@@ -542,8 +553,12 @@ func (asset *Asset) AutoTicketsBuyerConfig() *TicketBuyerConfig {
 }
 
 // TicketBuyerConfigIsSet checks if ticket buyer config is set for the asset.
+// A config without a VSP host is only valid when solo voting is enabled.
 func (asset *Asset) TicketBuyerConfigIsSet() bool {
-	return asset.ReadStringConfigValueForKey(sharedW.TicketBuyerVSPHostConfigKey, "") != ""
+	if asset.ReadStringConfigValueForKey(sharedW.TicketBuyerVSPHostConfigKey, "") != "" {
+		return true
+	}
+	return asset.IsSoloVotingEnabled() && asset.IsTicketBuyerAccountSet()
 }
 
 // IsTicketBuyerAccountSet checks if ticket buyer account is set for the asset.
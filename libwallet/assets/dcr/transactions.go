@@ -98,7 +98,12 @@ func (asset *Asset) GetTransactionRaw(txHash string) (*sharedW.Transaction, erro
 		return nil, err
 	}
 
-	return asset.decodeTransactionWithTxSummary(txSummary, blockHash)
+	tx, err := asset.decodeTransactionWithTxSummary(txSummary, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	asset.ApplyTxLabels(tx)
+	return tx, nil
 }
 
 func (asset *Asset) GetTransactions(offset, limit, txFilter int32, newestFirst bool) (string, error) {
@@ -119,12 +124,41 @@ func (asset *Asset) GetTransactionsRaw(offset, limit, txFilter int32, newestFirs
 	txHashSearch = strings.TrimSpace(txHashSearch)
 	if txHashSearch != "" {
 		err = asset.GetWalletDataDb().Find(q.Eq("Hash", txHashSearch), &transactions)
+		if err != nil && err != storm.ErrNotFound {
+			return nil, err
+		}
+		if len(transactions) == 0 {
+			// Search the labels and addresses of all the transactions.
+			transactions, err = asset.searchTransactions(txFilter, newestFirst, txHashSearch)
+		}
+		asset.ApplyTxLabels(transactions...)
 		return
 	}
 	err = asset.GetWalletDataDb().Read(offset, limit, txFilter, newestFirst, asset.RequiredConfirmations(), asset.GetBestBlockHeight(), &transactions)
+	asset.ApplyTxLabels(transactions...)
 	return
 }
 
+// searchTransactions returns the transactions that match the search text, see
+// TxSearchMatcher.
+func (asset *Asset) searchTransactions(txFilter int32, newestFirst bool, search string) ([]*sharedW.Transaction, error) {
+	var transactions []*sharedW.Transaction
+	err := asset.GetWalletDataDb().Read(0, 0, txFilter, newestFirst, asset.RequiredConfirmations(), asset.GetBestBlockHeight(), &transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	asset.ApplyTxLabels(transactions...)
+	matches := asset.TxSearchMatcher(search)
+	matchingTxs := make([]*sharedW.Transaction, 0)
+	for _, tx := range transactions {
+		if matches(tx) {
+			matchingTxs = append(matchingTxs, tx)
+		}
+	}
+	return matchingTxs, nil
+}
+
 func (asset *Asset) CountTransactions(txFilter int32) (int, error) {
 	return asset.GetWalletDataDb().Count(txFilter, asset.RequiredConfirmations(), asset.GetBestBlockHeight(), &sharedW.Transaction{})
 }
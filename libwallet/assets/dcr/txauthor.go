@@ -31,6 +31,7 @@ type TxAuthor struct {
 	utxos          []*sharedW.UnspentOutput
 	unsignedTx     *txauthor.AuthoredTx
 	needsConstruct bool
+	coinSelection  sharedW.CoinSelectionStrategy
 }
 
 func (asset *Asset) NewUnsignedTx(sourceAccountNumber int32, utxos []*sharedW.UnspentOutput) error {
@@ -44,10 +45,23 @@ func (asset *Asset) NewUnsignedTx(sourceAccountNumber int32, utxos []*sharedW.Un
 		destinations:        make(map[int]*sharedW.TransactionDestination, 0),
 		needsConstruct:      true,
 		utxos:               utxos,
+		coinSelection:       asset.DefaultCoinSelectionStrategy(),
 	}
 	return nil
 }
 
+// SetCoinSelectionStrategy sets the strategy used to select the outputs that
+// fund the transaction when they are not selected manually.
+func (asset *Asset) SetCoinSelectionStrategy(strategy sharedW.CoinSelectionStrategy) error {
+	if !strategy.IsValid() {
+		return errors.New(utils.ErrInvalid)
+	}
+
+	asset.TxAuthoredInfo.coinSelection = strategy
+	asset.TxAuthoredInfo.needsConstruct = true
+	return nil
+}
+
 // ComputeTxSizeEstimation computes the estimated size of the final raw transaction.
 func (asset *Asset) ComputeTxSizeEstimation(dstnAddress string, utxos []*sharedW.UnspentOutput) (int, error) {
 	if len(utxos) == 0 {
@@ -168,6 +182,13 @@ func (asset *Asset) EstimateFeeAndSize() (*sharedW.TxFeeAndSize, error) {
 	}
 
 	feeToSendTx := txrules.FeeForSerializeSize(txrules.DefaultRelayFeePerKb, unsignedTx.EstimatedSignedSerializeSize)
+	if unsignedTx.ChangeIndex < 0 {
+		// Without a change output, the inputs not sent are paid as fee.
+		feeToSendTx = unsignedTx.TotalInput
+		for _, txOut := range unsignedTx.Tx.TxOut {
+			feeToSendTx -= dcrutil.Amount(txOut.Value)
+		}
+	}
 	feeAmount := &sharedW.Amount{
 		UnitValue: int64(feeToSendTx),
 		CoinValue: feeToSendTx.ToCoin(),
@@ -289,17 +310,10 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byt
 		return nil, utils.TranslateError(err)
 	}
 
-	return txHash[:], asset.updateTxLabel(txHash, transactionLabel)
-}
-
-// updateTxLabel saves the tx label in the local instance.
-func (asset *Asset) updateTxLabel(hash *chainhash.Hash, txLabel string) error {
-	tx := &sharedW.Transaction{
-		Hash:  hash.String(),
-		Label: txLabel,
+	if transactionLabel == "" {
+		return txHash[:], nil
 	}
-	_, err := asset.GetWalletDataDb().SaveOrUpdate(&sharedW.Transaction{}, tx)
-	return err
+	return txHash[:], asset.SetTransactionLabel(txHash.String(), transactionLabel)
 }
 
 func (asset *Asset) unsignedTransaction() (*txauthor.AuthoredTx, error) {
@@ -371,33 +385,83 @@ func (asset *Asset) constructTransaction() (*txauthor.AuthoredTx, error) {
 	}
 
 	// if preset with a selected list of UTXOs exists, use them instead.
+	// The coin selection strategy only applies to the outputs selected by
+	// the wallet, the outputs selected manually are spent largest first.
+	strategy := sharedW.CoinSelectionLargestFirst
 	unspents := asset.TxAuthoredInfo.utxos
 	if len(unspents) == 0 {
 		unspents, err = asset.UnspentOutputs(int32(asset.TxAuthoredInfo.sourceAccountNumber))
 		if err != nil {
 			return nil, err
 		}
+		unspents = sharedW.UnfrozenOutputs(unspents)
+		strategy = asset.TxAuthoredInfo.coinSelection
 	}
 
 	// Use the custom input source function instead of querying the same data from the
 	// db for every utxo.
-	inputsSourceFunc := asset.makeInputSource(sendMax, unspents)
+	selection := coinSelection(strategy, outputs, changeSource.ScriptSize())
+	inputsSourceFunc := asset.makeInputSource(sendMax, unspents, selection)
 
 	requiredConfirmations := asset.RequiredConfirmations()
-	return asset.Internal().DCR.NewUnsignedTransaction(ctx, outputs, txrules.DefaultRelayFeePerKb, asset.TxAuthoredInfo.sourceAccountNumber,
+	unsignedTx, err := asset.Internal().DCR.NewUnsignedTransaction(ctx, outputs, txrules.DefaultRelayFeePerKb, asset.TxAuthoredInfo.sourceAccountNumber,
 		requiredConfirmations, outputSelectionAlgorithm, changeSource, inputsSourceFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sendMax && selection.Changeless && unsignedTx.ChangeIndex >= 0 &&
+		unsignedTx.Tx.TxOut[unsignedTx.ChangeIndex].Value <= selection.CostOfChange {
+		// The branch and bound search found outputs paying the target
+		// without change, the change costs more to create and spend than it
+		// is worth and is paid as fee instead. The change output is always
+		// the last one.
+		unsignedTx.Tx.TxOut = unsignedTx.Tx.TxOut[:unsignedTx.ChangeIndex]
+		unsignedTx.ChangeIndex = -1
+
+		scriptSizes := make([]int, len(unsignedTx.Tx.TxIn))
+		for i := range scriptSizes {
+			scriptSizes[i] = txsizes.RedeemP2PKHSigScriptSize
+		}
+		unsignedTx.EstimatedSignedSerializeSize = txsizes.EstimateSerializeSize(scriptSizes, unsignedTx.Tx.TxOut, 0)
+	}
+
+	return unsignedTx, nil
+}
+
+// coinSelection returns the values used by the coin selection strategy to
+// fund the outputs. Every input redeems a P2PKH output.
+func coinSelection(strategy sharedW.CoinSelectionStrategy, outputs []*wire.TxOut, changeScriptSize int) *sharedW.CoinSelection {
+	feeRate := txrules.DefaultRelayFeePerKb
+	var outputsValue int64
+	for _, output := range outputs {
+		outputsValue += output.Value
+	}
+
+	txSize := txsizes.EstimateSerializeSize(nil, outputs, changeScriptSize)
+	changeSize := txSize - txsizes.EstimateSerializeSize(nil, outputs, 0)
+	inputCost := txrules.FeeForSerializeSize(feeRate, txsizes.EstimateInputSize(txsizes.RedeemP2PKHSigScriptSize))
+
+	return &sharedW.CoinSelection{
+		Strategy:     strategy,
+		Target:       outputsValue + int64(txrules.FeeForSerializeSize(feeRate, txSize)),
+		InputCost:    int64(inputCost),
+		CostOfChange: int64(txrules.FeeForSerializeSize(feeRate, changeSize) + inputCost),
+	}
 }
 
 // makeInputSource creates an InputSource that creates inputs for every unspent
-// output with non-zero output values. The importsource aims to create the leanest
-// transaction possible. It plans not to spend all the utxos available when servicing
-// the current transaction spending amount if possible. The sendMax shows that
-// all utxos must be spent without any balance(unspent utxo) left in the account.
-func (asset *Asset) makeInputSource(sendMax bool, utxos []*sharedW.UnspentOutput) txauthor.InputSource {
+// output with non-zero output values. Unless sendMax is set, the inputs that
+// fund the transaction are picked by the coin selection strategy, which plans
+// not to spend all the utxos available when servicing the current transaction
+// spending amount if possible. The sendMax shows that all utxos must be spent
+// without any balance(unspent utxo) left in the account.
+func (asset *Asset) makeInputSource(sendMax bool, utxos []*sharedW.UnspentOutput, selection *sharedW.CoinSelection) txauthor.InputSource {
 	var (
 		sourceErr       error
 		totalInputValue dcrutil.Amount
 
+		candidates        = make([]*sharedW.UnspentOutput, 0, len(utxos))
 		inputs            = make([]*wire.TxIn, 0, len(utxos))
 		pkScripts         = make([][]byte, 0, len(utxos))
 		redeemScriptSizes = make([]int, 0, len(utxos))
@@ -426,6 +490,7 @@ func (asset *Asset) makeInputSource(sendMax bool, utxos []*sharedW.UnspentOutput
 		}
 
 		totalInputValue += dcrutil.Amount(output.Amount.(Amount))
+		candidates = append(candidates, output)
 		pkScripts = append(pkScripts, script)
 		redeemScriptSizes = append(redeemScriptSizes, txsizes.RedeemP2PKHSigScriptSize)
 		inputs = append(inputs, wire.NewTxIn(&previousOutPoint, output.Amount.ToInt(), nil))
@@ -447,7 +512,7 @@ func (asset *Asset) makeInputSource(sendMax bool, utxos []*sharedW.UnspentOutput
 		inputDetails := &txauthor.InputDetail{}
 
 		// All utxos are to be spent with no change amount expected.
-		if sendMax {
+		if sendMax || target == 0 {
 			inputDetails.Inputs = inputs
 			inputDetails.Amount = totalInputValue
 			inputDetails.Scripts = pkScripts
@@ -455,24 +520,21 @@ func (asset *Asset) makeInputSource(sendMax bool, utxos []*sharedW.UnspentOutput
 			return inputDetails, nil
 		}
 
-		var index int
-		var currentTotal dcrutil.Amount
-
-		for _, utxoAmount := range inputs {
-			if currentTotal < target || target == 0 {
-				// Found some utxo(s) we can spend in the current tx.
-				index++
-
-				currentTotal += dcrutil.Amount(utxoAmount.ValueIn)
-				continue
-			}
-			break
+		// The target already includes the fee of an input, the strategies
+		// add the cost of every input they select.
+		txSelection := *selection
+		if remaining := int64(target) - selection.InputCost; remaining > txSelection.Target {
+			txSelection.Target = remaining
 		}
 
-		inputDetails.Amount = currentTotal
-		inputDetails.Inputs = inputs[:index]
-		inputDetails.Scripts = pkScripts[:index]
-		inputDetails.RedeemScriptSizes = redeemScriptSizes[:index]
+		selected, changeless := sharedW.SelectCoins(&txSelection, candidates)
+		selection.Changeless = changeless
+		for _, i := range selected {
+			inputDetails.Amount += dcrutil.Amount(inputs[i].ValueIn)
+			inputDetails.Inputs = append(inputDetails.Inputs, inputs[i])
+			inputDetails.Scripts = append(inputDetails.Scripts, pkScripts[i])
+			inputDetails.RedeemScriptSizes = append(inputDetails.RedeemScriptSizes, redeemScriptSizes[i])
+		}
 		return inputDetails, nil
 	}
 }
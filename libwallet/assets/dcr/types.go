@@ -67,6 +67,15 @@ type AccountMixerNotificationListener struct {
 	OnAccountMixerEnded   func(walletID int)
 }
 
+// SoloVoterNotificationListener is notified of the solo voter state. The
+// voter keeps the wallet unlocked while it runs, OnWalletKeptUnlocked is called
+// when a lock of the wallet is skipped for the user to be warned.
+type SoloVoterNotificationListener struct {
+	OnSoloVoterStarted   func(walletID int)
+	OnSoloVoterStopped   func(walletID int, err error)
+	OnWalletKeptUnlocked func(walletID int)
+}
+
 /** begin ticket-related types */
 
 type TicketPriceResponse struct {
@@ -164,6 +173,7 @@ type Agenda struct {
 	AgendaID         string            `json:"agenda_id"`
 	Description      string            `json:"description"`
 	Mask             uint32            `json:"mask"`
+	VoteVersion      uint32            `json:"vote_version"`
 	Choices          []chaincfg.Choice `json:"choices"`
 	VotingPreference string            `json:"voting_preference"`
 	StartTime        int64             `json:"start_time"`
@@ -188,6 +198,37 @@ type DcrdataAgenda struct {
 	Mask          uint16 `json:"-"`
 }
 
+// TicketVoteChoices holds the agenda choices a ticket voted for, decoded from
+// the vote bits of its vote transaction. Choices maps agenda IDs to choice IDs
+// and only includes agendas that were being voted on when the vote was cast.
+type TicketVoteChoices struct {
+	TicketHash  string            `json:"ticket_hash"`
+	VoteHash    string            `json:"vote_hash"`
+	BlockHeight int32             `json:"block_height"`
+	Timestamp   int64             `json:"timestamp"`
+	VoteVersion uint32            `json:"vote_version"`
+	VoteBits    uint16            `json:"vote_bits"`
+	Choices     map[string]string `json:"choices"`
+}
+
+// AgendaVoteSummary counts the votes the wallet's tickets cast on an agenda.
+type AgendaVoteSummary struct {
+	AgendaID string `json:"agenda_id"`
+	Yes      int32  `json:"yes"`
+	No       int32  `json:"no"`
+	Abstain  int32  `json:"abstain"`
+}
+
+// TicketChoiceMismatch reports an agenda for which the choice registered with
+// the VSP voting a ticket differs from the choice saved in the wallet.
+type TicketChoiceMismatch struct {
+	TicketHash  string `json:"ticket_hash"`
+	VSPHost     string `json:"vsp_host"`
+	AgendaID    string `json:"agenda_id"`
+	LocalChoice string `json:"local_choice"`
+	VSPChoice   string `json:"vsp_choice"`
+}
+
 /** end agenda types */
 
 // TreasuryKeyPolicy records the voting policy for treasury spend transactions
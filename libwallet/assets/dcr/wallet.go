@@ -5,6 +5,7 @@ import (
 	"errors"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"decred.org/dcrwallet/v4/vsp"
 	dcrW "decred.org/dcrwallet/v4/wallet"
@@ -29,6 +30,13 @@ type Asset struct {
 	cancelAutoTicketBuyer   context.CancelFunc `json:"-"`
 	cancelAutoTicketBuyerMu sync.RWMutex
 
+	cancelSoloVoter   context.CancelFunc
+	cancelSoloVoterMu sync.RWMutex
+
+	// stakeOptions is shared with the wallet loader and is only read when the
+	// wallet is created or opened.
+	stakeOptions *dcr.StakeOptions
+
 	TxAuthoredInfo *TxAuthor
 
 	// VSP data
@@ -39,9 +47,15 @@ type Asset struct {
 	notificationListenersMu           sync.RWMutex
 	syncData                          *SyncData
 	accountMixerNotificationListeners map[string]*AccountMixerNotificationListener
+	soloVoterNotificationListeners    map[string]*SoloVoterNotificationListener
 	txAndBlockNotificationListeners   map[string]*sharedW.TxAndBlockNotificationListener
 	blocksRescanProgressListener      *sharedW.BlocksRescanProgressListener
 
+	// bannedPeers holds the expiry of the bans of the SPV peers banned by the
+	// user, keyed by host.
+	bannedPeersMu sync.Mutex
+	bannedPeers   map[string]time.Time
+
 	// dbMutex should be held when db transactions would circle back around
 	// and hold the mu lock to prevent a freeze.
 	dbMutex *sync.Mutex
@@ -51,7 +65,8 @@ type Asset struct {
 var _ sharedW.Asset = (*Asset)(nil)
 
 // initWalletLoader setups the loader.
-func initWalletLoader(chainParams *chaincfg.Params, rootdir, walletDbDriver string, dbMutex *sync.Mutex) loader.AssetLoader {
+func initWalletLoader(chainParams *chaincfg.Params, rootdir, walletDbDriver string,
+	stakeOptions *dcr.StakeOptions, dbMutex *sync.Mutex) loader.AssetLoader {
 	// TODO: Allow users provide values to override these defaults.
 	cfg := &sharedW.WConfig{
 		GapLimit:                20,
@@ -63,11 +78,6 @@ func initWalletLoader(chainParams *chaincfg.Params, rootdir, walletDbDriver stri
 		MixSplitLimit:           10,
 	}
 
-	stakeOptions := &dcr.StakeOptions{
-		VotingEnabled: false,
-		VotingAddress: nil,
-	}
-
 	dirName := ""
 	// testnet datadir takes a special structure to differentiate "testnet4" and "testnet3"
 	// data directory.
@@ -111,7 +121,8 @@ func CreateNewWallet(pass *sharedW.AuthInfo, params *sharedW.InitParams) (shared
 	}
 
 	var dbMutex sync.Mutex
-	ldr := initWalletLoader(chainParams, params.RootDir, params.DbDriver, &dbMutex)
+	stakeOptions := new(dcr.StakeOptions)
+	ldr := initWalletLoader(chainParams, params.RootDir, params.DbDriver, stakeOptions, &dbMutex)
 
 	w, err := sharedW.CreateNewWallet(pass, ldr, params, utils.DCRWalletAsset)
 	if err != nil {
@@ -126,7 +137,9 @@ func CreateNewWallet(pass *sharedW.AuthInfo, params *sharedW.InitParams) (shared
 		},
 		txAndBlockNotificationListeners:   make(map[string]*sharedW.TxAndBlockNotificationListener),
 		accountMixerNotificationListeners: make(map[string]*AccountMixerNotificationListener),
+		soloVoterNotificationListeners:    make(map[string]*SoloVoterNotificationListener),
 		vspClients:                        make(map[string]*vsp.Client),
+		stakeOptions:                      stakeOptions,
 		dbMutex:                           &dbMutex,
 	}
 
@@ -150,7 +163,8 @@ func CreateWatchOnlyWallet(walletName, extendedPublicKey string, params *sharedW
 	}
 
 	var dbMutex sync.Mutex
-	ldr := initWalletLoader(chainParams, params.RootDir, params.DbDriver, &dbMutex)
+	stakeOptions := new(dcr.StakeOptions)
+	ldr := initWalletLoader(chainParams, params.RootDir, params.DbDriver, stakeOptions, &dbMutex)
 	w, err := sharedW.CreateWatchOnlyWallet(walletName, extendedPublicKey,
 		ldr, params, utils.DCRWalletAsset)
 	if err != nil {
@@ -165,6 +179,8 @@ func CreateWatchOnlyWallet(walletName, extendedPublicKey string, params *sharedW
 		},
 		txAndBlockNotificationListeners:   make(map[string]*sharedW.TxAndBlockNotificationListener),
 		accountMixerNotificationListeners: make(map[string]*AccountMixerNotificationListener),
+		soloVoterNotificationListeners:    make(map[string]*SoloVoterNotificationListener),
+		stakeOptions:                      stakeOptions,
 		dbMutex:                           &dbMutex,
 	}
 
@@ -187,7 +203,8 @@ func RestoreWallet(seedMnemonic string, pass *sharedW.AuthInfo, params *sharedW.
 	}
 
 	var dbMutex sync.Mutex
-	ldr := initWalletLoader(chainParams, params.RootDir, params.DbDriver, &dbMutex)
+	stakeOptions := new(dcr.StakeOptions)
+	ldr := initWalletLoader(chainParams, params.RootDir, params.DbDriver, stakeOptions, &dbMutex)
 	w, err := sharedW.RestoreWallet(seedMnemonic, pass, ldr, params, utils.DCRWalletAsset)
 	if err != nil {
 		return nil, err
@@ -202,6 +219,8 @@ func RestoreWallet(seedMnemonic string, pass *sharedW.AuthInfo, params *sharedW.
 		vspClients:                        make(map[string]*vsp.Client),
 		txAndBlockNotificationListeners:   make(map[string]*sharedW.TxAndBlockNotificationListener),
 		accountMixerNotificationListeners: make(map[string]*AccountMixerNotificationListener),
+		soloVoterNotificationListeners:    make(map[string]*SoloVoterNotificationListener),
+		stakeOptions:                      stakeOptions,
 		dbMutex:                           &dbMutex,
 	}
 
@@ -224,7 +243,8 @@ func LoadExisting(w *sharedW.Wallet, params *sharedW.InitParams) (sharedW.Asset,
 	}
 
 	var dbMutex sync.Mutex
-	ldr := initWalletLoader(chainParams, params.RootDir, params.DbDriver, &dbMutex)
+	stakeOptions := new(dcr.StakeOptions)
+	ldr := initWalletLoader(chainParams, params.RootDir, params.DbDriver, stakeOptions, &dbMutex)
 	dcrWallet := &Asset{
 		Wallet:      w,
 		vspClients:  make(map[string]*vsp.Client),
@@ -234,6 +254,8 @@ func LoadExisting(w *sharedW.Wallet, params *sharedW.InitParams) (sharedW.Asset,
 		},
 		txAndBlockNotificationListeners:   make(map[string]*sharedW.TxAndBlockNotificationListener),
 		accountMixerNotificationListeners: make(map[string]*AccountMixerNotificationListener),
+		soloVoterNotificationListeners:    make(map[string]*SoloVoterNotificationListener),
+		stakeOptions:                      stakeOptions,
 		dbMutex:                           &dbMutex,
 	}
 
@@ -253,11 +275,24 @@ func LoadExisting(w *sharedW.Wallet, params *sharedW.InitParams) (sharedW.Asset,
 		return nil, err
 	}
 
+	// The loader only reads the voting flag when the wallet is opened. The
+	// wallet config can be read once Prepare has set the database.
+	stakeOptions.VotingEnabled = dcrWallet.IsSoloVotingEnabled()
+
 	dcrWallet.SetNetworkCancelCallback(dcrWallet.SafelyCancelSync)
 
 	return dcrWallet, nil
 }
 
+// OpenWallet opens the wallet and locks its frozen outputs.
+func (asset *Asset) OpenWallet() error {
+	if err := asset.Wallet.OpenWallet(); err != nil {
+		return err
+	}
+	asset.lockFrozenOutputs()
+	return nil
+}
+
 // AccountXPubMatches checks if the xpub of the provided account matches the
 // provided legacy or SLIP0044 xpub. While both the legacy and SLIP0044 xpubs
 // will be checked for watch-only wallets, other wallets will only check the
@@ -1,6 +1,7 @@
 package ltc
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -11,8 +12,11 @@ import (
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/dcrlabs/ltcwallet/waddrmgr"
+	"github.com/dcrlabs/ltcwallet/wallet"
+	"github.com/dcrlabs/ltcwallet/walletdb"
 	"github.com/ltcsuite/ltcd/chaincfg"
 	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/txscript"
 )
 
 const (
@@ -52,8 +56,30 @@ func (asset *Asset) GetAccountsRaw() (*sharedW.Accounts, error) {
 		return nil, err
 	}
 
-	accounts := make([]*sharedW.Account, len(resp.Accounts))
-	for i, a := range resp.Accounts {
+	walletAccounts := resp.Accounts
+	if !asset.IsWatchingOnlyWallet() {
+		for _, s := range keyScopeAccounts.Scopes {
+			scope := waddrmgr.KeyScope(s.Scope)
+			scopeResp, err := asset.Internal().LTC.Accounts(scope)
+			if err != nil {
+				if waddrmgr.IsError(err, waddrmgr.ErrScopeNotFound) {
+					continue
+				}
+				return nil, err
+			}
+
+			for _, a := range scopeResp.Accounts {
+				if !asset.isScopeAccountListed(s, &a.AccountProperties) {
+					continue
+				}
+				a.AccountNumber = uint32(scopedAccountNumber(scope, a.AccountNumber))
+				walletAccounts = append(walletAccounts, a)
+			}
+		}
+	}
+
+	accounts := make([]*sharedW.Account, len(walletAccounts))
+	for i, a := range walletAccounts {
 		balance, err := asset.GetAccountBalance(int32(a.AccountNumber))
 		if err != nil {
 			return nil, err
@@ -104,7 +130,7 @@ func (asset *Asset) GetAccountBalance(accountNumber int32) (*sharedW.Balance, er
 		return nil, utils.ErrLTCNotInitialized
 	}
 
-	balance, err := asset.Internal().LTC.CalculateAccountBalances(uint32(accountNumber), asset.RequiredConfirmations())
+	balance, err := asset.calculateAccountBalances(accountNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +149,50 @@ func (asset *Asset) GetAccountBalance(accountNumber int32) (*sharedW.Balance, er
 	}, nil
 }
 
+// calculateAccountBalances sums the unspent outputs of the account like
+// ltcwallet's CalculateAccountBalances, which only matches the account number
+// and would mix up the balances of accounts that have the same number in
+// different key scopes.
+func (asset *Asset) calculateAccountBalances(accountNumber int32) (wallet.Balances, error) {
+	var bals wallet.Balances
+	confirmations := asset.RequiredConfirmations()
+	w := asset.Internal().LTC
+
+	err := walletdb.View(w.Database(), func(dbtx walletdb.ReadTx) error {
+		addrmgrNs := dbtx.ReadBucket(wAddrMgrBkt)
+		txmgrNs := dbtx.ReadBucket(wTxMgrBkt)
+		syncHeight := w.Manager.SyncedTo().Height
+
+		unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
+		if err != nil {
+			return err
+		}
+
+		for i := range unspent {
+			output := &unspent[i]
+			_, addrs, _, err := txscript.ExtractPkScriptAddrs(output.PkScript, asset.chainParams)
+			if err != nil || len(addrs) == 0 {
+				continue
+			}
+
+			smgr, outputAcct, err := w.Manager.AddrAccount(addrmgrNs, addrs[0])
+			if err != nil || scopedAccountNumber(smgr.Scope(), outputAcct) != accountNumber {
+				continue
+			}
+
+			confs := confirms(output.Height, syncHeight)
+			bals.Total += output.Amount
+			if output.FromCoinBase && confs < int32(asset.chainParams.CoinbaseMaturity) {
+				bals.ImmatureReward += output.Amount
+			} else if confs >= confirmations {
+				bals.Spendable += output.Amount
+			}
+		}
+		return nil
+	})
+	return bals, err
+}
+
 // lockedAmount is the total value of locked outputs, as locked with
 // LockUnspent.
 func (asset *Asset) lockedAmount() (ltcutil.Amount, error) {
@@ -144,7 +214,7 @@ func (asset *Asset) SpendableForAccount(account int32) (int64, error) {
 		return -1, utils.ErrLTCNotInitialized
 	}
 
-	bals, err := asset.Internal().LTC.CalculateAccountBalances(uint32(account), asset.RequiredConfirmations())
+	bals, err := asset.calculateAccountBalances(account)
 	if err != nil {
 		return 0, utils.TranslateError(err)
 	}
@@ -179,6 +249,22 @@ func (asset *Asset) UnspentOutputs(account int32) ([]*sharedW.UnspentOutput, err
 	resp := make([]*sharedW.UnspentOutput, 0, len(unspents))
 
 	for _, utxo := range unspents {
+		// Accounts are matched by name, which is only unique within a key
+		// scope.
+		pkScript, err := hex.DecodeString(utxo.ScriptPubKey)
+		if err != nil {
+			continue
+		}
+		if utxoAccount, err := asset.scriptAccount(pkScript); err != nil || utxoAccount != account {
+			continue
+		}
+
+		// ltcwallet only flags P2PKH and P2WPKH outputs as spendable, the
+		// P2SH-P2WPKH outputs of the wallet's own accounts are spendable too.
+		if !asset.IsWatchingOnlyWallet() {
+			utxo.Spendable = true
+		}
+
 		// error returned is ignored because the amount value is from upstream
 		// and doesn't require an extra layer of validation.
 		amount, _ := ltcutil.NewAmount(utxo.Amount)
@@ -201,6 +287,7 @@ func (asset *Asset) UnspentOutputs(account int32) ([]*sharedW.UnspentOutput, err
 		})
 	}
 
+	asset.ApplyOutputLabels(resp...)
 	return resp, nil
 }
 
@@ -240,7 +327,8 @@ func (asset *Asset) RenameAccount(accountNumber int32, newName string) error {
 		return utils.ErrLTCNotInitialized
 	}
 
-	err := asset.Internal().LTC.RenameAccount(GetScope(), uint32(accountNumber), newName)
+	scope, account := accountScope(accountNumber)
+	err := asset.Internal().LTC.RenameAccount(scope, account, newName)
 	if err != nil {
 		return utils.TranslateError(err)
 	}
@@ -264,7 +352,8 @@ func (asset *Asset) AccountNameRaw(accountNumber uint32) (string, error) {
 		return "", utils.ErrLTCNotInitialized
 	}
 
-	return asset.Internal().LTC.AccountName(GetScope(), accountNumber)
+	scope, account := accountScope(int32(accountNumber))
+	return asset.Internal().LTC.AccountName(scope, account)
 }
 
 // AccountNumber returns the account number for the provided account name.
@@ -274,6 +363,15 @@ func (asset *Asset) AccountNumber(accountName string) (int32, error) {
 	}
 
 	accountNumber, err := asset.Internal().LTC.AccountNumber(GetScope(), accountName)
+	if err != nil && !asset.IsWatchingOnlyWallet() {
+		for _, s := range keyScopeAccounts.Scopes {
+			scope := waddrmgr.KeyScope(s.Scope)
+			scopeAccount, scopeErr := asset.Internal().LTC.AccountNumber(scope, accountName)
+			if scopeErr == nil {
+				return scopedAccountNumber(scope, scopeAccount), nil
+			}
+		}
+	}
 	return int32(accountNumber), utils.TranslateError(err)
 }
 
@@ -284,7 +382,7 @@ func (asset *Asset) HasAccount(accountName string) bool {
 		return false
 	}
 
-	_, err := asset.Internal().LTC.AccountNumber(GetScope(), accountName)
+	_, err := asset.AccountNumber(accountName)
 	return err == nil
 }
 
@@ -297,5 +395,12 @@ func (asset *Asset) HDPathForAccount(accountNumber int32) (string, error) {
 		hdPath = TestnetHDPath
 	}
 
-	return hdPath + strconv.Itoa(int(accountNumber)), nil
+	// Accounts of other key scopes use the purpose and coin type of their
+	// key scope.
+	scope, account := accountScope(accountNumber)
+	if scope != GetScope() {
+		hdPath = fmt.Sprintf("m / %d' / %d' / ", scope.Purpose, scope.Coin)
+	}
+
+	return hdPath + strconv.Itoa(int(account)), nil
 }
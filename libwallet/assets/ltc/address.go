@@ -69,13 +69,13 @@ func (asset *Asset) AddressInfo(address string) (*AddressInfo, error) {
 	if isMine {
 		addressInfo.IsMine = isMine
 
-		accountNumber, err := asset.Internal().LTC.AccountOfAddress(addr)
+		accountNumber, err := asset.addressAccount(addr)
 		if err != nil {
 			return nil, err
 		}
-		addressInfo.AccountNumber = accountNumber
+		addressInfo.AccountNumber = uint32(accountNumber)
 
-		accountName, err := asset.AccountName(int32(accountNumber))
+		accountName, err := asset.AccountName(accountNumber)
 		if err != nil {
 			return nil, err
 		}
@@ -89,6 +89,15 @@ func (asset *Asset) AddressInfo(address string) (*AddressInfo, error) {
 // asset. If that address has already been used to receive funds, the next
 // chained address is returned.
 func (asset *Asset) CurrentAddress(account int32) (string, error) {
+	if asset.IsMultisig() {
+		addr, _, err := asset.multisigBranchAddress(multisigExternalBranch, false)
+		if err != nil {
+			log.Errorf("CurrentAddress error: %v", err)
+			return "", err
+		}
+		return addr.String(), nil
+	}
+
 	if asset.IsRestored && !asset.ContainsDiscoveredAccounts() {
 		return "", errors.E(utils.ErrAddressDiscoveryNotDone)
 	}
@@ -97,7 +106,8 @@ func (asset *Asset) CurrentAddress(account int32) (string, error) {
 		return "", utils.ErrLTCNotInitialized
 	}
 
-	addr, err := asset.Internal().LTC.CurrentAddress(uint32(account), GetScope())
+	scope, acct := accountScope(account)
+	addr, err := asset.Internal().LTC.CurrentAddress(acct, scope)
 	if err != nil {
 		log.Errorf("CurrentAddress error: %v", err)
 		return "", err
@@ -109,6 +119,15 @@ func (asset *Asset) CurrentAddress(account int32) (string, error) {
 // payment address. If that address has already been used to receive funds,
 // the next chained address is returned.
 func (asset *Asset) NextAddress(account int32) (string, error) {
+	if asset.IsMultisig() {
+		addr, _, err := asset.multisigBranchAddress(multisigExternalBranch, true)
+		if err != nil {
+			log.Errorf("NextAddress error: %v", err)
+			return "", err
+		}
+		return addr.String(), nil
+	}
+
 	if asset.IsRestored && !asset.ContainsDiscoveredAccounts() {
 		return "", errors.E(utils.ErrAddressDiscoveryNotDone)
 	}
@@ -118,7 +137,8 @@ func (asset *Asset) NextAddress(account int32) (string, error) {
 	}
 
 	// NewAddress returns the next external chained address for a wallet.
-	address, err := asset.Internal().LTC.NewAddress(uint32(account), GetScope())
+	scope, acct := accountScope(account)
+	address, err := asset.Internal().LTC.NewAddress(acct, scope)
 	if err != nil {
 		log.Errorf("NewExternalAddress error: %w", err)
 		return "", err
@@ -138,12 +158,12 @@ func (asset *Asset) AccountOfAddress(address string) (string, error) {
 		return "", utils.ErrLTCNotInitialized
 	}
 
-	accountNumber, err := asset.Internal().LTC.AccountOfAddress(addr)
+	accountNumber, err := asset.addressAccount(addr)
 	if err != nil {
 		return "", utils.TranslateError(err)
 	}
 
-	accountName, err := asset.AccountName(int32(accountNumber))
+	accountName, err := asset.AccountName(accountNumber)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,77 @@
+package ltc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crypto-power/cryptopower/libwallet/internal/electrum"
+	"github.com/ltcsuite/ltcd/chaincfg"
+)
+
+func TestElectrumProofOfWork(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *chaincfg.Params
+	}{
+		{"mainnet", &chaincfg.MainNetParams},
+		{"testnet4", &chaincfg.TestNet4Params},
+	}
+	for _, tc := range tests {
+		genesis := tc.params.GenesisBlock.Header
+		var buf bytes.Buffer
+		if err := genesis.Serialize(&buf); err != nil {
+			t.Fatal(err)
+		}
+		headers, err := electrum.DecodeHeaders(buf.Bytes(), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The genesis block only meets its proof of work on the scrypt hash,
+		// its block hash is above the target.
+		params := electrumChainParams(tc.params)
+		if err := params.VerifyHeaders(0, nil, headers); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		}
+		params.PowHash = electrum.DoubleHash
+		if err := params.VerifyHeaders(0, nil, headers); err == nil {
+			t.Errorf("%s: the genesis block hash met the proof of work", tc.name)
+		}
+
+		// Changing the nonce breaks the proof of work.
+		genesis.Nonce++
+		buf.Reset()
+		if err := genesis.Serialize(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if headers, err = electrum.DecodeHeaders(buf.Bytes(), 1); err != nil {
+			t.Fatal(err)
+		}
+		if err := electrumChainParams(tc.params).VerifyHeaders(1, nil, []*electrum.Header{headers[0]}); err == nil {
+			t.Errorf("%s: a header without proof of work was accepted", tc.name)
+		}
+	}
+}
+
+func TestDecodeElectrumHeader(t *testing.T) {
+	genesis := &chaincfg.MainNetParams.GenesisBlock.Header
+	var buf bytes.Buffer
+	if err := genesis.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	headers, err := electrum.DecodeHeaders(buf.Bytes(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headers[0].Hash != electrum.Hash(genesis.BlockHash()) {
+		t.Fatalf("decoded hash %v, want %v", headers[0].Hash, genesis.BlockHash())
+	}
+
+	header, err := decodeElectrumHeader(headers[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.BlockHash() != genesis.BlockHash() || header.PowHash() != genesis.PowHash() {
+		t.Fatalf("got header %v, want %v", header.BlockHash(), genesis.BlockHash())
+	}
+}
@@ -2,24 +2,21 @@ package ltc
 
 import (
 	"fmt"
-	"net/http"
-	"sort"
-	"strconv"
 	"sync"
 
-	"decred.org/dcrwallet/v4/errors"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/ltcsuite/ltcd/ltcutil"
 )
 
-// TODO: Update this file for Litecoin
-
 const (
-	// MainnetAPIFeeRateURL defines the URL to fetch the mainnet fee rate from.
-	MainnetAPIFeeRateURL = "https://blockstream.info/api/fee-estimates" // TODO: Update for Litecoin
-	// TestnetAPIFeeRateURL defines the URL to fetch the testnet fee rate from.
-	TestnetAPIFeeRateURL = "https://blockstream.info/testnet/api/fee-estimates" // TODO: Update for Litecoin
+	// MainnetAPIFeeRateURL and TestnetAPIFeeRateURL are the litecoinspace.org
+	// URLs of the recommended fee rates.
+	MainnetAPIFeeRateURL = "https://litecoinspace.org/api/v1/fees/recommended"
+	TestnetAPIFeeRateURL = "https://litecoinspace.org/testnet/api/v1/fees/recommended"
+	// MainnetBlockCypherFeeRateURL is the URL of the BlockCypher mainnet fee
+	// estimates, there are none for testnet.
+	MainnetBlockCypherFeeRateURL = "https://api.blockcypher.com/v1/ltc/main"
 
 	// Since the introduction of segwit account, a different tx size measument was
 	// introduced (Lit/VB). When sending a transaction from the legacy account,
@@ -44,108 +41,58 @@ const (
 	MinFeeRatePerkvB ltcutil.Amount = 1000 // Equals to 1 lit/vB.
 )
 
-// feeEstimateCache helps to cache the resolved fee rate until a new
-// block is mined
+// feeEstimateCache holds the fee rate set by the user and the fee rate
+// estimator, which caches the estimates until a new block is mined.
 type feeEstimateCache struct {
 	// SetFeeRatePerkvB defines the fee rate. If set, the user wants to apply for
 	// all his transactions.
 	SetFeeRatePerkvB sharedW.AssetAmount
-	// If not empty, they hold the fee rate queries from the API when the best
-	// block was set at LastBestBlock.
-	APIFeeRates []sharedW.FeeEstimate
-	// LastBestblock defines the last height when results were cached. This
-	// helps to keep the API calls to under control.
-	LastBestblock int32
+	// estimator queries the fee rate sources in order, it is created on first
+	// use.
+	estimator *sharedW.FeeEstimator
 
 	mu sync.RWMutex
 }
 
-// fetchAPIFeeRate queries the API fee rate.
-func (asset *Asset) fetchAPIFeeRate() ([]sharedW.FeeEstimate, error) {
-	var feerateURL string
-	net := asset.NetType()
-	switch net {
+// feeRateSources returns the fee rate sources of the network, in the order
+// they are queried.
+func (asset *Asset) feeRateSources() []sharedW.FeeRateSource {
+	var sources []sharedW.FeeRateSource
+	switch asset.NetType() {
 	case utils.Mainnet:
-		feerateURL = MainnetAPIFeeRateURL
+		sources = []sharedW.FeeRateSource{
+			&sharedW.MempoolFeeSource{URL: MainnetAPIFeeRateURL},
+			&sharedW.BlockCypherFeeSource{URL: MainnetBlockCypherFeeRateURL},
+		}
 	case utils.Testnet:
-		feerateURL = TestnetAPIFeeRateURL
-	default:
-		return nil, fmt.Errorf("%v network is not supported", net)
-	}
-
-	resp := make(map[string]float64, 0)
-
-	req := &utils.ReqConfig{
-		Method:  http.MethodGet,
-		HTTPURL: feerateURL,
-	}
-
-	if _, err := utils.HTTPRequest(req, &resp); err != nil {
-		return nil, fmt.Errorf("fetching API fee estimates failed: %v", err)
-	}
-
-	// if no data was returned, return an error.
-	if len(resp) == 0 {
-		return nil, errors.New("API fee estimates not found")
-	}
-
-	results := make([]sharedW.FeeEstimate, 0, len(resp))
-
-	// Fee rate returned is in lit/vB units.
-	for blocks, feerate := range resp {
-		vals, err := strconv.ParseInt(blocks, 10, 64)
-		if err != nil {
-			// Invalid blocks confirmation found ignore it,
-			continue
+		sources = []sharedW.FeeRateSource{
+			&sharedW.MempoolFeeSource{URL: TestnetAPIFeeRateURL},
 		}
-
-		results = append(results, sharedW.FeeEstimate{
-			ConfirmedBlocks: int32(vals),
-			// Fee rate conversion from lit/vB to lit/kvB is at the rate of
-			// 1000 lit/kvB == 1 lit/vB
-			Feerate: Amount(int(feerate * 1000.0)),
-		})
 	}
-	return results, nil
+	return sources
 }
 
-// GetAPIFeeEstimateRate returns the fee estimates from the API.
-func (asset *Asset) GetAPIFeeEstimateRate() (feerates []sharedW.FeeEstimate, err error) {
-	asset.fees.mu.RLock()
-	feerates = asset.fees.APIFeeRates
-	lastblock := asset.fees.LastBestblock
-	asset.fees.mu.RUnlock()
-
-	// If best block hasn't changed, return the cached estimates.
-	if asset.GetBestBlockHeight() == lastblock && lastblock > 0 {
-		return feerates, nil
-	}
-
-	feerates, err = asset.fetchAPIFeeRate()
-	if err != nil {
-		return nil, err
-	}
-
-	// Do not cache empty results.
-	if len(feerates) == 0 {
-		return nil, errors.New("API feerates not available")
-	}
+func (asset *Asset) feeEstimator() *sharedW.FeeEstimator {
+	sources := asset.feeRateSources()
 
-	sort.Slice(feerates, func(i, j int) bool {
-		return feerates[i].ConfirmedBlocks < feerates[j].ConfirmedBlocks
-	})
+	asset.fees.mu.Lock()
+	defer asset.fees.mu.Unlock()
 
-	if len(feerates) > 5 {
-		// TODO: subject to confirmation! => persist top five fee rates only.
-		feerates = feerates[:5]
+	if asset.fees.estimator == nil {
+		asset.fees.estimator = sharedW.NewFeeEstimator(&sharedW.FeeEstimatorConfig{
+			Sources:         sources,
+			MinFeeRate:      int64(MinFeeRatePerkvB),
+			FallbackFeeRate: int64(FallBackFeeRatePerkvB),
+			ToAmount:        asset.ToAmount,
+		})
 	}
+	return asset.fees.estimator
+}
 
-	asset.fees.mu.Lock()
-	asset.fees.APIFeeRates = feerates
-	asset.fees.LastBestblock = asset.GetBestBlockHeight()
-	asset.fees.mu.Unlock()
-
-	return feerates, nil
+// GetAPIFeeEstimateRate returns up to sharedW.MaxFeeEstimates fee rate
+// estimates from the first fee rate source that returns valid estimates.
+func (asset *Asset) GetAPIFeeEstimateRate() ([]sharedW.FeeEstimate, error) {
+	return asset.feeEstimator().Estimates(asset.GetBestBlockHeight())
 }
 
 // SetUserFeeRate sets the fee rate in kvB units. Setting fee rate less than
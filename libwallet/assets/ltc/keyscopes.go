@@ -0,0 +1,245 @@
+package ltc
+
+import (
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/dcrlabs/ltcwallet/waddrmgr"
+	"github.com/dcrlabs/ltcwallet/wallet/txsizes"
+	"github.com/dcrlabs/ltcwallet/walletdb"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+// ltcwallet numbers the accounts of each key scope from zero. Accounts of key
+// scopes other than the BIP-84 key scope returned by GetScope are offered with
+// an offset added to their number that identifies the key scope wherever an
+// account number is used.
+const (
+	// SegwitAccountOffset is the offset of the BIP-84 (P2WPKH) accounts
+	// derived with the Litecoin coin type.
+	SegwitAccountOffset int32 = 1 << 24
+	// NestedSegwitAccountOffset is the offset of the BIP-49 (P2SH-P2WPKH)
+	// accounts derived with the Litecoin coin type.
+	NestedSegwitAccountOffset int32 = 2 << 24
+	// BitcoinNestedSegwitAccountOffset is the offset of the BIP-49
+	// (P2SH-P2WPKH) accounts derived with the Bitcoin coin type.
+	BitcoinNestedSegwitAccountOffset int32 = 3 << 24
+	// LegacyAccountOffset is the offset of the BIP-44 (P2PKH) accounts
+	// derived with the Litecoin coin type.
+	LegacyAccountOffset int32 = 4 << 24
+	// BitcoinLegacyAccountOffset is the offset of the BIP-44 (P2PKH) accounts
+	// derived with the Bitcoin coin type.
+	BitcoinLegacyAccountOffset int32 = 5 << 24
+)
+
+// keyScopeAccounts are the accounts of the key scopes used by other wallets,
+// sorted by offset. ltcwallet scans them while restoring a wallet but they are
+// only offered once imported, see ImportLegacyKeyScopes.
+var keyScopeAccounts = sharedW.AccountScopes{
+	Scopes: []*sharedW.ScopeAccounts{
+		{
+			Scope:  sharedW.KeyScope(waddrmgr.KeyScopeBIP0084),
+			Offset: SegwitAccountOffset,
+			Legacy: true,
+			Name:   "BIP-84 segwit",
+		},
+		{
+			Scope:  sharedW.KeyScope(waddrmgr.KeyScopeBIP0049Plus),
+			Offset: NestedSegwitAccountOffset,
+			Legacy: true,
+			Name:   "BIP-49 nested segwit",
+		},
+		{
+			Scope:  sharedW.KeyScope(waddrmgr.KeyScopeBIP0049PlusWithBitcoinCoinID),
+			Offset: BitcoinNestedSegwitAccountOffset,
+			Legacy: true,
+			Name:   "BIP-49 nested segwit (coin type 0)",
+		},
+		{
+			Scope:  sharedW.KeyScope(waddrmgr.KeyScopeBIP0044),
+			Offset: LegacyAccountOffset,
+			Legacy: true,
+			Name:   "BIP-44 legacy",
+		},
+		{
+			Scope:  sharedW.KeyScope(waddrmgr.KeyScopeBIP0044WithBitcoinCoinID),
+			Offset: BitcoinLegacyAccountOffset,
+			Legacy: true,
+			Name:   "BIP-44 legacy (coin type 0)",
+		},
+	},
+	ImportedAccount: ImportedAccountNumber,
+}
+
+// accountScope returns the key scope and the ltcwallet account number of the
+// provided account.
+func accountScope(accountNumber int32) (waddrmgr.KeyScope, uint32) {
+	scope, account := keyScopeAccounts.AccountScope(sharedW.KeyScope(GetScope()), accountNumber)
+	return waddrmgr.KeyScope(scope), account
+}
+
+// scopedAccountNumber returns the account number of a ltcwallet account of the
+// provided key scope.
+func scopedAccountNumber(scope waddrmgr.KeyScope, account uint32) int32 {
+	return keyScopeAccounts.ScopedAccountNumber(sharedW.KeyScope(scope), account)
+}
+
+// addressAccount returns the number of the account that the wallet address
+// belongs to. The BIP-84 key scopes of both coin types derive P2WPKH
+// addresses, the key scope is looked up in the address manager.
+func (asset *Asset) addressAccount(addr ltcutil.Address) (int32, error) {
+	var scope waddrmgr.KeyScope
+	var account uint32
+	w := asset.Internal().LTC
+	err := walletdb.View(w.Database(), func(dbtx walletdb.ReadTx) error {
+		smgr, acct, err := w.Manager.AddrAccount(dbtx.ReadBucket(wAddrMgrBkt), addr)
+		if err != nil {
+			return err
+		}
+		scope, account = smgr.Scope(), acct
+		return nil
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	return scopedAccountNumber(scope, account), nil
+}
+
+// scriptAccount returns the number of the account that the wallet output
+// with the provided pkScript belongs to.
+func (asset *Asset) scriptAccount(pkScript []byte) (int32, error) {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, asset.chainParams)
+	if err != nil {
+		return -1, err
+	}
+	if len(addrs) == 0 {
+		return -1, errors.New(utils.ErrNotExist)
+	}
+	return asset.addressAccount(addrs[0])
+}
+
+// SetScanLegacyKeyScopes sets whether the legacy key scopes used by other
+// wallets are imported as accounts once the address discovery of a restored
+// wallet completes, if they have any history.
+func (asset *Asset) SetScanLegacyKeyScopes(scan bool) {
+	asset.SetBoolConfigValueForKey(sharedW.ScanLegacyKeyScopesConfigKey, scan)
+}
+
+// LegacyKeyScopes reports which of the key scopes used by other wallets have
+// history: BIP-84 (P2WPKH), BIP-49 (P2SH-P2WPKH) and BIP-44 (P2PKH) with the
+// Litecoin coin type, and BIP-49 and BIP-44 with the Bitcoin coin type used by
+// some older wallets. ltcwallet scans these key scopes when a wallet is
+// restored, the report is only complete once address discovery is done.
+//
+// ltcwallet's BIP-49 key scopes derive P2WPKH change addresses, change that
+// another wallet sent to nested segwit change addresses is not found.
+func (asset *Asset) LegacyKeyScopes() ([]*sharedW.KeyScopeHistory, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrLTCNotInitialized
+	}
+
+	return asset.LegacyKeyScopeHistory(&keyScopeAccounts, scopeAccountStore{asset}, DefaultAccountNum)
+}
+
+// ImportLegacyKeyScopes imports the default account of every legacy key scope
+// with history as an account of the wallet, see LegacyKeyScopes. Outputs of
+// the imported accounts are spent like those of any other account.
+func (asset *Asset) ImportLegacyKeyScopes() ([]*sharedW.KeyScopeHistory, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrLTCNotInitialized
+	}
+	return asset.ImportLegacyScopeAccounts(&keyScopeAccounts, scopeAccountStore{asset}, DefaultAccountNum)
+}
+
+// scopeAccountStore gives the shared key scope code access to the ltcwallet
+// accounts of the wallet.
+type scopeAccountStore struct {
+	asset *Asset
+}
+
+func (s scopeAccountStore) ScopeAccountUsed(scope sharedW.KeyScope, account uint32) (bool, bool, error) {
+	props, err := s.asset.Internal().LTC.AccountProperties(waddrmgr.KeyScope(scope), account)
+	if err != nil {
+		if waddrmgr.IsError(err, waddrmgr.ErrScopeNotFound) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	return props.ExternalKeyCount > 0 || props.InternalKeyCount > 0, true, nil
+}
+
+func (s scopeAccountStore) AccountName(scope sharedW.KeyScope, account uint32) (string, error) {
+	return s.asset.Internal().LTC.AccountName(waddrmgr.KeyScope(scope), account)
+}
+
+func (s scopeAccountStore) RenameAccount(scope sharedW.KeyScope, account uint32, name string) error {
+	return s.asset.Internal().LTC.RenameAccount(waddrmgr.KeyScope(scope), account, name)
+}
+
+func (s scopeAccountStore) HasAccount(name string) bool {
+	return s.asset.HasAccount(name)
+}
+
+// isScopeAccountListed returns true if the account of a legacy key scope is
+// offered by GetAccountsRaw.
+func (asset *Asset) isScopeAccountListed(s *sharedW.ScopeAccounts, props *waddrmgr.AccountProperties) bool {
+	return props.AccountNumber == DefaultAccountNum && asset.IsKeyScopeImported(s.Scope)
+}
+
+// estimateTxVirtualSize returns the worst case virtual size of a signed
+// transaction spending outputs with the provided pkScripts and paying to
+// txOuts. A change output with a script of changeScriptSize bytes is included
+// if changeScriptSize is greater than zero.
+func estimateTxVirtualSize(prevScripts [][]byte, txOuts []*wire.TxOut, changeScriptSize int) int {
+	var numP2PKH, numP2TR, numP2WPKH, numNestedP2WPKH int
+	for _, pkScript := range prevScripts {
+		switch {
+		case txscript.IsPayToTaproot(pkScript):
+			numP2TR++
+		case txscript.IsPayToWitnessPubKeyHash(pkScript):
+			numP2WPKH++
+		case txscript.IsPayToScriptHash(pkScript):
+			numNestedP2WPKH++
+		default:
+			numP2PKH++
+		}
+	}
+
+	return txsizes.EstimateVirtualSize(numP2PKH, numP2TR, numP2WPKH, numNestedP2WPKH,
+		txOuts, changeScriptSize)
+}
+
+// changeScriptSize returns the size of the change output script for the
+// account. Change is sent back to an internal address of the account, which is
+// a P2WPKH address for BIP-49 accounts.
+func changeScriptSize(accountNumber int32) int {
+	switch scope, _ := accountScope(accountNumber); scope {
+	case waddrmgr.KeyScopeBIP0044, waddrmgr.KeyScopeBIP0044WithBitcoinCoinID:
+		return txsizes.P2PKHPkScriptSize
+	default:
+		return txsizes.P2WPKHPkScriptSize
+	}
+}
+
+// signP2PKHInput returns the signature script spending the P2PKH output of a
+// BIP-44 account, which ltcwallet's ComputeInputScript would sign as a witness
+// input.
+func (asset *Asset) signP2PKHInput(msgTx *wire.MsgTx, index int, pkScript []byte) ([]byte, error) {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, errors.New(utils.ErrNotExist)
+	}
+
+	privKey, err := asset.Internal().LTC.PrivKeyForAddress(addrs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return txscript.SignatureScript(msgTx, index, pkScript, txscript.SigHashAll, privKey, true)
+}
@@ -0,0 +1,38 @@
+package ltc
+
+import (
+	"testing"
+
+	"github.com/dcrlabs/ltcwallet/waddrmgr"
+)
+
+func TestAccountScope(t *testing.T) {
+	tests := []struct {
+		accountNumber int32
+		scope         waddrmgr.KeyScope
+		account       uint32
+	}{
+		{DefaultAccountNum, GetScope(), DefaultAccountNum},
+		{2, GetScope(), 2},
+		{SegwitAccountOffset, waddrmgr.KeyScopeBIP0084, DefaultAccountNum},
+		{NestedSegwitAccountOffset + 1, waddrmgr.KeyScopeBIP0049Plus, 1},
+		{BitcoinNestedSegwitAccountOffset, waddrmgr.KeyScopeBIP0049PlusWithBitcoinCoinID, DefaultAccountNum},
+		{LegacyAccountOffset, waddrmgr.KeyScopeBIP0044, DefaultAccountNum},
+		{BitcoinLegacyAccountOffset + 3, waddrmgr.KeyScopeBIP0044WithBitcoinCoinID, 3},
+	}
+	for _, tc := range tests {
+		scope, account := accountScope(tc.accountNumber)
+		if scope != tc.scope || account != tc.account {
+			t.Errorf("account %d: got account %d of %s, want account %d of %s",
+				tc.accountNumber, account, scope, tc.account, tc.scope)
+			continue
+		}
+		if got := scopedAccountNumber(scope, account); got != tc.accountNumber {
+			t.Errorf("account %d of %s: got account number %d, want %d", account, scope, got, tc.accountNumber)
+		}
+	}
+
+	if got := scopedAccountNumber(waddrmgr.KeyScopeBIP0044, ImportedAccountNumber); got != int32(ImportedAccountNumber) {
+		t.Errorf("got imported account number %d, want %d", got, int32(ImportedAccountNumber))
+	}
+}
@@ -0,0 +1,839 @@
+package ltc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/dcrlabs/ltcwallet/waddrmgr"
+	"github.com/dcrlabs/ltcwallet/wallet/txrules"
+	"github.com/dcrlabs/ltcwallet/walletdb"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/ltcutil/hdkeychain"
+	"github.com/ltcsuite/ltcd/ltcutil/psbt"
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+// A multisig wallet is a watch-only wallet without accounts of its own. Its
+// P2WSH addresses are derived from the cosigner keys and imported as witness
+// scripts into the imported account, so ltcwallet tracks their outputs like
+// those of any other address.
+const (
+	multisigExternalBranch = sharedW.MultisigExternalBranch
+	multisigInternalBranch = sharedW.MultisigInternalBranch
+)
+
+// multisigAddressIndexes is saved in the wallet config of multisig wallets.
+type multisigAddressIndexes struct {
+	// Current is the index of the current address of each branch.
+	Current [2]uint32
+	// Imported is the number of addresses of each branch that were imported
+	// into the wallet.
+	Imported [2]uint32
+}
+
+// multisigPath is the branch and the index of a multisig address.
+type multisigPath struct {
+	branch, index uint32
+}
+
+// multisigCosigner is a cosigner key decoded with the chain parameters of the
+// wallet.
+type multisigCosigner struct {
+	xpub *hdkeychain.ExtendedKey
+	// fingerprint and path are the master key fingerprint and the derivation
+	// path of xpub. If the key origin isn't known, they are the fingerprint
+	// and the empty path of xpub itself.
+	fingerprint uint32
+	path        []uint32
+}
+
+// multisigScript is the witness script of a multisig address and the
+// derivations of its public keys.
+type multisigScript struct {
+	script      []byte
+	derivations []*psbt.Bip32Derivation
+}
+
+// CreateMultisigWallet creates an m-of-n P2WSH multisig wallet for the LTC
+// asset from the cosigner keys of the config. The wallet derives the
+// addresses, tracks their outputs and creates the PSBTs the cosigners sign, it
+// holds no private keys.
+func CreateMultisigWallet(walletName string, config *sharedW.MultisigConfig, params *sharedW.InitParams) (sharedW.Asset, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := decodeCosigners(config); err != nil {
+		return nil, err
+	}
+
+	w, err := CreateWatchOnlyWallet(walletName, "", params)
+	if err != nil {
+		return nil, err
+	}
+
+	asset := w.(*Asset)
+	asset.SaveUserConfigValue(sharedW.MultisigConfigKey, config)
+	if err := asset.extendMultisigAddresses(); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// IsMultisig returns true if the wallet is a multisig wallet.
+func (asset *Asset) IsMultisig() bool {
+	_, err := asset.MultisigConfig()
+	return err == nil
+}
+
+// MultisigConfig returns the config of the multisig wallet.
+func (asset *Asset) MultisigConfig() (*sharedW.MultisigConfig, error) {
+	config := new(sharedW.MultisigConfig)
+	if err := asset.ReadUserConfigValue(sharedW.MultisigConfigKey, config); err != nil {
+		return nil, errors.New(utils.ErrNotExist)
+	}
+	return config, nil
+}
+
+// MultisigDescriptors returns the output descriptors of the receive and the
+// change addresses of the multisig wallet, to set up the wallet with other
+// coordinators.
+func (asset *Asset) MultisigDescriptors() (receive, change string, err error) {
+	config, err := asset.MultisigConfig()
+	if err != nil {
+		return "", "", err
+	}
+	return config.Descriptors()
+}
+
+// MultisigCosignerKey returns the cosigner key of the wallet, the key
+// expression of the BIP-48 P2WSH multisig key m/48'/coin'/0'/2' that is
+// given to the coordinator of a multisig wallet. seedPassphrase is the BIP-39
+// passphrase the wallet seed is used with, if any.
+func (asset *Asset) MultisigCosignerKey(privatePassphrase, seedPassphrase string) (string, error) {
+	master, err := asset.multisigMasterKey(privatePassphrase, seedPassphrase)
+	if err != nil {
+		return "", err
+	}
+	defer master.Zero()
+
+	path := []uint32{
+		hardenedKey(sharedW.MultisigPurpose),
+		hardenedKey(asset.chainParams.HDCoinType),
+		hardenedKey(0),
+		hardenedKey(sharedW.MultisigP2WSHScriptType),
+	}
+	key, err := deriveKeyPath(master, path)
+	if err != nil {
+		return "", err
+	}
+	xpub, err := key.Neuter()
+	if err != nil {
+		return "", err
+	}
+
+	fingerprint, err := keyFingerprint(master)
+	if err != nil {
+		return "", err
+	}
+	cosigner := &sharedW.CosignerKey{
+		Fingerprint: make([]byte, 4),
+		Path:        path,
+		XPub:        xpub.String(),
+	}
+	binary.LittleEndian.PutUint32(cosigner.Fingerprint, fingerprint)
+	return cosigner.String(), nil
+}
+
+// multisigMasterKey returns the master key of the wallet seed. The seed
+// passphrase isn't saved, it's entered again by the user.
+func (asset *Asset) multisigMasterKey(privatePassphrase, seedPassphrase string) (*hdkeychain.ExtendedKey, error) {
+	if asset.IsWatchingOnlyWallet() {
+		return nil, errors.New(utils.ErrWalletIsWatchOnly)
+	}
+
+	seed, err := asset.DecryptHDSeed(privatePassphrase, seedPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for i := range seed {
+			seed[i] = 0
+		}
+	}()
+
+	return hdkeychain.NewMaster(seed, asset.chainParams)
+}
+
+// decodeCosigners decodes the cosigner keys of the config.
+func decodeCosigners(config *sharedW.MultisigConfig) ([]*multisigCosigner, error) {
+	keys, err := config.Cosigners()
+	if err != nil {
+		return nil, err
+	}
+
+	cosigners := make([]*multisigCosigner, len(keys))
+	for i, key := range keys {
+		xpub, err := hdkeychain.NewKeyFromString(key.XPub)
+		if err != nil {
+			return nil, fmt.Errorf("%s: cosigner key %s: %v", utils.ErrInvalid, key.XPub, err)
+		}
+		if xpub.IsPrivate() {
+			return nil, fmt.Errorf("%s: cosigner key %s is a private key", utils.ErrInvalid, key.XPub)
+		}
+
+		cosigner := &multisigCosigner{xpub: xpub, path: key.Path}
+		if key.HasOrigin() {
+			cosigner.fingerprint = binary.LittleEndian.Uint32(key.Fingerprint)
+		} else if cosigner.fingerprint, err = keyFingerprint(xpub); err != nil {
+			return nil, err
+		}
+		cosigners[i] = cosigner
+	}
+	return cosigners, nil
+}
+
+// keyFingerprint returns the fingerprint of the key as it is written in PSBTs.
+func keyFingerprint(key *hdkeychain.ExtendedKey) (uint32, error) {
+	pubKey, err := key.ECPubKey()
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(ltcutil.Hash160(pubKey.SerializeCompressed())[:4]), nil
+}
+
+// deriveKeyPath derives the child key of the path.
+func deriveKeyPath(key *hdkeychain.ExtendedKey, path []uint32) (*hdkeychain.ExtendedKey, error) {
+	var err error
+	for _, index := range path {
+		if key, err = key.Derive(index); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// deriveMultisigScript derives the sorted multisig witness script of an
+// address of the wallet.
+func deriveMultisigScript(cosigners []*multisigCosigner, requiredSigs int, path multisigPath) (*multisigScript, error) {
+	derivations := make([]*psbt.Bip32Derivation, len(cosigners))
+	for i, cosigner := range cosigners {
+		key, err := deriveKeyPath(cosigner.xpub, []uint32{path.branch, path.index})
+		if err != nil {
+			return nil, err
+		}
+		pubKey, err := key.ECPubKey()
+		if err != nil {
+			return nil, err
+		}
+
+		bip32Path := make([]uint32, 0, len(cosigner.path)+2)
+		bip32Path = append(bip32Path, cosigner.path...)
+		derivations[i] = &psbt.Bip32Derivation{
+			PubKey:               pubKey.SerializeCompressed(),
+			MasterKeyFingerprint: cosigner.fingerprint,
+			Bip32Path:            append(bip32Path, path.branch, path.index),
+		}
+	}
+
+	// BIP-67 sorts the public keys so that the script doesn't depend on the
+	// order of the cosigners.
+	sort.Slice(derivations, func(i, j int) bool {
+		return bytes.Compare(derivations[i].PubKey, derivations[j].PubKey) < 0
+	})
+
+	builder := txscript.NewScriptBuilder().AddInt64(int64(requiredSigs))
+	for _, derivation := range derivations {
+		builder.AddData(derivation.PubKey)
+	}
+	builder.AddInt64(int64(len(derivations))).AddOp(txscript.OP_CHECKMULTISIG)
+	script, err := builder.Script()
+	if err != nil {
+		return nil, err
+	}
+	return &multisigScript{script: script, derivations: derivations}, nil
+}
+
+// multisigAddress returns the P2WSH address of the witness script.
+func (asset *Asset) multisigAddress(script []byte) (ltcutil.Address, error) {
+	scriptHash := sha256.Sum256(script)
+	return ltcutil.NewAddressWitnessScriptHash(scriptHash[:], asset.chainParams)
+}
+
+// multisigAddressIndexes returns the address indexes of the multisig wallet.
+func (asset *Asset) multisigAddressIndexes() *multisigAddressIndexes {
+	indexes := new(multisigAddressIndexes)
+	_ = asset.ReadUserConfigValue(sharedW.MultisigAddressIndexesConfigKey, indexes)
+	return indexes
+}
+
+// extendMultisigAddresses moves the current address of each branch past the
+// used addresses and imports AddressGapLimit addresses after it, so that the
+// outputs received by the addresses other coordinators hand out are found.
+// asset.multisigMu must not be held.
+func (asset *Asset) extendMultisigAddresses() error {
+	asset.multisigMu.Lock()
+	defer asset.multisigMu.Unlock()
+
+	_, err := asset.updateMultisigAddresses(func(*multisigAddressIndexes) {})
+	return err
+}
+
+// updateMultisigAddresses moves the current addresses past the used ones,
+// applies the update to the indexes, and imports the addresses of the gap
+// after the current addresses. The indexes are saved and returned. It must be
+// called with asset.multisigMu held.
+func (asset *Asset) updateMultisigAddresses(update func(*multisigAddressIndexes)) (*multisigAddressIndexes, error) {
+	config, err := asset.MultisigConfig()
+	if err != nil {
+		return nil, err
+	}
+	cosigners, err := decodeCosigners(config)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := asset.multisigAddressIndexes()
+	w := asset.Internal().LTC
+	scopedMgr, err := w.Manager.FetchScopedKeyManager(GetScope())
+	if err != nil {
+		return nil, err
+	}
+
+	// Advance the current addresses past the used imported addresses.
+	err = walletdb.View(w.Database(), func(dbtx walletdb.ReadTx) error {
+		ns := dbtx.ReadBucket(wAddrMgrBkt)
+		for branch := range indexes.Current {
+			for index := indexes.Current[branch]; index < indexes.Imported[branch]; index++ {
+				path := multisigPath{branch: uint32(branch), index: index}
+				addr, _, err := asset.deriveMultisigAddress(cosigners, config.RequiredSigs, path)
+				if err != nil {
+					return err
+				}
+				managedAddr, err := scopedMgr.Address(ns, addr)
+				if err != nil {
+					return err
+				}
+				if managedAddr.Used(ns) {
+					indexes.Current[branch] = index + 1
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	update(indexes)
+
+	var imported []ltcutil.Address
+	err = walletdb.Update(w.Database(), func(dbtx walletdb.ReadWriteTx) error {
+		ns := dbtx.ReadWriteBucket(wAddrMgrBkt)
+		// The addresses are new, only the blocks after the wallet's sync
+		// height need to be scanned for their outputs.
+		syncedTo := w.Manager.SyncedTo()
+		for branch := range indexes.Imported {
+			for indexes.Imported[branch] < indexes.Current[branch]+AddressGapLimit {
+				path := multisigPath{branch: uint32(branch), index: indexes.Imported[branch]}
+				addr, script, err := asset.deriveMultisigAddress(cosigners, config.RequiredSigs, path)
+				if err != nil {
+					return err
+				}
+				_, err = scopedMgr.ImportWitnessScript(ns, script.script, &syncedTo, 0, false)
+				if err != nil && !waddrmgr.IsError(err, waddrmgr.ErrDuplicateAddress) {
+					return err
+				}
+				imported = append(imported, addr)
+				indexes.Imported[branch]++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	asset.SaveUserConfigValue(sharedW.MultisigAddressIndexesConfigKey, indexes)
+
+	// The addresses are watched from the next sync if the wallet isn't
+	// syncing, the neutrino rescan only takes updates while it runs.
+	if !asset.IsSyncing() && !asset.IsSynced() {
+		return indexes, nil
+	}
+	if chainSource := asset.chainSource(); chainSource != nil && len(imported) > 0 {
+		if err := chainSource.NotifyReceived(imported); err != nil {
+			log.Errorf("[%d] watching the multisig addresses failed: %v", asset.ID, err)
+		}
+	}
+	return indexes, nil
+}
+
+// deriveMultisigAddress derives a multisig address and its witness script,
+// the path of the address is cached to find the scripts of the outputs the
+// wallet spends. It must be called with asset.multisigMu held.
+func (asset *Asset) deriveMultisigAddress(cosigners []*multisigCosigner, requiredSigs int, path multisigPath) (ltcutil.Address, *multisigScript, error) {
+	script, err := deriveMultisigScript(cosigners, requiredSigs, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	addr, err := asset.multisigAddress(script.script)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if asset.multisigPaths == nil {
+		asset.multisigPaths = make(map[string]multisigPath)
+	}
+	asset.multisigPaths[addr.EncodeAddress()] = path
+	return addr, script, nil
+}
+
+// multisigBranchAddress returns the current address of the branch, after
+// advancing it if next is true.
+func (asset *Asset) multisigBranchAddress(branch uint32, next bool) (ltcutil.Address, *multisigScript, error) {
+	asset.multisigMu.Lock()
+	defer asset.multisigMu.Unlock()
+
+	indexes, err := asset.updateMultisigAddresses(func(indexes *multisigAddressIndexes) {
+		if next {
+			indexes.Current[branch]++
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config, err := asset.MultisigConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	cosigners, err := decodeCosigners(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	path := multisigPath{branch: branch, index: indexes.Current[branch]}
+	return asset.deriveMultisigAddress(cosigners, config.RequiredSigs, path)
+}
+
+// multisigOutputScript returns the witness script of a multisig address of
+// the wallet.
+func (asset *Asset) multisigOutputScript(config *sharedW.MultisigConfig, cosigners []*multisigCosigner,
+	addr ltcutil.Address) (*multisigScript, error) {
+	asset.multisigMu.Lock()
+	defer asset.multisigMu.Unlock()
+
+	path, ok := asset.multisigPaths[addr.EncodeAddress()]
+	if !ok {
+		// The paths are cached as the addresses are derived, derive the
+		// imported addresses the first time an output is spent.
+		indexes := asset.multisigAddressIndexes()
+		for branch := range indexes.Imported {
+			for index := uint32(0); index < indexes.Imported[branch] && !ok; index++ {
+				p := multisigPath{branch: uint32(branch), index: index}
+				derived, _, err := asset.deriveMultisigAddress(cosigners, config.RequiredSigs, p)
+				if err != nil {
+					return nil, err
+				}
+				if derived.EncodeAddress() == addr.EncodeAddress() {
+					path, ok = p, true
+				}
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("%s: %s isn't a multisig address of the wallet", utils.ErrNotExist, addr)
+		}
+	}
+	return deriveMultisigScript(cosigners, config.RequiredSigs, path)
+}
+
+// multisigInputVSize is the largest virtual size of an input that spends a
+// multisig output of the wallet, with signatures of 72 bytes.
+func multisigInputVSize(requiredSigs, cosigners int) int {
+	scriptSize := 3 + 34*cosigners
+	// Outpoint, empty signature script and sequence.
+	const baseSize = 32 + 4 + 1 + 4
+	// Number of items, the empty item CHECKMULTISIG pops, the signatures and
+	// the witness script.
+	witnessSize := 1 + 1 + requiredSigs*(1+72) + wire.VarIntSerializeSize(uint64(scriptSize)) + scriptSize
+	return baseSize + (witnessSize+3)/4
+}
+
+// multisigTxVSize returns the virtual size of a transaction that spends
+// multisig outputs.
+func multisigTxVSize(numInputs, inputVSize int, outputs []*wire.TxOut) int {
+	// Version, lock time and the segwit marker and flag.
+	size := 4 + 4 + 1 + wire.VarIntSerializeSize(uint64(numInputs)) +
+		wire.VarIntSerializeSize(uint64(len(outputs)))
+	for _, output := range outputs {
+		size += output.SerializeSize()
+	}
+	return size + numInputs*inputVSize
+}
+
+// CreateMultisigPSBT creates a PSBT that pays the destinations from the
+// outputs of the multisig wallet at the user fee rate. The PSBT holds what
+// every cosigner needs to sign it, it is base64 encoded.
+func (asset *Asset) CreateMultisigPSBT(destinations []*sharedW.TransactionDestination) (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrLTCNotInitialized
+	}
+	config, err := asset.MultisigConfig()
+	if err != nil {
+		return "", err
+	}
+	cosigners, err := decodeCosigners(config)
+	if err != nil {
+		return "", err
+	}
+	if len(destinations) == 0 {
+		return "", errors.E(errors.Invalid, "no destination")
+	}
+
+	var sendMax bool
+	outputs := make([]*wire.TxOut, 0, len(destinations)+1)
+	for _, destination := range destinations {
+		if err := asset.validateSendAmount(destination.SendMax, destination.UnitAmount); err != nil {
+			return "", err
+		}
+		if destination.SendMax && sendMax {
+			return "", fmt.Errorf("cannot send max amount to multiple recipients")
+		}
+		sendMax = sendMax || destination.SendMax
+
+		addr, err := decodeAddress(destination.Address, asset.chainParams)
+		if err != nil {
+			return "", err
+		}
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return "", err
+		}
+		outputs = append(outputs, wire.NewTxOut(destination.UnitAmount, pkScript))
+	}
+
+	utxos, err := asset.UnspentOutputs(ImportedAccountNumber)
+	if err != nil {
+		return "", err
+	}
+	unspents := make([]*sharedW.UnspentOutput, 0, len(utxos))
+	for _, utxo := range utxos {
+		if !utxo.Frozen {
+			unspents = append(unspents, utxo)
+		}
+	}
+
+	feeRate := ltcutil.Amount(asset.GetUserFeeRate().ToInt())
+	inputVSize := multisigInputVSize(config.RequiredSigs, len(cosigners))
+	changeAddr, changeScript, err := asset.multisigBranchAddress(multisigInternalBranch, false)
+	if err != nil {
+		return "", err
+	}
+	changePkScript, err := txscript.PayToAddrScript(changeAddr)
+	if err != nil {
+		return "", err
+	}
+	change := wire.NewTxOut(0, changePkScript)
+
+	var target int64
+	for _, output := range outputs {
+		target += output.Value
+	}
+
+	selected := make([]int, len(unspents))
+	for i := range selected {
+		selected[i] = i
+	}
+	if !sendMax {
+		inputCost := int64(txrules.FeeForSerializeSize(feeRate, inputVSize))
+		selection := &sharedW.CoinSelection{
+			Strategy:     asset.DefaultCoinSelectionStrategy(),
+			Target:       target + int64(txrules.FeeForSerializeSize(feeRate, multisigTxVSize(0, 0, append(outputs, change)))),
+			InputCost:    inputCost,
+			CostOfChange: int64(txrules.FeeForSerializeSize(feeRate, change.SerializeSize())) + inputCost,
+		}
+		selected, _ = sharedW.SelectCoins(selection, unspents)
+	}
+
+	var total int64
+	inputs := make([]*sharedW.UnspentOutput, 0, len(selected))
+	for _, i := range selected {
+		total += unspents[i].Amount.ToInt()
+		inputs = append(inputs, unspents[i])
+	}
+	if len(inputs) == 0 {
+		return "", errors.New(utils.ErrInsufficientBalance)
+	}
+
+	fee := int64(txrules.FeeForSerializeSize(feeRate, multisigTxVSize(len(inputs), inputVSize, outputs)))
+	feeWithChange := int64(txrules.FeeForSerializeSize(feeRate, multisigTxVSize(len(inputs), inputVSize, append(outputs, change))))
+	switch {
+	case sendMax:
+		var others int64
+		for i, destination := range destinations {
+			if !destination.SendMax {
+				others += outputs[i].Value
+			}
+		}
+		for i, destination := range destinations {
+			if destination.SendMax {
+				outputs[i].Value = total - others - fee
+			}
+		}
+	case total < target+fee:
+		return "", errors.New(utils.ErrInsufficientBalance)
+	default:
+		change.Value = total - target - feeWithChange
+		if change.Value > 0 && !txrules.IsDustOutput(change, txrules.DefaultRelayFeePerKb) {
+			// Insert the change output at a random position so that it
+			// can't be told from the payments.
+			i := rand.Intn(len(outputs) + 1)
+			outputs = append(outputs[:i], append([]*wire.TxOut{change}, outputs[i:]...)...)
+		} else {
+			change = nil
+		}
+	}
+	for _, output := range outputs {
+		if err := txrules.CheckOutput(output, txrules.DefaultRelayFeePerKb); err != nil {
+			return "", fmt.Errorf("output validation failed: %v", err)
+		}
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	// To discourage fee sniping, LockTime is set to the best block.
+	tx.LockTime = uint32(asset.GetBestBlockHeight())
+	for _, input := range inputs {
+		outPoint, err := parseOutPoint(input)
+		if err != nil {
+			return "", err
+		}
+		txIn := wire.NewTxIn(outPoint, nil, nil)
+		txIn.Sequence = wire.MaxTxInSequenceNum - 1
+		tx.AddTxIn(txIn)
+	}
+	for _, output := range outputs {
+		tx.AddTxOut(output)
+	}
+
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		return "", err
+	}
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		return "", err
+	}
+
+	for i, txIn := range tx.TxIn {
+		prevTx, prevOut, _, _, err := asset.Internal().LTC.FetchInputInfo(&txIn.PreviousOutPoint)
+		if err != nil {
+			return "", err
+		}
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(prevOut.PkScript, asset.chainParams)
+		if err != nil || len(addrs) != 1 {
+			return "", fmt.Errorf("unexpected script of the output %v", txIn.PreviousOutPoint)
+		}
+		script, err := asset.multisigOutputScript(config, cosigners, addrs[0])
+		if err != nil {
+			return "", err
+		}
+
+		// The previous transaction is included as well, signers that
+		// verify the amount spent need it.
+		if err := updater.AddInNonWitnessUtxo(prevTx, i); err != nil {
+			return "", err
+		}
+		if err := updater.AddInWitnessUtxo(prevOut, i); err != nil {
+			return "", err
+		}
+		if err := updater.AddInWitnessScript(script.script, i); err != nil {
+			return "", err
+		}
+		if err := updater.AddInSighashType(txscript.SigHashAll, i); err != nil {
+			return "", err
+		}
+		for _, derivation := range script.derivations {
+			err := updater.AddInBip32Derivation(derivation.MasterKeyFingerprint, derivation.Bip32Path, derivation.PubKey, i)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if change != nil {
+		// Let the cosigners verify that the change returns to the wallet.
+		for i, output := range tx.TxOut {
+			if output != change {
+				continue
+			}
+			if err := updater.AddOutWitnessScript(changeScript.script, i); err != nil {
+				return "", err
+			}
+			for _, derivation := range changeScript.derivations {
+				err := updater.AddOutBip32Derivation(derivation.MasterKeyFingerprint, derivation.Bip32Path, derivation.PubKey, i)
+				if err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+
+	return packet.B64Encode()
+}
+
+// decodePSBT decodes a base64 encoded PSBT.
+func decodePSBT(b64PSBT string) (*psbt.Packet, error) {
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(strings.TrimSpace(b64PSBT)), true)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid PSBT: %v", utils.ErrInvalid, err)
+	}
+	return packet, nil
+}
+
+// SignMultisigPSBT adds the signatures of the wallet to the inputs of the PSBT
+// whose keys are derived from the wallet seed, as its MultisigCosignerKey is.
+// The signed PSBT is returned to the coordinator of the multisig wallet.
+// seedPassphrase is the BIP-39 passphrase the wallet seed is used with, if
+// any.
+func (asset *Asset) SignMultisigPSBT(b64PSBT, privatePassphrase, seedPassphrase string) (string, error) {
+	packet, err := decodePSBT(b64PSBT)
+	if err != nil {
+		return "", err
+	}
+
+	master, err := asset.multisigMasterKey(privatePassphrase, seedPassphrase)
+	if err != nil {
+		return "", err
+	}
+	defer master.Zero()
+	fingerprint, err := keyFingerprint(master)
+	if err != nil {
+		return "", err
+	}
+
+	tx := packet.UnsignedTx
+	prevOutFetcher := txscript.NewMultiPrevOutFetcher(nil)
+	for i, input := range packet.Inputs {
+		if input.WitnessUtxo == nil || input.WitnessScript == nil {
+			return "", fmt.Errorf("%s: input %d isn't a P2WSH input", utils.ErrInvalid, i)
+		}
+		// Don't trust the amount of a witness output that the previous
+		// transaction contradicts.
+		if input.NonWitnessUtxo != nil {
+			prevOut := tx.TxIn[i].PreviousOutPoint
+			if input.NonWitnessUtxo.TxHash() != prevOut.Hash ||
+				int(prevOut.Index) >= len(input.NonWitnessUtxo.TxOut) ||
+				!psbt.TxOutsEqual(input.NonWitnessUtxo.TxOut[prevOut.Index], input.WitnessUtxo) {
+				return "", fmt.Errorf("%s: input %d spends a different output", utils.ErrInvalid, i)
+			}
+		}
+		prevOutFetcher.AddPrevOut(tx.TxIn[i].PreviousOutPoint, input.WitnessUtxo)
+	}
+	sigHashes := txscript.NewTxSigHashes(tx, prevOutFetcher)
+
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		return "", err
+	}
+
+	var signed int
+	for i, input := range packet.Inputs {
+		for _, derivation := range input.Bip32Derivation {
+			if derivation.MasterKeyFingerprint != fingerprint {
+				continue
+			}
+
+			key, err := deriveKeyPath(master, derivation.Bip32Path)
+			if err != nil {
+				return "", err
+			}
+			privKey, err := key.ECPrivKey()
+			if err != nil {
+				return "", err
+			}
+			pubKey := privKey.PubKey().SerializeCompressed()
+			if !bytes.Equal(pubKey, derivation.PubKey) {
+				continue
+			}
+
+			sig, err := txscript.RawTxInWitnessSignature(tx, sigHashes, i, input.WitnessUtxo.Value,
+				input.WitnessScript, txscript.SigHashAll, privKey)
+			if err != nil {
+				return "", err
+			}
+			_, err = updater.Sign(i, sig, pubKey, nil, nil)
+			if err != nil && err != psbt.ErrDuplicateKey {
+				return "", err
+			}
+			signed++
+		}
+	}
+	if signed == 0 {
+		return "", fmt.Errorf("%s: the wallet isn't a cosigner of the PSBT", utils.ErrInvalid)
+	}
+
+	return packet.B64Encode()
+}
+
+// CombineMultisigPSBTs combines the signatures of the copies of a PSBT that
+// the cosigners signed.
+func (asset *Asset) CombineMultisigPSBTs(b64PSBTs []string) (string, error) {
+	return sharedW.CombineMultisigPSBTs(b64PSBTs)
+}
+
+// MultisigPSBTStatus returns the signing progress of a PSBT of the multisig
+// wallet.
+func (asset *Asset) MultisigPSBTStatus(b64PSBT string) (*sharedW.MultisigPSBTStatus, error) {
+	config, err := asset.MultisigConfig()
+	if err != nil {
+		return nil, err
+	}
+	return config.PSBTStatus(b64PSBT)
+}
+
+// FinalizeMultisigPSBT finalizes the inputs of a PSBT signed by enough
+// cosigners and returns the signed transaction.
+func (asset *Asset) FinalizeMultisigPSBT(b64PSBT string) (*wire.MsgTx, error) {
+	config, err := asset.MultisigConfig()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := config.FinalizePSBT(b64PSBT)
+	if err != nil {
+		return nil, err
+	}
+	return convertMsgTxToLTC(tx)
+}
+
+// BroadcastMultisigPSBT finalizes a PSBT signed by enough cosigners and
+// broadcasts its transaction, the transaction hash is returned.
+func (asset *Asset) BroadcastMultisigPSBT(b64PSBT, transactionLabel string) ([]byte, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrLTCNotInitialized
+	}
+
+	tx, err := asset.FinalizeMultisigPSBT(b64PSBT)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := asset.Internal().LTC.PublishTransaction(tx, transactionLabel); err != nil {
+		return nil, utils.TranslateError(err)
+	}
+
+	txHash := tx.TxHash()
+	if transactionLabel != "" {
+		if err = asset.SetTransactionLabel(txHash.String(), transactionLabel); err != nil {
+			log.Errorf("error saving the transaction label: %v", err)
+		}
+	}
+	return txHash[:], nil
+}
@@ -50,9 +50,6 @@ type SyncData struct {
 	rescanStartTime   time.Time // rescanStartTime tracks the time when syncing starts.
 	rescanStartHeight *int32    // rescanStartHeight tracks the height when syncing starts.
 
-	// forcedRescanActive is set to true if forcedRescan is activated.
-	forcedRescanActive bool
-
 	wg sync.WaitGroup
 
 	// Listeners
@@ -117,8 +114,19 @@ func (asset *Asset) RemoveSyncProgressListener(uniqueIdentifier string) {
 // bestServerPeerBlockHeight accesses the connected peers and requests for the
 // last synced block height.
 func (asset *Asset) bestServerPeerBlockHeight() {
-	serverPeers := asset.cl.Peers()
-	for _, p := range serverPeers {
+	if remoteClient := asset.remoteClient(); remoteClient != nil {
+		// The litecoind node is the only peer of the wallet.
+		if _, height, err := remoteClient.GetBestBlock(); err == nil && height > asset.syncData.bestBlockHeight {
+			asset.syncData.bestBlockHeight = height
+		}
+		return
+	}
+
+	chainService := asset.neutrinoService()
+	if chainService == nil {
+		return
+	}
+	for _, p := range chainService.Peers() {
 		if p.LastBlock() > asset.syncData.bestBlockHeight {
 			asset.syncData.bestBlockHeight = p.LastBlock()
 			// If a dormant peer is picked, on the next iteration it will be dropped
@@ -251,6 +259,18 @@ notificationsLoop:
 				asset.mempoolTransactionNotification(txToCache[i])
 			}
 
+			// Keep the gap of unused multisig addresses after the
+			// addresses that received funds.
+			walletTxs := len(n.UnminedTransactions)
+			for _, block := range n.AttachedBlocks {
+				walletTxs += len(block.Transactions)
+			}
+			if walletTxs > 0 && asset.IsMultisig() {
+				if err := asset.extendMultisigAddresses(); err != nil {
+					log.Errorf("[%d] extending the multisig addresses failed: %v", asset.ID, err)
+				}
+			}
+
 			if len(n.UnminedTransactions) > 0 {
 				// Since the tx cache receives a fresh update only when a new
 				// block is detected, update cache with the newly received mempool tx(s).
@@ -279,14 +299,29 @@ func (asset *Asset) rescanFinished(height int32) {
 		// to when the privatekey was first used.
 		asset.updateAssetBirthday()
 		_ = asset.MarkWalletAsDiscoveredAccounts()
+		asset.UpdateSyncCheckpoint(func(checkpoint *sharedW.SyncCheckpoint) {
+			checkpoint.RecoveryStarted = false
+		})
+
+		if asset.ReadBoolConfigValueForKey(sharedW.ScanLegacyKeyScopesConfigKey, false) {
+			if _, err := asset.ImportLegacyKeyScopes(); err != nil {
+				log.Errorf("[%d] importing the legacy key scopes failed: %v", asset.ID, err)
+			}
+		}
+	}
+
+	if asset.IsMultisig() {
+		if err := asset.extendMultisigAddresses(); err != nil {
+			log.Errorf("[%d] extending the multisig addresses failed: %v", asset.ID, err)
+		}
 	}
 
 	asset.syncData.mu.Lock()
 	asset.syncData.isRescan = false
 	asset.syncData.mu.Unlock()
 
-	if asset.blocksRescanProgressListener != nil {
-		asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, nil)
+	if listener := asset.rescanner.Listener(); listener != nil {
+		listener.OnBlocksRescanEnded(asset.ID, nil)
 	}
 }
 
@@ -300,6 +335,11 @@ func (asset *Asset) prepareChain() error {
 		return errors.New("wallet not found")
 	}
 
+	if asset.ChainBackend() != sharedW.SPVBackend {
+		// The RPC and Electrum backends connect when syncing starts.
+		return nil
+	}
+
 	log.Debug("Starting native LTC wallet sync...")
 	asset.cl, err = asset.loadChainService()
 	if err != nil {
@@ -338,7 +378,7 @@ func (asset *Asset) loadChainService() (chainService *neutrino.ChainService, err
 		ConnectPeers:  validPeerAddresses,
 		AddPeers:      asset.setSeedPeers(),
 		// Dailer function helps to better control the dailer functionality.
-		Dialer: utils.DialerFunc(asset.dailerCtx),
+		Dialer: asset.DataUsageDialer(utils.DialerFunc(asset.dailerCtx)),
 		// WARNING: PublishTransaction currently uses the entire duration
 		// because if an external bug, but even if the resolved, a typical
 		// inv/getdata round trip is ~4 seconds, so we set this so neutrino does
@@ -365,7 +405,9 @@ func (asset *Asset) CancelSync() {
 	log.Info("Canceling sync. May take a while for sync to fully cancel.")
 
 	// Cancel all the pending tcp connection at the node level.
-	asset.dailerCancel()
+	if asset.dailerCancel != nil {
+		asset.dailerCancel()
+	}
 
 	// reset the sync data first.
 	asset.resetSyncProgressData()
@@ -401,26 +443,36 @@ func (asset *Asset) stopSync() {
 	}
 
 	// 2. shutdown the chain client.
-	asset.chainClient.Stop() // If active, attempt to shut it down.
+	chainClient := asset.chainSource()
+	if chainClient != nil {
+		chainClient.Stop() // If active, attempt to shut it down.
+	}
 
-	if asset.WalletOpened() {
+	if chainService := asset.neutrinoService(); asset.WalletOpened() && chainService != nil {
 		// Neutrino performs explicit chain service start but never explicit
 		// chain service stop thus the need to have it done here when stopping
 		// a wallet sync.
 		// 3. Disabling the peers connectivity allows the upstream handleChainNotification
 		// goroutine to return.
-		if err := asset.chainClient.CS.Stop(); err != nil {
+		if err := chainService.Stop(); err != nil {
 			// ignore the error and proceed with shutdown.
 			log.Errorf("Stopping chain client failed: %v", err)
 		}
 
 		asset.syncData.chainServiceStopped = true
+	}
+
+	if asset.WalletOpened() {
 		// 4. Wait for the upstream wallet to shutdown completely.
 		loadedAsset.WaitForShutdown()
 	}
 
-	// 5. Wait for the chain client to shutdown
-	asset.chainClient.WaitForShutdown()
+	// 5. Wait for the chain client to shutdown and disconnect from the
+	// litecoind node or Electrum server if syncing with a remote backend.
+	if chainClient != nil {
+		chainClient.WaitForShutdown()
+	}
+	asset.disconnectRemoteBackend()
 
 	// Declares that the sync context is done and goroutines listening to it
 	// should exit. The shutdown protocol will eventually attempt to end this
@@ -448,26 +500,49 @@ func (asset *Asset) stopSync() {
 func (asset *Asset) startSync() error {
 	g, _ := errgroup.WithContext(asset.syncCtx)
 
-	if asset.syncData.chainServiceStopped {
+	switch {
+	case asset.ChainBackend() == sharedW.RPCBackend:
+		if err := asset.connectRPCBackend(); err != nil {
+			asset.CancelSync()
+			log.Errorf("couldn't start litecoind client: %v", err)
+			return err
+		}
+	case asset.ChainBackend() == sharedW.ElectrumBackend:
+		if err := asset.setElectrumBackend(); err != nil {
+			asset.CancelSync()
+			log.Errorf("couldn't start electrum client: %v", err)
+			return err
+		}
+	case asset.syncData.chainServiceStopped || asset.chainClient == nil:
 		chainService, err := asset.loadChainService()
 		if err != nil {
 			return err
 		}
-		asset.chainClient.CS = chainService
+		asset.cl = chainService
+		if asset.chainClient == nil {
+			asset.chainClient = chain.NewNeutrinoClient(asset.chainParams, chainService)
+		} else {
+			asset.chainClient.CS = chainService
+		}
+	}
+
+	chainClient := asset.chainSource()
+	if chainClient == nil {
+		return errors.New(utils.ErrNotConnected)
 	}
 
 	// Chain client performs explicit chain service start up thus no need
 	// to re-initialize it.
-	g.Go(asset.chainClient.Start)
+	g.Go(chainClient.Start)
 
 	if err := g.Wait(); err != nil {
 		asset.CancelSync()
-		log.Errorf("couldn't start Neutrino client: %v", err)
+		log.Errorf("couldn't start %s client: %v", chainClient.BackEnd(), err)
 		return err
 	}
 
 	// Subscribe to chainclient notifications.
-	if err := asset.chainClient.NotifyBlocks(); err != nil {
+	if err := chainClient.NotifyBlocks(); err != nil {
 		log.Errorf("subscribing to notifications failed: %v", err)
 		return err
 	}
@@ -479,7 +554,7 @@ func (asset *Asset) startSync() error {
 
 	log.Infof("Synchronizing wallet (%s) with network...", asset.GetWalletName())
 	// Initializes the goroutines handling chain notifications, rescan progress and handlers.
-	asset.Internal().LTC.SynchronizeRPC(asset.chainClient)
+	asset.Internal().LTC.SynchronizeRPC(chainClient)
 
 	return nil
 }
@@ -499,8 +574,17 @@ func (asset *Asset) IsConnectedToLitecoinNetwork() bool {
 func (asset *Asset) startWallet() (err error) {
 	// If this is an imported wallet and address discovery has not been performed,
 	// We want to set the assets birtday to the genesis block.
+	// A recovery interrupted by a previous shutdown continues from where it
+	// stopped instead of starting over from the genesis block.
 	if asset.IsRestored && !asset.ContainsDiscoveredAccounts() {
-		asset.forceRescan()
+		if asset.SyncCheckpoint().RecoveryStarted {
+			asset.resumeRecovery()
+		} else {
+			asset.forceRescan()
+			asset.UpdateSyncCheckpoint(func(checkpoint *sharedW.SyncCheckpoint) {
+				checkpoint.RecoveryStarted = true
+			})
+		}
 	}
 	// Initiate the sync protocol and return an error incase of failure.
 	return asset.startSync()
@@ -517,14 +601,16 @@ func (asset *Asset) waitForSyncCompletion() {
 	for {
 		select {
 		case <-t.C:
-			block, err := asset.chainClient.CS.BestBlock()
+			block, err := asset.bestBlock()
 			if err != nil {
 				log.Error("GetBestBlock hash for LTC failed, Err: ", err)
+				continue
 			}
 			asset.updateSyncProgress(block.Height)
 			asset.updateRescanProgress(block.Height)
+			asset.SaveHeadersCheckpoint(block.Height)
 
-			if asset.chainClient.IsCurrent() {
+			if asset.isChainCurrent() {
 				asset.rescanFinished(block.Height)
 
 				asset.syncData.mu.Lock()
@@ -534,6 +620,9 @@ func (asset *Asset) waitForSyncCompletion() {
 
 				// Trigger UI update showing ltc address recovery is in progress.
 				asset.handleSyncUIUpdate()
+
+				// Pick up a rescan the previous sync didn't finish.
+				go asset.resumeRescan()
 				return
 			}
 		case <-asset.syncCtx.Done():
@@ -589,8 +678,9 @@ func (asset *Asset) SpvSync() (err error) {
 }
 
 // reloadChainService loads a new instance of chain service to be used
-// for sync. It restarts sync if the wallet was previously connected to the ltc newtork
-// before the function call.
+// for sync, or drops it if the wallet syncs with the RPC backend. It restarts
+// sync if the wallet was previously connected to the ltc newtork before the
+// function call.
 func (asset *Asset) reloadChainService() error {
 	if !asset.WalletOpened() {
 		return utils.ErrLTCNotInitialized
@@ -601,12 +691,26 @@ func (asset *Asset) reloadChainService() error {
 		asset.CancelSync()
 	}
 
-	_ = asset.chainClient.CS.Stop()
-	chainService, err := asset.loadChainService()
-	if err != nil {
-		return err
+	if chainService := asset.neutrinoService(); chainService != nil {
+		_ = chainService.Stop()
+	}
+
+	if asset.ChainBackend() != sharedW.SPVBackend {
+		// The RPC and Electrum backends connect when syncing starts.
+		asset.cl = nil
+		asset.chainClient = nil
+	} else {
+		chainService, err := asset.loadChainService()
+		if err != nil {
+			return err
+		}
+		asset.cl = chainService
+		if asset.chainClient == nil {
+			asset.chainClient = chain.NewNeutrinoClient(asset.chainParams, chainService)
+		} else {
+			asset.chainClient.CS = chainService
+		}
 	}
-	asset.chainClient.CS = chainService
 
 	// If the asset is previously connected to the network call SpvSync to
 	// start sync using the new instance of chain service.
@@ -68,6 +68,7 @@ func (asset *Asset) GetTransactionRaw(txHash string) (*sharedW.Transaction, erro
 	transactions, err := asset.getTransactionsRaw(0, 0, true)
 	for _, tx := range transactions {
 		if tx.Hash == txHash {
+			asset.ApplyTxLabels(tx)
 			return tx, nil
 		}
 	}
@@ -115,12 +116,14 @@ func (asset *Asset) GetTransactionsRaw(offset, limit, txFilter int32, newestFirs
 	}
 
 	if txHashSearch != "" {
+		matches := asset.TxSearchMatcher(txHashSearch)
+		matchingTxs := make([]*sharedW.Transaction, 0)
 		for _, tx := range transactions {
-			if tx.Hash == txHashSearch {
-				return []*sharedW.Transaction{tx}, nil
+			if matches(tx) {
+				matchingTxs = append(matchingTxs, tx)
 			}
 		}
-		return []*sharedW.Transaction{}, nil
+		return matchingTxs, nil
 	}
 
 	if offset == 0 && limit == 0 {
@@ -161,6 +164,7 @@ func (asset *Asset) filterTxs(offset, limit, txFilter int32, newestFirst bool) (
 	if err != nil {
 		return []*sharedW.Transaction{}, nil
 	}
+	asset.ApplyTxLabels(transactions...)
 
 	if txType == txhelper.TxDirectionAll {
 		return transactions, err
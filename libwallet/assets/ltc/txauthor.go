@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
-	"sort"
 	"sync"
 	"time"
 
@@ -39,6 +38,7 @@ type TxAuthor struct {
 	needsConstruct bool
 
 	selectedUXTOs []*sharedW.UnspentOutput
+	coinSelection sharedW.CoinSelectionStrategy
 
 	mu sync.RWMutex
 }
@@ -62,10 +62,26 @@ func (asset *Asset) NewUnsignedTx(sourceAccountNumber int32, utxos []*sharedW.Un
 		destinations:        make(map[int]*sharedW.TransactionDestination, 0),
 		needsConstruct:      true,
 		selectedUXTOs:       utxos,
+		coinSelection:       asset.DefaultCoinSelectionStrategy(),
 	}
 	return nil
 }
 
+// SetCoinSelectionStrategy sets the strategy used to select the outputs that
+// fund the transaction when they are not selected manually.
+func (asset *Asset) SetCoinSelectionStrategy(strategy sharedW.CoinSelectionStrategy) error {
+	if !strategy.IsValid() {
+		return errors.New(utils.ErrInvalid)
+	}
+
+	asset.TxAuthoredInfo.mu.Lock()
+	defer asset.TxAuthoredInfo.mu.Unlock()
+
+	asset.TxAuthoredInfo.coinSelection = strategy
+	asset.TxAuthoredInfo.needsConstruct = true
+	return nil
+}
+
 // GetUnsignedTx returns the unsigned transaction.
 func (asset *Asset) GetUnsignedTx() *TxAuthor {
 	return asset.TxAuthoredInfo
@@ -76,7 +92,8 @@ func (asset *Asset) IsUnsignedTxExist() bool {
 	return asset.TxAuthoredInfo != nil
 }
 
-// ComputeTxSizeEstimation computes the estimated size of the final raw transaction.
+// ComputeTxSizeEstimation computes the estimated virtual size of the final raw
+// transaction, accounting for the type of each input.
 func (asset *Asset) ComputeTxSizeEstimation(dstAddress string, utxos []*sharedW.UnspentOutput) (int, error) {
 	if len(utxos) == 0 {
 		return 0, nil
@@ -87,8 +104,15 @@ func (asset *Asset) ComputeTxSizeEstimation(dstAddress string, utxos []*sharedW.
 	}
 
 	var sendAmount int64
+	prevScripts := make([][]byte, 0, len(utxos))
 	for _, c := range utxos {
 		sendAmount += c.Amount.ToInt()
+
+		script, err := hex.DecodeString(c.ScriptPubKey)
+		if err != nil {
+			return -1, fmt.Errorf("invalid utxo pkScript: %v", err)
+		}
+		prevScripts = append(prevScripts, script)
 	}
 
 	output, err := txhelper.MakeLTCTxOutput(dstAddress, sendAmount, asset.chainParams)
@@ -96,7 +120,12 @@ func (asset *Asset) ComputeTxSizeEstimation(dstAddress string, utxos []*sharedW.
 		return -1, fmt.Errorf("computing utxo size failed: %v", err)
 	}
 
-	estimatedSize := txsizes.EstimateSerializeSize(len(utxos), []*wire.TxOut{output}, true)
+	changeSize := txsizes.P2WPKHPkScriptSize
+	if asset.TxAuthoredInfo != nil {
+		changeSize = changeScriptSize(int32(asset.TxAuthoredInfo.sourceAccountNumber))
+	}
+
+	estimatedSize := estimateTxVirtualSize(prevScripts, []*wire.TxOut{output}, changeSize)
 	return estimatedSize, nil
 }
 
@@ -199,6 +228,10 @@ func (asset *Asset) EstimateFeeAndSize() (*sharedW.TxFeeAndSize, error) {
 	// Since the fee is already calculated when computing the change source out
 	// or single destination to send max amount, no need to repeat calculations again.
 	feeToSpend := asset.TxAuthoredInfo.txSpendAmount - sendAmount
+	if unsignedTx.ChangeIndex < 0 {
+		// Without a change output, the inputs not sent are paid as fee.
+		feeToSpend = unsignedTx.TotalInput - sendAmount
+	}
 	feeAmount := &sharedW.Amount{
 		UnitValue: int64(feeToSpend),
 		CoinValue: feeToSpend.ToBTC(),
@@ -213,11 +246,9 @@ func (asset *Asset) EstimateFeeAndSize() (*sharedW.TxFeeAndSize, error) {
 		}
 	}
 
-	// TODO: confirm if the size on UI needs to be in vB to B.
-	// This estimation returns size in Bytes (B).
-	estimatedSize := txsizes.EstimateSerializeSize(len(unsignedTx.Tx.TxIn), unsignedTx.Tx.TxOut, true)
-	// This estimation returns size in virtualBytes (vB).
-	// estimatedSize := feeToSpend.ToBTC() / fallBackFeeRate.ToBTC()
+	// This estimation returns size in virtualBytes (vB), the change output is
+	// already part of the unsigned tx outputs.
+	estimatedSize := estimateTxVirtualSize(unsignedTx.PrevScripts, unsignedTx.Tx.TxOut, 0)
 
 	return &sharedW.TxFeeAndSize{
 		FeeRate:             asset.GetUserFeeRate().ToInt(),
@@ -289,6 +320,15 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byt
 	// https://bitcoin.stackexchange.com/questions/48384/why-bitcoin-core-creates-time-locked-transactions-by-default
 	msgTx.LockTime = uint32(asset.GetBestBlockHeight())
 
+	// Segwit v1 signatures commit to the amounts and scripts of every input,
+	// the sighashes are computed from all the previous outputs.
+	prevOutFetcher, err := txauthor.TXPrevOutFetcher(msgTx, unsignedTx.PrevScripts, unsignedTx.PrevInputValues)
+	if err != nil {
+		log.Errorf("fetching previous outputs failed: %v", err)
+		return nil, err
+	}
+	sigHashes := txscript.NewTxSigHashes(msgTx, prevOutFetcher)
+
 	for index, txIn := range msgTx.TxIn {
 		_, previousTXout, _, _, err := asset.Internal().LTC.FetchInputInfo(&txIn.PreviousOutPoint)
 		if err != nil {
@@ -296,14 +336,17 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byt
 			return nil, err
 		}
 
-		prevOutScript := unsignedTx.PrevScripts[index]
 		prevOutAmount := int64(asset.TxAuthoredInfo.inputValues[index])
-		prevOutFetcher := txscript.NewCannedPrevOutputFetcher(prevOutScript, prevOutAmount)
-		sigHashes := txscript.NewTxSigHashes(msgTx, prevOutFetcher)
 
-		witness, signature, err := asset.Internal().LTC.ComputeInputScript(
-			msgTx, previousTXout, index, sigHashes, txscript.SigHashAll, nil,
-		)
+		var witness wire.TxWitness
+		var signature []byte
+		if txscript.IsPayToPubKeyHash(previousTXout.PkScript) {
+			signature, err = asset.signP2PKHInput(msgTx, index, previousTXout.PkScript)
+		} else {
+			witness, signature, err = asset.Internal().LTC.ComputeInputScript(
+				msgTx, previousTXout, index, sigHashes, txscript.SigHashAll, nil,
+			)
+		}
 		if err != nil {
 			log.Errorf("generating input signatures failed: %v", err)
 			return nil, err
@@ -315,8 +358,9 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byt
 		// Prove that the transaction has been validly signed by executing the
 		// script pair.
 		flags := txscript.ScriptBip16 | txscript.ScriptVerifyDERSignatures |
-			txscript.ScriptStrictMultiSig | txscript.ScriptDiscourageUpgradableNops
-		vm, err := txscript.NewEngine(previousTXout.PkScript, msgTx, 0, flags, nil, nil,
+			txscript.ScriptStrictMultiSig | txscript.ScriptDiscourageUpgradableNops |
+			txscript.ScriptVerifyWitness
+		vm, err := txscript.NewEngine(previousTXout.PkScript, msgTx, index, flags, nil, sigHashes,
 			prevOutAmount, prevOutFetcher)
 		if err != nil {
 			log.Errorf("creating validation engine failed: %v", err)
@@ -344,7 +388,19 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) ([]byt
 	}
 
 	err = asset.Internal().LTC.PublishTransaction(msgTx, transactionLabel)
-	return nil, utils.TranslateError(err)
+	if err != nil {
+		return nil, utils.TranslateError(err)
+	}
+
+	txHash := msgTx.TxHash()
+	if transactionLabel != "" {
+		// The label is saved by the wallet as well, so that it can be
+		// edited later.
+		if err = asset.SetTransactionLabel(txHash.String(), transactionLabel); err != nil {
+			log.Errorf("error saving the transaction label: %v", err)
+		}
+	}
+	return txHash[:], nil
 }
 
 func (asset *Asset) unsignedTransaction() (*txauthor.AuthoredTx, error) {
@@ -361,6 +417,9 @@ func (asset *Asset) unsignedTransaction() (*txauthor.AuthoredTx, error) {
 	return asset.TxAuthoredInfo.unsignedTx, nil
 }
 
+// constructTransaction builds the unsigned transaction paying the
+// destinations. A change output that would be dust is left out, its value is
+// paid as fee.
 func (asset *Asset) constructTransaction() (*txauthor.AuthoredTx, error) {
 	var err error
 	outputs := make([]*wire.TxOut, 0)
@@ -418,34 +477,85 @@ func (asset *Asset) constructTransaction() (*txauthor.AuthoredTx, error) {
 	}
 
 	// if preset with a selected list of UTXOs exists, use them instead.
+	// The coin selection strategy only applies to the outputs selected by
+	// the wallet, the outputs selected manually are spent largest first.
+	strategy := sharedW.CoinSelectionLargestFirst
 	unspents := asset.TxAuthoredInfo.selectedUXTOs
 	if len(unspents) == 0 {
 		unspents, err = asset.UnspentOutputs(int32(asset.TxAuthoredInfo.sourceAccountNumber))
 		if err != nil {
 			return nil, err
 		}
+		unspents = sharedW.UnfrozenOutputs(unspents)
+		strategy = asset.TxAuthoredInfo.coinSelection
 	}
 
-	inputSource := asset.makeInputSource(unspents, sendMax)
+	selection := coinSelection(strategy, unspents, outputs, changeSource.ScriptSize, setFeeRate)
+	inputSource := asset.makeInputSource(unspents, sendMax, selection)
 	unsignedTx, err := txauthor.NewUnsignedTransaction(outputs, setFeeRate, inputSource, changeSource)
 	if err != nil {
 		return nil, fmt.Errorf("creating unsigned tx failed: %v", err)
 	}
 
 	if unsignedTx.ChangeIndex == -1 {
-		// The change amount is zero or the Txout is likely to be considered as dust
-		// if sent to the mempool the whole tx will be rejected.
-		return nil, errors.New("adding the change txOut or sendMax tx failed")
+		if sendMax {
+			// The change amount is zero or the Txout is likely to be considered as dust
+			// if sent to the mempool the whole tx will be rejected.
+			return nil, errors.New("adding the change txOut or sendMax tx failed")
+		}
+		// The change would be dust, txauthor leaves it out and it is paid as
+		// fee whatever the coin selection strategy.
+		return unsignedTx, nil
+	}
+
+	change := unsignedTx.Tx.TxOut[unsignedTx.ChangeIndex]
+	if !sendMax && selection.Changeless && change.Value <= selection.CostOfChange {
+		// The branch and bound search found outputs paying the target
+		// without change, the change costs more to create and spend than it
+		// is worth and is paid as fee instead. The change output is always
+		// the last one.
+		unsignedTx.Tx.TxOut = unsignedTx.Tx.TxOut[:unsignedTx.ChangeIndex]
+		unsignedTx.ChangeIndex = -1
+		return unsignedTx, nil
 	}
 
 	// Confirm that the change output is valid too.
-	if err = txrules.CheckOutput(unsignedTx.Tx.TxOut[unsignedTx.ChangeIndex], setFeeRate); err != nil {
+	if err = txrules.CheckOutput(change, setFeeRate); err != nil {
 		return nil, fmt.Errorf("change txOut validation failed %v", err)
 	}
 
 	return unsignedTx, nil
 }
 
+// coinSelection returns the values used by the coin selection strategy to
+// fund the outputs. The cost of an input is the fee of the largest input
+// that spends one of the unspent outputs.
+func coinSelection(strategy sharedW.CoinSelectionStrategy, unspents []*sharedW.UnspentOutput,
+	outputs []*wire.TxOut, changeScriptSize int, feeRate ltcutil.Amount) *sharedW.CoinSelection {
+	var inputSize int
+	for _, unspent := range unspents {
+		script, err := hex.DecodeString(unspent.ScriptPubKey)
+		if err != nil {
+			continue
+		}
+		if size := txsizes.GetMinInputVirtualSize(script); size > inputSize {
+			inputSize = size
+		}
+	}
+
+	txSize := txsizes.EstimateVirtualSize(0, 0, 0, 0, outputs, changeScriptSize)
+	changeSize := txsizes.EstimateVirtualSize(0, 0, 0, 0, nil, changeScriptSize) -
+		txsizes.EstimateVirtualSize(0, 0, 0, 0, nil, 0)
+	inputCost := txrules.FeeForSerializeSize(feeRate, inputSize)
+
+	return &sharedW.CoinSelection{
+		Strategy:     strategy,
+		Target:       int64(txauthor.SumOutputValues(outputs) + txrules.FeeForSerializeSize(feeRate, txSize)),
+		InputCost:    int64(inputCost),
+		CostOfChange: int64(txrules.FeeForSerializeSize(feeRate, changeSize) + inputCost),
+	}
+}
+
 // changeSource derives an internal address from the source wallet and account
 // for this unsigned tx, if a change address had not been previously derived.
 // The derived (or previously derived) address is used to prepare a
@@ -453,7 +563,8 @@ func (asset *Asset) constructTransaction() (*txauthor.AuthoredTx, error) {
 func (asset *Asset) changeSource() (*txauthor.ChangeSource, error) {
 	if asset.TxAuthoredInfo.changeAddress == "" {
 		changeAccount := asset.TxAuthoredInfo.sourceAccountNumber
-		address, err := asset.Internal().LTC.NewChangeAddress(changeAccount, GetScope())
+		scope, acct := accountScope(int32(changeAccount))
+		address, err := asset.Internal().LTC.NewChangeAddress(acct, scope)
 		if err != nil {
 			return nil, fmt.Errorf("change address error: %v", err)
 		}
@@ -503,28 +614,22 @@ func (asset *Asset) UpdateSendDestination(id int, address string, atomAmount int
 }
 
 // makeInputSource creates an InputSource that creates inputs for every unspent
-// output with non-zero output values. The importsource aims to create the leanest
-// transaction possible. It plans not to spend all the utxos available when servicing
-// the current transaction spending amount if possible. The sendMax shows that
-// all utxos must be spent without any balance(unspent utxo) left in the account.
-func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bool) txauthor.InputSource {
+// output with non-zero output values. Unless sendMax is set, the inputs that
+// fund the transaction are picked by the coin selection strategy, which plans
+// not to spend all the utxos available when servicing the current transaction
+// spending amount if possible. The sendMax shows that all utxos must be spent
+// without any balance(unspent utxo) left in the account.
+func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bool, selection *sharedW.CoinSelection) txauthor.InputSource {
 	var (
 		sourceErr       error
 		totalInputValue ltcutil.Amount
 
+		candidates  = make([]*sharedW.UnspentOutput, 0, len(outputs))
 		inputs      = make([]*wire.TxIn, 0, len(outputs))
 		inputValues = make([]ltcutil.Amount, 0, len(outputs))
 		pkScripts   = make([][]byte, 0, len(outputs))
 	)
 
-	// sorting is only necessary when send max is false.
-	if !sendMax {
-		// Sorts the outputs in the descending order (utxo with largest amount start)
-		// This descending order helps in selecting the least number of utxos needed
-		// in the servicing the transaction to be made.
-		sort.Slice(outputs, func(i, j int) bool { return outputs[i].Amount.ToCoin() > outputs[j].Amount.ToCoin() })
-	}
-
 	// validates the utxo amounts and if an invalid amount is discovered an
 	// error is returned.
 	for _, output := range outputs {
@@ -561,6 +666,7 @@ func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bo
 		}
 
 		totalInputValue += ltcutil.Amount(output.Amount.(Amount))
+		candidates = append(candidates, output)
 		pkScripts = append(pkScripts, script)
 		inputValues = append(inputValues, ltcutil.Amount(output.Amount.(Amount)))
 		inputs = append(inputs, wire.NewTxIn(previousOutPoint, nil, nil))
@@ -590,22 +696,28 @@ func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bo
 			return totalInputValue, inputs, inputValues, pkScripts, nil
 		}
 
-		var index int
-		var totalUtxo ltcutil.Amount
-
-		for _, utxoAmount := range inputValues {
-			if totalUtxo < target {
-				// Found some utxo(s) we can spend in the current tx.
-				index++
+		// The target already includes the fee of an input, the strategies
+		// add the cost of every input they select.
+		txSelection := *selection
+		if remaining := int64(target) - selection.InputCost; remaining > txSelection.Target {
+			txSelection.Target = remaining
+		}
 
-				totalUtxo += utxoAmount
-				continue
-			}
-			break
+		var totalUtxo ltcutil.Amount
+		selected, changeless := sharedW.SelectCoins(&txSelection, candidates)
+		selection.Changeless = changeless
+		selectedInputs := make([]*wire.TxIn, 0, len(selected))
+		selectedValues := make([]ltcutil.Amount, 0, len(selected))
+		selectedScripts := make([][]byte, 0, len(selected))
+		for _, i := range selected {
+			totalUtxo += inputValues[i]
+			selectedInputs = append(selectedInputs, inputs[i])
+			selectedValues = append(selectedValues, inputValues[i])
+			selectedScripts = append(selectedScripts, pkScripts[i])
 		}
-		asset.TxAuthoredInfo.inputs = inputs[:index]
-		asset.TxAuthoredInfo.inputValues = inputValues[:index]
-		return totalUtxo, inputs[:index], inputValues[:index], pkScripts[:index], nil
+		asset.TxAuthoredInfo.inputs = selectedInputs
+		asset.TxAuthoredInfo.inputValues = selectedValues
+		return totalUtxo, selectedInputs, selectedValues, selectedScripts, nil
 	}
 }
 
@@ -23,7 +23,10 @@ const (
 	MainnetHDPath = "m / 84' / 0' / "
 )
 
-var wAddrMgrBkt = []byte("waddrmgr")
+var (
+	wAddrMgrBkt = []byte("waddrmgr")
+	wTxMgrBkt   = []byte("wtxmgr")
+)
 
 // GetScope returns the key scope that will be used within the waddrmgr to
 // create an HD chain for deriving all of our required keys. A different
@@ -56,8 +59,8 @@ func (asset *Asset) ToAmount(v int64) sharedW.AssetAmount {
 }
 
 // DeriveAccountXpub derives the xpub for the given account.
-func (asset *Asset) DeriveAccountXpub(seedMnemonic string, wordSeedType sharedW.WordSeedType, account uint32, params *chaincfg.Params) (xpub string, err error) {
-	seed, err := sharedW.DecodeSeedMnemonic(seedMnemonic, asset.Type, wordSeedType)
+func (asset *Asset) DeriveAccountXpub(seedMnemonic, seedPassphrase string, wordSeedType sharedW.WordSeedType, account uint32, params *chaincfg.Params) (xpub string, err error) {
+	seed, err := sharedW.DecodeSeedMnemonic(seedMnemonic, asset.Type, wordSeedType, seedPassphrase)
 	if err != nil {
 		return "", err
 	}
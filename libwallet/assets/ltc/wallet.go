@@ -13,6 +13,7 @@ import (
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader/ltc"
+	"github.com/crypto-power/cryptopower/libwallet/internal/rescan"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/dcrlabs/ltcwallet/chain"
 	neutrino "github.com/dcrlabs/ltcwallet/spv"
@@ -44,6 +45,14 @@ type Asset struct {
 	chainParams    *ltcchaincfg.Params
 	TxAuthoredInfo *TxAuthor
 
+	// rpcConn and rpcClient connect the wallet to a litecoind node and
+	// electrumConn to an Electrum server, they are only set while syncing
+	// with the RPC or Electrum backend.
+	rpcMu        sync.RWMutex
+	rpcConn      *chain.BitcoindConn
+	rpcClient    *chain.BitcoindClient
+	electrumConn *electrumClient
+
 	cancelSync context.CancelFunc
 	syncCtx    context.Context
 
@@ -64,7 +73,12 @@ type Asset struct {
 
 	syncData                        *SyncData
 	txAndBlockNotificationListeners map[string]*sharedW.TxAndBlockNotificationListener
-	blocksRescanProgressListener    *sharedW.BlocksRescanProgressListener
+	rescanner                       *rescan.Rescanner
+
+	// multisigMu guards the address indexes of multisig wallets and
+	// multisigPaths, the paths of the multisig addresses derived so far.
+	multisigMu    sync.Mutex
+	multisigPaths map[string]multisigPath
 }
 
 const (
@@ -106,6 +120,7 @@ func CreateNewWallet(pass *sharedW.AuthInfo, params *sharedW.InitParams) (shared
 			syncProgressListeners: make(map[string]*sharedW.SyncProgressListener),
 		},
 		txAndBlockNotificationListeners: make(map[string]*sharedW.TxAndBlockNotificationListener),
+		rescanner:                       rescan.New(w, log),
 	}
 
 	if err := ltcWallet.prepareChain(); err != nil {
@@ -176,6 +191,7 @@ func CreateWatchOnlyWallet(walletName, extendedPublicKey string, params *sharedW
 			syncProgressListeners: make(map[string]*sharedW.SyncProgressListener),
 		},
 		txAndBlockNotificationListeners: make(map[string]*sharedW.TxAndBlockNotificationListener),
+		rescanner:                       rescan.New(w, log),
 	}
 
 	if err := ltcWallet.prepareChain(); err != nil {
@@ -213,6 +229,7 @@ func RestoreWallet(seedMnemonic string, pass *sharedW.AuthInfo, params *sharedW.
 			syncProgressListeners: make(map[string]*sharedW.SyncProgressListener),
 		},
 		txAndBlockNotificationListeners: make(map[string]*sharedW.TxAndBlockNotificationListener),
+		rescanner:                       rescan.New(w, log),
 	}
 
 	if err := ltcWallet.prepareChain(); err != nil {
@@ -248,6 +265,7 @@ func LoadExisting(w *sharedW.Wallet, params *sharedW.InitParams) (sharedW.Asset,
 			syncProgressListeners: make(map[string]*sharedW.SyncProgressListener),
 		},
 		txAndBlockNotificationListeners: make(map[string]*sharedW.TxAndBlockNotificationListener),
+		rescanner:                       rescan.New(w, log),
 	}
 
 	// w.EncryptedMnemonic was previously deleted after verification. Existing
@@ -341,8 +359,16 @@ func (asset *Asset) ConnectedPeers() int32 {
 	if !asset.IsConnectedToNetwork() {
 		return -1
 	}
+	if asset.remoteClient() != nil {
+		// The litecoind node or Electrum server is the only peer of the wallet.
+		return 1
+	}
+	chainService := asset.neutrinoService()
+	if chainService == nil {
+		return 0
+	}
 
-	return int32(len(asset.cl.Peers()))
+	return int32(len(chainService.Peers()))
 }
 
 // IsConnectedToNetwork returns true if the wallet is connected to the network.
@@ -352,16 +378,12 @@ func (asset *Asset) IsConnectedToNetwork() bool {
 
 // GetBestBlock returns the best block.
 func (asset *Asset) GetBestBlock() *sharedW.BlockInfo {
-	block, err := asset.chainClient.CS.BestBlock()
+	block, err := asset.bestBlock()
 	if err != nil {
 		log.Error("GetBestBlock hash for LTC failed, Err: ", err)
 		return sharedW.InvalidBlock
 	}
-
-	return &sharedW.BlockInfo{
-		Height:    block.Height,
-		Timestamp: block.Timestamp.Unix(),
-	}
+	return block
 }
 
 // GetBestBlockHeight returns the best block height.
@@ -376,7 +398,7 @@ func (asset *Asset) GetBestBlockTimeStamp() int64 {
 
 // GetBlockHeight returns the block height for the given block hash.
 func (asset *Asset) GetBlockHeight(hash chainhash.Hash) (int32, error) {
-	height, err := asset.chainClient.GetBlockHeight(&hash)
+	height, err := asset.blockHeight(&hash)
 	if err != nil {
 		log.Warn("GetBlockHeight for LTC failed, Err: %v", err)
 		return -1, err
@@ -386,7 +408,12 @@ func (asset *Asset) GetBlockHeight(hash chainhash.Hash) (int32, error) {
 
 // GetBlockHash returns the block hash for the given block height.
 func (asset *Asset) GetBlockHash(height int64) (*chainhash.Hash, error) {
-	blockhash, err := asset.chainClient.GetBlockHash(height)
+	chainClient := asset.chainSource()
+	if chainClient == nil {
+		return nil, errors.New(utils.ErrNotConnected)
+	}
+
+	blockhash, err := chainClient.GetBlockHash(height)
 	if err != nil {
 		log.Warn("GetBlockHash for LTC failed, Err: %v", err)
 		return nil, err
@@ -505,16 +532,16 @@ func (asset *Asset) SetSpecificPeer(addresses string) {
 }
 
 // GetExtendedPubKey returns the extended public key of the given account, to do
-// that it calls LTCwallet's AccountProperties method, using
-// KeyScopeBIP0084WithBitcoinCoinID and the account number. On failure it
-// returns error.
+// that it calls LTCwallet's AccountProperties method, using the key scope of
+// the account and the account number. On failure it returns error.
 func (asset *Asset) GetExtendedPubKey(account int32) (string, error) {
 	loadedAsset := asset.Internal().LTC
 	if loadedAsset == nil {
 		return "", utils.ErrLTCNotInitialized
 	}
 
-	extendedPublicKey, err := loadedAsset.AccountProperties(GetScope(), uint32(account))
+	scope, acct := accountScope(account)
+	extendedPublicKey, err := loadedAsset.AccountProperties(scope, acct)
 	if err != nil {
 		return "", err
 	}
@@ -524,7 +551,8 @@ func (asset *Asset) GetExtendedPubKey(account int32) (string, error) {
 // AccountXPubMatches checks if the xpub of the provided account matches the
 // provided xpub.
 func (asset *Asset) AccountXPubMatches(account uint32, xPub string) (bool, error) {
-	acctXPubKey, err := asset.Internal().LTC.AccountProperties(GetScope(), account)
+	scope, acct := accountScope(int32(account))
+	acctXPubKey, err := asset.Internal().LTC.AccountProperties(scope, acct)
 	if err != nil {
 		return false, err
 	}
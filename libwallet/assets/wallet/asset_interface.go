@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"context"
+	"io"
 
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
@@ -17,13 +18,30 @@ type Asset interface {
 	CancelSync()
 	IsRescanning() bool
 	RescanBlocks() error
+	RescanWithOptions(opts *RescanOptions) error
+	EstimateRescan(opts *RescanOptions) (*RescanEstimate, error)
 	ConnectedPeers() int32
 	RemovePeers()
 	SetSpecificPeer(address string)
+	PeerInfoRaw() ([]PeerInfo, error)
+	DisconnectPeer(addr string) error
+	BanPeer(addr string) error
+	AddPeer(addr string) error
+	ChainBackend() ChainBackend
+	RPCConfig() *RPCConfig
+	RPCPassLocked() bool
+	SetRPCPass(pass string) error
+	RPCUsesTLS() bool
+	SetChainBackend(backend ChainBackend, cfg *RPCConfig, privatePassphrase string) error
+	ElectrumConfig() *ElectrumConfig
+	SaveElectrumConfig(cfg *ElectrumConfig) error
 	GetExtendedPubKey(account int32) (string, error)
 	IsSyncShuttingDown() bool
 	EnableSyncShuttingDown()
 	EndSyncShuttingDown()
+	DataUsage() DataUsage
+	ResetDataUsage()
+	SyncCheckpoint() SyncCheckpoint
 
 	LockWallet()
 	IsLocked() bool
@@ -37,7 +55,10 @@ type Asset interface {
 	DeleteWallet(privPass string) error
 	RenameWallet(newName string) error
 	DecryptSeed(privatePassphrase string) (string, error)
-	VerifySeedForWallet(seedMnemonic, privpass string) (bool, error)
+	WalletHasSeedPassphrase() bool
+	VerifySeedForWallet(seedMnemonic, seedPassphrase, privpass string) (bool, error)
+	SeedShares(privatePassphrase string, groupThreshold int, groups []SeedShareGroup) ([][]string, error)
+	VerifySeedSharesForWallet(shares []string, seedPassphrase, privpass string) (bool, error)
 	ChangePrivatePassphraseForWallet(oldPrivatePassphrase, newPrivatePassphrase string, privatePassphraseType int32) error
 
 	RootDir() string
@@ -59,6 +80,17 @@ type Asset interface {
 	GetTransactionRaw(txHash string) (*Transaction, error)
 	TxMatchesFilter(tx *Transaction, txFilter int32) bool
 	GetTransactionsRaw(offset, limit, txFilter int32, newestFirst bool, txHashSearch string) ([]*Transaction, error)
+	SetTransactionLabel(txHash, label string) error
+	TransactionLabel(txHash string) string
+	SetAddressLabel(address, label string) error
+	AddressLabel(address string) string
+	TxSearchMatcher(search string) func(tx *Transaction) bool
+	SetOutputLabel(txHash string, index uint32, label string) error
+	OutputLabel(txHash string, index uint32) string
+	SetOutputFrozen(txHash string, index uint32, frozen bool) error
+	IsOutputFrozen(txHash string, index uint32) bool
+	ExportLabels(w io.Writer) error
+	ImportLabels(r io.Reader) (int, error)
 
 	GetBestBlock() *BlockInfo
 	GetBestBlockHeight() int32
@@ -117,4 +149,12 @@ type Asset interface {
 	RemoveSendDestination(id int)
 	SendDestination(id int) *TransactionDestination
 	UpdateSendDestination(id int, address string, atomAmount int64, sendMax bool) error
+	SetCoinSelectionStrategy(strategy CoinSelectionStrategy) error
+	DefaultCoinSelectionStrategy() CoinSelectionStrategy
+	SetDefaultCoinSelectionStrategy(strategy CoinSelectionStrategy) error
+
+	DustThreshold() int64
+	SetDustThreshold(threshold int64) error
+	AutoFreezeDust() bool
+	SetAutoFreezeDust(enabled bool)
 }
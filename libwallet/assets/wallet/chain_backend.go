@@ -0,0 +1,309 @@
+package wallet
+
+import (
+	"math/rand"
+	"os"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/internal/electrum"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// ChainBackend is the source of the blockchain data a wallet syncs with.
+type ChainBackend string
+
+const (
+	// SPVBackend syncs the wallet with peers of the P2P network using compact
+	// block filters. It is the default backend.
+	SPVBackend ChainBackend = "spv"
+
+	// RPCBackend syncs the wallet with a full node run by the user, over the
+	// JSON-RPC interface of the node: dcrd for DCR wallets, bitcoind and
+	// litecoind for BTC and LTC wallets.
+	RPCBackend ChainBackend = "rpc"
+
+	// ElectrumBackend syncs the wallet with an Electrum server, such as
+	// ElectrumX or Fulcrum, which indexes the history of addresses. Only BTC
+	// and LTC wallets support it.
+	ElectrumBackend ChainBackend = "electrum"
+)
+
+// RPCConfig holds the connection settings of the full node used by the
+// RPCBackend.
+type RPCConfig struct {
+	// Host is the address of the RPC server of the node, the default RPC port
+	// of the network is used if no port is given.
+	Host string
+	User string
+	Pass string
+	// CertPath is the path to the TLS certificate of the RPC server. Only
+	// dcrd serves RPC over TLS, BTC and LTC wallets reject a certificate.
+	CertPath string
+}
+
+// Address returns the host and port of the RPC server.
+func (cfg *RPCConfig) Address(defaultPort string) (string, error) {
+	return utils.NormalizeAddress(cfg.Host, defaultPort)
+}
+
+// ReadCert returns the TLS certificate of the RPC server, nil if no
+// certificate is set.
+func (cfg *RPCConfig) ReadCert() ([]byte, error) {
+	if cfg.CertPath == "" {
+		return nil, nil
+	}
+	return os.ReadFile(cfg.CertPath)
+}
+
+// ElectrumConfig holds the server settings of the ElectrumBackend.
+type ElectrumConfig struct {
+	// Server is the address of the Electrum server as "ssl://host:port" or
+	// "tcp://host:port". A server is picked from the default servers of the
+	// network if none is set.
+	Server string
+	// CertFingerprint is the sha256 fingerprint of the TLS certificate to pin
+	// for the server, hex encoded.
+	CertFingerprint string
+}
+
+// Servers returns the servers to try in order: the server set, or the default
+// servers given shuffled for wallets not to all pick the same server.
+func (cfg *ElectrumConfig) Servers(defaults []string) ([]*electrum.Server, error) {
+	if cfg.Server != "" {
+		server, err := electrum.ParseServer(cfg.Server, cfg.CertFingerprint)
+		if err != nil {
+			return nil, err
+		}
+		return []*electrum.Server{server}, nil
+	}
+
+	servers := make([]*electrum.Server, 0, len(defaults))
+	for _, addr := range defaults {
+		server, err := electrum.ParseServer(addr, "")
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, server)
+	}
+	rand.Shuffle(len(servers), func(i, j int) {
+		servers[i], servers[j] = servers[j], servers[i]
+	})
+	return servers, nil
+}
+
+// ChainBackend returns the backend the wallet syncs with.
+func (wallet *Wallet) ChainBackend() ChainBackend {
+	backend := ChainBackend(wallet.ReadStringConfigValueForKey(ChainBackendConfigKey, string(SPVBackend)))
+	switch backend {
+	case RPCBackend, ElectrumBackend:
+		return backend
+	default:
+		return SPVBackend
+	}
+}
+
+// RPCConfig returns the connection settings of the full node used when the
+// wallet syncs with the RPCBackend. The password is only set once the wallet
+// has been unlocked, or once it is entered again for watching only wallets,
+// see RPCPassLocked.
+func (wallet *Wallet) RPCConfig() *RPCConfig {
+	wallet.rpcPassMu.Lock()
+	pass := wallet.rpcPass
+	wallet.rpcPassMu.Unlock()
+
+	return &RPCConfig{
+		Host:     wallet.ReadStringConfigValueForKey(RPCHostConfigKey, ""),
+		User:     wallet.ReadStringConfigValueForKey(RPCUserConfigKey, ""),
+		Pass:     pass,
+		CertPath: wallet.ReadStringConfigValueForKey(RPCCertConfigKey, ""),
+	}
+}
+
+// encryptedRPCPass returns the RPC password saved encrypted with the private
+// passphrase, nil if no password is saved.
+func (wallet *Wallet) encryptedRPCPass() []byte {
+	var encryptedPass []byte
+	if err := wallet.ReadUserConfigValue(RPCPassConfigKey, &encryptedPass); err != nil {
+		return nil
+	}
+	return encryptedPass
+}
+
+// RPCPassLocked returns true if the full node requires an RPC password that
+// the wallet doesn't hold since it was loaded. The wallet can't sync with the
+// RPCBackend until UnlockWallet decrypts the saved password or, for watching
+// only wallets which don't save it, until SetRPCPass sets it again.
+func (wallet *Wallet) RPCPassLocked() bool {
+	wallet.rpcPassMu.Lock()
+	defer wallet.rpcPassMu.Unlock()
+	if wallet.rpcPass != "" {
+		return false
+	}
+	return wallet.encryptedRPCPass() != nil || wallet.ReadBoolConfigValueForKey(RPCPassRequiredConfigKey, false)
+}
+
+// SetRPCPass sets the RPC password of the full node of a watching only wallet
+// until the wallet is shut down. Watching only wallets have no private
+// passphrase to save the password encrypted with, it is entered again
+// whenever RPCPassLocked returns true.
+func (wallet *Wallet) SetRPCPass(pass string) error {
+	if !wallet.IsWatchingOnlyWallet() {
+		return errors.E(errors.Invalid, "the RPC password of a wallet with a seed is saved encrypted, see SaveChainBackend")
+	}
+	if pass == "" {
+		return errors.E(errors.Invalid, "the RPC password is required")
+	}
+
+	wallet.rpcPassMu.Lock()
+	wallet.rpcPass = pass
+	wallet.rpcPassMu.Unlock()
+	return nil
+}
+
+// unlockRPCPass decrypts the saved RPC password with the private passphrase
+// and keeps it in memory for the next connections to the full node.
+func (wallet *Wallet) unlockRPCPass(privPass []byte) {
+	encryptedPass := wallet.encryptedRPCPass()
+	if encryptedPass == nil {
+		return
+	}
+
+	pass, err := decryptWalletMnemonic(privPass, encryptedPass)
+	if err != nil {
+		log.Errorf("error decrypting the RPC password of wallet-[%d]: %v", wallet.ID, err)
+		return
+	}
+
+	wallet.rpcPassMu.Lock()
+	wallet.rpcPass = pass
+	wallet.rpcPassMu.Unlock()
+}
+
+// reencryptRPCPass returns the saved RPC password encrypted with the new
+// private passphrase, nil if no password is saved.
+func (wallet *Wallet) reencryptRPCPass(oldPass, newPass []byte) ([]byte, error) {
+	encryptedPass := wallet.encryptedRPCPass()
+	if encryptedPass == nil {
+		return nil, nil
+	}
+
+	pass, err := decryptWalletMnemonic(oldPass, encryptedPass)
+	if err != nil {
+		return nil, err
+	}
+	return encryptWalletMnemonic(newPass, pass)
+}
+
+// saveRPCPass saves the RPC password encrypted with the private passphrase.
+// Watching only wallets only keep it in memory and save that a password is
+// required, for it to be asked for once they are loaded again. An empty
+// password clears the saved password.
+func (wallet *Wallet) saveRPCPass(pass, privatePassphrase string) error {
+	var encryptedPass []byte
+	if pass != "" && !wallet.IsWatchingOnlyWallet() {
+		// Unlocking the wallet checks the private passphrase.
+		wasLocked := wallet.IsLocked()
+		if err := wallet.UnlockWallet(privatePassphrase); err != nil {
+			return err
+		}
+		if wasLocked {
+			wallet.LockWallet()
+		}
+
+		var err error
+		encryptedPass, err = encryptWalletMnemonic([]byte(privatePassphrase), pass)
+		if err != nil {
+			return err
+		}
+	}
+
+	if encryptedPass != nil {
+		wallet.SaveUserConfigValue(RPCPassConfigKey, encryptedPass)
+	} else {
+		wallet.DeleteUserConfigValueForKey(RPCPassConfigKey)
+	}
+	wallet.SetBoolConfigValueForKey(RPCPassRequiredConfigKey, pass != "" && wallet.IsWatchingOnlyWallet())
+
+	wallet.rpcPassMu.Lock()
+	wallet.rpcPass = pass
+	wallet.rpcPassMu.Unlock()
+	return nil
+}
+
+// SaveChainBackend validates and saves the backend the wallet syncs with. The
+// connection settings are required by the RPCBackend and kept otherwise, for
+// the user to switch back to the node later. The RPC password is only set
+// with the RPCBackend, it is saved encrypted with the private passphrase.
+// Watching only wallets have no private passphrase, they keep the password
+// until they are shut down and ask for it again, see SetRPCPass. The backend is used from the next sync, assets
+// restart an active sync in SetChainBackend.
+func (wallet *Wallet) SaveChainBackend(backend ChainBackend, cfg *RPCConfig, privatePassphrase string) error {
+	switch backend {
+	case SPVBackend:
+	case RPCBackend:
+		if cfg == nil || cfg.Host == "" {
+			return errors.E(errors.Invalid, "the RPC host is required")
+		}
+		if _, err := cfg.Address("0"); err != nil {
+			return errors.E(errors.Invalid, err)
+		}
+		if cfg.CertPath != "" && !wallet.RPCUsesTLS() {
+			return utils.ErrRPCCertUnsupported
+		}
+		if _, err := cfg.ReadCert(); err != nil {
+			return errors.E(errors.Invalid, err)
+		}
+	case ElectrumBackend:
+		if wallet.Type == utils.DCRWalletAsset {
+			return errors.E(errors.Invalid, "electrum servers are not supported by DCR wallets")
+		}
+	default:
+		return errors.New(utils.ErrInvalid)
+	}
+
+	if cfg != nil {
+		wallet.SetStringConfigValueForKey(RPCHostConfigKey, cfg.Host)
+		wallet.SetStringConfigValueForKey(RPCUserConfigKey, cfg.User)
+		wallet.SetStringConfigValueForKey(RPCCertConfigKey, cfg.CertPath)
+	}
+	if backend == RPCBackend {
+		if err := wallet.saveRPCPass(cfg.Pass, privatePassphrase); err != nil {
+			return err
+		}
+	}
+	wallet.SetStringConfigValueForKey(ChainBackendConfigKey, string(backend))
+	return nil
+}
+
+// RPCUsesTLS returns true if the RPC server of the full node of the wallet
+// serves TLS and takes a certificate. Only dcrd does, bitcoind and litecoind
+// serve RPC without TLS.
+func (wallet *Wallet) RPCUsesTLS() bool {
+	return wallet.Type == utils.DCRWalletAsset
+}
+
+// ElectrumConfig returns the server settings used when the wallet syncs with
+// the ElectrumBackend.
+func (wallet *Wallet) ElectrumConfig() *ElectrumConfig {
+	return &ElectrumConfig{
+		Server:          wallet.ReadStringConfigValueForKey(ElectrumServerConfigKey, ""),
+		CertFingerprint: wallet.ReadStringConfigValueForKey(ElectrumCertPinConfigKey, ""),
+	}
+}
+
+// SaveElectrumConfig validates and saves the server settings of the
+// ElectrumBackend. They are used from the next connection to a server, call
+// SetChainBackend to switch to or restart the sync with the backend.
+func (wallet *Wallet) SaveElectrumConfig(cfg *ElectrumConfig) error {
+	if cfg.Server != "" {
+		if _, err := electrum.ParseServer(cfg.Server, cfg.CertFingerprint); err != nil {
+			return errors.E(errors.Invalid, err)
+		}
+	} else if cfg.CertFingerprint != "" {
+		return errors.E(errors.Invalid, "a certificate can only be pinned for a given server")
+	}
+
+	wallet.SetStringConfigValueForKey(ElectrumServerConfigKey, cfg.Server)
+	wallet.SetStringConfigValueForKey(ElectrumCertPinConfigKey, cfg.CertFingerprint)
+	return nil
+}
@@ -0,0 +1,296 @@
+package wallet
+
+import (
+	"sort"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// CoinSelectionStrategy is the algorithm used to pick the unspent outputs
+// that fund a transaction when the outputs are not selected manually.
+type CoinSelectionStrategy string
+
+const (
+	// CoinSelectionLargestFirst spends the largest outputs first, it uses the
+	// fewest inputs. It is the default strategy.
+	CoinSelectionLargestFirst CoinSelectionStrategy = "largest_first"
+	// CoinSelectionOldestFirst spends the outputs with the most confirmations
+	// first.
+	CoinSelectionOldestFirst CoinSelectionStrategy = "oldest_first"
+	// CoinSelectionBranchAndBound looks for a set of outputs that pays the
+	// transaction without a change output, and spends the largest outputs
+	// first if there is none.
+	CoinSelectionBranchAndBound CoinSelectionStrategy = "branch_and_bound"
+	// CoinSelectionPrivacy avoids spending outputs of different addresses or
+	// labels together, which would link them on chain.
+	CoinSelectionPrivacy CoinSelectionStrategy = "privacy"
+
+	// bnbMaxTries bounds the number of branches explored by the branch and
+	// bound search.
+	bnbMaxTries = 100000
+)
+
+// CoinSelectionStrategies returns the supported coin selection strategies.
+func CoinSelectionStrategies() []CoinSelectionStrategy {
+	return []CoinSelectionStrategy{
+		CoinSelectionLargestFirst,
+		CoinSelectionOldestFirst,
+		CoinSelectionBranchAndBound,
+		CoinSelectionPrivacy,
+	}
+}
+
+// IsValid returns true if the strategy is supported.
+func (strategy CoinSelectionStrategy) IsValid() bool {
+	for _, s := range CoinSelectionStrategies() {
+		if s == strategy {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultCoinSelectionStrategy returns the coin selection strategy used for
+// the transactions of the wallet unless another one is set for a transaction.
+func (wallet *Wallet) DefaultCoinSelectionStrategy() CoinSelectionStrategy {
+	strategy := CoinSelectionStrategy(wallet.ReadStringConfigValueForKey(CoinSelectionStrategyConfigKey, ""))
+	if !strategy.IsValid() {
+		return CoinSelectionLargestFirst
+	}
+	return strategy
+}
+
+// SetDefaultCoinSelectionStrategy sets the coin selection strategy used for
+// the transactions of the wallet.
+func (wallet *Wallet) SetDefaultCoinSelectionStrategy(strategy CoinSelectionStrategy) error {
+	if !strategy.IsValid() {
+		return errors.New(utils.ErrInvalid)
+	}
+	wallet.SetStringConfigValueForKey(CoinSelectionStrategyConfigKey, string(strategy))
+	return nil
+}
+
+// CoinSelection holds the values that the coin selection strategies need.
+// Amounts are in the smallest unit of the asset. The strategies compare the
+// effective value of the outputs, which is their amount less InputCost.
+type CoinSelection struct {
+	Strategy CoinSelectionStrategy
+	// Target is the effective value that the selected outputs must at least
+	// pay, it includes the fee of the transaction without inputs.
+	Target int64
+	// InputCost is the fee paid to spend an output.
+	InputCost int64
+	// CostOfChange is the fee paid to create a change output and spend it
+	// later. A changeless selection may pay up to this amount over the
+	// target as extra fee.
+	CostOfChange int64
+	// Changeless is set by the input sources of the assets once the outputs
+	// are selected, it's the changeless result of SelectCoins.
+	Changeless bool
+}
+
+// SelectCoins returns the indexes of the outputs to spend, in spending order.
+// If the outputs cannot pay the target, the indexes of all the outputs are
+// returned so that the caller can report the missing funds. changeless is
+// true if the branch and bound search found the outputs, they pay the target
+// with at most CostOfChange over it and the change, if any, is meant to be
+// paid as fee. It's false when the search finds nothing and the largest
+// outputs are selected instead.
+func SelectCoins(selection *CoinSelection, utxos []*UnspentOutput) (selected []int, changeless bool) {
+	switch selection.Strategy {
+	case CoinSelectionOldestFirst:
+		return selectOldestFirst(selection, utxos), false
+	case CoinSelectionBranchAndBound:
+		if selected := selectBranchAndBound(selection, utxos); selected != nil {
+			return selected, true
+		}
+	case CoinSelectionPrivacy:
+		return selectPrivately(selection, utxos), false
+	}
+	return selectLargestFirst(selection, utxos), false
+}
+
+func utxoAmount(utxo *UnspentOutput) int64 {
+	if utxo.Amount == nil {
+		return 0
+	}
+	return utxo.Amount.ToInt()
+}
+
+// accumulate returns the shortest prefix of the ordered indexes that pays the
+// target, or all of them if the target cannot be paid.
+func accumulate(selection *CoinSelection, utxos []*UnspentOutput, order []int) []int {
+	var total int64
+	for i, index := range order {
+		total += utxoAmount(utxos[index]) - selection.InputCost
+		if total >= selection.Target {
+			return order[:i+1]
+		}
+	}
+	return order
+}
+
+// indexes returns the indexes of the outputs sorted with less, ties are
+// broken by the position of the outputs to keep the selection deterministic.
+func indexes(utxos []*UnspentOutput, less func(a, b *UnspentOutput) bool) []int {
+	order := make([]int, len(utxos))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return less(utxos[order[i]], utxos[order[j]])
+	})
+	return order
+}
+
+func largerAmount(a, b *UnspentOutput) bool {
+	return utxoAmount(a) > utxoAmount(b)
+}
+
+func selectLargestFirst(selection *CoinSelection, utxos []*UnspentOutput) []int {
+	return accumulate(selection, utxos, indexes(utxos, largerAmount))
+}
+
+func selectOldestFirst(selection *CoinSelection, utxos []*UnspentOutput) []int {
+	order := indexes(utxos, func(a, b *UnspentOutput) bool {
+		if a.Confirmations != b.Confirmations {
+			return a.Confirmations > b.Confirmations
+		}
+		return a.ReceiveTime.Before(b.ReceiveTime)
+	})
+	return accumulate(selection, utxos, order)
+}
+
+// selectBranchAndBound searches the combination of outputs whose value, less
+// the cost of spending them, pays the target with less waste than a change
+// output would cost. The search is depth first over the outputs sorted by
+// value, including an output before excluding it. nil is returned if there
+// is no such combination.
+func selectBranchAndBound(selection *CoinSelection, utxos []*UnspentOutput) []int {
+	target := selection.Target
+	upperBound := target + selection.CostOfChange
+
+	// Outputs that cost more to spend than they are worth are left out.
+	candidates := make([]int, 0, len(utxos))
+	values := make([]int64, 0, len(utxos))
+	var available int64
+	for _, index := range indexes(utxos, largerAmount) {
+		value := utxoAmount(utxos[index]) - selection.InputCost
+		if value <= 0 {
+			continue
+		}
+		candidates = append(candidates, index)
+		values = append(values, value)
+		available += value
+	}
+	if available < target {
+		return nil
+	}
+
+	var (
+		selected  = make([]bool, len(candidates))
+		best      []bool
+		bestWaste int64 = -1
+		total     int64
+		depth     int
+	)
+
+	for tries := 0; tries < bnbMaxTries; tries++ {
+		backtrack := false
+		switch {
+		case total+available < target, total > upperBound:
+			// The branch cannot pay the target or pays too much.
+			backtrack = true
+		case total >= target:
+			if waste := total - target; bestWaste < 0 || waste < bestWaste {
+				best = append(best[:0], selected...)
+				bestWaste = waste
+				if waste == 0 {
+					tries = bnbMaxTries // exact match
+				}
+			}
+			backtrack = true
+		}
+
+		if backtrack {
+			// Go back to the last included output and exclude it instead.
+			for depth--; depth >= 0 && !selected[depth]; depth-- {
+				available += values[depth]
+			}
+			if depth < 0 {
+				break // every branch was explored
+			}
+			selected[depth] = false
+			total -= values[depth]
+			depth++
+			continue
+		}
+
+		// Include the output at this depth and go deeper.
+		available -= values[depth]
+		selected[depth] = true
+		total += values[depth]
+		depth++
+	}
+
+	if best == nil {
+		return nil
+	}
+
+	chosen := make([]int, 0)
+	for i, ok := range best {
+		if ok {
+			chosen = append(chosen, candidates[i])
+		}
+	}
+	return chosen
+}
+
+// selectPrivately groups the outputs by label, or by address if they have no
+// label, and spends the outputs of as few groups as possible. The smallest
+// group that pays the target is preferred, otherwise the largest groups are
+// spent first.
+func selectPrivately(selection *CoinSelection, utxos []*UnspentOutput) []int {
+	type group struct {
+		total   int64
+		members []int
+	}
+
+	groups := make([]*group, 0)
+	groupOf := make(map[string]*group)
+	for _, index := range indexes(utxos, largerAmount) {
+		key := "addr:" + utxos[index].Address
+		if utxos[index].Label != "" {
+			key = "label:" + utxos[index].Label
+		}
+
+		g, ok := groupOf[key]
+		if !ok {
+			g = &group{}
+			groupOf[key] = g
+			groups = append(groups, g)
+		}
+		g.total += utxoAmount(utxos[index]) - selection.InputCost
+		g.members = append(g.members, index)
+	}
+
+	var smallest *group
+	for _, g := range groups {
+		if g.total >= selection.Target && (smallest == nil || g.total < smallest.total) {
+			smallest = g
+		}
+	}
+	if smallest != nil {
+		return accumulate(selection, utxos, smallest.members)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].total > groups[j].total
+	})
+	order := make([]int, 0, len(utxos))
+	for _, g := range groups {
+		order = append(order, g.members...)
+	}
+	return accumulate(selection, utxos, order)
+}
@@ -0,0 +1,242 @@
+package wallet
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testAmount is an AssetAmount in the smallest unit of a test asset.
+type testAmount int64
+
+func (a testAmount) ToCoin() float64              { return float64(a) / 1e8 }
+func (a testAmount) String() string               { return fmt.Sprintf("%d", int64(a)) }
+func (a testAmount) MulF64(f float64) AssetAmount { return testAmount(float64(a) * f) }
+func (a testAmount) ToInt() int64                 { return int64(a) }
+
+// testUTXO returns an unspent output of the amount. The output is received
+// at a time derived from the confirmations so that older outputs sort first.
+func testUTXO(amount int64, address, label string, confirmations int32) *UnspentOutput {
+	return &UnspentOutput{
+		TxID:          fmt.Sprintf("%064x", amount),
+		Address:       address,
+		Label:         label,
+		Amount:        testAmount(amount),
+		Confirmations: confirmations,
+		Spendable:     true,
+		ReceiveTime:   time.Unix(1700000000-int64(confirmations)*600, 0),
+	}
+}
+
+func amountsUTXOs(amounts ...int64) []*UnspentOutput {
+	utxos := make([]*UnspentOutput, len(amounts))
+	for i, amount := range amounts {
+		utxos[i] = testUTXO(amount, fmt.Sprintf("addr%d", i), "", 1)
+	}
+	return utxos
+}
+
+func TestSelectLargestFirst(t *testing.T) {
+	utxos := amountsUTXOs(10, 50, 30, 20)
+	tests := []struct {
+		name      string
+		target    int64
+		inputCost int64
+		want      []int
+	}{{
+		name:   "single largest output",
+		target: 50,
+		want:   []int{1},
+	}, {
+		name:   "two largest outputs",
+		target: 60,
+		want:   []int{1, 2},
+	}, {
+		name:      "input cost needs another output",
+		target:    75,
+		inputCost: 5,
+		want:      []int{1, 2, 3},
+	}, {
+		name:   "insufficient funds returns all outputs",
+		target: 1000,
+		want:   []int{1, 2, 3, 0},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selection := &CoinSelection{
+				Strategy:  CoinSelectionLargestFirst,
+				Target:    test.target,
+				InputCost: test.inputCost,
+			}
+			if got, _ := SelectCoins(selection, utxos); !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSelectOldestFirst(t *testing.T) {
+	utxos := []*UnspentOutput{
+		testUTXO(40, "addr0", "", 1),
+		testUTXO(10, "addr1", "", 100),
+		testUTXO(30, "addr2", "", 20),
+		testUTXO(20, "addr3", "", 100),
+	}
+	// Outputs with the same confirmations are ordered by receive time.
+	utxos[3].ReceiveTime = utxos[1].ReceiveTime.Add(-time.Minute)
+
+	tests := []struct {
+		name   string
+		target int64
+		want   []int
+	}{{
+		name:   "oldest output",
+		target: 15,
+		want:   []int{3},
+	}, {
+		name:   "oldest outputs",
+		target: 55,
+		want:   []int{3, 1, 2},
+	}, {
+		name:   "insufficient funds returns all outputs",
+		target: 1000,
+		want:   []int{3, 1, 2, 0},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selection := &CoinSelection{Strategy: CoinSelectionOldestFirst, Target: test.target}
+			if got, _ := SelectCoins(selection, utxos); !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSelectBranchAndBound(t *testing.T) {
+	tests := []struct {
+		name         string
+		amounts      []int64
+		target       int64
+		inputCost    int64
+		costOfChange int64
+		want         []int
+		changeless   bool
+	}{{
+		name:       "exact match",
+		amounts:    []int64{100, 60, 40, 35, 25},
+		target:     75,
+		want:       []int{2, 3},
+		changeless: true,
+	}, {
+		name:         "match within the cost of change",
+		amounts:      []int64{100, 60, 40, 35, 25},
+		target:       70,
+		costOfChange: 6,
+		want:         []int{2, 3},
+		changeless:   true,
+	}, {
+		name:         "least waste",
+		amounts:      []int64{25, 35, 40, 60, 100},
+		target:       84,
+		costOfChange: 20,
+		want:         []int{3, 0},
+		changeless:   true,
+	}, {
+		name:       "effective values",
+		amounts:    []int64{100, 60, 40, 35, 25},
+		target:     65,
+		inputCost:  5,
+		want:       []int{2, 3},
+		changeless: true,
+	}, {
+		name:       "uneconomic outputs are skipped",
+		amounts:    []int64{4, 30, 4, 20},
+		target:     40,
+		inputCost:  5,
+		want:       []int{1, 3},
+		changeless: true,
+	}, {
+		name:    "no match falls back to largest first",
+		amounts: []int64{100, 60, 40, 35, 25},
+		target:  70,
+		want:    []int{0},
+	}, {
+		name:    "insufficient funds returns all outputs",
+		amounts: []int64{10, 20},
+		target:  100,
+		want:    []int{1, 0},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selection := &CoinSelection{
+				Strategy:     CoinSelectionBranchAndBound,
+				Target:       test.target,
+				InputCost:    test.inputCost,
+				CostOfChange: test.costOfChange,
+			}
+			got, changeless := SelectCoins(selection, amountsUTXOs(test.amounts...))
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("got %v, want %v", got, test.want)
+			}
+			// The change of the largest first fallback isn't paid as fee.
+			if changeless != test.changeless {
+				t.Fatalf("got changeless %v, want %v", changeless, test.changeless)
+			}
+		})
+	}
+}
+
+func TestSelectPrivately(t *testing.T) {
+	utxos := []*UnspentOutput{
+		testUTXO(50, "addrA", "", 1),
+		testUTXO(30, "addrA", "", 1),
+		testUTXO(70, "addrB", "", 1),
+		testUTXO(15, "addrC", "savings", 1),
+		testUTXO(10, "addrD", "savings", 1),
+	}
+	tests := []struct {
+		name   string
+		target int64
+		want   []int
+	}{{
+		name:   "smallest group that pays the target",
+		target: 60,
+		want:   []int{2},
+	}, {
+		name:   "outputs of the same address",
+		target: 75,
+		want:   []int{0, 1},
+	}, {
+		name:   "outputs with the same label",
+		target: 20,
+		want:   []int{3, 4},
+	}, {
+		name:   "largest groups first",
+		target: 120,
+		want:   []int{0, 1, 2},
+	}, {
+		name:   "insufficient funds returns all outputs",
+		target: 1000,
+		want:   []int{0, 1, 2, 3, 4},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selection := &CoinSelection{Strategy: CoinSelectionPrivacy, Target: test.target}
+			if got, _ := SelectCoins(selection, utxos); !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCoinSelectionStrategyIsValid(t *testing.T) {
+	for _, strategy := range CoinSelectionStrategies() {
+		if !strategy.IsValid() {
+			t.Fatalf("%q is not valid", strategy)
+		}
+	}
+	if CoinSelectionStrategy("random").IsValid() {
+		t.Fatal("unknown strategy is valid")
+	}
+}
@@ -0,0 +1,98 @@
+package wallet
+
+import (
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// userFeeRateSetter is implemented by the assets whose transactions pay the
+// fee rate set by the user.
+type userFeeRateSetter interface {
+	GetUserFeeRate() AssetAmount
+	SetUserFeeRate(feeRatePerkvB AssetAmount) error
+}
+
+// Consolidation is a transaction authored by PrepareConsolidation that merges
+// unspent outputs of an account into a single output of the same account.
+// Fee rates are per kB and amounts are in the smallest unit of the asset.
+type Consolidation struct {
+	UTXOs         []*UnspentOutput
+	Address       string
+	FeeRate       int64
+	Fee           int64
+	EstimatedSize int
+
+	// LaterFeeRate is the fee rate expected when the outputs are spent.
+	LaterFeeRate int64
+	// LaterFee is the fee paid to spend the outputs at LaterFeeRate without
+	// consolidating them, ConsolidatedLaterFee is the fee paid to spend the
+	// consolidated output instead.
+	LaterFee             int64
+	ConsolidatedLaterFee int64
+}
+
+// Savings returns the fee saved by consolidating the outputs now rather than
+// spending them at LaterFeeRate. It is negative if consolidating costs more.
+func (c *Consolidation) Savings() int64 {
+	return c.LaterFee - c.ConsolidatedLaterFee - c.Fee
+}
+
+// PrepareConsolidation authors the unsigned transaction of the asset that
+// spends every one of the utxos to the current address of the account. The fee
+// rate is only used by the assets that let the user set the fee rate, the
+// transaction is sent with the Broadcast method of the asset.
+func PrepareConsolidation(asset Asset, account int32, utxos []*UnspentOutput, feeRate, laterFeeRate int64) (*Consolidation, error) {
+	if len(utxos) < 2 || feeRate <= 0 || laterFeeRate <= 0 {
+		return nil, errors.New(utils.ErrInvalid)
+	}
+
+	address, err := asset.CurrentAddress(account)
+	if err != nil {
+		return nil, err
+	}
+
+	if setter, ok := asset.(userFeeRateSetter); ok {
+		// The transaction is authored when its fee is estimated, the fee
+		// rate of other transactions is restored afterwards.
+		previous := setter.GetUserFeeRate()
+		if err := setter.SetUserFeeRate(asset.ToAmount(feeRate)); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := setter.SetUserFeeRate(previous); err != nil {
+				log.Errorf("Error restoring the fee rate: %v", err)
+			}
+		}()
+	}
+
+	if err := asset.NewUnsignedTx(account, utxos); err != nil {
+		return nil, err
+	}
+	if err := asset.AddSendDestination(0, address, 0, true); err != nil {
+		return nil, err
+	}
+	feeAndSize, err := asset.EstimateFeeAndSize()
+	if err != nil {
+		return nil, err
+	}
+
+	laterSize, err := asset.ComputeTxSizeEstimation(address, utxos)
+	if err != nil {
+		return nil, err
+	}
+	consolidatedSize, err := asset.ComputeTxSizeEstimation(address, utxos[:1])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Consolidation{
+		UTXOs:                utxos,
+		Address:              address,
+		FeeRate:              feeRate,
+		Fee:                  feeAndSize.Fee.UnitValue,
+		EstimatedSize:        feeAndSize.EstimatedSignedSize,
+		LaterFeeRate:         laterFeeRate,
+		LaterFee:             int64(laterSize) * laterFeeRate / 1000,
+		ConsolidatedLaterFee: int64(consolidatedSize) * laterFeeRate / 1000,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// countingConn counts the traffic of a connection towards the data usage of
+// a wallet.
+type countingConn struct {
+	net.Conn
+	wallet    *Wallet
+	closeOnce sync.Once
+}
+
+func (c *countingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.wallet.bytesReceived.Add(uint64(n))
+	return n, err
+}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.wallet.bytesSent.Add(uint64(n))
+	return n, err
+}
+
+// Close closes the connection and saves the traffic counted so far, so it
+// survives an unclean shutdown.
+func (c *countingConn) Close() error {
+	err := c.Conn.Close()
+	c.closeOnce.Do(c.wallet.saveDataUsage)
+	return err
+}
+
+// TrackDataUsage wraps the connection so that its traffic counts towards the
+// data usage of the wallet.
+func (wallet *Wallet) TrackDataUsage(conn net.Conn) net.Conn {
+	return &countingConn{Conn: conn, wallet: wallet}
+}
+
+// DataUsageDialer wraps dial so that the traffic of the connections it opens
+// counts towards the data usage of the wallet.
+func (wallet *Wallet) DataUsageDialer(dial utils.Dailer) utils.Dailer {
+	return func(addr net.Addr) (net.Conn, error) {
+		conn, err := dial(addr)
+		if err != nil {
+			return nil, err
+		}
+		return wallet.TrackDataUsage(conn), nil
+	}
+}
+
+// DataUsage returns the traffic of the sync connections of the wallet since
+// it was created or the usage was last reset.
+func (wallet *Wallet) DataUsage() DataUsage {
+	wallet.dataUsageMu.Lock()
+	defer wallet.dataUsageMu.Unlock()
+
+	usage := wallet.savedDataUsage()
+	usage.BytesSent += wallet.bytesSent.Load()
+	usage.BytesReceived += wallet.bytesReceived.Load()
+	return usage
+}
+
+// ResetDataUsage clears the data usage of the wallet.
+func (wallet *Wallet) ResetDataUsage() {
+	wallet.dataUsageMu.Lock()
+	defer wallet.dataUsageMu.Unlock()
+
+	wallet.bytesSent.Store(0)
+	wallet.bytesReceived.Store(0)
+	wallet.SaveUserConfigValue(DataUsageConfigKey, DataUsage{Since: time.Now()})
+}
+
+// savedDataUsage returns the data usage saved in the wallet config. The
+// dataUsageMu lock must be held.
+func (wallet *Wallet) savedDataUsage() DataUsage {
+	usage := DataUsage{Since: wallet.CreatedAt}
+	_ = wallet.ReadUserConfigValue(DataUsageConfigKey, &usage)
+	return usage
+}
+
+// saveDataUsage adds the traffic counted since the last save to the data
+// usage saved in the wallet config.
+func (wallet *Wallet) saveDataUsage() {
+	wallet.dataUsageMu.Lock()
+	defer wallet.dataUsageMu.Unlock()
+
+	sent, received := wallet.bytesSent.Swap(0), wallet.bytesReceived.Swap(0)
+	if sent == 0 && received == 0 {
+		return
+	}
+
+	usage := wallet.savedDataUsage()
+	usage.BytesSent += sent
+	usage.BytesReceived += received
+	wallet.SaveUserConfigValue(DataUsageConfigKey, usage)
+}
@@ -0,0 +1,141 @@
+package wallet
+
+import (
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// defaultDustThresholds are the amounts, in the smallest unit of each asset,
+// under which unsolicited outputs are flagged as dust unless another
+// threshold is set with SetDustThreshold.
+var defaultDustThresholds = map[utils.AssetType]int64{
+	utils.DCRWalletAsset: 10000, // 0.0001 DCR
+	utils.BTCWalletAsset: 1000,  // 1000 sats
+	utils.LTCWalletAsset: 10000, // 10000 litoshi
+}
+
+// DustThreshold returns the amount under which unsolicited outputs received by
+// reused addresses are flagged as dust. Zero disables the detection.
+func (wallet *Wallet) DustThreshold() int64 {
+	return wallet.ReadLongConfigValueForKey(DustThresholdConfigKey, defaultDustThresholds[wallet.GetAssetType()])
+}
+
+// SetDustThreshold sets the amount, in the smallest unit of the asset, under
+// which unsolicited outputs received by reused addresses are flagged as dust.
+func (wallet *Wallet) SetDustThreshold(threshold int64) error {
+	if threshold < 0 {
+		return errors.New(utils.ErrInvalid)
+	}
+	wallet.SetLongConfigValueForKey(DustThresholdConfigKey, threshold)
+	return nil
+}
+
+// AutoFreezeDust returns true if the outputs flagged as dust are frozen as
+// soon as they are found.
+func (wallet *Wallet) AutoFreezeDust() bool {
+	return wallet.ReadBoolConfigValueForKey(AutoFreezeDustConfigKey, false)
+}
+
+// SetAutoFreezeDust sets whether the outputs flagged as dust are frozen as
+// soon as they are found.
+func (wallet *Wallet) SetAutoFreezeDust(enabled bool) {
+	wallet.SetBoolConfigValueForKey(AutoFreezeDustConfigKey, enabled)
+}
+
+// FindDust returns the outputs that are probable dust attacks: outputs worth
+// less than the dust threshold of the wallet, received in a transaction that
+// did not spend any output of the wallet, by an address that received other
+// funds. Such outputs are sent to link the addresses of the wallet once they
+// are spent together.
+func FindDust(asset Asset, utxos []*UnspentOutput) ([]*UnspentOutput, error) {
+	threshold := asset.DustThreshold()
+	candidates := make([]*UnspentOutput, 0)
+	for _, utxo := range utxos {
+		if threshold > 0 && utxoAmount(utxo) < threshold {
+			candidates = append(candidates, utxo)
+		}
+	}
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	transactions, err := asset.GetTransactionsRaw(0, 0, utils.TxFilterAll, true, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// The transactions that paid each address of the wallet.
+	directions := make(map[string]int32, len(transactions))
+	addressTxs := make(map[string]map[string]bool)
+	for _, tx := range transactions {
+		directions[tx.Hash] = tx.Direction
+		for _, output := range tx.Outputs {
+			if output.AccountNumber < 0 || output.Address == "" {
+				continue
+			}
+			if addressTxs[output.Address] == nil {
+				addressTxs[output.Address] = make(map[string]bool)
+			}
+			addressTxs[output.Address][tx.Hash] = true
+		}
+	}
+
+	dust := make([]*UnspentOutput, 0, len(candidates))
+	for _, utxo := range candidates {
+		direction, ok := directions[utxo.TxID]
+		unsolicited := ok && direction == txhelper.TxDirectionReceived
+		reused := len(addressTxs[utxo.Address]) > 1
+		if unsolicited && reused {
+			dust = append(dust, utxo)
+		}
+	}
+	return dust, nil
+}
+
+// FindWalletDust returns the unspent outputs of every account of the wallet
+// that FindDust flags as dust.
+func FindWalletDust(asset Asset) ([]*UnspentOutput, error) {
+	accounts, err := asset.GetAccountsRaw()
+	if err != nil {
+		return nil, err
+	}
+
+	dust := make([]*UnspentOutput, 0)
+	for _, account := range accounts.Accounts {
+		utxos, err := asset.UnspentOutputs(account.Number)
+		if err != nil {
+			log.Errorf("Error reading the unspent outputs of account %d: %v", account.Number, err)
+			continue
+		}
+
+		accountDust, err := FindDust(asset, utxos)
+		if err != nil {
+			return nil, err
+		}
+		dust = append(dust, accountDust...)
+	}
+	return dust, nil
+}
+
+// FreezeDust freezes the outputs of the wallet that FindDust flags as dust and
+// returns the outputs that were not frozen before.
+func FreezeDust(asset Asset) ([]*UnspentOutput, error) {
+	dust, err := FindWalletDust(asset)
+	if err != nil {
+		return nil, err
+	}
+
+	frozen := make([]*UnspentOutput, 0, len(dust))
+	for _, utxo := range dust {
+		if utxo.Frozen {
+			continue
+		}
+		if err := asset.SetOutputFrozen(utxo.TxID, utxo.Vout, true); err != nil {
+			return nil, err
+		}
+		utxo.Frozen = true
+		frozen = append(frozen, utxo)
+	}
+	return frozen, nil
+}
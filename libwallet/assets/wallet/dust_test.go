@@ -0,0 +1,176 @@
+package wallet
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/asdine/storm"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// dustTestAsset is an asset with a fixed list of transactions and unspent
+// outputs, the other methods of Asset are not implemented.
+type dustTestAsset struct {
+	Asset
+	threshold int64
+	txs       []*Transaction
+	utxos     map[int32][]*UnspentOutput
+	frozen    map[string]bool
+}
+
+func (a *dustTestAsset) DustThreshold() int64 { return a.threshold }
+
+func (a *dustTestAsset) GetTransactionsRaw(_, _, _ int32, _ bool, _ string) ([]*Transaction, error) {
+	return a.txs, nil
+}
+
+func (a *dustTestAsset) GetAccountsRaw() (*Accounts, error) {
+	return &Accounts{Accounts: []*Account{{Number: 0}, {Number: 1}}}, nil
+}
+
+func (a *dustTestAsset) UnspentOutputs(account int32) ([]*UnspentOutput, error) {
+	return a.utxos[account], nil
+}
+
+func (a *dustTestAsset) SetOutputFrozen(txHash string, index uint32, frozen bool) error {
+	a.frozen[outpointRef(txHash, index)] = frozen
+	return nil
+}
+
+// dustTestTx returns a transaction of the direction paying the addresses of
+// the wallet.
+func dustTestTx(hash string, direction int32, addresses ...string) *Transaction {
+	tx := &Transaction{Hash: hash, Direction: direction}
+	for _, address := range addresses {
+		tx.Outputs = append(tx.Outputs, &TxOutput{Address: address, AccountNumber: 0})
+	}
+	return tx
+}
+
+func dustTestUTXO(txHash, address string, amount int64) *UnspentOutput {
+	return &UnspentOutput{TxID: txHash, Address: address, Amount: testAmount(amount)}
+}
+
+func TestFindDust(t *testing.T) {
+	asset := &dustTestAsset{
+		threshold: 1000,
+		txs: []*Transaction{
+			dustTestTx("deposit", txhelper.TxDirectionReceived, "reused", "fresh"),
+			dustTestTx("attack", txhelper.TxDirectionReceived, "reused"),
+			dustTestTx("attack-fresh", txhelper.TxDirectionReceived, "fresh-2"),
+			dustTestTx("change", txhelper.TxDirectionSent, "reused-change"),
+			dustTestTx("change-2", txhelper.TxDirectionSent, "reused-change"),
+			dustTestTx("self", txhelper.TxDirectionTransferred, "reused-self"),
+			dustTestTx("self-2", txhelper.TxDirectionReceived, "reused-self"),
+			// Outputs to other wallets don't make an address reused.
+			{Hash: "payment", Direction: txhelper.TxDirectionSent, Outputs: []*TxOutput{
+				{Address: "fresh-2", AccountNumber: -1},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		utxo *UnspentOutput
+		dust bool
+	}{
+		{"small unsolicited output to a reused address", dustTestUTXO("attack", "reused", 546), true},
+		{"output at the threshold", dustTestUTXO("attack", "reused", 1000), false},
+		{"large output to a reused address", dustTestUTXO("deposit", "reused", 50000), false},
+		{"small output to a fresh address", dustTestUTXO("attack-fresh", "fresh-2", 546), false},
+		{"small change to a reused address", dustTestUTXO("change-2", "reused-change", 546), false},
+		{"small transfer to a reused address", dustTestUTXO("self", "reused-self", 546), false},
+		{"small received output to a reused address", dustTestUTXO("self-2", "reused-self", 546), true},
+		{"output of an unknown transaction", dustTestUTXO("unknown", "reused", 546), false},
+	}
+	for _, tc := range tests {
+		dust, err := FindDust(asset, []*UnspentOutput{tc.utxo})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if got := len(dust) == 1; got != tc.dust {
+			t.Errorf("%s: got dust %v, want %v", tc.name, got, tc.dust)
+		}
+	}
+
+	asset.threshold = 0
+	if dust, _ := FindDust(asset, []*UnspentOutput{tests[0].utxo}); len(dust) != 0 {
+		t.Error("found dust with the detection disabled")
+	}
+}
+
+func TestFreezeDust(t *testing.T) {
+	attack := dustTestUTXO("attack", "reused", 546)
+	frozenAttack := dustTestUTXO("attack", "reused", 600)
+	frozenAttack.Vout, frozenAttack.Frozen = 1, true
+	asset := &dustTestAsset{
+		threshold: 1000,
+		txs: []*Transaction{
+			dustTestTx("deposit", txhelper.TxDirectionReceived, "reused"),
+			dustTestTx("attack", txhelper.TxDirectionReceived, "reused", "reused"),
+		},
+		utxos: map[int32][]*UnspentOutput{
+			0: {dustTestUTXO("deposit", "reused", 50000), attack},
+			1: {frozenAttack},
+		},
+		frozen: make(map[string]bool),
+	}
+
+	dust, err := FindWalletDust(asset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dust) != 2 || dust[0] != attack || dust[1] != frozenAttack {
+		t.Fatalf("got %d dust outputs, want the outputs of both accounts", len(dust))
+	}
+
+	// The outputs that are already frozen are not returned.
+	frozen, err := FreezeDust(asset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frozen) != 1 || frozen[0] != attack || !attack.Frozen {
+		t.Fatalf("got %d outputs frozen, want the unfrozen dust output", len(frozen))
+	}
+	if len(asset.frozen) != 1 || !asset.frozen["attack:0"] {
+		t.Errorf("got frozen outputs %v, want attack:0", asset.frozen)
+	}
+}
+
+func TestDustThreshold(t *testing.T) {
+	db, err := storm.Open(filepath.Join(t.TempDir(), "wallets.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tests := []struct {
+		assetType utils.AssetType
+		threshold int64
+	}{
+		{utils.DCRWalletAsset, 10000},
+		{utils.BTCWalletAsset, 1000},
+		{utils.LTCWalletAsset, 10000},
+	}
+	for i, tc := range tests {
+		wallet := &Wallet{ID: i + 1, Type: tc.assetType, db: db}
+		if got := wallet.DustThreshold(); got != tc.threshold {
+			t.Errorf("%s: got default dust threshold %d, want %d", tc.assetType, got, tc.threshold)
+		}
+	}
+
+	wallet := &Wallet{ID: 1, Type: utils.DCRWalletAsset, db: db}
+	if err = wallet.SetDustThreshold(-1); err == nil {
+		t.Error("set a negative dust threshold")
+	}
+	if err = wallet.SetDustThreshold(0); err != nil {
+		t.Fatal(err)
+	}
+	if got := wallet.DustThreshold(); got != 0 {
+		t.Errorf("got dust threshold %d, want the detection disabled", got)
+	}
+	if got := (&Wallet{ID: 2, Type: utils.BTCWalletAsset, db: db}).DustThreshold(); got != 1000 {
+		t.Errorf("got dust threshold %d for another wallet, want %d", got, 1000)
+	}
+}
@@ -0,0 +1,148 @@
+package wallet
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"decred.org/dcrwallet/v4/errors"
+)
+
+const (
+	// MaxFeeEstimates is the number of fee rate estimates returned by
+	// FeeEstimator, the estimates with the fewest confirmation blocks are
+	// kept.
+	MaxFeeEstimates = 5
+
+	// maxFeeRateFactor bounds the fee rates accepted from a source to this
+	// many times the fallback fee rate. A source returning a higher rate is
+	// considered broken and the next source is queried.
+	maxFeeRateFactor = 20
+)
+
+// FeeRateSource is a source of fee rate estimates. The rates are in the
+// smallest unit of the asset per kvB, keyed by the number of blocks a
+// transaction paying the rate is expected to be confirmed in.
+type FeeRateSource interface {
+	// Name identifies the source in logs.
+	Name() string
+	FeeRates() (map[int32]int64, error)
+}
+
+// FeeEstimatorConfig holds the sources and bounds of a FeeEstimator. Rates
+// are in the smallest unit of the asset per kvB.
+type FeeEstimatorConfig struct {
+	// Sources are queried in order until one returns valid estimates.
+	Sources []FeeRateSource
+	// MinFeeRate is the lowest rate returned, lower estimates are raised to
+	// it.
+	MinFeeRate int64
+	// FallbackFeeRate is the rate used by the asset when no estimate is
+	// available. Sources returning rates over maxFeeRateFactor times this
+	// rate are skipped.
+	FallbackFeeRate int64
+	// ToAmount converts the rates to amounts of the asset.
+	ToAmount func(int64) AssetAmount
+}
+
+// FeeEstimator returns the fee rate estimates of the first of its sources
+// that returns valid estimates. The estimates are cached until a new block is
+// mined.
+type FeeEstimator struct {
+	cfg *FeeEstimatorConfig
+
+	mu        sync.Mutex
+	estimates []FeeEstimate
+	// bestBlock is the best block height when the estimates were cached.
+	bestBlock int32
+}
+
+// NewFeeEstimator returns a FeeEstimator querying the sources of cfg.
+func NewFeeEstimator(cfg *FeeEstimatorConfig) *FeeEstimator {
+	return &FeeEstimator{cfg: cfg}
+}
+
+// Estimates returns up to MaxFeeEstimates fee rate estimates sorted by
+// confirmation blocks, the fastest first. The sources are not queried again
+// while the best block is unchanged.
+func (e *FeeEstimator) Estimates(bestBlock int32) ([]FeeEstimate, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if bestBlock > 0 && bestBlock == e.bestBlock && len(e.estimates) > 0 {
+		return e.estimates, nil
+	}
+
+	var errs []error
+	for _, source := range e.cfg.Sources {
+		var rates []blocksFeeRate
+		sourceRates, err := source.FeeRates()
+		if err == nil {
+			rates, err = e.checkRates(sourceRates)
+		}
+		if err != nil {
+			log.Debugf("Fee rate source %s failed: %v", source.Name(), err)
+			errs = append(errs, fmt.Errorf("%s: %v", source.Name(), err))
+			continue
+		}
+
+		estimates := make([]FeeEstimate, len(rates))
+		for i, rate := range rates {
+			estimates[i] = FeeEstimate{
+				ConfirmedBlocks: rate.blocks,
+				Feerate:         e.cfg.ToAmount(rate.feeRate),
+			}
+		}
+
+		e.estimates = estimates
+		e.bestBlock = bestBlock
+		return estimates, nil
+	}
+
+	if len(errs) == 0 {
+		return nil, errors.New("no fee rate sources")
+	}
+	return nil, fmt.Errorf("fee rate estimates not available: %v", errs)
+}
+
+type blocksFeeRate struct {
+	blocks  int32
+	feeRate int64
+}
+
+// checkRates sorts the rates by confirmation blocks and applies the bounds of
+// the estimator. A transaction confirmed in more blocks never pays a higher
+// rate than one confirmed in fewer blocks.
+func (e *FeeEstimator) checkRates(rates map[int32]int64) ([]blocksFeeRate, error) {
+	maxFeeRate := e.cfg.FallbackFeeRate * maxFeeRateFactor
+	sorted := make([]blocksFeeRate, 0, len(rates))
+	for blocks, feeRate := range rates {
+		if blocks <= 0 || feeRate <= 0 {
+			continue
+		}
+		if maxFeeRate > 0 && feeRate > maxFeeRate {
+			return nil, fmt.Errorf("fee rate %d for %d blocks is over the maximum of %d", feeRate, blocks, maxFeeRate)
+		}
+		if feeRate < e.cfg.MinFeeRate {
+			feeRate = e.cfg.MinFeeRate
+		}
+		sorted = append(sorted, blocksFeeRate{blocks: blocks, feeRate: feeRate})
+	}
+	if len(sorted) == 0 {
+		return nil, errors.New("no fee rates returned")
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].blocks < sorted[j].blocks
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].feeRate > sorted[i-1].feeRate {
+			sorted[i].feeRate = sorted[i-1].feeRate
+		}
+	}
+
+	if len(sorted) > MaxFeeEstimates {
+		sorted = sorted[:MaxFeeEstimates]
+	}
+	return sorted, nil
+}
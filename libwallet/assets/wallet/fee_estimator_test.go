@@ -0,0 +1,187 @@
+package wallet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// feeRateServer is a stub fee rate API. It serves each path with a fixed
+// response and counts the requests made to each path.
+type feeRateServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+func newFeeRateServer(t *testing.T, responses map[string]string) *feeRateServer {
+	s := &feeRateServer{requests: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[r.URL.Path]++
+		s.mu.Unlock()
+
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *feeRateServer) requestsTo(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+var stubFeeRateResponses = map[string]string{
+	"/esplora":     `{"1": 20.5, "3": 10, "6": 5.25, "144": 1.5, "invalid": 3}`,
+	"/mempool":     `{"fastestFee": 30, "halfHourFee": 25, "hourFee": 20, "economyFee": 8, "minimumFee": 4}`,
+	"/blockcypher": `{"name": "LTC.main", "high_fee_per_kb": 40000, "medium_fee_per_kb": 20000, "low_fee_per_kb": 10000}`,
+	"/insane":      `{"1": 100000}`,
+	"/empty":       `{}`,
+}
+
+func TestFeeRateSources(t *testing.T) {
+	server := newFeeRateServer(t, stubFeeRateResponses)
+	tests := []struct {
+		name   string
+		source FeeRateSource
+		want   map[int32]int64
+	}{{
+		name:   "esplora",
+		source: &EsploraFeeSource{URL: server.URL + "/esplora"},
+		want:   map[int32]int64{1: 20500, 3: 10000, 6: 5250, 144: 1500},
+	}, {
+		name:   "mempool",
+		source: &MempoolFeeSource{URL: server.URL + "/mempool"},
+		want:   map[int32]int64{1: 30000, 3: 25000, 6: 20000, 144: 8000},
+	}, {
+		name:   "blockcypher",
+		source: &BlockCypherFeeSource{URL: server.URL + "/blockcypher"},
+		want:   map[int32]int64{2: 40000, 6: 20000, 7: 10000},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.source.FeeRates()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	if _, err := (&EsploraFeeSource{URL: server.URL + "/unavailable"}).FeeRates(); err == nil {
+		t.Fatal("expected an error from an unavailable source")
+	}
+}
+
+func testFeeEstimator(sources ...FeeRateSource) *FeeEstimator {
+	return NewFeeEstimator(&FeeEstimatorConfig{
+		Sources:         sources,
+		MinFeeRate:      2000,
+		FallbackFeeRate: 50000,
+		ToAmount:        func(v int64) AssetAmount { return testAmount(v) },
+	})
+}
+
+func estimatedRates(estimates []FeeEstimate) map[int32]int64 {
+	rates := make(map[int32]int64, len(estimates))
+	for _, estimate := range estimates {
+		rates[estimate.ConfirmedBlocks] = estimate.Feerate.ToInt()
+	}
+	return rates
+}
+
+func TestFeeEstimatorFallback(t *testing.T) {
+	server := newFeeRateServer(t, stubFeeRateResponses)
+	estimator := testFeeEstimator(
+		&EsploraFeeSource{URL: server.URL + "/unavailable"},
+		&EsploraFeeSource{URL: server.URL + "/empty"},
+		&EsploraFeeSource{URL: server.URL + "/insane"},
+		&MempoolFeeSource{URL: server.URL + "/mempool"},
+		&EsploraFeeSource{URL: server.URL + "/esplora"},
+	)
+
+	estimates, err := estimator.Estimates(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[int32]int64{1: 30000, 3: 25000, 6: 20000, 144: 8000}
+	if got := estimatedRates(estimates); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := 1; i < len(estimates); i++ {
+		if estimates[i].ConfirmedBlocks < estimates[i-1].ConfirmedBlocks {
+			t.Fatalf("estimates are not sorted by confirmation blocks: %v", estimates)
+		}
+	}
+	if n := server.requestsTo("/esplora"); n != 0 {
+		t.Fatalf("sources after the first valid source were queried %d times", n)
+	}
+}
+
+func TestFeeEstimatorCache(t *testing.T) {
+	server := newFeeRateServer(t, stubFeeRateResponses)
+	estimator := testFeeEstimator(&MempoolFeeSource{URL: server.URL + "/mempool"})
+
+	for _, bestBlock := range []int32{100, 100, 101} {
+		if _, err := estimator.Estimates(bestBlock); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := server.requestsTo("/mempool"); n != 2 {
+		t.Fatalf("got %d requests, want one per best block", n)
+	}
+}
+
+func TestFeeEstimatorBounds(t *testing.T) {
+	server := newFeeRateServer(t, stubFeeRateResponses)
+
+	// The 144 blocks estimate is raised to the minimum fee rate.
+	estimates, err := testFeeEstimator(&EsploraFeeSource{URL: server.URL + "/esplora"}).Estimates(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[int32]int64{1: 20500, 3: 10000, 6: 5250, 144: 2000}
+	if got := estimatedRates(estimates); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// A source without fee rates fails.
+	if _, err := testFeeEstimator(&fixedFeeRates{}).Estimates(1); err == nil {
+		t.Fatal("expected an error without fee rates")
+	}
+
+	// Slower confirmations never pay more.
+	unordered := testFeeEstimator(&fixedFeeRates{1: 5000, 3: 8000, 6: 3000})
+	estimates, err = unordered.Estimates(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = map[int32]int64{1: 5000, 3: 5000, 6: 3000}
+	if got := estimatedRates(estimates); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// Sources returning an absurd fee rate are skipped.
+	if _, err := testFeeEstimator(&EsploraFeeSource{URL: server.URL + "/insane"}).Estimates(1); err == nil {
+		t.Fatal("expected an error for a fee rate over the maximum")
+	}
+}
+
+// fixedFeeRates is a FeeRateSource returning fixed rates.
+type fixedFeeRates map[int32]int64
+
+func (f *fixedFeeRates) Name() string                       { return "fixed" }
+func (f *fixedFeeRates) FeeRates() (map[int32]int64, error) { return *f, nil }
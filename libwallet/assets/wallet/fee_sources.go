@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// EsploraFeeSource queries the fee estimates of an Esplora API such as
+// blockstream.info, at URLs like https://blockstream.info/api/fee-estimates.
+type EsploraFeeSource struct {
+	URL string
+}
+
+// Name is part of the FeeRateSource interface.
+func (s *EsploraFeeSource) Name() string {
+	return s.URL
+}
+
+// FeeRates is part of the FeeRateSource interface.
+func (s *EsploraFeeSource) FeeRates() (map[int32]int64, error) {
+	// The fee rates are in sat/vB keyed by the confirmation blocks.
+	resp := make(map[string]float64)
+	if err := getFeeRates(s.URL, &resp); err != nil {
+		return nil, err
+	}
+
+	rates := make(map[int32]int64, len(resp))
+	for blocks, feeRate := range resp {
+		b, err := strconv.ParseInt(blocks, 10, 32)
+		if err != nil {
+			// Invalid blocks confirmation found ignore it.
+			continue
+		}
+		rates[int32(b)] = perkvB(feeRate)
+	}
+	return rates, nil
+}
+
+// MempoolFeeSource queries the recommended fees of a mempool.space API, at
+// URLs like https://mempool.space/api/v1/fees/recommended. Litecoin fees are
+// available from the same API at litecoinspace.org.
+type MempoolFeeSource struct {
+	URL string
+}
+
+// Name is part of the FeeRateSource interface.
+func (s *MempoolFeeSource) Name() string {
+	return s.URL
+}
+
+// FeeRates is part of the FeeRateSource interface.
+func (s *MempoolFeeSource) FeeRates() (map[int32]int64, error) {
+	// The fee rates are in sat/vB.
+	var resp struct {
+		FastestFee  float64 `json:"fastestFee"`
+		HalfHourFee float64 `json:"halfHourFee"`
+		HourFee     float64 `json:"hourFee"`
+		EconomyFee  float64 `json:"economyFee"`
+	}
+	if err := getFeeRates(s.URL, &resp); err != nil {
+		return nil, err
+	}
+
+	// The recommendations are for the next block, half an hour, an hour and
+	// a day of bitcoin blocks.
+	return map[int32]int64{
+		1:   perkvB(resp.FastestFee),
+		3:   perkvB(resp.HalfHourFee),
+		6:   perkvB(resp.HourFee),
+		144: perkvB(resp.EconomyFee),
+	}, nil
+}
+
+// BlockCypherFeeSource queries the fee estimates of the BlockCypher chain
+// API, at URLs like https://api.blockcypher.com/v1/ltc/main.
+type BlockCypherFeeSource struct {
+	URL string
+}
+
+// Name is part of the FeeRateSource interface.
+func (s *BlockCypherFeeSource) Name() string {
+	return s.URL
+}
+
+// FeeRates is part of the FeeRateSource interface.
+func (s *BlockCypherFeeSource) FeeRates() (map[int32]int64, error) {
+	// The fee rates are in the smallest unit of the asset per kB.
+	var resp struct {
+		HighFeePerKb   int64 `json:"high_fee_per_kb"`
+		MediumFeePerKb int64 `json:"medium_fee_per_kb"`
+		LowFeePerKb    int64 `json:"low_fee_per_kb"`
+	}
+	if err := getFeeRates(s.URL, &resp); err != nil {
+		return nil, err
+	}
+
+	// The high, medium and low fees are for transactions confirmed in 1 to
+	// 2 blocks, 3 to 6 blocks and 7 blocks or more.
+	return map[int32]int64{
+		2: resp.HighFeePerKb,
+		6: resp.MediumFeePerKb,
+		7: resp.LowFeePerKb,
+	}, nil
+}
+
+func getFeeRates(url string, resp interface{}) error {
+	req := &utils.ReqConfig{
+		Method:  http.MethodGet,
+		HTTPURL: url,
+	}
+	if _, err := utils.HTTPRequest(req, resp); err != nil {
+		return err
+	}
+	return nil
+}
+
+// perkvB converts a fee rate per vB to a fee rate per kvB, at the rate of
+// 1000 sat/kvB == 1 sat/vB.
+func perkvB(feeRate float64) int64 {
+	return int64(feeRate * 1000.0)
+}
@@ -0,0 +1,166 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// defaultScopeAccountName is the name btcwallet and ltcwallet give the default
+// account of every key scope.
+const defaultScopeAccountName = "default"
+
+// String identifies the key scope in the wallet config.
+func (scope KeyScope) String() string {
+	return fmt.Sprintf("%d/%d", scope.Purpose, scope.Coin)
+}
+
+// ScopeAccounts describes a key scope whose accounts are offered next to the
+// accounts of the wallet's key scope.
+type ScopeAccounts struct {
+	Scope KeyScope
+	// Offset is added to the number of the accounts of the key scope
+	// wherever an account number is used.
+	Offset int32
+	// Legacy key scopes are used by other wallets. They are scanned while
+	// restoring a wallet but only offered once imported, see
+	// ImportLegacyScopeAccounts.
+	Legacy bool
+	// Name is given to the default account of a legacy key scope when it is
+	// imported.
+	Name string
+}
+
+// AccountScopes maps the account numbers offered by a wallet to key scopes.
+// The address managers number the accounts of each key scope from zero, the
+// accounts of the key scopes other than the wallet's key scope are offered
+// with the offset of their key scope added to their number.
+type AccountScopes struct {
+	// Scopes is sorted by offset.
+	Scopes []*ScopeAccounts
+	// ImportedAccount is the number of the account of imported addresses,
+	// which is not offset.
+	ImportedAccount uint32
+}
+
+// AccountScope returns the key scope and the address manager account number of
+// the provided account, the key scope is defaultScope if the account is one of
+// the wallet's key scope.
+func (a *AccountScopes) AccountScope(defaultScope KeyScope, accountNumber int32) (KeyScope, uint32) {
+	for i := len(a.Scopes) - 1; i >= 0; i-- {
+		if accountNumber >= a.Scopes[i].Offset {
+			return a.Scopes[i].Scope, uint32(accountNumber - a.Scopes[i].Offset)
+		}
+	}
+	return defaultScope, uint32(accountNumber)
+}
+
+// ScopedAccountNumber returns the account number of an address manager account
+// of the provided key scope.
+func (a *AccountScopes) ScopedAccountNumber(scope KeyScope, account uint32) int32 {
+	if account == a.ImportedAccount {
+		return int32(account)
+	}
+	for _, s := range a.Scopes {
+		if s.Scope == scope {
+			return int32(account) + s.Offset
+		}
+	}
+	return int32(account)
+}
+
+// ScopeAccountStore gives access to the accounts of the key scopes of a
+// btcwallet or ltcwallet wallet.
+type ScopeAccountStore interface {
+	// ScopeAccountUsed returns true if addresses of the account were
+	// derived. found is false if the wallet doesn't have the key scope.
+	ScopeAccountUsed(scope KeyScope, account uint32) (used, found bool, err error)
+	AccountName(scope KeyScope, account uint32) (string, error)
+	RenameAccount(scope KeyScope, account uint32, name string) error
+	HasAccount(name string) bool
+}
+
+// IsKeyScopeImported returns true if the default account of the legacy key
+// scope was imported.
+func (wallet *Wallet) IsKeyScopeImported(scope KeyScope) bool {
+	var imported []string
+	_ = wallet.ReadUserConfigValue(ImportedKeyScopesConfigKey, &imported)
+	for _, id := range imported {
+		if id == scope.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// LegacyKeyScopeHistory reports which of the legacy key scopes have history.
+// The key scopes are scanned when a wallet is restored, the report is only
+// complete once address discovery is done.
+func (wallet *Wallet) LegacyKeyScopeHistory(scopes *AccountScopes, store ScopeAccountStore, defaultAccount uint32) ([]*KeyScopeHistory, error) {
+	report := make([]*KeyScopeHistory, 0, len(scopes.Scopes))
+	for _, s := range scopes.Scopes {
+		if !s.Legacy {
+			continue
+		}
+
+		used, found, err := store.ScopeAccountUsed(s.Scope, defaultAccount)
+		if err != nil {
+			return nil, err
+		}
+		// Watch only wallets only have the key scope they were created
+		// from.
+		if !found {
+			continue
+		}
+
+		report = append(report, &KeyScopeHistory{
+			Purpose:       s.Scope.Purpose,
+			CoinType:      s.Scope.Coin,
+			Name:          s.Name,
+			HasHistory:    used,
+			Imported:      wallet.IsKeyScopeImported(s.Scope),
+			AccountNumber: scopes.ScopedAccountNumber(s.Scope, defaultAccount),
+		})
+	}
+
+	return report, nil
+}
+
+// ImportLegacyScopeAccounts imports the default account of every legacy key
+// scope with history as an account of the wallet, see LegacyKeyScopeHistory.
+func (wallet *Wallet) ImportLegacyScopeAccounts(scopes *AccountScopes, store ScopeAccountStore, defaultAccount uint32) ([]*KeyScopeHistory, error) {
+	report, err := wallet.LegacyKeyScopeHistory(scopes, store, defaultAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	var imported []string
+	_ = wallet.ReadUserConfigValue(ImportedKeyScopesConfigKey, &imported)
+	for _, history := range report {
+		if !history.HasHistory || history.Imported {
+			continue
+		}
+
+		scope := KeyScope{Purpose: history.Purpose, Coin: history.CoinType}
+		name, err := store.AccountName(scope, defaultAccount)
+		if err != nil {
+			return nil, err
+		}
+
+		// The default account of every key scope is named "default", a
+		// distinct name avoids confusing it with the wallet's default account.
+		if name == defaultScopeAccountName && !store.HasAccount(history.Name) {
+			err = store.RenameAccount(scope, defaultAccount, history.Name)
+			if err != nil {
+				return nil, utils.TranslateError(err)
+			}
+		}
+
+		imported = append(imported, scope.String())
+		history.Imported = true
+		log.Infof("[%d] Imported the %s account", wallet.ID, history.Name)
+	}
+	wallet.SaveUserConfigValue(ImportedKeyScopesConfigKey, imported)
+
+	return report, nil
+}
@@ -0,0 +1,168 @@
+package wallet
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/asdine/storm"
+)
+
+const testImportedAccount = 1<<31 - 1
+
+var (
+	testDefaultScope  = KeyScope{Purpose: 84, Coin: 2}
+	testAccountScopes = &AccountScopes{
+		Scopes: []*ScopeAccounts{
+			{Scope: KeyScope{Purpose: 86, Coin: 2}, Offset: 1 << 24},
+			{Scope: KeyScope{Purpose: 49, Coin: 2}, Offset: 2 << 24, Legacy: true, Name: "BIP-49 nested segwit"},
+			{Scope: KeyScope{Purpose: 44, Coin: 2}, Offset: 3 << 24, Legacy: true, Name: "BIP-44 legacy"},
+			{Scope: KeyScope{Purpose: 44, Coin: 0}, Offset: 4 << 24, Legacy: true, Name: "BIP-44 legacy (coin type 0)"},
+		},
+		ImportedAccount: testImportedAccount,
+	}
+)
+
+func TestAccountScopes(t *testing.T) {
+	tests := []struct {
+		name          string
+		accountNumber int32
+		scope         KeyScope
+		account       uint32
+	}{
+		{"default account", 0, testDefaultScope, 0},
+		{"account of the wallet's key scope", 5, testDefaultScope, 5},
+		{"last account of the wallet's key scope", 1<<24 - 1, testDefaultScope, 1<<24 - 1},
+		{"first offset scope", 1 << 24, KeyScope{Purpose: 86, Coin: 2}, 0},
+		{"second account of an offset scope", 1<<24 + 1, KeyScope{Purpose: 86, Coin: 2}, 1},
+		{"legacy scope", 3 << 24, KeyScope{Purpose: 44, Coin: 2}, 0},
+		{"coin type 0", 4<<24 + 2, KeyScope{Purpose: 44, Coin: 0}, 2},
+	}
+	for _, tc := range tests {
+		scope, account := testAccountScopes.AccountScope(testDefaultScope, tc.accountNumber)
+		if scope != tc.scope || account != tc.account {
+			t.Errorf("%s: got account %d of %s, want account %d of %s", tc.name, account, scope, tc.account, tc.scope)
+			continue
+		}
+
+		accountNumber := testAccountScopes.ScopedAccountNumber(scope, account)
+		if accountNumber != tc.accountNumber {
+			t.Errorf("%s: got account number %d, want %d", tc.name, accountNumber, tc.accountNumber)
+		}
+	}
+
+	// The imported account is not offset.
+	if got := testAccountScopes.ScopedAccountNumber(KeyScope{Purpose: 44, Coin: 2}, testImportedAccount); got != testImportedAccount {
+		t.Errorf("got imported account number %d, want %d", got, testImportedAccount)
+	}
+	// Accounts of unknown key scopes are not offset either.
+	if got := testAccountScopes.ScopedAccountNumber(KeyScope{Purpose: 1017, Coin: 2}, 3); got != 3 {
+		t.Errorf("got account number %d of an unknown key scope, want 3", got)
+	}
+}
+
+// fakeScopeAccountStore holds the default account of the key scopes of a
+// restored wallet.
+type fakeScopeAccountStore struct {
+	// used maps the key scopes of the wallet to whether their default
+	// account has history.
+	used  map[KeyScope]bool
+	names map[KeyScope]string
+}
+
+func (s *fakeScopeAccountStore) ScopeAccountUsed(scope KeyScope, _ uint32) (bool, bool, error) {
+	used, found := s.used[scope]
+	return used, found, nil
+}
+
+func (s *fakeScopeAccountStore) AccountName(scope KeyScope, _ uint32) (string, error) {
+	if name, ok := s.names[scope]; ok {
+		return name, nil
+	}
+	return defaultScopeAccountName, nil
+}
+
+func (s *fakeScopeAccountStore) RenameAccount(scope KeyScope, _ uint32, name string) error {
+	s.names[scope] = name
+	return nil
+}
+
+func (s *fakeScopeAccountStore) HasAccount(name string) bool {
+	for _, n := range s.names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestImportLegacyScopeAccounts(t *testing.T) {
+	db, err := storm.Open(filepath.Join(t.TempDir(), "wallets.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	wallet := &Wallet{ID: 1, db: db}
+
+	// The restored wallet found history on the BIP-44 key scope. The BIP-49
+	// key scope is unused and the wallet lacks the key scope of coin type 0.
+	store := &fakeScopeAccountStore{
+		used: map[KeyScope]bool{
+			{Purpose: 86, Coin: 2}: true,
+			{Purpose: 49, Coin: 2}: false,
+			{Purpose: 44, Coin: 2}: true,
+		},
+		names: make(map[KeyScope]string),
+	}
+
+	report, err := wallet.LegacyKeyScopeHistory(testAccountScopes, store, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []KeyScopeHistory{
+		{Purpose: 49, CoinType: 2, Name: "BIP-49 nested segwit", AccountNumber: 2 << 24},
+		{Purpose: 44, CoinType: 2, Name: "BIP-44 legacy", HasHistory: true, AccountNumber: 3 << 24},
+	}
+	checkReport := func(report []*KeyScopeHistory) {
+		t.Helper()
+		if len(report) != len(want) {
+			t.Fatalf("got %d key scopes in the report, want %d", len(report), len(want))
+		}
+		for i := range want {
+			if *report[i] != want[i] {
+				t.Errorf("got key scope %+v, want %+v", *report[i], want[i])
+			}
+		}
+	}
+	checkReport(report)
+
+	report, err = wallet.ImportLegacyScopeAccounts(testAccountScopes, store, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want[1].Imported = true
+	checkReport(report)
+
+	if !wallet.IsKeyScopeImported(KeyScope{Purpose: 44, Coin: 2}) {
+		t.Error("the BIP-44 key scope is not imported")
+	}
+	if wallet.IsKeyScopeImported(KeyScope{Purpose: 49, Coin: 2}) {
+		t.Error("the unused BIP-49 key scope is imported")
+	}
+	if name := store.names[KeyScope{Purpose: 44, Coin: 2}]; name != "BIP-44 legacy" {
+		t.Errorf("got imported account name %q, want %q", name, "BIP-44 legacy")
+	}
+
+	// A second import keeps the imported key scopes and the account names
+	// given by the user.
+	store.names[KeyScope{Purpose: 44, Coin: 2}] = "savings"
+	store.used[KeyScope{Purpose: 49, Coin: 2}] = true
+	if _, err = wallet.ImportLegacyScopeAccounts(testAccountScopes, store, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !wallet.IsKeyScopeImported(KeyScope{Purpose: 44, Coin: 2}) || !wallet.IsKeyScopeImported(KeyScope{Purpose: 49, Coin: 2}) {
+		t.Error("the key scopes with history are not all imported")
+	}
+	if name := store.names[KeyScope{Purpose: 44, Coin: 2}]; name != "savings" {
+		t.Errorf("got account name %q after the second import, want %q", name, "savings")
+	}
+}
@@ -0,0 +1,328 @@
+package wallet
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// labelRecord is a line of a BIP-329 label export.
+type labelRecord struct {
+	Type   string `json:"type"`
+	Ref    string `json:"ref"`
+	Label  string `json:"label"`
+	Origin string `json:"origin,omitempty"`
+	// Spendable is only set for outputs, it is false for frozen outputs.
+	Spendable *bool `json:"spendable,omitempty"`
+}
+
+// outpointRef returns the BIP-329 reference of an output.
+func outpointRef(txHash string, index uint32) string {
+	return fmt.Sprintf("%s:%d", strings.TrimSpace(txHash), index)
+}
+
+func (wallet *Wallet) labelsDB() (*walletdata.DB, error) {
+	db := wallet.GetWalletDataDb()
+	if db == nil {
+		return nil, errors.New(utils.ErrWalletNotLoaded)
+	}
+	return db, nil
+}
+
+// SetTransactionLabel sets the label of a transaction of the wallet. Sent and
+// received transactions can be labeled, an empty label clears the label.
+func (wallet *Wallet) SetTransactionLabel(txHash, label string) error {
+	db, err := wallet.labelsDB()
+	if err != nil {
+		return err
+	}
+	return db.SetLabel(walletdata.LabelTypeTx, strings.TrimSpace(txHash), strings.TrimSpace(label))
+}
+
+// TransactionLabel returns the label set for the transaction.
+func (wallet *Wallet) TransactionLabel(txHash string) string {
+	db, err := wallet.labelsDB()
+	if err != nil {
+		return ""
+	}
+	label, _ := db.ReadLabel(walletdata.LabelTypeTx, txHash)
+	return label
+}
+
+// SetAddressLabel sets the label of an address, an empty label clears the
+// label.
+func (wallet *Wallet) SetAddressLabel(address, label string) error {
+	db, err := wallet.labelsDB()
+	if err != nil {
+		return err
+	}
+	return db.SetLabel(walletdata.LabelTypeAddr, strings.TrimSpace(address), strings.TrimSpace(label))
+}
+
+// AddressLabel returns the label set for the address.
+func (wallet *Wallet) AddressLabel(address string) string {
+	db, err := wallet.labelsDB()
+	if err != nil {
+		return ""
+	}
+	label, _ := db.ReadLabel(walletdata.LabelTypeAddr, address)
+	return label
+}
+
+// SetOutputLabel sets the label of an output of the wallet, an empty label
+// clears the label.
+func (wallet *Wallet) SetOutputLabel(txHash string, index uint32, label string) error {
+	db, err := wallet.labelsDB()
+	if err != nil {
+		return err
+	}
+	return db.SetLabel(walletdata.LabelTypeOutput, outpointRef(txHash, index), strings.TrimSpace(label))
+}
+
+// OutputLabel returns the label set for the output.
+func (wallet *Wallet) OutputLabel(txHash string, index uint32) string {
+	db, err := wallet.labelsDB()
+	if err != nil {
+		return ""
+	}
+	label, _ := db.ReadLabel(walletdata.LabelTypeOutput, outpointRef(txHash, index))
+	return label
+}
+
+// SetOutputFrozen freezes or unfreezes an output of the wallet. Frozen outputs
+// are not selected automatically to fund transactions.
+func (wallet *Wallet) SetOutputFrozen(txHash string, index uint32, frozen bool) error {
+	db, err := wallet.labelsDB()
+	if err != nil {
+		return err
+	}
+	return db.SetFrozen(walletdata.LabelTypeOutput, outpointRef(txHash, index), frozen)
+}
+
+// IsOutputFrozen returns true if the output was frozen with SetOutputFrozen.
+func (wallet *Wallet) IsOutputFrozen(txHash string, index uint32) bool {
+	db, err := wallet.labelsDB()
+	if err != nil {
+		return false
+	}
+	return db.IsFrozen(walletdata.LabelTypeOutput, outpointRef(txHash, index))
+}
+
+// FrozenOutputs returns the references of the frozen outputs of the wallet,
+// formatted as "txHash:index".
+func (wallet *Wallet) FrozenOutputs() ([]string, error) {
+	db, err := wallet.labelsDB()
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := db.Labels(walletdata.LabelTypeOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	frozen := make([]string, 0)
+	for _, label := range labels {
+		if label.Frozen {
+			frozen = append(frozen, label.Ref)
+		}
+	}
+	return frozen, nil
+}
+
+// ApplyOutputLabels sets the label and the frozen state of each unspent
+// output.
+func (wallet *Wallet) ApplyOutputLabels(utxos ...*UnspentOutput) {
+	db, err := wallet.labelsDB()
+	if err != nil {
+		return
+	}
+
+	labels, err := db.Labels(walletdata.LabelTypeOutput)
+	if err != nil {
+		log.Errorf("Error reading output labels: %v", err)
+		return
+	}
+
+	outputLabels := make(map[string]*walletdata.Label, len(labels))
+	for _, label := range labels {
+		outputLabels[label.Ref] = label
+	}
+	for _, utxo := range utxos {
+		if label, ok := outputLabels[outpointRef(utxo.TxID, utxo.Vout)]; ok {
+			utxo.Label = label.Label
+			utxo.Frozen = label.Frozen
+		}
+	}
+}
+
+// UnfrozenOutputs returns the outputs that are not frozen.
+func UnfrozenOutputs(utxos []*UnspentOutput) []*UnspentOutput {
+	unfrozen := make([]*UnspentOutput, 0, len(utxos))
+	for _, utxo := range utxos {
+		if !utxo.Frozen {
+			unfrozen = append(unfrozen, utxo)
+		}
+	}
+	return unfrozen
+}
+
+// ApplyTxLabels sets the label of each transaction to the label saved with
+// SetTransactionLabel. The label held by the wallet backend is kept if no
+// label was saved.
+func (wallet *Wallet) ApplyTxLabels(txs ...*Transaction) {
+	db, err := wallet.labelsDB()
+	if err != nil {
+		return
+	}
+
+	labels, err := db.Labels(walletdata.LabelTypeTx)
+	if err != nil {
+		log.Errorf("Error reading transaction labels: %v", err)
+		return
+	}
+	if len(labels) == 0 {
+		return
+	}
+
+	txLabels := make(map[string]string, len(labels))
+	for _, label := range labels {
+		txLabels[label.Ref] = label.Label
+	}
+	for _, tx := range txs {
+		if label, ok := txLabels[tx.Hash]; ok {
+			tx.Label = label
+		}
+	}
+}
+
+// TxSearchMatcher returns a function that reports whether a transaction
+// matches the search text. A transaction matches if its hash is the search
+// text, or if its label, one of its output addresses or the label of one of
+// its output addresses contains the search text.
+func (wallet *Wallet) TxSearchMatcher(search string) func(tx *Transaction) bool {
+	search = strings.TrimSpace(search)
+	lowerSearch := strings.ToLower(search)
+
+	var addressLabels map[string]string
+	if db, err := wallet.labelsDB(); err == nil {
+		labels, err := db.Labels(walletdata.LabelTypeAddr)
+		if err != nil {
+			log.Errorf("Error reading address labels: %v", err)
+		}
+		addressLabels = make(map[string]string, len(labels))
+		for _, label := range labels {
+			addressLabels[label.Ref] = strings.ToLower(label.Label)
+		}
+	}
+
+	return func(tx *Transaction) bool {
+		if tx.Hash == search {
+			return true
+		}
+		if lowerSearch == "" {
+			return false
+		}
+		if strings.Contains(strings.ToLower(tx.Label), lowerSearch) {
+			return true
+		}
+		for _, output := range tx.Outputs {
+			if output.Address == search || strings.Contains(addressLabels[output.Address], lowerSearch) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ExportLabels writes the transaction and address labels of the wallet in the
+// BIP-329 format, one JSON record per line.
+func (wallet *Wallet) ExportLabels(w io.Writer) error {
+	db, err := wallet.labelsDB()
+	if err != nil {
+		return err
+	}
+
+	labels, err := db.Labels()
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	for _, label := range labels {
+		if label.Label == "" && !label.Frozen {
+			continue
+		}
+		record := &labelRecord{
+			Type:   label.Type,
+			Ref:    label.Ref,
+			Label:  label.Label,
+			Origin: label.Origin,
+		}
+		if label.Type == walletdata.LabelTypeOutput {
+			spendable := !label.Frozen
+			record.Spendable = &spendable
+		}
+		err = encoder.Encode(record)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportLabels reads labels exported in the BIP-329 format and saves them,
+// replacing the labels already set for the same records. Nothing is saved
+// unless every record is valid. The number of labels imported is returned.
+func (wallet *Wallet) ImportLabels(r io.Reader) (int, error) {
+	db, err := wallet.labelsDB()
+	if err != nil {
+		return 0, err
+	}
+
+	var labels []*walletdata.Label
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var record labelRecord
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return 0, fmt.Errorf("invalid label on line %d: %v", line, err)
+		}
+
+		switch record.Type {
+		case walletdata.LabelTypeTx, walletdata.LabelTypeAddr, walletdata.LabelTypePubkey,
+			walletdata.LabelTypeInput, walletdata.LabelTypeOutput, walletdata.LabelTypeXpub:
+		default:
+			return 0, fmt.Errorf("invalid label on line %d: unknown type %q", line, record.Type)
+		}
+		if record.Ref == "" {
+			return 0, fmt.Errorf("invalid label on line %d: missing ref", line)
+		}
+
+		labels = append(labels, &walletdata.Label{
+			Type:   record.Type,
+			Ref:    record.Ref,
+			Label:  record.Label,
+			Origin: record.Origin,
+			Frozen: record.Spendable != nil && !*record.Spendable,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	if err := db.ImportLabels(labels); err != nil {
+		return 0, err
+	}
+	return len(labels), nil
+}
@@ -0,0 +1,268 @@
+package wallet
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
+)
+
+// newLabelsWallet returns a wallet with its data database in a temporary
+// directory.
+func newLabelsWallet(t *testing.T) *Wallet {
+	t.Helper()
+	db, err := walletdata.Initialize(filepath.Join(t.TempDir(), "walletdata.db"), &Transaction{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return &Wallet{walletDataDB: db}
+}
+
+const testTxHash = "f4184fc596403b9d638783cf57adfe4c75c605f6356fbc91338530e9831e9e16"
+
+func TestImportLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		imported int
+		valid    bool
+	}{{
+		name: "every type",
+		data: `{"type":"tx","ref":"` + testTxHash + `","label":"rent","origin":"wpkh([d34db33f/84'/0'/0'])"}
+{"type":"addr","ref":"bc1q34aq5drpuwy3wgl9lhup9892qp6svr8ldzyy7c","label":"Alice"}
+{"type":"pubkey","ref":"0283409659355b6d1cc3c32decd5d561abaac86c37a353b52895a5e6c196d6f448","label":"key"}
+{"type":"input","ref":"` + testTxHash + `:0","label":"input"}
+{"type":"output","ref":"` + testTxHash + `:1","label":"change","spendable":false}
+{"type":"xpub","ref":"xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8","label":"Account #1"}`,
+		imported: 6,
+		valid:    true,
+	}, {
+		name:     "blank lines",
+		data:     "\n" + `{"type":"tx","ref":"` + testTxHash + `","label":"rent"}` + "\n\n",
+		imported: 1,
+		valid:    true,
+	}, {
+		name:  "empty",
+		valid: true,
+	}, {
+		name: "unknown type",
+		data: `{"type":"tx","ref":"` + testTxHash + `","label":"rent"}
+{"type":"block","ref":"` + testTxHash + `","label":"rent"}`,
+	}, {
+		name: "missing ref",
+		data: `{"type":"tx","label":"rent"}`,
+	}, {
+		name: "invalid json",
+		data: `{"type":"tx","ref":"` + testTxHash + `","label":"rent"`,
+	}, {
+		name: "csv",
+		data: "tx," + testTxHash + ",rent",
+	}}
+
+	for _, tc := range tests {
+		wallet := newLabelsWallet(t)
+		imported, err := wallet.ImportLabels(strings.NewReader(tc.data))
+		if tc.valid != (err == nil) {
+			t.Errorf("%s: got error %v, want valid %v", tc.name, err, tc.valid)
+			continue
+		}
+		if imported != tc.imported {
+			t.Errorf("%s: got %d labels imported, want %d", tc.name, imported, tc.imported)
+		}
+
+		// Nothing is saved unless every record is valid.
+		labels, err := wallet.GetWalletDataDb().Labels()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(labels) != tc.imported {
+			t.Errorf("%s: got %d labels saved, want %d", tc.name, len(labels), tc.imported)
+		}
+	}
+
+	wallet := newLabelsWallet(t)
+	if _, err := wallet.ImportLabels(strings.NewReader(tests[0].data)); err != nil {
+		t.Fatal(err)
+	}
+	if got := wallet.TransactionLabel(testTxHash); got != "rent" {
+		t.Errorf("got transaction label %q, want %q", got, "rent")
+	}
+	if got := wallet.AddressLabel("bc1q34aq5drpuwy3wgl9lhup9892qp6svr8ldzyy7c"); got != "Alice" {
+		t.Errorf("got address label %q, want %q", got, "Alice")
+	}
+	if got := wallet.OutputLabel(testTxHash, 1); got != "change" || !wallet.IsOutputFrozen(testTxHash, 1) {
+		t.Errorf("got output label %q and frozen %v, want %q and frozen", got, wallet.IsOutputFrozen(testTxHash, 1), "change")
+	}
+
+	// Imported labels replace the labels already set.
+	if _, err := wallet.ImportLabels(strings.NewReader(`{"type":"tx","ref":"` + testTxHash + `","label":"deposit"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if got := wallet.TransactionLabel(testTxHash); got != "deposit" {
+		t.Errorf("got transaction label %q after import, want %q", got, "deposit")
+	}
+}
+
+func TestExportLabels(t *testing.T) {
+	wallet := newLabelsWallet(t)
+	otherTxHash := strings.Repeat("ab", 32)
+	if err := wallet.SetTransactionLabel(testTxHash, " rent "); err != nil {
+		t.Fatal(err)
+	}
+	if err := wallet.SetAddressLabel("bc1q34aq5drpuwy3wgl9lhup9892qp6svr8ldzyy7c", "Alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := wallet.SetOutputLabel(testTxHash, 0, "savings"); err != nil {
+		t.Fatal(err)
+	}
+	if err := wallet.SetOutputFrozen(testTxHash, 1, true); err != nil {
+		t.Fatal(err)
+	}
+	// A cleared label is not exported.
+	if err := wallet.SetTransactionLabel(otherTxHash, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := wallet.ExportLabels(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bool{
+		`{"type":"tx","ref":"` + testTxHash + `","label":"rent"}`:                            true,
+		`{"type":"addr","ref":"bc1q34aq5drpuwy3wgl9lhup9892qp6svr8ldzyy7c","label":"Alice"}`: true,
+		`{"type":"output","ref":"` + testTxHash + `:0","label":"savings","spendable":true}`:  true,
+		`{"type":"output","ref":"` + testTxHash + `:1","label":"","spendable":false}`:        true,
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("got %d exported labels, want %d:\n%s", len(lines), len(want), buf.String())
+	}
+	for _, line := range lines {
+		if !want[line] {
+			t.Errorf("unexpected exported label %s", line)
+		}
+	}
+
+	other := newLabelsWallet(t)
+	if imported, err := other.ImportLabels(&buf); err != nil || imported != len(want) {
+		t.Fatalf("got %d labels imported (%v), want %d", imported, err, len(want))
+	}
+	if other.TransactionLabel(testTxHash) != "rent" || other.OutputLabel(testTxHash, 0) != "savings" ||
+		other.IsOutputFrozen(testTxHash, 0) || !other.IsOutputFrozen(testTxHash, 1) {
+		t.Error("the imported labels differ from the exported labels")
+	}
+}
+
+func TestTxSearchMatcher(t *testing.T) {
+	wallet := newLabelsWallet(t)
+	if err := wallet.SetAddressLabel("addr-alice", "Alice Cooper"); err != nil {
+		t.Fatal(err)
+	}
+	if err := wallet.SetTransactionLabel(testTxHash, "Rent"); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := &Transaction{
+		Hash:    testTxHash,
+		Outputs: []*TxOutput{{Address: "addr-alice"}, {Address: "addr-bob"}},
+	}
+	wallet.ApplyTxLabels(tx)
+	if tx.Label != "Rent" {
+		t.Fatalf("got transaction label %q, want %q", tx.Label, "Rent")
+	}
+
+	tests := []struct {
+		search string
+		match  bool
+	}{
+		{testTxHash, true},
+		{testTxHash[:10], false},
+		{"rent", true},
+		{" RENT ", true},
+		{"cooper", true},
+		{"addr-bob", true},
+		{"addr-b", false},
+		{"carol", false},
+		{"", false},
+	}
+	for _, tc := range tests {
+		if got := wallet.TxSearchMatcher(tc.search)(tx); got != tc.match {
+			t.Errorf("search %q: got match %v, want %v", tc.search, got, tc.match)
+		}
+	}
+}
+
+func TestOutputFreezing(t *testing.T) {
+	wallet := newLabelsWallet(t)
+	if err := wallet.SetOutputLabel(testTxHash, 0, "savings"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Freezing an output keeps its label, labeling it keeps it frozen.
+	if err := wallet.SetOutputFrozen(testTxHash, 0, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := wallet.SetOutputFrozen(testTxHash, 2, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := wallet.SetOutputLabel(testTxHash, 2, "cold"); err != nil {
+		t.Fatal(err)
+	}
+	if wallet.OutputLabel(testTxHash, 0) != "savings" || !wallet.IsOutputFrozen(testTxHash, 0) ||
+		wallet.OutputLabel(testTxHash, 2) != "cold" || !wallet.IsOutputFrozen(testTxHash, 2) {
+		t.Fatal("freezing and labeling an output overwrote each other")
+	}
+	if wallet.IsOutputFrozen(testTxHash, 1) {
+		t.Error("an output that was never frozen is frozen")
+	}
+
+	frozen, err := wallet.FrozenOutputs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frozen) != 2 || !containsString(frozen, testTxHash+":0") || !containsString(frozen, testTxHash+":2") {
+		t.Errorf("got frozen outputs %v, want outputs 0 and 2", frozen)
+	}
+
+	if err = wallet.SetOutputFrozen(testTxHash, 2, false); err != nil {
+		t.Fatal(err)
+	}
+	if frozen, _ = wallet.FrozenOutputs(); len(frozen) != 1 || frozen[0] != testTxHash+":0" {
+		t.Errorf("got frozen outputs %v after unfreezing output 2, want output 0", frozen)
+	}
+
+	utxos := []*UnspentOutput{
+		{TxID: testTxHash, Vout: 0},
+		{TxID: testTxHash, Vout: 1},
+		{TxID: testTxHash, Vout: 2},
+		{TxID: strings.Repeat("ab", 32), Vout: 0},
+	}
+	wallet.ApplyOutputLabels(utxos...)
+	want := []struct {
+		label  string
+		frozen bool
+	}{{"savings", true}, {"", false}, {"cold", false}, {"", false}}
+	for i, utxo := range utxos {
+		if utxo.Label != want[i].label || utxo.Frozen != want[i].frozen {
+			t.Errorf("output %d: got label %q and frozen %v, want %q and %v",
+				i, utxo.Label, utxo.Frozen, want[i].label, want[i].frozen)
+		}
+	}
+
+	unfrozen := UnfrozenOutputs(utxos)
+	if len(unfrozen) != 3 || unfrozen[0] != utxos[1] || unfrozen[1] != utxos[2] || unfrozen[2] != utxos[3] {
+		t.Errorf("got %d unfrozen outputs, want outputs 1, 2 and 3", len(unfrozen))
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
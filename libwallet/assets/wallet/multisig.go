@@ -0,0 +1,406 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// MaxMultisigCosigners is the largest number of cosigners of a multisig
+	// wallet, the limit of the keys of a standard P2WSH multisig script.
+	MaxMultisigCosigners = 15
+
+	// MultisigPurpose is the BIP-48 purpose of the cosigner keys derived by
+	// the wallets, the cosigner key of a wallet is the extended public key
+	// of m/48'/coin'/0'/2'.
+	MultisigPurpose = 48
+	// MultisigP2WSHScriptType is the BIP-48 script type of P2WSH multisig.
+	MultisigP2WSHScriptType = 2
+
+	// MultisigExternalBranch and MultisigInternalBranch are the branches of
+	// the receive and the change addresses, derived from each cosigner key.
+	MultisigExternalBranch uint32 = 0
+	MultisigInternalBranch uint32 = 1
+
+	// hardenedKeyStart is the index of the first hardened child key.
+	hardenedKeyStart = 0x80000000
+)
+
+// MultisigConfig describes an m-of-n multisig wallet. It is saved in the wallet
+// config of the coordinator wallet, a watch-only wallet that derives the P2WSH
+// addresses, tracks their outputs and creates the PSBTs the cosigners sign.
+type MultisigConfig struct {
+	// RequiredSigs is the number of signatures that spend an output, m.
+	RequiredSigs int
+	// CosignerKeys are the key expressions of the n cosigners, an extended
+	// public key optionally preceded by its origin, e.g.
+	// "[d34db33f/48h/0h/0h/2h]xpub...". Their order doesn't change the
+	// addresses, the public keys of every address are sorted.
+	CosignerKeys []string
+	// LocalWalletID is the ID of the wallet whose cosigner key is one of the
+	// CosignerKeys, it is 0 if all the cosigners are external.
+	LocalWalletID int
+}
+
+// Validate returns an error if the config doesn't describe an m-of-n
+// multisig wallet with valid key expressions.
+func (config *MultisigConfig) Validate() error {
+	n := len(config.CosignerKeys)
+	if n == 0 || n > MaxMultisigCosigners {
+		return fmt.Errorf("%s: a multisig wallet has 1 to %d cosigners", utils.ErrInvalid, MaxMultisigCosigners)
+	}
+	if config.RequiredSigs < 1 || config.RequiredSigs > n {
+		return fmt.Errorf("%s: the required signatures must be between 1 and %d", utils.ErrInvalid, n)
+	}
+
+	seen := make(map[string]bool, n)
+	for _, key := range config.CosignerKeys {
+		cosigner, err := ParseCosignerKey(key)
+		if err != nil {
+			return err
+		}
+		if seen[cosigner.XPub] {
+			return fmt.Errorf("%s: duplicate cosigner key %s", utils.ErrInvalid, cosigner.XPub)
+		}
+		seen[cosigner.XPub] = true
+	}
+	return nil
+}
+
+// Cosigners returns the parsed cosigner keys of the config.
+func (config *MultisigConfig) Cosigners() ([]*CosignerKey, error) {
+	cosigners := make([]*CosignerKey, len(config.CosignerKeys))
+	for i, key := range config.CosignerKeys {
+		cosigner, err := ParseCosignerKey(key)
+		if err != nil {
+			return nil, err
+		}
+		cosigners[i] = cosigner
+	}
+	return cosigners, nil
+}
+
+// Descriptor returns the output descriptor, with its checksum, of the
+// addresses of a branch of the multisig wallet: 0 for the receive addresses
+// and 1 for the change addresses.
+func (config *MultisigConfig) Descriptor(branch uint32) (string, error) {
+	cosigners, err := config.Cosigners()
+	if err != nil {
+		return "", err
+	}
+
+	keys := make([]string, len(cosigners))
+	for i, cosigner := range cosigners {
+		keys[i] = fmt.Sprintf("%s/%d/*", cosigner, branch)
+	}
+	desc := fmt.Sprintf("wsh(sortedmulti(%d,%s))", config.RequiredSigs, strings.Join(keys, ","))
+
+	checksum, err := DescriptorChecksum(desc)
+	if err != nil {
+		return "", err
+	}
+	return desc + "#" + checksum, nil
+}
+
+// Descriptors returns the output descriptors of the receive and the change
+// addresses of the multisig wallet, to set up the wallet with other
+// coordinators.
+func (config *MultisigConfig) Descriptors() (receive, change string, err error) {
+	if receive, err = config.Descriptor(MultisigExternalBranch); err != nil {
+		return "", "", err
+	}
+	if change, err = config.Descriptor(MultisigInternalBranch); err != nil {
+		return "", "", err
+	}
+	return receive, change, nil
+}
+
+// CosignerKey is a key expression of an output descriptor: an extended
+// public key and, if known, the fingerprint of the master key it is derived
+// from and its derivation path.
+type CosignerKey struct {
+	// Fingerprint is the fingerprint of the master key, it is only set with
+	// the Path.
+	Fingerprint []byte
+	// Path is the derivation path of XPub from the master key.
+	Path []uint32
+	XPub string
+}
+
+// HasOrigin returns true if the fingerprint and the path of the key are known.
+func (key *CosignerKey) HasOrigin() bool {
+	return len(key.Fingerprint) == 4
+}
+
+// String returns the key expression of the key, hardened derivation steps are
+// marked with "h".
+func (key *CosignerKey) String() string {
+	if !key.HasOrigin() {
+		return key.XPub
+	}
+
+	var origin strings.Builder
+	origin.WriteString(hex.EncodeToString(key.Fingerprint))
+	for _, index := range key.Path {
+		origin.WriteByte('/')
+		if index >= hardenedKeyStart {
+			origin.WriteString(strconv.FormatUint(uint64(index-hardenedKeyStart), 10))
+			origin.WriteByte('h')
+		} else {
+			origin.WriteString(strconv.FormatUint(uint64(index), 10))
+		}
+	}
+	return fmt.Sprintf("[%s]%s", origin.String(), key.XPub)
+}
+
+// ParseCosignerKey parses a key expression, "[fingerprint/path]xpub" or a
+// plain extended public key. The extended public key isn't decoded, it is
+// decoded with the chain parameters of the wallet.
+func ParseCosignerKey(expr string) (*CosignerKey, error) {
+	expr = strings.TrimSpace(expr)
+	invalidKey := func(reason string) error {
+		return fmt.Errorf("%s: cosigner key %q: %s", utils.ErrInvalid, expr, reason)
+	}
+
+	key := &CosignerKey{XPub: expr}
+	if strings.HasPrefix(expr, "[") {
+		end := strings.Index(expr, "]")
+		if end < 0 {
+			return nil, invalidKey("unterminated key origin")
+		}
+
+		steps := strings.Split(expr[1:end], "/")
+		fingerprint, err := hex.DecodeString(steps[0])
+		if err != nil || len(fingerprint) != 4 {
+			return nil, invalidKey("the fingerprint must be 8 hex characters")
+		}
+		key.Fingerprint = fingerprint
+
+		for _, step := range steps[1:] {
+			var hardened uint32
+			if trimmed := strings.TrimRight(step, "h'H"); len(trimmed) == len(step)-1 {
+				step, hardened = trimmed, hardenedKeyStart
+			}
+			index, err := strconv.ParseUint(step, 10, 31)
+			if err != nil {
+				return nil, invalidKey("invalid derivation step " + step)
+			}
+			key.Path = append(key.Path, uint32(index)+hardened)
+		}
+		key.XPub = expr[end+1:]
+	}
+
+	if key.XPub == "" || strings.ContainsAny(key.XPub, "/*[](),") {
+		return nil, invalidKey("expected an extended public key without derivation steps")
+	}
+	return key, nil
+}
+
+const (
+	descriptorInputCharset = "0123456789()[],'/*abcdefgh@:$%{}" +
+		"IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~" +
+		"ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+	descriptorChecksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+// descriptorPolyMod computes the BCH checksum of the output descriptors.
+func descriptorPolyMod(c uint64, val int) uint64 {
+	c0 := c >> 35
+	c = ((c & 0x7ffffffff) << 5) ^ uint64(val)
+	generators := [5]uint64{0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a, 0x644d626ffd}
+	for i, generator := range generators {
+		if c0&(1<<i) != 0 {
+			c ^= generator
+		}
+	}
+	return c
+}
+
+// DescriptorChecksum returns the BIP-380 checksum of an output descriptor,
+// without the "#" separator.
+func DescriptorChecksum(desc string) (string, error) {
+	c := uint64(1)
+	cls, clsCount := 0, 0
+	for _, ch := range desc {
+		pos := strings.IndexRune(descriptorInputCharset, ch)
+		if pos < 0 {
+			return "", fmt.Errorf("%s: invalid character %q in descriptor", utils.ErrInvalid, ch)
+		}
+		c = descriptorPolyMod(c, pos&31)
+		cls = cls*3 + pos>>5
+		clsCount++
+		if clsCount == 3 {
+			c = descriptorPolyMod(c, cls)
+			cls, clsCount = 0, 0
+		}
+	}
+	if clsCount > 0 {
+		c = descriptorPolyMod(c, cls)
+	}
+	for i := 0; i < 8; i++ {
+		c = descriptorPolyMod(c, 0)
+	}
+	c ^= 1
+
+	checksum := make([]byte, 8)
+	for i := range checksum {
+		checksum[i] = descriptorChecksumCharset[(c>>(5*(7-i)))&31]
+	}
+	return string(checksum), nil
+}
+
+// MultisigPSBTStatus describes the signing progress of a multisig PSBT.
+type MultisigPSBTStatus struct {
+	// TxID is the hash of the unsigned transaction, it is the same for every
+	// cosigner's copy of the PSBT.
+	TxID string
+	Fee  int64
+	// RequiredSigs is the number of signatures each input needs and
+	// Signatures the number of signatures of the input that has the fewest.
+	RequiredSigs int
+	Signatures   int
+	// Complete is true once every input has enough signatures to finalize
+	// the transaction.
+	Complete bool
+}
+
+// The PSBTs of the BTC and LTC multisig wallets share their encoding and the
+// segwit v0 signature hashes, they are combined and finalized as Bitcoin
+// PSBTs.
+
+// DecodePSBT decodes a base64 encoded PSBT.
+func DecodePSBT(b64PSBT string) (*psbt.Packet, error) {
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(strings.TrimSpace(b64PSBT)), true)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid PSBT: %v", utils.ErrInvalid, err)
+	}
+	return packet, nil
+}
+
+// CombineMultisigPSBTs combines the signatures of the copies of a PSBT that
+// the cosigners signed.
+func CombineMultisigPSBTs(b64PSBTs []string) (string, error) {
+	if len(b64PSBTs) == 0 {
+		return "", fmt.Errorf("%s: no PSBT", utils.ErrInvalid)
+	}
+
+	combined, err := DecodePSBT(b64PSBTs[0])
+	if err != nil {
+		return "", err
+	}
+	txHash := combined.UnsignedTx.TxHash()
+	for _, b64PSBT := range b64PSBTs[1:] {
+		packet, err := DecodePSBT(b64PSBT)
+		if err != nil {
+			return "", err
+		}
+		if packet.UnsignedTx.TxHash() != txHash {
+			return "", fmt.Errorf("%s: the PSBTs spend different transactions", utils.ErrInvalid)
+		}
+
+		for i, input := range packet.Inputs {
+			for _, sig := range input.PartialSigs {
+				if !hasPartialSig(combined.Inputs[i].PartialSigs, sig.PubKey) {
+					combined.Inputs[i].PartialSigs = append(combined.Inputs[i].PartialSigs, sig)
+				}
+			}
+		}
+	}
+
+	return combined.B64Encode()
+}
+
+func hasPartialSig(sigs []*psbt.PartialSig, pubKey []byte) bool {
+	for _, sig := range sigs {
+		if bytes.Equal(sig.PubKey, pubKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// PSBTStatus returns the signing progress of a PSBT of the multisig wallet.
+func (config *MultisigConfig) PSBTStatus(b64PSBT string) (*MultisigPSBTStatus, error) {
+	packet, err := DecodePSBT(b64PSBT)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &MultisigPSBTStatus{
+		TxID:         packet.UnsignedTx.TxHash().String(),
+		RequiredSigs: config.RequiredSigs,
+		Signatures:   config.RequiredSigs,
+	}
+	if fee, err := packet.GetTxFee(); err == nil {
+		status.Fee = int64(fee)
+	}
+	for _, input := range packet.Inputs {
+		sigs := len(input.PartialSigs)
+		if input.FinalScriptWitness != nil {
+			sigs = config.RequiredSigs
+		}
+		if sigs < status.Signatures {
+			status.Signatures = sigs
+		}
+	}
+	status.Complete = status.Signatures >= config.RequiredSigs
+	return status, nil
+}
+
+// FinalizePSBT finalizes the inputs of a PSBT of the multisig wallet signed by
+// enough cosigners and returns the signed transaction. The witness of every
+// input is executed against the output it spends.
+func (config *MultisigConfig) FinalizePSBT(b64PSBT string) (*wire.MsgTx, error) {
+	packet, err := DecodePSBT(b64PSBT)
+	if err != nil {
+		return nil, err
+	}
+
+	// The finalizer expects the exact number of signatures the script
+	// checks, extra signatures are left out.
+	for i := range packet.Inputs {
+		if sigs := packet.Inputs[i].PartialSigs; len(sigs) > config.RequiredSigs {
+			packet.Inputs[i].PartialSigs = sigs[:config.RequiredSigs]
+		}
+	}
+	if err := psbt.MaybeFinalizeAll(packet); err != nil {
+		return nil, fmt.Errorf("%s: the PSBT can't be finalized: %v", utils.ErrInvalid, err)
+	}
+	tx, err := psbt.Extract(packet)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prove that the transaction has been validly signed by executing the
+	// script pairs.
+	prevOutFetcher := txscript.NewMultiPrevOutFetcher(nil)
+	for i, input := range packet.Inputs {
+		if input.WitnessUtxo == nil {
+			return nil, fmt.Errorf("%s: input %d isn't a P2WSH input", utils.ErrInvalid, i)
+		}
+		prevOutFetcher.AddPrevOut(tx.TxIn[i].PreviousOutPoint, input.WitnessUtxo)
+	}
+	sigHashes := txscript.NewTxSigHashes(tx, prevOutFetcher)
+	flags := txscript.ScriptBip16 | txscript.ScriptVerifyDERSignatures |
+		txscript.ScriptStrictMultiSig | txscript.ScriptDiscourageUpgradableNops |
+		txscript.ScriptVerifyWitness
+	for i, input := range packet.Inputs {
+		vm, err := txscript.NewEngine(input.WitnessUtxo.PkScript, tx, i, flags, nil, sigHashes,
+			input.WitnessUtxo.Value, prevOutFetcher)
+		if err != nil {
+			return nil, err
+		}
+		if err := vm.Execute(); err != nil {
+			return nil, fmt.Errorf("%s: input %d: %v", utils.ErrInvalid, i, err)
+		}
+	}
+	return tx, nil
+}
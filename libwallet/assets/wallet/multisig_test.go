@@ -0,0 +1,298 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+func TestDescriptorChecksum(t *testing.T) {
+	// Test vector of BIP-380.
+	got, err := DescriptorChecksum("raw(deadbeef)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "89f8spxm" {
+		t.Errorf("got checksum %s, want 89f8spxm", got)
+	}
+
+	if _, err := DescriptorChecksum("raw(deadbeef)\n"); err == nil {
+		t.Errorf("expected an error for a character outside the descriptor charset")
+	}
+}
+
+func TestParseCosignerKey(t *testing.T) {
+	const xpub = "tpubDFH9dgzveyD8zTbPUFuLrGmCydNvxehyNdUXKJAQN8x4aZ4j6UZqGfnqFrD4NqyaTVGKbvEW54tsvPTK2UoSbCC1PJY8iCNiwTL3RWZEheQ"
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    string
+		path    []uint32
+		invalid bool
+	}{
+		{"plain xpub", xpub, xpub, nil, false},
+		{"origin", "[d34db33f/48'/1'/0'/2']" + xpub, "[d34db33f/48h/1h/0h/2h]" + xpub,
+			[]uint32{48 + hardenedKeyStart, 1 + hardenedKeyStart, hardenedKeyStart, 2 + hardenedKeyStart}, false},
+		{"unhardened step", "[d34db33f/48h/1]" + xpub, "[d34db33f/48h/1]" + xpub,
+			[]uint32{48 + hardenedKeyStart, 1}, false},
+		{"short fingerprint", "[d34db3/48h]" + xpub, "", nil, true},
+		{"unterminated origin", "[d34db33f/48h" + xpub, "", nil, true},
+		{"derivation steps", xpub + "/0/*", "", nil, true},
+		{"invalid step", "[d34db33f/48hh]" + xpub, "", nil, true},
+	}
+	for _, tc := range tests {
+		key, err := ParseCosignerKey(tc.expr)
+		if tc.invalid {
+			if err == nil {
+				t.Errorf("%s: expected an error", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if key.String() != tc.want {
+			t.Errorf("%s: got key %s, want %s", tc.name, key, tc.want)
+		}
+		if len(key.Path) != len(tc.path) {
+			t.Fatalf("%s: got path %v, want %v", tc.name, key.Path, tc.path)
+		}
+		for i := range tc.path {
+			if key.Path[i] != tc.path[i] {
+				t.Errorf("%s: got path %v, want %v", tc.name, key.Path, tc.path)
+				break
+			}
+		}
+	}
+}
+
+func TestMultisigConfigValidate(t *testing.T) {
+	keys := []string{"tpubA", "[d34db33f/48h/1h/0h/2h]tpubB", "tpubC"}
+
+	tests := []struct {
+		name     string
+		required int
+		keys     []string
+		valid    bool
+	}{
+		{"2 of 3", 2, keys, true},
+		{"3 of 3", 3, keys, true},
+		{"no required signatures", 0, keys, false},
+		{"more signatures than cosigners", 4, keys, false},
+		{"no cosigners", 1, nil, false},
+		{"duplicate cosigner", 2, []string{"tpubA", "[d34db33f/48h]tpubA"}, false},
+	}
+	for _, tc := range tests {
+		config := &MultisigConfig{RequiredSigs: tc.required, CosignerKeys: tc.keys}
+		if err := config.Validate(); (err == nil) != tc.valid {
+			t.Errorf("%s: got error %v, want valid %v", tc.name, err, tc.valid)
+		}
+	}
+}
+
+func TestMultisigDescriptors(t *testing.T) {
+	config := &MultisigConfig{
+		RequiredSigs: 2,
+		CosignerKeys: []string{"[d34db33f/48'/1'/0'/2']tpubA", "tpubB", "[0badc0de/48h/1h/0h/2h]tpubC"},
+	}
+	receive, change, err := config.Descriptors()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc string
+		want string
+	}{
+		{receive, "wsh(sortedmulti(2,[d34db33f/48h/1h/0h/2h]tpubA/0/*,tpubB/0/*,[0badc0de/48h/1h/0h/2h]tpubC/0/*))"},
+		{change, "wsh(sortedmulti(2,[d34db33f/48h/1h/0h/2h]tpubA/1/*,tpubB/1/*,[0badc0de/48h/1h/0h/2h]tpubC/1/*))"},
+	}
+	for _, tc := range tests {
+		desc, checksum, ok := strings.Cut(tc.desc, "#")
+		if !ok || desc != tc.want {
+			t.Errorf("got descriptor %s, want %s#<checksum>", tc.desc, tc.want)
+			continue
+		}
+		if want, _ := DescriptorChecksum(tc.want); checksum != want {
+			t.Errorf("%s: got checksum %s, want %s", desc, checksum, want)
+		}
+	}
+
+	config.CosignerKeys = append(config.CosignerKeys, "tpubD/0/*")
+	if _, _, err := config.Descriptors(); err == nil {
+		t.Error("expected an error for an invalid cosigner key")
+	}
+}
+
+// testMultisigPSBT is a PSBT spending a 2-of-3 P2WSH output.
+type testMultisigPSBT struct {
+	keys   []*btcec.PrivateKey
+	script []byte
+	b64    string
+}
+
+func newTestMultisigPSBT(t *testing.T) *testMultisigPSBT {
+	t.Helper()
+	keys := make([]*btcec.PrivateKey, 3)
+	for i := range keys {
+		keys[i], _ = btcec.PrivKeyFromBytes(bytes.Repeat([]byte{byte(i + 1)}, 32))
+	}
+	pubKeys := make([][]byte, len(keys))
+	for i, key := range keys {
+		pubKeys[i] = key.PubKey().SerializeCompressed()
+	}
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i], pubKeys[j]) < 0
+	})
+
+	builder := txscript.NewScriptBuilder().AddInt64(2)
+	for _, pubKey := range pubKeys {
+		builder.AddData(pubKey)
+	}
+	script, err := builder.AddInt64(3).AddOp(txscript.OP_CHECKMULTISIG).Script()
+	if err != nil {
+		t.Fatal(err)
+	}
+	scriptHash := sha256.Sum256(script)
+	pkScript, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(scriptHash[:]).Script()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{1}}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(90000, pkScript))
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packet.Inputs[0].WitnessUtxo = wire.NewTxOut(100000, pkScript)
+	packet.Inputs[0].WitnessScript = script
+	b64, err := packet.B64Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testMultisigPSBT{keys: keys, script: script, b64: b64}
+}
+
+// sign returns a copy of the PSBT signed by the cosigner and its signature.
+func (p *testMultisigPSBT) sign(t *testing.T, cosigner int) (string, []byte) {
+	t.Helper()
+	packet, err := DecodePSBT(p.b64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := packet.Inputs[0]
+	prevOutFetcher := txscript.NewCannedPrevOutputFetcher(input.WitnessUtxo.PkScript, input.WitnessUtxo.Value)
+	sigHashes := txscript.NewTxSigHashes(packet.UnsignedTx, prevOutFetcher)
+	sig, err := txscript.RawTxInWitnessSignature(packet.UnsignedTx, sigHashes, 0, input.WitnessUtxo.Value,
+		p.script, txscript.SigHashAll, p.keys[cosigner])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := updater.Sign(0, sig, p.keys[cosigner].PubKey().SerializeCompressed(), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	b64, err := packet.B64Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b64, sig
+}
+
+func TestCombineAndFinalizeMultisigPSBT(t *testing.T) {
+	p := newTestMultisigPSBT(t)
+	config := &MultisigConfig{RequiredSigs: 2}
+
+	status, err := config.PSBTStatus(p.b64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Signatures != 0 || status.Complete || status.Fee != 10000 {
+		t.Fatalf("got status %+v for the unsigned PSBT", status)
+	}
+
+	// The cosigners sign their copy of the PSBT, out of the order of their
+	// keys in the script.
+	signedBy2, sig2 := p.sign(t, 2)
+	signedBy0, sig0 := p.sign(t, 0)
+	if _, err := config.FinalizePSBT(signedBy2); err == nil {
+		t.Fatal("a PSBT signed by 1 of 2 cosigners was finalized")
+	}
+	if status, err = config.PSBTStatus(signedBy0); err != nil || status.Signatures != 1 || status.Complete {
+		t.Fatalf("got status %+v (%v) for the PSBT signed once", status, err)
+	}
+
+	// Combining the same signature twice doesn't count it twice.
+	combined, err := CombineMultisigPSBTs([]string{signedBy2, signedBy0, signedBy2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, err = config.PSBTStatus(combined); err != nil || status.Signatures != 2 || !status.Complete {
+		t.Fatalf("got status %+v (%v) for the combined PSBT", status, err)
+	}
+
+	tx, err := config.FinalizePSBT(combined)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The witness holds the empty element consumed by the CHECKMULTISIG bug,
+	// the signatures in the order of their keys in the script, then the
+	// script.
+	keyIndex := func(key *btcec.PrivateKey) int {
+		return bytes.Index(p.script, key.PubKey().SerializeCompressed())
+	}
+	wantSigs := [][]byte{sig0, sig2}
+	if keyIndex(p.keys[2]) < keyIndex(p.keys[0]) {
+		wantSigs = [][]byte{sig2, sig0}
+	}
+	witness := tx.TxIn[0].Witness
+	if len(witness) != 4 || len(witness[0]) != 0 || !bytes.Equal(witness[1], wantSigs[0]) ||
+		!bytes.Equal(witness[2], wantSigs[1]) || !bytes.Equal(witness[3], p.script) {
+		t.Fatalf("unexpected witness %x", witness)
+	}
+	if len(tx.TxIn[0].SignatureScript) != 0 {
+		t.Fatal("the P2WSH input has a signature script")
+	}
+
+	// Extra signatures are left out of the witness.
+	signedBy1, _ := p.sign(t, 1)
+	combined, err = CombineMultisigPSBTs([]string{signedBy0, signedBy1, signedBy2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx, err = config.FinalizePSBT(combined); err != nil || len(tx.TxIn[0].Witness) != 4 {
+		t.Fatalf("unable to finalize the PSBT signed by all 3 cosigners: %v", err)
+	}
+
+	// The copies of different PSBTs aren't combined.
+	other, err := DecodePSBT(p.b64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other.UnsignedTx.TxOut[0].Value--
+	otherB64, err := other.B64Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CombineMultisigPSBTs([]string{signedBy0, otherB64}); err == nil {
+		t.Fatal("the copies of different PSBTs were combined")
+	}
+	if _, err := CombineMultisigPSBTs(nil); err == nil {
+		t.Fatal("expected an error without PSBT")
+	}
+}
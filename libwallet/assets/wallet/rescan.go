@@ -0,0 +1,94 @@
+package wallet
+
+import (
+	"sort"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// MaxRescanGapLimit is the largest gap limit a rescan accepts.
+const MaxRescanGapLimit = 1000
+
+// defaultRescanBlockDurations are the times each asset is assumed to take to
+// rescan a block until a rescan of the wallet has been timed.
+var defaultRescanBlockDurations = map[utils.AssetType]time.Duration{
+	utils.DCRWalletAsset: 2 * time.Millisecond,  // cfilters are kept locally
+	utils.BTCWalletAsset: 20 * time.Millisecond, // cfilters are fetched from peers
+	utils.LTCWalletAsset: 10 * time.Millisecond,
+}
+
+// BlockTimeFunc returns the timestamp of the block at height.
+type BlockTimeFunc func(height int32) (time.Time, error)
+
+// EstimateRescanFromBlocks resolves the start block of the rescan described
+// by opts and estimates how long rescanning up to bestHeight takes, going by
+// the time previous rescans of the wallet took per block.
+func (wallet *Wallet) EstimateRescanFromBlocks(opts *RescanOptions, bestHeight int32, blockTime BlockTimeFunc) (*RescanEstimate, error) {
+	if opts.GapLimit > MaxRescanGapLimit || opts.StartHeight < 0 || opts.StartHeight > bestHeight {
+		return nil, errors.New(utils.ErrInvalid)
+	}
+
+	startHeight := opts.StartHeight
+	if !opts.StartDate.IsZero() {
+		var err error
+		startHeight, err = heightAtTime(opts.StartDate, bestHeight, blockTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	startTime, err := blockTime(startHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := bestHeight - startHeight + 1
+	return &RescanEstimate{
+		StartHeight: startHeight,
+		StartTime:   startTime,
+		Blocks:      blocks,
+		Duration:    time.Duration(blocks) * wallet.rescanBlockDuration(),
+	}, nil
+}
+
+// SaveRescanDuration records how long a finished rescan of blocks took, to
+// improve the estimates of later rescans.
+func (wallet *Wallet) SaveRescanDuration(blocks int32, elapsed time.Duration) {
+	if blocks <= 0 || elapsed <= 0 {
+		return
+	}
+	wallet.SaveUserConfigValue(RescanBlockDurationConfigKey, elapsed/time.Duration(blocks))
+}
+
+func (wallet *Wallet) rescanBlockDuration() time.Duration {
+	var blockDuration time.Duration
+	if err := wallet.ReadUserConfigValue(RescanBlockDurationConfigKey, &blockDuration); err != nil || blockDuration <= 0 {
+		return defaultRescanBlockDurations[wallet.GetAssetType()]
+	}
+	return blockDuration
+}
+
+// heightAtTime returns the height of the first block whose timestamp isn't
+// before t. Block timestamps only roughly increase with the height, which is
+// precise enough to pick the start of a rescan.
+func heightAtTime(t time.Time, bestHeight int32, blockTime BlockTimeFunc) (int32, error) {
+	var err error
+	height := sort.Search(int(bestHeight)+1, func(h int) bool {
+		if err != nil {
+			return true
+		}
+		var ts time.Time
+		ts, err = blockTime(int32(h))
+		return !ts.Before(t)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if height > int(bestHeight) {
+		// No block was mined after t.
+		return bestHeight, nil
+	}
+	return int32(height), nil
+}
@@ -0,0 +1,48 @@
+package wallet
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHeightAtTime(t *testing.T) {
+	genesis := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	// A block every 10 minutes, except that block 5 is timestamped before
+	// block 4 as miners' clocks allow.
+	blockTime := func(height int32) (time.Time, error) {
+		if height == 5 {
+			return genesis.Add(35 * time.Minute), nil
+		}
+		return genesis.Add(time.Duration(height) * 10 * time.Minute), nil
+	}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want int32
+	}{
+		{"before genesis", genesis.Add(-time.Hour), 0},
+		{"genesis", genesis, 0},
+		{"exact block time", genesis.Add(30 * time.Minute), 3},
+		{"between blocks", genesis.Add(25 * time.Minute), 3},
+		{"after best block", genesis.Add(24 * time.Hour), 100},
+	}
+	for _, tc := range tests {
+		got, err := heightAtTime(tc.t, 100, blockTime)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: got height %d, want %d", tc.name, got, tc.want)
+		}
+	}
+
+	errBlock := errors.New("block not found")
+	_, err := heightAtTime(genesis, 100, func(int32) (time.Time, error) {
+		return time.Time{}, errBlock
+	})
+	if !errors.Is(err, errBlock) {
+		t.Fatalf("got error %v, want %v", err, errBlock)
+	}
+}
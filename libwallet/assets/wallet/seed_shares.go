@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+	"decred.org/dcrwallet/v4/walletseed"
+	"github.com/crypto-power/cryptopower/libwallet/internal/slip39"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// SeedShares splits the wallet seed into SLIP-39 share mnemonics so that no
+// single complete copy of the seed has to be kept. Any groupThreshold of the
+// groups recover the seed, each group with its member threshold of shares.
+// The shares encode the entropy of the wallet's seed words, the optional
+// BIP-39 seed passphrase is not part of the shares.
+func (wallet *Wallet) SeedShares(privatePassphrase string, groupThreshold int, groups []SeedShareGroup) ([][]string, error) {
+	seed, err := wallet.DecryptSeed(privatePassphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	entropy, err := seedEntropy(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	shareGroups := make([]slip39.Group, len(groups))
+	for i, group := range groups {
+		shareGroups[i] = slip39.Group{MemberThreshold: group.MemberThreshold, MemberCount: group.MemberCount}
+	}
+
+	return slip39.GenerateMnemonics(groupThreshold, shareGroups, entropy, nil)
+}
+
+// VerifySeedSharesForWallet compares the seed recovered from the SLIP-39
+// share mnemonics and seedPassphrase with the wallet seed, see
+// VerifySeedForWallet.
+func (wallet *Wallet) VerifySeedSharesForWallet(shares []string, seedPassphrase, privpass string) (bool, error) {
+	seed, err := wallet.DecryptSeed(privpass)
+	if err != nil {
+		return false, err
+	}
+
+	entropy, err := seedEntropy(seed)
+	if err != nil {
+		return false, err
+	}
+
+	sharedEntropy, err := slip39.CombineMnemonics(shares, nil)
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(entropy, sharedEntropy) {
+		return false, errors.New(utils.ErrInvalid)
+	}
+
+	return wallet.VerifySeedForWallet(seed, seedPassphrase, privpass)
+}
+
+// VerifySeedShare returns an error if the SLIP-39 share mnemonic can not be
+// decoded.
+func VerifySeedShare(share string) error {
+	_, err := slip39.DecodeMnemonic(share)
+	return err
+}
+
+// SeedFromShares recovers the seed words of the provided seed type from
+// SLIP-39 share mnemonics created by SeedShares. The recovered seed words are
+// used to restore the wallet.
+func SeedFromShares(shares []string, seedType WordSeedType) (string, error) {
+	entropy, err := slip39.CombineMnemonics(shares, nil)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case seedType == WordSeed33 && len(entropy) == 32:
+		return walletseed.EncodeMnemonic(entropy), nil
+	case seedType == WordSeed24 && len(entropy) == 32, seedType == WordSeed12 && len(entropy) == 16:
+		return bip39.NewMnemonic(entropy)
+	default:
+		return "", utils.ErrSeedSharesMismatch
+	}
+}
+
+// seedEntropy returns the entropy encoded by the seed words or hex encoded
+// seed.
+func seedEntropy(seed string) ([]byte, error) {
+	words := strings.Fields(seed)
+	switch len(words) {
+	case 1:
+		return hex.DecodeString(words[0])
+	case WordSeed33.ToInt():
+		return walletseed.DecodeUserInput(seed)
+	default:
+		return bip39.EntropyFromMnemonic(seed)
+	}
+}
@@ -0,0 +1,128 @@
+package wallet
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/asdine/storm"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const testPrivatePassphrase = "passphrase"
+
+// newSeedWallet saves a wallet with a new seed of the provided type in a
+// temporary database.
+func newSeedWallet(t *testing.T, seedType WordSeedType) (*Wallet, string) {
+	t.Helper()
+	db, err := storm.Open(filepath.Join(t.TempDir(), "wallets.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	mnemonic, err := generateMnemonic(seedType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedMnemonic, err := encryptWalletMnemonic([]byte(testPrivatePassphrase), mnemonic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wallet := &Wallet{Name: "shares", db: db, EncryptedMnemonic: encryptedMnemonic}
+	if err = db.Save(wallet); err != nil {
+		t.Fatal(err)
+	}
+	return wallet, mnemonic
+}
+
+func TestSeedShares(t *testing.T) {
+	groups := []SeedShareGroup{{MemberThreshold: 2, MemberCount: 3}, {MemberThreshold: 1, MemberCount: 1}}
+
+	for _, seedType := range []WordSeedType{WordSeed12, WordSeed24, WordSeed33} {
+		wallet, mnemonic := newSeedWallet(t, seedType)
+
+		if _, err := wallet.SeedShares("wrong", 1, groups); err == nil {
+			t.Fatalf("%d words: created shares with a wrong passphrase", seedType)
+		}
+
+		shares, err := wallet.SeedShares(testPrivatePassphrase, 1, groups)
+		if err != nil {
+			t.Fatalf("%d words: unexpected error: %v", seedType, err)
+		}
+		if len(shares) != 2 || len(shares[0]) != 3 || len(shares[1]) != 1 {
+			t.Fatalf("%d words: got shares %v, want groups of 3 and 1 shares", seedType, shares)
+		}
+		for _, share := range append(shares[0], shares[1]...) {
+			if err = VerifySeedShare(share); err != nil {
+				t.Errorf("%d words: share %q does not verify: %v", seedType, share, err)
+			}
+		}
+
+		// Either group recovers the seed.
+		for _, recoveryShares := range [][]string{shares[0][1:], shares[1]} {
+			seed, err := SeedFromShares(recoveryShares, seedType)
+			if err != nil {
+				t.Fatalf("%d words: unexpected error recovering the seed: %v", seedType, err)
+			}
+			if seed != mnemonic {
+				t.Errorf("%d words: got seed %q, want %q", seedType, seed, mnemonic)
+			}
+		}
+
+		if _, err = SeedFromShares(shares[0][:1], seedType); err == nil {
+			t.Errorf("%d words: recovered the seed from 1 of 2 shares", seedType)
+		}
+
+		verified, err := wallet.VerifySeedSharesForWallet(shares[1], "", testPrivatePassphrase)
+		if err != nil || !verified {
+			t.Errorf("%d words: got verified %v (%v), want the shares verified", seedType, verified, err)
+		}
+		if !wallet.IsBackedUp {
+			t.Errorf("%d words: the wallet is not backed up after verifying its shares", seedType)
+		}
+	}
+}
+
+func TestSeedFromSharesSeedType(t *testing.T) {
+	wallet12, _ := newSeedWallet(t, WordSeed12)
+	wallet24, _ := newSeedWallet(t, WordSeed24)
+	groups := []SeedShareGroup{{MemberThreshold: 1, MemberCount: 1}}
+	shares12, err := wallet12.SeedShares(testPrivatePassphrase, 1, groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shares24, err := wallet24.SeedShares(testPrivatePassphrase, 1, groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		shares   []string
+		seedType WordSeedType
+		valid    bool
+	}{
+		{"12 words", shares12[0], WordSeed12, true},
+		{"12 words as 24 words", shares12[0], WordSeed24, false},
+		{"12 words as 33 words", shares12[0], WordSeed33, false},
+		// 24 and 33 word seeds both encode 256 bits of entropy.
+		{"24 words", shares24[0], WordSeed24, true},
+		{"24 words as 33 words", shares24[0], WordSeed33, true},
+		{"24 words as 12 words", shares24[0], WordSeed12, false},
+	}
+	for _, tc := range tests {
+		_, err := SeedFromShares(tc.shares, tc.seedType)
+		if tc.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+		if !tc.valid && !errors.Is(err, utils.ErrSeedSharesMismatch) {
+			t.Errorf("%s: got error %v, want %v", tc.name, err, utils.ErrSeedSharesMismatch)
+		}
+	}
+
+	// The shares of one wallet don't verify another.
+	if verified, _ := wallet24.VerifySeedSharesForWallet(shares12[0], "", testPrivatePassphrase); verified {
+		t.Error("the shares of another wallet verified")
+	}
+}
@@ -0,0 +1,67 @@
+package wallet
+
+import (
+	"reflect"
+	"time"
+)
+
+// SyncCheckpoint returns the sync checkpoint saved in the wallet config.
+func (wallet *Wallet) SyncCheckpoint() SyncCheckpoint {
+	var checkpoint SyncCheckpoint
+	_ = wallet.ReadUserConfigValue(SyncCheckpointConfigKey, &checkpoint)
+	return checkpoint
+}
+
+// UpdateSyncCheckpoint applies update to the saved sync checkpoint and saves
+// the result. Nothing is saved if update leaves the checkpoint unchanged.
+func (wallet *Wallet) UpdateSyncCheckpoint(update func(checkpoint *SyncCheckpoint)) {
+	wallet.syncCheckpointMu.Lock()
+	defer wallet.syncCheckpointMu.Unlock()
+
+	checkpoint := wallet.SyncCheckpoint()
+	updated := checkpoint
+	update(&updated)
+	if reflect.DeepEqual(updated, checkpoint) {
+		return
+	}
+
+	updated.UpdatedAt = time.Now()
+	wallet.SaveUserConfigValue(SyncCheckpointConfigKey, updated)
+}
+
+// SaveHeadersCheckpoint records height as the last header height processed.
+func (wallet *Wallet) SaveHeadersCheckpoint(height int32) {
+	wallet.UpdateSyncCheckpoint(func(checkpoint *SyncCheckpoint) {
+		if height > checkpoint.HeadersHeight {
+			checkpoint.HeadersHeight = height
+		}
+	})
+}
+
+// SaveRescanCheckpoint records that a rescan started from startHeight has
+// reached height.
+func (wallet *Wallet) SaveRescanCheckpoint(startHeight, height int32) {
+	wallet.UpdateSyncCheckpoint(func(checkpoint *SyncCheckpoint) {
+		checkpoint.Rescanning = true
+		checkpoint.RescanStartHeight = startHeight
+		checkpoint.RescanHeight = height
+	})
+}
+
+// SaveRescanScope records the subset of the addresses the rescan looks for,
+// nil if it looks for all the addresses of the wallet.
+func (wallet *Wallet) SaveRescanScope(scope *RescanScope) {
+	wallet.UpdateSyncCheckpoint(func(checkpoint *SyncCheckpoint) {
+		checkpoint.RescanScope = scope
+	})
+}
+
+// ClearRescanCheckpoint records that no rescan needs to be resumed.
+func (wallet *Wallet) ClearRescanCheckpoint() {
+	wallet.UpdateSyncCheckpoint(func(checkpoint *SyncCheckpoint) {
+		checkpoint.Rescanning = false
+		checkpoint.RescanStartHeight = 0
+		checkpoint.RescanHeight = 0
+		checkpoint.RescanScope = nil
+	})
+}
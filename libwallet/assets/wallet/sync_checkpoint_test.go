@@ -0,0 +1,66 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSyncCheckpoint(t *testing.T) {
+	wallet, _ := newSeedWallet(t, WordSeed12)
+
+	if checkpoint := wallet.SyncCheckpoint(); checkpoint != (SyncCheckpoint{}) {
+		t.Fatalf("got checkpoint %+v before any sync", checkpoint)
+	}
+
+	// The headers height only moves forward, a reorg or a restarted fetch
+	// doesn't lose the progress.
+	for _, height := range []int32{100, 250, 180} {
+		wallet.SaveHeadersCheckpoint(height)
+	}
+	checkpoint := wallet.SyncCheckpoint()
+	if checkpoint.HeadersHeight != 250 {
+		t.Errorf("got headers height %d, want 250", checkpoint.HeadersHeight)
+	}
+	if checkpoint.UpdatedAt.IsZero() {
+		t.Error("the update time wasn't recorded")
+	}
+
+	// An unchanged checkpoint isn't saved again.
+	updatedAt := checkpoint.UpdatedAt
+	time.Sleep(time.Millisecond)
+	wallet.SaveHeadersCheckpoint(200)
+	if checkpoint = wallet.SyncCheckpoint(); !checkpoint.UpdatedAt.Equal(updatedAt) {
+		t.Errorf("the unchanged checkpoint was saved at %v", checkpoint.UpdatedAt)
+	}
+
+	// A rescan resumes from the last height it reached, its start height is
+	// kept for indexing the transactions once it finishes.
+	wallet.SaveRescanCheckpoint(50, 50)
+	wallet.SaveRescanScope(&RescanScope{Accounts: []int32{0, 2}, GapLimit: 100})
+	wallet.SaveRescanCheckpoint(50, 120)
+	checkpoint = wallet.SyncCheckpoint()
+	if !checkpoint.Rescanning || checkpoint.RescanStartHeight != 50 || checkpoint.RescanHeight != 120 {
+		t.Errorf("got rescan checkpoint %+v, want rescanning from 120, started at 50", checkpoint)
+	}
+	// The resumed rescan looks for the same addresses.
+	if scope := checkpoint.RescanScope; scope == nil || len(scope.Accounts) != 2 || scope.Accounts[1] != 2 || scope.GapLimit != 100 {
+		t.Errorf("got rescan scope %+v, want accounts 0 and 2 with gap limit 100", scope)
+	}
+
+	// An unchanged scope isn't saved again.
+	updatedAt = checkpoint.UpdatedAt
+	time.Sleep(time.Millisecond)
+	wallet.SaveRescanScope(&RescanScope{Accounts: []int32{0, 2}, GapLimit: 100})
+	if checkpoint = wallet.SyncCheckpoint(); !checkpoint.UpdatedAt.Equal(updatedAt) {
+		t.Errorf("the unchanged rescan scope was saved at %v", checkpoint.UpdatedAt)
+	}
+
+	wallet.ClearRescanCheckpoint()
+	checkpoint = wallet.SyncCheckpoint()
+	if checkpoint.Rescanning || checkpoint.RescanStartHeight != 0 || checkpoint.RescanHeight != 0 || checkpoint.RescanScope != nil {
+		t.Errorf("got rescan checkpoint %+v after clearing it", checkpoint)
+	}
+	if checkpoint.HeadersHeight != 250 {
+		t.Errorf("clearing the rescan checkpoint changed the headers height to %d", checkpoint.HeadersHeight)
+	}
+}
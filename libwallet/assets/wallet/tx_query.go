@@ -0,0 +1,192 @@
+package wallet
+
+import (
+	"strings"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// TxConfirmationState selects transactions by how many confirmations they
+// have.
+type TxConfirmationState int
+
+const (
+	// TxAnyConfirmation matches every transaction.
+	TxAnyConfirmation TxConfirmationState = iota
+	// TxUnmined matches the transactions that are not mined yet.
+	TxUnmined
+	// TxConfirming matches the mined transactions that have fewer than the
+	// required confirmations.
+	TxConfirming
+	// TxConfirmed matches the transactions with at least the required
+	// confirmations.
+	TxConfirmed
+)
+
+// TxQuery describes the transactions to return from QueryTransactions. The
+// zero value of a field means that the transactions are not filtered by it,
+// amounts and fee rates are in the smallest unit of the asset and the fee
+// rate is per kB, as stored in Transaction.
+type TxQuery struct {
+	// TxFilter is one of the utils.TxFilter* values.
+	TxFilter int32
+	// AssetTypes limits the query to wallets of these asset types, it is
+	// only used when querying several wallets.
+	AssetTypes []utils.AssetType
+
+	// MinAmount and MaxAmount bound the absolute amount of the transaction.
+	MinAmount int64
+	MaxAmount int64
+	// From and To are unix timestamps bounding the transaction time, both
+	// are inclusive.
+	From int64
+	To   int64
+	// MinFeeRate and MaxFeeRate bound the fee rate of the transaction.
+	MinFeeRate int64
+	MaxFeeRate int64
+
+	// Text matches the hash of the transaction, or a substring of its label,
+	// of one of its output addresses or of the label of such an address.
+	Text string
+	// Accounts matches the transactions that spend from or pay to one of
+	// these accounts.
+	Accounts     []int32
+	Confirmation TxConfirmationState
+
+	NewestFirst bool
+	// Offset and Limit paginate the matching transactions, all of them are
+	// returned if Limit is zero.
+	Offset int
+	Limit  int
+}
+
+// HasAssetType returns true if the query includes wallets of the asset type.
+func (query *TxQuery) HasAssetType(assetType utils.AssetType) bool {
+	if len(query.AssetTypes) == 0 {
+		return true
+	}
+	for _, t := range query.AssetTypes {
+		if t == assetType {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAccounts returns true if the transaction spends from or pays to one
+// of the accounts of the query.
+func (query *TxQuery) matchesAccounts(tx *Transaction) bool {
+	if len(query.Accounts) == 0 {
+		return true
+	}
+
+	accounts := make(map[int32]bool, len(query.Accounts))
+	for _, account := range query.Accounts {
+		accounts[account] = true
+	}
+	for _, input := range tx.Inputs {
+		if input.AccountNumber >= 0 && accounts[input.AccountNumber] {
+			return true
+		}
+	}
+	for _, output := range tx.Outputs {
+		if output.AccountNumber >= 0 && accounts[output.AccountNumber] {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesConfirmation returns true if the confirmations of the transaction
+// match the confirmation state of the query.
+func (query *TxQuery) matchesConfirmation(tx *Transaction, requiredConfirmations, bestBlock int32) bool {
+	if query.Confirmation == TxAnyConfirmation {
+		return true
+	}
+
+	var confirmations int32
+	if tx.BlockHeight != UnminedTxHeight && tx.BlockHeight > 0 {
+		confirmations = bestBlock - tx.BlockHeight + 1
+	}
+
+	switch query.Confirmation {
+	case TxUnmined:
+		return confirmations == 0
+	case TxConfirming:
+		return confirmations > 0 && confirmations < requiredConfirmations
+	case TxConfirmed:
+		return confirmations >= requiredConfirmations
+	}
+	return false
+}
+
+// matches returns true if the transaction matches every field of the query
+// except the tx filter, the text and the asset types.
+func (query *TxQuery) matches(tx *Transaction, requiredConfirmations, bestBlock int32) bool {
+	amount := tx.Amount
+	if amount < 0 {
+		amount = -amount
+	}
+
+	switch {
+	case query.MinAmount > 0 && amount < query.MinAmount,
+		query.MaxAmount > 0 && amount > query.MaxAmount,
+		query.From > 0 && tx.Timestamp < query.From,
+		query.To > 0 && tx.Timestamp > query.To,
+		query.MinFeeRate > 0 && tx.FeeRate < query.MinFeeRate,
+		query.MaxFeeRate > 0 && tx.FeeRate > query.MaxFeeRate:
+		return false
+	}
+
+	return query.matchesAccounts(tx) && query.matchesConfirmation(tx, requiredConfirmations, bestBlock)
+}
+
+// QueryTransactions returns the transactions of the wallet that match the
+// query, ordered by time. The transactions are read from the wallet's
+// transaction index.
+func QueryTransactions(asset Asset, query *TxQuery) ([]*Transaction, error) {
+	matching, err := queryTransactions(asset, query)
+	if err != nil {
+		return nil, err
+	}
+	return paginateTransactions(matching, query.Offset, query.Limit), nil
+}
+
+// queryTransactions returns every transaction of the wallet that matches the
+// query, ignoring the pagination.
+func queryTransactions(asset Asset, query *TxQuery) ([]*Transaction, error) {
+	transactions, err := asset.GetTransactionsRaw(0, 0, query.TxFilter, query.NewestFirst, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var matchesText func(*Transaction) bool
+	if text := strings.TrimSpace(query.Text); text != "" {
+		matchesText = asset.TxSearchMatcher(text)
+	}
+
+	requiredConfirmations := asset.RequiredConfirmations()
+	bestBlock := asset.GetBestBlockHeight()
+	matching := make([]*Transaction, 0)
+	for _, tx := range transactions {
+		if !query.matches(tx, requiredConfirmations, bestBlock) {
+			continue
+		}
+		if matchesText != nil && !matchesText(tx) {
+			continue
+		}
+		matching = append(matching, tx)
+	}
+	return matching, nil
+}
+
+func paginateTransactions[T any](txs []T, offset, limit int) []T {
+	if offset >= len(txs) {
+		return txs[:0]
+	}
+	txs = txs[offset:]
+	if limit > 0 && limit < len(txs) {
+		txs = txs[:limit]
+	}
+	return txs
+}
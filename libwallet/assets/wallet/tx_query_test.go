@@ -0,0 +1,105 @@
+package wallet
+
+import (
+	"testing"
+)
+
+// queryTestAsset is an asset with a fixed list of transactions, the other
+// methods of Asset are not implemented.
+type queryTestAsset struct {
+	Asset
+	wallet    *Wallet
+	txs       []*Transaction
+	bestBlock int32
+}
+
+func (a *queryTestAsset) GetTransactionsRaw(_, _, _ int32, newestFirst bool, _ string) ([]*Transaction, error) {
+	txs := make([]*Transaction, len(a.txs))
+	for i, tx := range a.txs {
+		if newestFirst {
+			txs[len(txs)-1-i] = tx
+		} else {
+			txs[i] = tx
+		}
+	}
+	return txs, nil
+}
+
+func (a *queryTestAsset) TxSearchMatcher(search string) func(tx *Transaction) bool {
+	return a.wallet.TxSearchMatcher(search)
+}
+
+func (a *queryTestAsset) RequiredConfirmations() int32 { return 6 }
+
+func (a *queryTestAsset) GetBestBlockHeight() int32 { return a.bestBlock }
+
+func TestQueryTransactions(t *testing.T) {
+	wallet := newLabelsWallet(t)
+	if err := wallet.SetAddressLabel("addr-alice", "Alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The transactions are ordered by time, oldest first.
+	asset := &queryTestAsset{
+		wallet:    wallet,
+		bestBlock: 110,
+		txs: []*Transaction{{
+			Hash: "tx0", Timestamp: 1000, Amount: -5000, FeeRate: 10000, BlockHeight: 100,
+			Inputs:  []*TxInput{{AccountNumber: 0}},
+			Outputs: []*TxOutput{{Address: "addr-alice", AccountNumber: -1}},
+		}, {
+			Hash: "tx1", Timestamp: 2000, Amount: 20000, FeeRate: 2000, BlockHeight: 108,
+			Outputs: []*TxOutput{{Address: "addr-1", AccountNumber: 1}},
+		}, {
+			Hash: "tx2", Timestamp: 3000, Amount: 100000, FeeRate: 1000, BlockHeight: 105,
+			Outputs: []*TxOutput{{Address: "addr-2", AccountNumber: 2}},
+		}, {
+			Hash: "tx3", Timestamp: 4000, Amount: -300, FeeRate: 50000, BlockHeight: UnminedTxHeight, Label: "coffee",
+			Inputs:  []*TxInput{{AccountNumber: 1}},
+			Outputs: []*TxOutput{{Address: "addr-bob", AccountNumber: -1}},
+		}},
+	}
+
+	tests := []struct {
+		name  string
+		query TxQuery
+		want  []string
+	}{
+		{"everything", TxQuery{}, []string{"tx0", "tx1", "tx2", "tx3"}},
+		{"newest first", TxQuery{NewestFirst: true}, []string{"tx3", "tx2", "tx1", "tx0"}},
+		{"minimum absolute amount", TxQuery{MinAmount: 5000}, []string{"tx0", "tx1", "tx2"}},
+		{"amount range", TxQuery{MinAmount: 1000, MaxAmount: 20000}, []string{"tx0", "tx1"}},
+		{"time range", TxQuery{From: 2000, To: 3000}, []string{"tx1", "tx2"}},
+		{"fee rate range", TxQuery{MinFeeRate: 2000, MaxFeeRate: 10000}, []string{"tx0", "tx1"}},
+		{"hash", TxQuery{Text: "tx2"}, []string{"tx2"}},
+		{"transaction label", TxQuery{Text: "Coffee"}, []string{"tx3"}},
+		{"address label", TxQuery{Text: "alice"}, []string{"tx0"}},
+		{"address", TxQuery{Text: "addr-1"}, []string{"tx1"}},
+		{"spending account", TxQuery{Accounts: []int32{0}}, []string{"tx0"}},
+		{"accounts", TxQuery{Accounts: []int32{1, 2}}, []string{"tx1", "tx2", "tx3"}},
+		{"unmined", TxQuery{Confirmation: TxUnmined}, []string{"tx3"}},
+		{"confirming", TxQuery{Confirmation: TxConfirming}, []string{"tx1"}},
+		{"confirmed", TxQuery{Confirmation: TxConfirmed}, []string{"tx0", "tx2"}},
+		{"combined", TxQuery{Accounts: []int32{1}, Confirmation: TxConfirming, MaxAmount: 50000}, []string{"tx1"}},
+		{"first page", TxQuery{Limit: 3}, []string{"tx0", "tx1", "tx2"}},
+		{"last page", TxQuery{Offset: 3, Limit: 3}, []string{"tx3"}},
+		{"page after the last", TxQuery{Offset: 4, Limit: 3}, nil},
+		{"offset without limit", TxQuery{Offset: 1, NewestFirst: true}, []string{"tx2", "tx1", "tx0"}},
+		{"no match", TxQuery{Text: "carol"}, nil},
+	}
+	for _, tc := range tests {
+		txs, err := QueryTransactions(asset, &tc.query)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if len(txs) != len(tc.want) {
+			t.Errorf("%s: got %d transactions, want %v", tc.name, len(txs), tc.want)
+			continue
+		}
+		for i, tx := range txs {
+			if tx.Hash != tc.want[i] {
+				t.Errorf("%s: got transaction %s at %d, want %s", tc.name, tx.Hash, i, tc.want[i])
+			}
+		}
+	}
+}
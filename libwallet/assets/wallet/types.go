@@ -54,6 +54,10 @@ type AuthInfo struct {
 	PrivatePass     string
 	PrivatePassType int32
 	WordSeedType    WordSeedType
+	// SeedPassphrase is the optional BIP-39 passphrase, sometimes called the
+	// 25th word, that is combined with the seed words to derive the wallet
+	// seed. It is not supported for 33 word seeds.
+	SeedPassphrase string
 }
 
 type BlockInfo struct {
@@ -133,6 +137,29 @@ type Accounts struct {
 	CurrentBlockHeight int32
 }
 
+// KeyScopeHistory reports whether the addresses of a BIP-32 key scope other
+// than the wallet's default scope were used, e.g. by another wallet that was
+// restored from the same seed. Key scopes with history can be imported as
+// additional accounts of the wallet.
+type KeyScopeHistory struct {
+	Purpose  uint32
+	CoinType uint32
+	// Name describes the address type of the key scope, e.g. BIP-44 legacy.
+	Name       string
+	HasHistory bool
+	Imported   bool
+	// AccountNumber is the number of the account holding the default account
+	// of the key scope once imported.
+	AccountNumber int32
+}
+
+// SeedShareGroup is a group of SLIP-39 seed shares, MemberThreshold of its
+// MemberCount shares are required to recover the group.
+type SeedShareGroup struct {
+	MemberThreshold int
+	MemberCount     int
+}
+
 // AccountProperties contains properties associated with each account, such as
 // the account name, number, and the nubmer of derived and imported keys.
 type AccountProperties struct {
@@ -213,6 +240,8 @@ type ScopeAddrSchema struct {
 	InternalAddrType AddressType
 }
 
+// PeerInfo describes a peer the wallet is connected to. Statistics the chain
+// backend doesn't track are left zero.
 type PeerInfo struct {
 	ID             int32  `json:"id"`
 	Addr           string `json:"addr"`
@@ -221,7 +250,76 @@ type PeerInfo struct {
 	Version        uint32 `json:"version"`
 	SubVer         string `json:"sub_ver"`
 	StartingHeight int64  `json:"starting_height"`
+	BestHeight     int64  `json:"best_height"`
 	BanScore       int32  `json:"ban_score"`
+	PingMicros     int64  `json:"ping_micros"`
+	BytesSent      uint64 `json:"bytes_sent"`
+	BytesRecv      uint64 `json:"bytes_recv"`
+	Inbound        bool   `json:"inbound"`
+}
+
+// DataUsage is the traffic of the sync connections of a wallet since Since.
+type DataUsage struct {
+	BytesSent     uint64    `json:"bytes_sent"`
+	BytesReceived uint64    `json:"bytes_received"`
+	Since         time.Time `json:"since"`
+}
+
+// RescanOptions selects where a rescan starts and the addresses it looks for.
+type RescanOptions struct {
+	// StartHeight is the block the rescan starts from. StartDate is used
+	// instead if set, the rescan then starts from the first block mined on
+	// or after it.
+	StartHeight int32
+	StartDate   time.Time
+	// GapLimit, if set, derives that many addresses past the last used
+	// address of each account before rescanning, to find transactions to
+	// addresses beyond the default gap limit.
+	GapLimit uint32
+	// Accounts limits the rescan to the addresses of these accounts, all
+	// accounts are rescanned if empty.
+	Accounts []int32
+}
+
+// RescanEstimate is the range of blocks a rescan covers and how long it's
+// expected to take.
+type RescanEstimate struct {
+	StartHeight int32
+	// StartTime is the timestamp of the start block.
+	StartTime time.Time
+	Blocks    int32
+	Duration  time.Duration
+}
+
+// SyncCheckpoint records how far the sync and rescan of a wallet got so that
+// an interrupted sync or rescan resumes where it stopped.
+type SyncCheckpoint struct {
+	// HeadersHeight is the last header height processed by the wallet.
+	HeadersHeight int32 `json:"headers_height"`
+	// RecoveryStarted is set once address discovery on a restored wallet has
+	// started. The addresses found so far are kept in the wallet db.
+	RecoveryStarted bool `json:"recovery_started"`
+	// Rescanning is set while a rescan requested by the user hasn't finished.
+	Rescanning bool `json:"rescanning"`
+	// RescanStartHeight is the height an unfinished rescan was started from.
+	RescanStartHeight int32 `json:"rescan_start_height"`
+	// RescanHeight is the height an unfinished rescan resumes from.
+	RescanHeight int32 `json:"rescan_height"`
+	// RescanScope is the subset of the addresses an unfinished rescan looks
+	// for, nil if it looks for all the addresses of the wallet.
+	RescanScope *RescanScope `json:"rescan_scope,omitempty"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// RescanScope is the subset of the addresses of the wallet a rescan started
+// with RescanOptions looks for.
+type RescanScope struct {
+	// Accounts are the accounts whose addresses are rescanned, all the
+	// accounts if empty.
+	Accounts []int32 `json:"accounts"`
+	// GapLimit is the number of addresses derived past the last used address
+	// of each account before the rescan started.
+	GapLimit uint32 `json:"gap_limit"`
 }
 
 /** begin sync-related types */
@@ -420,6 +518,10 @@ type UnspentOutput struct {
 	Spendable     bool
 	ReceiveTime   time.Time
 	Tree          int8
+	// Label and Frozen are set with SetOutputLabel and SetOutputFrozen,
+	// frozen outputs are not selected automatically to fund transactions.
+	Label  string
+	Frozen bool
 }
 
 type WordSeedType int
@@ -27,12 +27,28 @@ const (
 	BeepNewBlocksConfigKey           = "beep_new_blocks"
 
 	SyncOnCellularConfigKey             = "always_sync"
+	SyncPolicyConfigKey                 = "sync_policy"
+	DataUsageConfigKey                  = "data_usage"
+	SyncCheckpointConfigKey             = "sync_checkpoint"
+	RescanBlockDurationConfigKey        = "rescan_block_duration"
 	NetworkModeConfigKey                = "network_mode"
 	SpvPersistentPeerAddressesConfigKey = "spv_peer_addresses"
 	UserAgentConfigKey                  = "user_agent"
 
+	ChainBackendConfigKey    = "chain_backend"
+	RPCHostConfigKey         = "rpc_host"
+	RPCUserConfigKey         = "rpc_user"
+	RPCPassConfigKey         = "rpc_pass"
+	RPCPassRequiredConfigKey = "rpc_pass_required"
+	RPCCertConfigKey         = "rpc_cert"
+	ElectrumServerConfigKey  = "electrum_server"
+	ElectrumCertPinConfigKey = "electrum_cert_pin"
+
 	PoliteiaNotificationConfigKey = "politeia_notification"
 
+	ProposalVoteRemindersConfigKey          = "proposal_vote_reminders"
+	ProposalVoteReminderThresholdsConfigKey = "proposal_vote_reminder_thresholds"
+
 	LastTxHashConfigKey = "last_tx_hash"
 
 	KnownVSPsConfigKey = "known_vsps"
@@ -42,6 +58,18 @@ const (
 	TicketBuyerAccountConfigKey = "tb_account_number"
 	TicketBuyerATMConfigKey     = "tb_amount_to_maintain"
 
+	SoloVotingConfigKey = "solo_voting_enabled"
+
+	CoinSelectionStrategyConfigKey = "coin_selection_strategy"
+	DustThresholdConfigKey         = "dust_threshold"
+	AutoFreezeDustConfigKey        = "auto_freeze_dust"
+
+	ScanLegacyKeyScopesConfigKey = "scan_legacy_key_scopes"
+	ImportedKeyScopesConfigKey   = "imported_key_scopes"
+
+	MultisigConfigKey               = "multisig_config"
+	MultisigAddressIndexesConfigKey = "multisig_address_indexes"
+
 	ExchangeSourceDstnTypeConfigKey = "exchange_source_destination_key"
 
 	HideBalanceConfigKey             = "hide_balance"
@@ -28,8 +28,13 @@ type Wallet struct {
 	db        *storm.DB
 	logDir    string
 
-	EncryptedMnemonic     []byte
-	IsBackedUp            bool
+	EncryptedMnemonic []byte
+	IsBackedUp        bool
+	// HasSeedPassphrase is true if the wallet seed was derived using a BIP-39
+	// passphrase. The passphrase isn't saved, SeedFingerprint tells whether a
+	// passphrase entered by the user is the one used.
+	HasSeedPassphrase     bool
+	SeedFingerprint       []byte
 	IsRestored            bool
 	HasDiscoveredAccounts bool
 	PrivatePassphraseType int32
@@ -44,6 +49,19 @@ type Wallet struct {
 	// sync switch is disabled from receiving more user clicks until its over.
 	isSyncShuttingDown atomic.Bool
 
+	// bytesSent and bytesReceived count the sync traffic not yet added to
+	// the data usage saved in the wallet config.
+	bytesSent     atomic.Uint64
+	bytesReceived atomic.Uint64
+	dataUsageMu   sync.Mutex
+
+	syncCheckpointMu sync.Mutex
+
+	// rpcPass is the password of the RPC server of the full node, decrypted
+	// from the wallet config when the wallet is unlocked.
+	rpcPass   string
+	rpcPassMu sync.Mutex
+
 	// Birthday holds the timestamp of the birthday block from where wallet
 	// restoration begins from. CreatedAt is available for audit purposes
 	// in relation to how long the wallet has been in existence.
@@ -152,6 +170,8 @@ func (wallet *Wallet) prepare() (err error) {
 }
 
 func (wallet *Wallet) Shutdown() {
+	wallet.saveDataUsage()
+
 	// Trigger shuttingDown signal to cancel all contexts created with
 	// `wallet.shutdownContextWithCancel()`.
 	wallet.shuttingDown <- true
@@ -184,8 +204,11 @@ func (wallet *Wallet) Shutdown() {
 }
 
 func (wallet *Wallet) TargetTimePerBlockMinutes() float64 {
-	if wallet.Type == utils.BTCWalletAsset {
+	switch wallet.Type {
+	case utils.BTCWalletAsset:
 		return wallet.chainsParams.BTC.TargetTimePerBlock.Minutes()
+	case utils.LTCWalletAsset:
+		return wallet.chainsParams.LTC.TargetTimePerBlock.Minutes()
 	}
 	return wallet.chainsParams.DCR.TargetTimePerBlock.Minutes()
 }
@@ -398,12 +421,16 @@ func CreateNewWallet(pass *AuthInfo, loader loader.AssetLoader,
 		netType:               params.NetType,
 	}
 
+	if err = wallet.setSeedPassphrase(mnemonic, pass); err != nil {
+		return nil, err
+	}
+
 	if err := wallet.saveNewWallet(func() error {
 		err := wallet.prepare()
 		if err != nil {
 			return err
 		}
-		return wallet.createWallet(pass.PrivatePass, mnemonic, pass.WordSeedType)
+		return wallet.createWallet(pass.PrivatePass, mnemonic, pass.SeedPassphrase, pass.WordSeedType)
 	}); err != nil {
 		return nil, err
 	}
@@ -417,13 +444,35 @@ func CreateNewWallet(pass *AuthInfo, loader loader.AssetLoader,
 	return wallet, nil
 }
 
-func (wallet *Wallet) createWallet(privatePassphrase, seedMnemonic string, wordSeedType WordSeedType) error {
+// setSeedPassphrase records whether the seed is used with a BIP-39
+// passphrase and the fingerprint of the key it derives, the passphrase itself
+// isn't saved.
+func (wallet *Wallet) setSeedPassphrase(seedMnemonic string, pass *AuthInfo) error {
+	if pass.SeedPassphrase == "" {
+		return nil
+	}
+
+	seed, err := DecodeSeedMnemonic(seedMnemonic, wallet.Type, pass.WordSeedType, pass.SeedPassphrase)
+	if err != nil {
+		return err
+	}
+	fingerprint, err := wallet.seedFingerprint(seed)
+	if err != nil {
+		return err
+	}
+
+	wallet.HasSeedPassphrase = true
+	wallet.SeedFingerprint = fingerprint
+	return nil
+}
+
+func (wallet *Wallet) createWallet(privatePassphrase, seedMnemonic, seedPassphrase string, wordSeedType WordSeedType) error {
 	log.Info("Creating Wallet")
 	if len(seedMnemonic) == 0 {
 		return errors.New(utils.ErrEmptySeed)
 	}
 
-	seed, err := DecodeSeedMnemonic(seedMnemonic, wallet.Type, wordSeedType)
+	seed, err := DecodeSeedMnemonic(seedMnemonic, wallet.Type, wordSeedType, seedPassphrase)
 	if err != nil {
 		log.Error(err)
 		return err
@@ -530,12 +579,16 @@ func RestoreWallet(seedMnemonic string, pass *AuthInfo, loader loader.AssetLoade
 		netType:               params.NetType,
 	}
 
+	if err = wallet.setSeedPassphrase(seedMnemonic, pass); err != nil {
+		return nil, err
+	}
+
 	if err := wallet.saveNewWallet(func() error {
 		err := wallet.prepare()
 		if err != nil {
 			return err
 		}
-		return wallet.createWallet(pass.PrivatePass, seedMnemonic, pass.WordSeedType)
+		return wallet.createWallet(pass.PrivatePass, seedMnemonic, pass.SeedPassphrase, pass.WordSeedType)
 	}); err != nil {
 		return nil, err
 	}
@@ -700,6 +753,7 @@ func (wallet *Wallet) UnlockWallet(privPass string) (err error) {
 		return utils.TranslateError(err)
 	}
 
+	wallet.unlockRPCPass([]byte(privPass))
 	return nil
 }
 
@@ -761,7 +815,12 @@ func (wallet *Wallet) ChangePrivatePassphraseForWallet(oldPrivatePassphrase, new
 		}
 	}
 
-	err := wallet.changePrivatePassphrase(oldPassphrase, newPassphrase)
+	encryptedRPCPass, err := wallet.reencryptRPCPass(oldPassphrase, newPassphrase)
+	if err != nil {
+		return err
+	}
+
+	err = wallet.changePrivatePassphrase(oldPassphrase, newPassphrase)
 	if err != nil {
 		return utils.TranslateError(err)
 	}
@@ -782,6 +841,9 @@ func (wallet *Wallet) ChangePrivatePassphraseForWallet(oldPrivatePassphrase, new
 		return errors.New(utils.ErrChangingPassphrase)
 	}
 
+	if encryptedRPCPass != nil {
+		wallet.SaveUserConfigValue(RPCPassConfigKey, encryptedRPCPass)
+	}
 	return nil
 }
 
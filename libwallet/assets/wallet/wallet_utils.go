@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
@@ -13,7 +14,9 @@ import (
 	"decred.org/dcrwallet/v4/errors"
 	"decred.org/dcrwallet/v4/walletseed"
 	"github.com/asdine/storm"
+	"github.com/btcsuite/btcd/btcutil"
 	btchdkeychain "github.com/btcsuite/btcd/btcutil/hdkeychain"
+	btcchaincfg "github.com/btcsuite/btcd/chaincfg"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	dcrhdkeychain "github.com/decred/dcrd/hdkeychain/v3"
 	"github.com/kevinburke/nacl"
@@ -126,9 +129,97 @@ func (wallet *Wallet) DecryptSeed(privatePassphrase string) (string, error) {
 	return decryptWalletMnemonic([]byte(privatePassphrase), wallet.EncryptedMnemonic)
 }
 
+// DecryptHDSeed returns the seed the HD keys of the wallet are derived from.
+// seedPassphrase is the BIP-39 passphrase the wallet seed is used with, it's
+// empty if the wallet seed doesn't use one. The seed type is told by the
+// number of seed words.
+func (wallet *Wallet) DecryptHDSeed(privatePassphrase, seedPassphrase string) ([]byte, error) {
+	seedMnemonic, err := wallet.DecryptSeed(privatePassphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	seedType := WordSeedType(len(strings.Fields(seedMnemonic)))
+	seed, err := DecodeSeedMnemonic(seedMnemonic, wallet.Type, seedType, seedPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := wallet.seedPassphraseMatches(seed, seedPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	if !matches {
+		return nil, errors.New(utils.ErrInvalid)
+	}
+	return seed, nil
+}
+
+// seedPassphraseMatches returns true if seedPassphrase is the BIP-39
+// passphrase the wallet seed is used with, seed being the seed it derived.
+func (wallet *Wallet) seedPassphraseMatches(seed []byte, seedPassphrase string) (bool, error) {
+	if !wallet.HasSeedPassphrase {
+		return seedPassphrase == "", nil
+	}
+	if seedPassphrase == "" {
+		return false, nil
+	}
+
+	fingerprint, err := wallet.seedFingerprint(seed)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(fingerprint, wallet.SeedFingerprint), nil
+}
+
+// seedFingerprint returns the hash of the public key of the first BIP-44
+// account derived from seed, m/44'/coin type'/0', which is saved in place of
+// the seed passphrase to check it. The key derivation doesn't depend on the
+// network the key is serialized for, btcd's hdkeychain derives it for every
+// asset.
+func (wallet *Wallet) seedFingerprint(seed []byte) ([]byte, error) {
+	params, err := utils.GetChainParams(wallet.Type, wallet.netType)
+	if err != nil {
+		return nil, err
+	}
+
+	var coinType uint32
+	switch wallet.Type {
+	case utils.DCRWalletAsset:
+		coinType = params.DCR.SLIP0044CoinType
+	case utils.BTCWalletAsset:
+		coinType = params.BTC.HDCoinType
+	case utils.LTCWalletAsset:
+		coinType = params.LTC.HDCoinType
+	}
+
+	key, err := btchdkeychain.NewMaster(seed, &btcchaincfg.MainNetParams)
+	if err != nil {
+		return nil, err
+	}
+	defer key.Zero()
+	for _, index := range []uint32{44, coinType, 0} {
+		if key, err = key.Derive(btchdkeychain.HardenedKeyStart + index); err != nil {
+			return nil, err
+		}
+	}
+
+	pubKey, err := key.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+	return btcutil.Hash160(pubKey.SerializeCompressed()), nil
+}
+
+// WalletHasSeedPassphrase returns true if the wallet seed is protected by a
+// BIP-39 passphrase.
+func (wallet *Wallet) WalletHasSeedPassphrase() bool {
+	return wallet.HasSeedPassphrase
+}
+
 // VerifySeedForWallet compares seedMnemonic with the decrypted
-// wallet.EncryptedMnemonic.
-func (wallet *Wallet) VerifySeedForWallet(seedMnemonic, privpass string) (bool, error) {
+// wallet.EncryptedMnemonic, and the key derived with seedPassphrase with
+// wallet.SeedFingerprint.
+func (wallet *Wallet) VerifySeedForWallet(seedMnemonic, seedPassphrase, privpass string) (bool, error) {
 	wallet.mu.RLock()
 	defer wallet.mu.RUnlock()
 
@@ -137,16 +228,33 @@ func (wallet *Wallet) VerifySeedForWallet(seedMnemonic, privpass string) (bool,
 		return false, err
 	}
 
-	if decryptedMnemonic == seedMnemonic {
-		if wallet.IsBackedUp {
-			return true, nil // return early
+	if decryptedMnemonic != seedMnemonic {
+		return false, errors.New(utils.ErrInvalid)
+	}
+
+	// The seed is only derived to check the passphrase of a seed used with
+	// one.
+	passphraseMatches := !wallet.HasSeedPassphrase && seedPassphrase == ""
+	if wallet.HasSeedPassphrase {
+		seedType := WordSeedType(len(strings.Fields(seedMnemonic)))
+		seed, err := DecodeSeedMnemonic(seedMnemonic, wallet.Type, seedType, seedPassphrase)
+		if err != nil {
+			return false, err
+		}
+		if passphraseMatches, err = wallet.seedPassphraseMatches(seed, seedPassphrase); err != nil {
+			return false, err
 		}
+	}
+	if !passphraseMatches {
+		return false, errors.New(utils.ErrInvalid)
+	}
 
-		wallet.IsBackedUp = true
-		return true, utils.TranslateError(wallet.db.Save(wallet))
+	if wallet.IsBackedUp {
+		return true, nil // return early
 	}
 
-	return false, errors.New(utils.ErrInvalid)
+	wallet.IsBackedUp = true
+	return true, utils.TranslateError(wallet.db.Save(wallet))
 }
 
 // naclLoadFromPass derives a nacl.Key from pass using scrypt.Key.
@@ -220,11 +328,18 @@ func generateMnemonic(wordSeedType WordSeedType) (v string, err error) {
 }
 
 func VerifyMnemonic(seedMnemonic string, assetType utils.AssetType, seedType WordSeedType) bool {
-	_, err := DecodeSeedMnemonic(seedMnemonic, assetType, seedType)
+	_, err := DecodeSeedMnemonic(seedMnemonic, assetType, seedType, "")
 	return err == nil
 }
 
-func DecodeSeedMnemonic(seedMnemonic string, assetType utils.AssetType, seedType WordSeedType) (hashedSeed []byte, err error) {
+// DecodeSeedMnemonic returns the wallet seed of the provided seed words or hex
+// encoded seed. seedPassphrase is the optional BIP-39 passphrase, it must be
+// empty for 33 word seeds.
+func DecodeSeedMnemonic(seedMnemonic string, assetType utils.AssetType, seedType WordSeedType, seedPassphrase string) (hashedSeed []byte, err error) {
+	if seedPassphrase != "" && seedType == WordSeed33 {
+		return nil, utils.ErrSeedPassphraseUnsupported
+	}
+
 	seedMnemonic = strings.TrimSpace(seedMnemonic)
 	switch assetType {
 	case utils.BTCWalletAsset, utils.DCRWalletAsset, utils.LTCWalletAsset:
@@ -245,7 +360,7 @@ func DecodeSeedMnemonic(seedMnemonic string, assetType utils.AssetType, seedType
 		if seedType == WordSeed33 {
 			hashedSeed, err = walletseed.DecodeUserInput(seedMnemonic)
 		} else {
-			hashedSeed, err = bip39.NewSeedWithErrorChecking(seedMnemonic, "")
+			hashedSeed, err = bip39.NewSeedWithErrorChecking(seedMnemonic, seedPassphrase)
 		}
 	default:
 		err = fmt.Errorf("%v: (%v)", utils.ErrAssetUnknown, assetType)
@@ -0,0 +1,43 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+func TestSeedPassphrase(t *testing.T) {
+	for _, assetType := range []utils.AssetType{utils.BTCWalletAsset, utils.DCRWalletAsset, utils.LTCWalletAsset} {
+		wallet, mnemonic := newSeedWallet(t, WordSeed12)
+		wallet.Type, wallet.netType = assetType, utils.Testnet
+		pass := &AuthInfo{PrivatePass: testPrivatePassphrase, WordSeedType: WordSeed12, SeedPassphrase: "25th word"}
+		if err := wallet.setSeedPassphrase(mnemonic, pass); err != nil {
+			t.Fatalf("%v: %v", assetType, err)
+		}
+		if !wallet.HasSeedPassphrase || len(wallet.SeedFingerprint) == 0 {
+			t.Fatalf("%v: the seed passphrase wasn't recorded", assetType)
+		}
+
+		for _, seedPassphrase := range []string{"", "26th word"} {
+			if ok, err := wallet.VerifySeedForWallet(mnemonic, seedPassphrase, testPrivatePassphrase); ok || err == nil {
+				t.Errorf("%v: verified the seed with the seed passphrase %q", assetType, seedPassphrase)
+			}
+			if _, err := wallet.DecryptHDSeed(testPrivatePassphrase, seedPassphrase); err == nil {
+				t.Errorf("%v: decrypted the seed with the seed passphrase %q", assetType, seedPassphrase)
+			}
+		}
+
+		if ok, err := wallet.VerifySeedForWallet(mnemonic, pass.SeedPassphrase, testPrivatePassphrase); !ok || err != nil {
+			t.Errorf("%v: the seed with its passphrase doesn't verify: %v", assetType, err)
+		}
+		seed, err := wallet.DecryptHDSeed(testPrivatePassphrase, pass.SeedPassphrase)
+		if err != nil {
+			t.Fatalf("%v: %v", assetType, err)
+		}
+		want, _ := DecodeSeedMnemonic(mnemonic, assetType, WordSeed12, pass.SeedPassphrase)
+		if !bytes.Equal(seed, want) {
+			t.Errorf("%v: decrypted the wrong seed", assetType)
+		}
+	}
+}
@@ -52,6 +52,12 @@ func Initialize(dbPath string, txData interface{}) (*DB, error) {
 		return nil, fmt.Errorf("error initializing tx bucket for wallet: %s", err.Error())
 	}
 
+	// init bucket for saving/reading labels
+	err = walletDataDB.Init(&Label{})
+	if err != nil {
+		return nil, fmt.Errorf("error initializing labels bucket for wallet: %s", err.Error())
+	}
+
 	return &DB{
 		BTC: &BTCDB{
 			Bolt: walletDataDB.Bolt,
@@ -0,0 +1,107 @@
+package walletdata
+
+import (
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+)
+
+// Label types, as defined by BIP-329.
+const (
+	LabelTypeTx     = "tx"
+	LabelTypeAddr   = "addr"
+	LabelTypePubkey = "pubkey"
+	LabelTypeInput  = "input"
+	LabelTypeOutput = "output"
+	LabelTypeXpub   = "xpub"
+)
+
+// Label is a user defined label of a transaction, address or other wallet
+// record. Labels are kept apart from the indexed transactions so that they
+// are not lost when the transactions are re-indexed.
+type Label struct {
+	// Key identifies the label, it is the type and the reference joined by a
+	// colon.
+	Key    string `storm:"id,unique"`
+	Type   string `storm:"index"`
+	Ref    string
+	Label  string
+	Origin string `json:",omitempty"`
+	// Frozen is set for outputs that must not be spent, it is the opposite
+	// of the BIP-329 spendable field.
+	Frozen bool `json:",omitempty"`
+}
+
+func labelKey(labelType, ref string) string {
+	return labelType + ":" + ref
+}
+
+// SetLabel saves the label of the record of the type with the reference ref,
+// e.g. the hash of a transaction. An empty label is saved as well, it clears
+// the label that the wallet backend may hold for the record.
+func (db *DB) SetLabel(labelType, ref, label string) error {
+	saved := db.readLabel(labelType, ref)
+	saved.Label = label
+	return db.walletDataDB.Save(saved)
+}
+
+// SetFrozen sets whether the record of the type with the reference ref is
+// frozen, the label of the record is kept.
+func (db *DB) SetFrozen(labelType, ref string, frozen bool) error {
+	saved := db.readLabel(labelType, ref)
+	saved.Frozen = frozen
+	return db.walletDataDB.Save(saved)
+}
+
+// IsFrozen returns true if the record of the type with the reference ref is
+// frozen.
+func (db *DB) IsFrozen(labelType, ref string) bool {
+	return db.readLabel(labelType, ref).Frozen
+}
+
+// readLabel returns the saved label of the record, or an empty label for the
+// record if none was saved.
+func (db *DB) readLabel(labelType, ref string) *Label {
+	saved := &Label{
+		Key:  labelKey(labelType, ref),
+		Type: labelType,
+		Ref:  ref,
+	}
+	_ = db.walletDataDB.One("Key", saved.Key, saved)
+	return saved
+}
+
+// ImportLabels saves the labels, replacing any label already saved for the
+// same records.
+func (db *DB) ImportLabels(labels []*Label) error {
+	for _, label := range labels {
+		label.Key = labelKey(label.Type, label.Ref)
+		if err := db.walletDataDB.Save(label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadLabel returns the label of the record of the type with the reference
+// ref. ok is false if no label was saved.
+func (db *DB) ReadLabel(labelType, ref string) (label string, ok bool) {
+	var saved Label
+	if err := db.walletDataDB.One("Key", labelKey(labelType, ref), &saved); err != nil {
+		return "", false
+	}
+	return saved.Label, true
+}
+
+// Labels returns the labels saved for the types, or all the labels if no type
+// is provided. Cleared labels are included with an empty label.
+func (db *DB) Labels(labelTypes ...string) ([]*Label, error) {
+	var labels []*Label
+	query := db.walletDataDB.Select()
+	if len(labelTypes) > 0 {
+		query = db.walletDataDB.Select(q.In("Type", labelTypes))
+	}
+	if err := query.Find(&labels); err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return labels, nil
+}
@@ -22,11 +22,10 @@ import (
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/notification"
 	"github.com/crypto-power/cryptopower/ui/values"
+	"github.com/decred/dcrd/chaincfg/v3"
 	bolt "go.etcd.io/bbolt"
 
-	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
-	"github.com/crypto-power/cryptopower/libwallet/assets/ltc"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 )
 
@@ -37,20 +36,23 @@ const LogFilename = "cryptopower.log"
 // assetIdentifier use for listen balance of all wallet changed
 const assetIdentifier = "assets_manager"
 
-// Assets is a struct that holds all the assets supported by the wallet.
+// Assets is a struct that holds the wallets of all the assets supported by the
+// wallet, keyed by asset type and wallet ID.
 type Assets struct {
-	DCR struct {
-		Wallets    map[int]sharedW.Asset
-		BadWallets map[int]*sharedW.Wallet
-	}
-	BTC struct {
-		Wallets    map[int]sharedW.Asset
-		BadWallets map[int]*sharedW.Wallet
+	Wallets    map[utils.AssetType]map[int]sharedW.Asset
+	BadWallets map[utils.AssetType]map[int]*sharedW.Wallet
+}
+
+func newAssets() *Assets {
+	assets := &Assets{
+		Wallets:    make(map[utils.AssetType]map[int]sharedW.Asset),
+		BadWallets: make(map[utils.AssetType]map[int]*sharedW.Wallet),
 	}
-	LTC struct {
-		Wallets    map[int]sharedW.Asset
-		BadWallets map[int]*sharedW.Wallet
+	for _, assetType := range driverAssetTypes() {
+		assets.Wallets[assetType] = make(map[int]sharedW.Asset)
+		assets.BadWallets[assetType] = make(map[int]*sharedW.Wallet)
 	}
+	return assets
 }
 
 // AssetsManager is a struct that holds all the necessary parameters
@@ -61,7 +63,8 @@ type AssetsManager struct {
 
 	shuttingDown chan bool
 	cancelFuncs  []context.CancelFunc
-	chainsParams utils.ChainsParams
+	// dcrChainParams are read for the Politeia keys and the vote agendas.
+	dcrChainParams *chaincfg.Params
 
 	Politeia        *politeia.Politeia
 	InstantSwap     *instantswap.InstantSwap
@@ -69,6 +72,12 @@ type AssetsManager struct {
 	RateSource      ext.RateSource
 	rateMutex       sync.Mutex
 
+	proposalReminders proposalReminders
+	dustWatch         dustWatch
+	syncScheduler     syncScheduler
+	aggregateSync     aggregateSync
+	metrics           *metricsCollector
+
 	dexcMtx     sync.RWMutex
 	dexcCtx     context.Context
 	dexc        DEXClient
@@ -81,22 +90,16 @@ type AssetsManager struct {
 // initializeAssetsFields validate the network provided is valid for all assets before proceeding
 // to initialize the rest of the other fields.
 func initializeAssetsFields(rootDir, dbDriver, logDir string, netType utils.NetworkType, dexTestAddr string) (*AssetsManager, error) {
-	dcrChainParams, err := initializeDCRWalletParameters(netType)
-	if err != nil {
-		log.Errorf("error initializing DCR parameters: %s", err.Error())
-		return nil, errors.Errorf("error initializing DCR parameters: %s", err.Error())
-	}
-
-	btcChainParams, err := initializeBTCWalletParameters(netType)
-	if err != nil {
-		log.Errorf("error initializing BTC parameters: %s", err.Error())
-		return nil, errors.Errorf("error initializing BTC parameters: %s", err.Error())
-	}
-
-	ltcChainParams, err := initializeLTCWalletParameters(netType)
-	if err != nil {
-		log.Errorf("error initializing LTC parameters: %s", err.Error())
-		return nil, errors.Errorf("error initializing LTC parameters: %s", err.Error())
+	var dcrChainParams *chaincfg.Params
+	for _, assetType := range driverAssetTypes() {
+		params, err := utils.GetChainParams(assetType, netType)
+		if err != nil {
+			log.Errorf("error initializing %s parameters: %s", assetType, err.Error())
+			return nil, errors.Errorf("error initializing %s parameters: %s", assetType, err.Error())
+		}
+		if assetType == utils.DCRWalletAsset {
+			dcrChainParams = params.DCR
+		}
 	}
 
 	params := &sharedW.InitParams{
@@ -108,21 +111,10 @@ func initializeAssetsFields(rootDir, dbDriver, logDir string, netType utils.Netw
 	}
 
 	mgr := &AssetsManager{
-		params: params,
-		Assets: new(Assets),
+		params:         params,
+		Assets:         newAssets(),
+		dcrChainParams: dcrChainParams,
 	}
-
-	mgr.Assets.BTC.Wallets = make(map[int]sharedW.Asset)
-	mgr.Assets.DCR.Wallets = make(map[int]sharedW.Asset)
-	mgr.Assets.LTC.Wallets = make(map[int]sharedW.Asset)
-
-	mgr.Assets.BTC.BadWallets = make(map[int]*sharedW.Wallet)
-	mgr.Assets.DCR.BadWallets = make(map[int]*sharedW.Wallet)
-	mgr.Assets.LTC.BadWallets = make(map[int]*sharedW.Wallet)
-
-	mgr.chainsParams.DCR = dcrChainParams
-	mgr.chainsParams.BTC = btcChainParams
-	mgr.chainsParams.LTC = ltcChainParams
 	return mgr, nil
 }
 
@@ -164,6 +156,12 @@ func NewAssetsManager(rootDir, logDir string, netType utils.NetworkType, dexTest
 		return nil, err
 	}
 
+	// init the address book shared by all wallets
+	if err = mwDB.Init(&Contact{}); err != nil {
+		log.Errorf("Error initializing address book: %s", err.Error())
+		return nil, err
+	}
+
 	politeiaHost := PoliteiaMainnetHost
 	if netType == Testnet {
 		politeiaHost = PoliteiaTestnetHost
@@ -279,37 +277,19 @@ func (mgr *AssetsManager) prepareExistingWallets() error {
 		path := filepath.Join(mgr.params.RootDir, wallet.DataDir())
 		log.Infof("loading properties of wallet=%v at location=%v", wallet.Name, path)
 
-		switch wallet.Type {
-		case utils.BTCWalletAsset:
-			w, err := btc.LoadExisting(wallet, mgr.params)
-			if err != nil {
-				mgr.Assets.BTC.BadWallets[wallet.ID] = wallet
-				log.Warnf("Ignored btc wallet load error for wallet %d (%s)", wallet.ID, wallet.Name)
-			} else {
-				mgr.Assets.BTC.Wallets[wallet.ID] = w
-			}
-
-		case utils.DCRWalletAsset:
-			w, err := dcr.LoadExisting(wallet, mgr.params)
-			if err != nil {
-				mgr.Assets.DCR.BadWallets[wallet.ID] = wallet
-				log.Warnf("Ignored dcr wallet load error for wallet %d (%s)", wallet.ID, wallet.Name)
-			} else {
-				mgr.Assets.DCR.Wallets[wallet.ID] = w
-			}
-
-		case utils.LTCWalletAsset:
-			w, err := ltc.LoadExisting(wallet, mgr.params)
-			if err != nil {
-				mgr.Assets.LTC.BadWallets[wallet.ID] = wallet
-				log.Warnf("Ignored ltc wallet load error for wallet %d (%s)", wallet.ID, wallet.Name)
-			} else {
-				mgr.Assets.LTC.Wallets[wallet.ID] = w
-			}
-
-		default:
+		driver, ok := assetDrivers[wallet.Type]
+		if !ok {
 			// Classify all wallets with missing AssetTypes as DCR badwallets.
-			mgr.Assets.DCR.BadWallets[wallet.ID] = wallet
+			mgr.Assets.BadWallets[utils.DCRWalletAsset][wallet.ID] = wallet
+			continue
+		}
+
+		w, err := driver.LoadExisting(wallet, mgr.params)
+		if err != nil {
+			mgr.Assets.BadWallets[wallet.Type][wallet.ID] = wallet
+			log.Warnf("Ignored %s wallet load error for wallet %d (%s)", wallet.Type.ToStringLower(), wallet.ID, wallet.Name)
+		} else {
+			mgr.Assets.Wallets[wallet.Type][wallet.ID] = w
 		}
 	}
 	return nil
@@ -355,7 +335,7 @@ func (mgr *AssetsManager) Shutdown() {
 		wallet.Shutdown() // Cancels the wallet sync too.
 		wallet.CancelRescan()
 	}
-	mgr.Assets = new(Assets)
+	mgr.Assets = newAssets()
 
 	// Disable all active network connections
 	utils.ShutdownHTTPClients()
@@ -414,19 +394,9 @@ func (mgr *AssetsManager) OpenWallets(startupPassphrase string) error {
 	return nil
 }
 
-// DCRBadWallets returns a map of all bad DCR wallets.
-func (mgr *AssetsManager) DCRBadWallets() map[int]*sharedW.Wallet {
-	return mgr.Assets.DCR.BadWallets
-}
-
-// BTCBadWallets returns a map of all bad BTC wallets.
-func (mgr *AssetsManager) BTCBadWallets() map[int]*sharedW.Wallet {
-	return mgr.Assets.BTC.BadWallets
-}
-
-// LTCBadWallets returns a map of all bad LTC wallets.
-func (mgr *AssetsManager) LTCBadWallets() map[int]*sharedW.Wallet {
-	return mgr.Assets.LTC.BadWallets
+// BadWallets returns a map of all bad wallets of the asset type.
+func (mgr *AssetsManager) BadWallets(assetType utils.AssetType) map[int]*sharedW.Wallet {
+	return mgr.Assets.BadWallets[assetType]
 }
 
 // LoadedWalletsCount returns the number of wallets loaded in the assets manager.
@@ -447,13 +417,13 @@ func (mgr *AssetsManager) OpenedWalletsCount() int32 {
 
 // PiKeys returns the sanctioned Politeia keys for the current network.
 func (mgr *AssetsManager) PiKeys() [][]byte {
-	return mgr.chainsParams.DCR.PiKeys
+	return mgr.dcrChainParams.PiKeys
 }
 
 // AllVoteAgendas returns all agendas of all stake versions for the active
 // network and this version of the software.
 func (mgr *AssetsManager) AllVoteAgendas(newestFirst bool) ([]*dcr.Agenda, error) {
-	return dcr.AllVoteAgendas(mgr.chainsParams.DCR, newestFirst)
+	return dcr.AllVoteAgendas(mgr.dcrChainParams, newestFirst)
 }
 
 // sortWallets returns the watchonly wallets ordered last.
@@ -461,17 +431,7 @@ func (mgr *AssetsManager) sortWallets(assetType utils.AssetType) []sharedW.Asset
 	normalWallets := make([]sharedW.Asset, 0)
 	watchOnlyWallets := make([]sharedW.Asset, 0)
 
-	var unsortedWallets map[int]sharedW.Asset
-	switch assetType {
-	case utils.DCRWalletAsset:
-		unsortedWallets = mgr.Assets.DCR.Wallets
-	case utils.BTCWalletAsset:
-		unsortedWallets = mgr.Assets.BTC.Wallets
-	case utils.LTCWalletAsset:
-		unsortedWallets = mgr.Assets.LTC.Wallets
-	}
-
-	for _, wallet := range unsortedWallets {
+	for _, wallet := range mgr.Assets.Wallets[assetType] {
 		if wallet.IsWatchingOnlyWallet() {
 			watchOnlyWallets = append(watchOnlyWallets, wallet)
 		} else {
@@ -495,21 +455,11 @@ func (mgr *AssetsManager) AllDCRWallets() (wallets []sharedW.Asset) {
 	return mgr.sortWallets(utils.DCRWalletAsset)
 }
 
-// AllBTCWallets returns all BTC wallets in the assets manager.
-func (mgr *AssetsManager) AllBTCWallets() (wallets []sharedW.Asset) {
-	return mgr.sortWallets(utils.BTCWalletAsset)
-}
-
-// AllLTCWallets returns all LTC wallets in the assets manager.
-func (mgr *AssetsManager) AllLTCWallets() (wallets []sharedW.Asset) {
-	return mgr.sortWallets(utils.LTCWalletAsset)
-}
-
 // AllWallets returns all wallets in the assets manager.
 func (mgr *AssetsManager) AllWallets() (wallets []sharedW.Asset) {
-	wallets = mgr.AllDCRWallets()
-	wallets = append(wallets, mgr.AllBTCWallets()...)
-	wallets = append(wallets, mgr.AllLTCWallets()...)
+	for _, assetType := range driverAssetTypes() {
+		wallets = append(wallets, mgr.sortWallets(assetType)...)
+	}
 	return wallets
 }
 
@@ -524,28 +474,17 @@ func (mgr *AssetsManager) DeleteWallet(walletID int, privPass string) error {
 		return err
 	}
 
-	switch wallet.GetAssetType() {
-	case utils.BTCWalletAsset:
-		delete(mgr.Assets.BTC.Wallets, walletID)
-	case utils.DCRWalletAsset:
-		delete(mgr.Assets.DCR.Wallets, walletID)
-	case utils.LTCWalletAsset:
-		delete(mgr.Assets.LTC.Wallets, walletID)
-	}
+	delete(mgr.Assets.Wallets[wallet.GetAssetType()], walletID)
 
 	return nil
 }
 
 // WalletWithID returns a wallet with the given ID.
 func (mgr *AssetsManager) WalletWithID(walletID int) sharedW.Asset {
-	if wallet, ok := mgr.Assets.BTC.Wallets[walletID]; ok {
-		return wallet
-	}
-	if wallet, ok := mgr.Assets.DCR.Wallets[walletID]; ok {
-		return wallet
-	}
-	if wallet, ok := mgr.Assets.LTC.Wallets[walletID]; ok {
-		return wallet
+	for _, wallets := range mgr.Assets.Wallets {
+		if wallet, ok := wallets[walletID]; ok {
+			return wallet
+		}
 	}
 	return nil
 }
@@ -554,14 +493,7 @@ func (mgr *AssetsManager) WalletWithID(walletID int) sharedW.Asset {
 func (mgr *AssetsManager) AssetWallets(assetTypes ...utils.AssetType) []sharedW.Asset {
 	var wallets []sharedW.Asset
 	for _, asset := range assetTypes {
-		switch asset {
-		case utils.BTCWalletAsset:
-			wallets = append(wallets, mgr.AllBTCWallets()...)
-		case utils.DCRWalletAsset:
-			wallets = append(wallets, mgr.AllDCRWallets()...)
-		case utils.LTCWalletAsset:
-			wallets = append(wallets, mgr.AllLTCWallets()...)
-		}
+		wallets = append(wallets, mgr.sortWallets(asset)...)
 	}
 
 	if len(wallets) == 0 && len(assetTypes) == 0 {
@@ -572,14 +504,10 @@ func (mgr *AssetsManager) AssetWallets(assetTypes ...utils.AssetType) []sharedW.
 }
 
 func (mgr *AssetsManager) getbadWallet(walletID int) *sharedW.Wallet {
-	if badWallet, ok := mgr.Assets.BTC.BadWallets[walletID]; ok {
-		return badWallet
-	}
-	if badWallet, ok := mgr.Assets.DCR.BadWallets[walletID]; ok {
-		return badWallet
-	}
-	if badWallet, ok := mgr.Assets.LTC.BadWallets[walletID]; ok {
-		return badWallet
+	for _, badWallets := range mgr.Assets.BadWallets {
+		if badWallet, ok := badWallets[walletID]; ok {
+			return badWallet
+		}
 	}
 	return nil
 }
@@ -600,13 +528,8 @@ func (mgr *AssetsManager) DeleteBadWallet(walletID int) error {
 
 	os.RemoveAll(wallet.DataDir())
 
-	switch wallet.GetAssetType() {
-	case utils.BTCWalletAsset:
-		delete(mgr.Assets.BTC.BadWallets, walletID)
-	case utils.DCRWalletAsset:
-		delete(mgr.Assets.DCR.BadWallets, walletID)
-	case utils.LTCWalletAsset:
-		delete(mgr.Assets.LTC.BadWallets, walletID)
+	for _, badWallets := range mgr.Assets.BadWallets {
+		delete(badWallets, walletID)
 	}
 
 	return nil
@@ -628,48 +551,166 @@ func (mgr *AssetsManager) RootDirFileSizeInBytes(dataDir string) (int64, error)
 	return size, err
 }
 
-// WalletWithSeed returns the ID of the wallet with the given seed. If a wallet
-// with the given seed does not exist, it returns -1.
-func (mgr *AssetsManager) WalletWithSeed(walletType utils.AssetType, seedMnemonic string, wordSeedType sharedW.WordSeedType) (int, error) {
-	switch walletType {
-	case utils.BTCWalletAsset:
-		return mgr.BTCWalletWithSeed(seedMnemonic, wordSeedType)
-	case utils.DCRWalletAsset:
-		return mgr.DCRWalletWithSeed(seedMnemonic, wordSeedType)
-	case utils.LTCWalletAsset:
-		return mgr.LTCWalletWithSeed(seedMnemonic, wordSeedType)
-	default:
+// CreateNewWallet creates a new wallet of the asset type and returns it.
+func (mgr *AssetsManager) CreateNewWallet(assetType utils.AssetType, walletName, privatePassphrase string, privatePassphraseType int32, wordSeedType sharedW.WordSeedType, seedPassphrase string) (sharedW.Asset, error) {
+	driver, ok := assetDrivers[assetType]
+	if !ok {
+		return nil, utils.ErrAssetUnknown
+	}
+
+	pass := &sharedW.AuthInfo{
+		Name:            walletName,
+		PrivatePass:     privatePassphrase,
+		PrivatePassType: privatePassphraseType,
+		WordSeedType:    wordSeedType,
+		SeedPassphrase:  seedPassphrase,
+	}
+	wallet, err := driver.CreateWallet(pass, mgr.params)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr.Assets.Wallets[assetType][wallet.GetWalletID()] = wallet
+
+	return wallet, nil
+}
+
+// CreateNewWatchOnlyWallet creates a new watch only wallet of the asset type
+// and returns it.
+func (mgr *AssetsManager) CreateNewWatchOnlyWallet(assetType utils.AssetType, walletName, extendedPublicKey string) (sharedW.Asset, error) {
+	driver, ok := assetDrivers[assetType]
+	if !ok {
+		return nil, utils.ErrAssetUnknown
+	}
+
+	wallet, err := driver.CreateWatchOnlyWallet(walletName, extendedPublicKey, mgr.params)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr.Assets.Wallets[assetType][wallet.GetWalletID()] = wallet
+
+	return wallet, nil
+}
+
+// CreateMultisigWallet creates an m-of-n multisig wallet of the asset type
+// from the key expressions of the cosigners and returns it. If localWalletID
+// isn't 0, the cosigner key of that wallet, unlocked with privatePassphrase
+// and derived with the BIP-39 seedPassphrase of its seed if any, is one of
+// the cosigner keys.
+func (mgr *AssetsManager) CreateMultisigWallet(assetType utils.AssetType, walletName string, requiredSigs int,
+	cosignerKeys []string, localWalletID int, privatePassphrase, seedPassphrase string,
+) (sharedW.Asset, error) {
+	driver, ok := assetDrivers[assetType]
+	if !ok {
+		return nil, utils.ErrAssetUnknown
+	}
+	if driver.CreateMultisigWallet == nil {
+		return nil, fmt.Errorf("%v wallets can't be multisig wallets", assetType)
+	}
+
+	config := &sharedW.MultisigConfig{
+		RequiredSigs:  requiredSigs,
+		CosignerKeys:  cosignerKeys,
+		LocalWalletID: localWalletID,
+	}
+	if localWalletID != 0 {
+		localWallet := mgr.WalletWithID(localWalletID)
+		if localWallet == nil || localWallet.GetAssetType() != assetType {
+			return nil, errors.New(utils.ErrWalletNotFound)
+		}
+		localKey, err := driver.MultisigCosignerKey(localWallet, privatePassphrase, seedPassphrase)
+		if err != nil {
+			return nil, err
+		}
+		config.CosignerKeys = append([]string{localKey}, cosignerKeys...)
+	}
+
+	wallet, err := driver.CreateMultisigWallet(walletName, config, mgr.params)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr.Assets.Wallets[assetType][wallet.GetWalletID()] = wallet
+
+	return wallet, nil
+}
+
+// WalletWithSeed returns the ID of the wallet with the given seed and optional
+// BIP-39 seed passphrase. If a wallet with the given seed does not exist, it
+// returns -1.
+func (mgr *AssetsManager) WalletWithSeed(walletType utils.AssetType, seedMnemonic string, wordSeedType sharedW.WordSeedType, seedPassphrase string) (int, error) {
+	driver, ok := assetDrivers[walletType]
+	if !ok {
 		return -1, utils.ErrAssetUnknown
 	}
+	if len(seedMnemonic) == 0 {
+		return -1, errors.New(utils.ErrEmptySeed)
+	}
+
+	for _, wallet := range mgr.Assets.Wallets[walletType] {
+		if !wallet.WalletOpened() {
+			return -1, errors.Errorf("cannot check if seed matches unloaded wallet %d", wallet.GetWalletID())
+		}
+
+		usesSameSeed, err := driver.WalletUsesSeed(wallet, seedMnemonic, seedPassphrase, wordSeedType)
+		if err != nil {
+			return -1, err
+		}
+		if usesSameSeed {
+			return wallet.GetWalletID(), nil
+		}
+	}
+	return -1, nil
 }
 
-// RestoreWallet restores a wallet from the given seed.
-func (mgr *AssetsManager) RestoreWallet(walletType utils.AssetType, walletName, seedMnemonic, privatePassphrase string, privatePassphraseType int32, wordSeedType sharedW.WordSeedType) (sharedW.Asset, error) {
-	switch walletType {
-	case utils.BTCWalletAsset:
-		return mgr.RestoreBTCWallet(walletName, seedMnemonic, privatePassphrase, wordSeedType, privatePassphraseType)
-	case utils.DCRWalletAsset:
-		return mgr.RestoreDCRWallet(walletName, seedMnemonic, privatePassphrase, wordSeedType, privatePassphraseType)
-	case utils.LTCWalletAsset:
-		return mgr.RestoreLTCWallet(walletName, seedMnemonic, privatePassphrase, wordSeedType, privatePassphraseType)
-	default:
+// RestoreWallet restores a wallet from the given seed and optional BIP-39 seed
+// passphrase.
+func (mgr *AssetsManager) RestoreWallet(walletType utils.AssetType, walletName, seedMnemonic, privatePassphrase string, privatePassphraseType int32, wordSeedType sharedW.WordSeedType, seedPassphrase string) (sharedW.Asset, error) {
+	driver, ok := assetDrivers[walletType]
+	if !ok {
 		return nil, utils.ErrAssetUnknown
 	}
+
+	pass := &sharedW.AuthInfo{
+		Name:            walletName,
+		PrivatePass:     privatePassphrase,
+		PrivatePassType: privatePassphraseType,
+		WordSeedType:    wordSeedType,
+		SeedPassphrase:  seedPassphrase,
+	}
+	wallet, err := driver.RestoreWallet(seedMnemonic, pass, mgr.params)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr.Assets.Wallets[walletType][wallet.GetWalletID()] = wallet
+
+	return wallet, nil
 }
 
 // WalletWithXPub returns the ID of the wallet with the given xpub. If a wallet
 // with the given xpub does not exist, it returns -1.
 func (mgr *AssetsManager) WalletWithXPub(walletType utils.AssetType, xPub string) (int, error) {
-	switch walletType {
-	case utils.DCRWalletAsset:
-		return mgr.DCRWalletWithXPub(xPub)
-	case utils.BTCWalletAsset:
-		return mgr.BTCWalletWithXPub(xPub)
-	case utils.LTCWalletAsset:
-		return mgr.LTCWalletWithXPub(xPub)
-	default:
+	driver, ok := assetDrivers[walletType]
+	if !ok {
 		return -1, utils.ErrAssetUnknown
 	}
+
+	for _, wallet := range mgr.Assets.Wallets[walletType] {
+		if !wallet.WalletOpened() {
+			return -1, errors.Errorf("wallet %d is not open and cannot be checked", wallet.GetWalletID())
+		}
+
+		hasXPub, err := driver.WalletHasXPub(wallet, xPub)
+		if err != nil {
+			return -1, err
+		}
+		if hasXPub {
+			return wallet.GetWalletID(), nil
+		}
+	}
+	return -1, nil
 }
 
 // on windows os after a wallet is deleted, the dir of deleted wallet still exists,
@@ -695,7 +736,7 @@ func (mgr *AssetsManager) cleanDeletedWallets() {
 	}
 
 	// filter all wallets to be deleted.
-	for _, wType := range mgr.AllAssetTypes() {
+	for _, wType := range driverAssetTypes() {
 		dirName := ""
 		if mgr.NetType() == utils.Testnet {
 			dirName = utils.NetDir(wType, utils.Testnet)
@@ -734,46 +775,21 @@ func (mgr *AssetsManager) cleanDeletedWallets() {
 
 // AllAssetTypes returns all asset types supported by the assets manager.
 func (mgr *AssetsManager) AllAssetTypes() []utils.AssetType {
-	return []utils.AssetType{
-		utils.DCRWalletAsset,
-		utils.BTCWalletAsset,
-		utils.LTCWalletAsset,
-	}
+	return driverAssetTypes()
 }
 
 // BlockExplorerURLForTx returns a URL for viewing a transaction on the block
 // explorer of the specified asset.
 func (mgr *AssetsManager) BlockExplorerURLForTx(assetType utils.AssetType, txHash string) string {
-	var isMainnet bool
-	switch mgr.NetType() {
-	case utils.Mainnet:
-		isMainnet = true
-	case utils.Testnet:
-		isMainnet = false
-	default:
-		return "" // block explorer only exists for mainnet and testnet
+	driver, ok := assetDrivers[assetType]
+	if !ok {
+		return ""
 	}
 
-	switch assetType {
-	case utils.DCRWalletAsset:
-		if isMainnet {
-			return "https://explorer.dcrdata.org/tx/" + txHash
-		}
-		return "https://testnet.dcrdata.org/tx/" + txHash
-
-	case utils.BTCWalletAsset:
-		if isMainnet {
-			return "https://www.blockchain.com/btc/tx/" + txHash
-		}
-		return "https://live.blockcypher.com/btc-testnet/tx/" + txHash
-
-	case utils.LTCWalletAsset:
-		if isMainnet {
-			return "https://chain.so/tx/LTC/" + txHash
-		}
-		return "https://chain.so/tx/LTCTEST/" + txHash
+	// block explorer only exists for mainnet and testnet
+	if url, ok := driver.ExplorerTxURLs[mgr.NetType()]; ok {
+		return url + txHash
 	}
-
 	return ""
 }
 
@@ -781,38 +797,13 @@ func (mgr *AssetsManager) LogFile() string {
 	return filepath.Join(mgr.params.LogDir, LogFilename)
 }
 
-func (mgr *AssetsManager) DCRHDPrefix() string {
-	switch mgr.NetType() {
-	case utils.Testnet:
-		return dcr.TestnetHDPath
-	case utils.Mainnet:
-		return dcr.MainnetHDPath
-	default:
-		return ""
-	}
-}
-
-func (mgr *AssetsManager) BTCHDPrefix() string {
-	switch mgr.NetType() {
-	case utils.Testnet:
-		return btc.TestnetHDPath
-	case utils.Mainnet:
-		return btc.MainnetHDPath
-	default:
-		return ""
-	}
-}
-
-// LTC HDPrefix returns the HD path prefix for the Litecoin wallet network.
-func (mgr *AssetsManager) LTCHDPrefix() string {
-	switch mgr.NetType() {
-	case utils.Testnet:
-		return ltc.TestnetHDPath
-	case utils.Mainnet:
-		return ltc.MainnetHDPath
-	default:
-		return ""
+// HDPrefix returns the HD path prefix of the accounts of the asset on the
+// current network, the account number is appended to it.
+func (mgr *AssetsManager) HDPrefix(assetType utils.AssetType) string {
+	if driver, ok := assetDrivers[assetType]; ok {
+		return driver.HDPrefixes[mgr.NetType()]
 	}
+	return ""
 }
 
 func (mgr *AssetsManager) CalculateTotalAssetsBalance(includeWatchWallet bool) (map[utils.AssetType]sharedW.AssetAmount, error) {
@@ -862,7 +853,8 @@ func (mgr *AssetsManager) CalculateAssetsUSDBalance(balances map[utils.AssetType
 	for assetType, balance := range balances {
 		marketValue, exist := values.AssetExchangeMarketValue[assetType]
 		if !exist {
-			return nil, fmt.Errorf("unsupported asset type: %s", assetType)
+			// Assets without a market have no USD balance.
+			continue
 		}
 		usdBal, err := usdBalance(balance, marketValue)
 		if err != nil {
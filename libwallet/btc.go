@@ -3,8 +3,7 @@ package libwallet
 import (
 	"fmt"
 
-	"decred.org/dcrwallet/v4/errors"
-	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcwallet/waddrmgr"
 
 	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
@@ -12,134 +11,99 @@ import (
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 )
 
-func initializeBTCWalletParameters(netType utils.NetworkType) (*chaincfg.Params, error) {
-	chainParams, err := utils.BTCChainParams(netType)
-	if err != nil {
-		return chainParams, err
-	}
-
-	return chainParams, nil
+func init() {
+	RegisterAssetDriver(&AssetDriver{
+		Type:                  utils.BTCWalletAsset,
+		LoadExisting:          btc.LoadExisting,
+		CreateWallet:          btc.CreateNewWallet,
+		RestoreWallet:         btc.RestoreWallet,
+		CreateWatchOnlyWallet: btc.CreateWatchOnlyWallet,
+		CreateMultisigWallet:  btc.CreateMultisigWallet,
+		MultisigCosignerKey:   btcMultisigCosignerKey,
+		WalletUsesSeed:        btcWalletUsesSeed,
+		WalletHasXPub:         btcWalletHasXPub,
+		ValidateAddress:       btcValidateAddress,
+		ExplorerTxURLs: map[utils.NetworkType]string{
+			utils.Mainnet: "https://www.blockchain.com/btc/tx/",
+			utils.Testnet: "https://live.blockcypher.com/btc-testnet/tx/",
+		},
+		HDPrefixes: map[utils.NetworkType]string{
+			utils.Mainnet: btc.MainnetHDPath,
+			utils.Testnet: btc.TestnetHDPath,
+		},
+	})
 }
 
-// CreateNewBTCWallet creates a new BTC wallet and returns it.
-func (mgr *AssetsManager) CreateNewBTCWallet(walletName, privatePassphrase string, privatePassphraseType int32, wordSeedType sharedW.WordSeedType) (sharedW.Asset, error) {
-	pass := &sharedW.AuthInfo{
-		Name:            walletName,
-		PrivatePass:     privatePassphrase,
-		PrivatePassType: privatePassphraseType,
-		WordSeedType:    wordSeedType,
-	}
-	wallet, err := btc.CreateNewWallet(pass, mgr.params)
+// btcWalletHasXPub returns true if the BTC wallet has an account with the
+// provided xpub.
+func btcWalletHasXPub(wallet sharedW.Asset, xpub string) (bool, error) {
+	wAccs, err := wallet.GetAccountsRaw()
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 
-	mgr.Assets.BTC.Wallets[wallet.GetWalletID()] = wallet
-
-	return wallet, nil
-}
+	for _, accs := range wAccs.Accounts {
+		if accs.AccountNumber == btc.ImportedAccountNumber {
+			continue
+		}
+		acctXPubKey, err := wallet.Internal().BTC.AccountProperties(btc.GetScope(), accs.AccountNumber)
+		if err != nil {
+			return false, err
+		}
 
-// CreateNewBTCWatchOnlyWallet creates a new BTC watch only wallet and returns it.
-func (mgr *AssetsManager) CreateNewBTCWatchOnlyWallet(walletName, extendedPublicKey string) (sharedW.Asset, error) {
-	wallet, err := btc.CreateWatchOnlyWallet(walletName, extendedPublicKey, mgr.params)
-	if err != nil {
-		return nil, err
+		if acctXPubKey.AccountPubKey.String() == xpub {
+			return true, nil
+		}
 	}
-
-	mgr.Assets.BTC.Wallets[wallet.GetWalletID()] = wallet
-
-	return wallet, nil
+	return false, nil
 }
 
-// RestoreBTCWallet restores a BTC wallet from a seed and returns it.
-func (mgr *AssetsManager) RestoreBTCWallet(walletName, seedMnemonic, privatePassphrase string, wordSeedType sharedW.WordSeedType, privatePassphraseType int32) (sharedW.Asset, error) {
-	pass := &sharedW.AuthInfo{
-		Name:            walletName,
-		PrivatePass:     privatePassphrase,
-		PrivatePassType: privatePassphraseType,
-		WordSeedType:    wordSeedType,
+// btcWalletUsesSeed returns true if the BTC wallet was created or restored
+// using the same seed as the one provided.
+func btcWalletUsesSeed(wallet sharedW.Asset, seedMnemonic, seedPassphrase string, wordSeedType sharedW.WordSeedType) (bool, error) {
+	asset, ok := wallet.(*btc.Asset)
+	if !ok {
+		return false, fmt.Errorf("invalid asset type")
 	}
-	wallet, err := btc.RestoreWallet(seedMnemonic, pass, mgr.params)
+
+	wAccs, err := wallet.GetAccountsRaw()
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 
-	mgr.Assets.BTC.Wallets[wallet.GetWalletID()] = wallet
-
-	return wallet, nil
-}
-
-// BTCWalletWithXPub returns the ID of the BTC wallet that has an account with the
-// provided xpub. Returns -1 if there is no such wallet.
-func (mgr *AssetsManager) BTCWalletWithXPub(xpub string) (int, error) {
-	for _, wallet := range mgr.Assets.BTC.Wallets {
-		if !wallet.WalletOpened() {
-			return -1, errors.Errorf("wallet %d is not open and cannot be checked", wallet.GetWalletID())
+	for _, accs := range wAccs.Accounts {
+		if accs.AccountNumber == waddrmgr.ImportedAddrAccount {
+			continue
 		}
-
-		wAccs, err := wallet.GetAccountsRaw()
+		xpub, err := asset.DeriveAccountXpub(seedMnemonic, seedPassphrase, wordSeedType,
+			accs.AccountNumber, wallet.Internal().BTC.ChainParams())
 		if err != nil {
-			return -1, err
+			return false, err
 		}
 
-		for _, accs := range wAccs.Accounts {
-			if accs.AccountNumber == btc.ImportedAccountNumber {
-				continue
-			}
-			acctXPubKey, err := wallet.Internal().BTC.AccountProperties(btc.GetScope(), accs.AccountNumber)
-			if err != nil {
-				return -1, err
-			}
-
-			if acctXPubKey.AccountPubKey.String() == xpub {
-				return wallet.GetWalletID(), nil
-			}
+		usesSameSeed, err := asset.AccountXPubMatches(accs.AccountNumber, xpub)
+		if err != nil {
+			return false, err
+		}
+		if usesSameSeed {
+			return true, nil
 		}
 	}
-	return -1, nil
+	return false, nil
 }
 
-// BTCWalletWithSeed returns the ID of the BTC wallet that was created or restored
-// using the same seed as the one provided. Returns -1 if no wallet uses the
-// provided seed.
-func (mgr *AssetsManager) BTCWalletWithSeed(seedMnemonic string, wordSeedType sharedW.WordSeedType) (int, error) {
-	if len(seedMnemonic) == 0 {
-		return -1, errors.New(utils.ErrEmptySeed)
+// btcMultisigCosignerKey returns the cosigner key of the BTC wallet.
+func btcMultisigCosignerKey(wallet sharedW.Asset, privatePassphrase, seedPassphrase string) (string, error) {
+	asset, ok := wallet.(*btc.Asset)
+	if !ok {
+		return "", fmt.Errorf("invalid asset type")
 	}
+	return asset.MultisigCosignerKey(privatePassphrase, seedPassphrase)
+}
 
-	for _, wallet := range mgr.Assets.BTC.Wallets {
-		if !wallet.WalletOpened() {
-			return -1, errors.Errorf("cannot check if seed matches unloaded wallet %d", wallet.GetWalletID())
-		}
-
-		asset, ok := wallet.(*btc.Asset)
-		if !ok {
-			return -1, fmt.Errorf("invalid asset type")
-		}
-
-		wAccs, err := wallet.GetAccountsRaw()
-		if err != nil {
-			return -1, err
-		}
-
-		for _, accs := range wAccs.Accounts {
-			if accs.AccountNumber == waddrmgr.ImportedAddrAccount {
-				continue
-			}
-			xpub, err := asset.DeriveAccountXpub(seedMnemonic, wordSeedType,
-				accs.AccountNumber, wallet.Internal().BTC.ChainParams())
-			if err != nil {
-				return -1, err
-			}
-
-			usesSameSeed, err := asset.AccountXPubMatches(accs.AccountNumber, xpub)
-			if err != nil {
-				return -1, err
-			}
-			if usesSameSeed {
-				return wallet.GetWalletID(), nil
-			}
-		}
-	}
-	return -1, nil
+// btcValidateAddress returns an error if the address isn't a BTC address of
+// the network.
+func btcValidateAddress(address string, params *utils.ChainsParams) error {
+	_, err := btcutil.DecodeAddress(address, params.BTC)
+	return err
 }
@@ -3,155 +3,101 @@ package libwallet
 import (
 	"context"
 
-	"decred.org/dcrwallet/v4/errors"
-
 	"github.com/decred/dcrd/chaincfg/v3"
 	"github.com/decred/dcrd/hdkeychain/v3"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
 
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 )
 
-// initializeDCRWalletParameters initializes the fields each DCR wallet is going to need to be setup
-// such as chainparams.
-func initializeDCRWalletParameters(netType utils.NetworkType) (*chaincfg.Params, error) {
-	chainParams, err := utils.DCRChainParams(netType)
-	if err != nil {
-		return chainParams, err
-	}
-	return chainParams, nil
-}
-
-// CreateNewDCRWallet creates a new DCR wallet and returns it.
-func (mgr *AssetsManager) CreateNewDCRWallet(walletName, privatePassphrase string, privatePassphraseType int32, wordSeedType sharedW.WordSeedType) (sharedW.Asset, error) {
-	pass := &sharedW.AuthInfo{
-		Name:            walletName,
-		PrivatePass:     privatePassphrase,
-		PrivatePassType: privatePassphraseType,
-		WordSeedType:    wordSeedType,
-	}
-	wallet, err := dcr.CreateNewWallet(pass, mgr.params)
-	if err != nil {
-		return nil, err
-	}
-
-	mgr.Assets.DCR.Wallets[wallet.GetWalletID()] = wallet
-
-	// Allow spending from the default account by default.
-	wallet.SetBoolConfigValueForKey(sharedW.SpendUnmixedFundsKey, true)
-
-	return wallet, nil
-}
-
-// CreateNewDCRWatchOnlyWallet creates a new DCR watch only wallet and returns it.
-func (mgr *AssetsManager) CreateNewDCRWatchOnlyWallet(walletName, extendedPublicKey string) (sharedW.Asset, error) {
-	wallet, err := dcr.CreateWatchOnlyWallet(walletName, extendedPublicKey, mgr.params)
-	if err != nil {
-		return nil, err
-	}
-
-	mgr.Assets.DCR.Wallets[wallet.GetWalletID()] = wallet
-
-	// Allow spending from the default account by default.
-	wallet.SetBoolConfigValueForKey(sharedW.SpendUnmixedFundsKey, true)
-
-	return wallet, nil
+func init() {
+	RegisterAssetDriver(&AssetDriver{
+		Type:         utils.DCRWalletAsset,
+		LoadExisting: dcr.LoadExisting,
+		CreateWallet: func(pass *sharedW.AuthInfo, params *sharedW.InitParams) (sharedW.Asset, error) {
+			return allowUnmixedSpending(dcr.CreateNewWallet(pass, params))
+		},
+		RestoreWallet: func(seedMnemonic string, pass *sharedW.AuthInfo, params *sharedW.InitParams) (sharedW.Asset, error) {
+			return allowUnmixedSpending(dcr.RestoreWallet(seedMnemonic, pass, params))
+		},
+		CreateWatchOnlyWallet: func(walletName, extendedPublicKey string, params *sharedW.InitParams) (sharedW.Asset, error) {
+			return allowUnmixedSpending(dcr.CreateWatchOnlyWallet(walletName, extendedPublicKey, params))
+		},
+		WalletUsesSeed:  dcrWalletUsesSeed,
+		WalletHasXPub:   dcrWalletHasXPub,
+		ValidateAddress: dcrValidateAddress,
+		ExplorerTxURLs: map[utils.NetworkType]string{
+			utils.Mainnet: "https://explorer.dcrdata.org/tx/",
+			utils.Testnet: "https://testnet.dcrdata.org/tx/",
+		},
+		HDPrefixes: map[utils.NetworkType]string{
+			utils.Mainnet: dcr.MainnetHDPath,
+			utils.Testnet: dcr.TestnetHDPath,
+		},
+	})
 }
 
-// RestoreDCRWallet restores a DCR wallet from a seed and returns it.
-func (mgr *AssetsManager) RestoreDCRWallet(walletName, seedMnemonic, privatePassphrase string, wordSeedType sharedW.WordSeedType, privatePassphraseType int32) (sharedW.Asset, error) {
-	pass := &sharedW.AuthInfo{
-		Name:            walletName,
-		PrivatePass:     privatePassphrase,
-		PrivatePassType: privatePassphraseType,
-		WordSeedType:    wordSeedType,
-	}
-	wallet, err := dcr.RestoreWallet(seedMnemonic, pass, mgr.params)
+// allowUnmixedSpending allows spending from the default account of a new DCR
+// wallet by default.
+func allowUnmixedSpending(wallet sharedW.Asset, err error) (sharedW.Asset, error) {
 	if err != nil {
 		return nil, err
 	}
-
-	mgr.Assets.DCR.Wallets[wallet.GetWalletID()] = wallet
-
-	// Allow spending from the default account by default.
 	wallet.SetBoolConfigValueForKey(sharedW.SpendUnmixedFundsKey, true)
-
 	return wallet, nil
 }
 
-// DCRWalletWithXPub returns the ID of the DCR wallet that has an account with the
-// provided xpub. Returns -1 if there is no such wallet.
-func (mgr *AssetsManager) DCRWalletWithXPub(xpub string) (int, error) {
+// dcrWalletHasXPub returns true if the DCR wallet has an account with the
+// provided xpub.
+func dcrWalletHasXPub(w sharedW.Asset, xpub string) (bool, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	for _, w := range mgr.Assets.DCR.Wallets {
-		if !w.WalletOpened() {
-			return -1, errors.Errorf("wallet %d is not open and cannot be checked", w.GetWalletID())
-		}
-		accounts, err := w.Internal().DCR.Accounts(ctx)
-		if err != nil {
-			return -1, err
-		}
-		for _, account := range accounts.Accounts {
-			if account.AccountNumber == dcr.ImportedAccountNumber {
-				continue
-			}
-			acctXPub, err := w.Internal().DCR.AccountXpub(ctx, account.AccountNumber)
-			if err != nil {
-				return -1, err
-			}
-			if acctXPub.String() == xpub {
-				return w.GetWalletID(), nil
-			}
-		}
-	}
-	return -1, nil
-}
-
-// DCRWalletWithSeed returns the ID of the DCR wallet that was created or restored
-// using the same seed as the one provided. Returns -1 if no wallet uses the
-// provided seed.
-func (mgr *AssetsManager) DCRWalletWithSeed(seedMnemonic string, wordSeedType sharedW.WordSeedType) (int, error) {
-	if len(seedMnemonic) == 0 {
-		return -1, errors.New(utils.ErrEmptySeed)
-	}
-
-	newSeedLegacyXPUb, newSeedSLIP0044XPUb, err := deriveBIP44AccountXPubsForDCR(seedMnemonic, wordSeedType,
-		dcr.DefaultAccountNum, mgr.chainsParams.DCR)
+	accounts, err := w.Internal().DCR.Accounts(ctx)
 	if err != nil {
-		return -1, err
+		return false, err
 	}
-
-	for _, wallet := range mgr.Assets.DCR.Wallets {
-		if !wallet.WalletOpened() {
-			return -1, errors.Errorf("cannot check if seed matches unloaded wallet %d", wallet.GetWalletID())
+	for _, account := range accounts.Accounts {
+		if account.AccountNumber == dcr.ImportedAccountNumber {
+			continue
 		}
-		// NOTE: Existing watch-only wallets may have been created using the
-		// xpub of an account that is NOT the default account and may return
-		// incorrect result from the check below. But this would return true
-		// if the watch-only wallet was created using the xpub of the default
-		// account of the provided seed.
-		fn := wallet.(interface {
-			AccountXPubMatches(account uint32, legacyXPub, slip044XPub string) (bool, error)
-		})
-		usesSameSeed, err := fn.AccountXPubMatches(dcr.DefaultAccountNum, newSeedLegacyXPUb, newSeedSLIP0044XPUb)
+		acctXPub, err := w.Internal().DCR.AccountXpub(ctx, account.AccountNumber)
 		if err != nil {
-			return -1, err
+			return false, err
 		}
-		if usesSameSeed {
-			return wallet.GetWalletID(), nil
+		if acctXPub.String() == xpub {
+			return true, nil
 		}
 	}
+	return false, nil
+}
 
-	return -1, nil
+// dcrWalletUsesSeed returns true if the DCR wallet was created or restored
+// using the same seed as the one provided.
+func dcrWalletUsesSeed(wallet sharedW.Asset, seedMnemonic, seedPassphrase string, wordSeedType sharedW.WordSeedType) (bool, error) {
+	newSeedLegacyXPUb, newSeedSLIP0044XPUb, err := deriveBIP44AccountXPubsForDCR(seedMnemonic, seedPassphrase, wordSeedType,
+		dcr.DefaultAccountNum, wallet.Internal().DCR.ChainParams())
+	if err != nil {
+		return false, err
+	}
+
+	// NOTE: Existing watch-only wallets may have been created using the
+	// xpub of an account that is NOT the default account and may return
+	// incorrect result from the check below. But this would return true
+	// if the watch-only wallet was created using the xpub of the default
+	// account of the provided seed.
+	fn := wallet.(interface {
+		AccountXPubMatches(account uint32, legacyXPub, slip044XPub string) (bool, error)
+	})
+	return fn.AccountXPubMatches(dcr.DefaultAccountNum, newSeedLegacyXPUb, newSeedSLIP0044XPUb)
 }
 
 // deriveBIP44AccountXPubForDCR derives and returns the legacy and SLIP0044 account
 // xpubs using the BIP44 HD path for accounts: m/44'/<coin type>'/<account>'.
-func deriveBIP44AccountXPubsForDCR(seedMnemonic string, wordSeedType sharedW.WordSeedType, account uint32, params *chaincfg.Params) (string, string, error) {
-	seed, err := sharedW.DecodeSeedMnemonic(seedMnemonic, utils.DCRWalletAsset, wordSeedType)
+func deriveBIP44AccountXPubsForDCR(seedMnemonic, seedPassphrase string, wordSeedType sharedW.WordSeedType, account uint32, params *chaincfg.Params) (string, string, error) {
+	seed, err := sharedW.DecodeSeedMnemonic(seedMnemonic, utils.DCRWalletAsset, wordSeedType, seedPassphrase)
 	if err != nil {
 		return "", "", err
 	}
@@ -200,3 +146,10 @@ func deriveBIP44AccountXPubsForDCR(seedMnemonic string, wordSeedType sharedW.Wor
 
 	return legacyXPUb, slip0044XPUb, nil
 }
+
+// dcrValidateAddress returns an error if the address isn't a DCR address of
+// the network.
+func dcrValidateAddress(address string, params *utils.ChainsParams) error {
+	_, err := stdaddr.DecodeAddress(address, params.DCR)
+	return err
+}
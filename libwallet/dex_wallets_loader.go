@@ -186,6 +186,14 @@ func btcCloneWalletConstructor(isLtc bool) func(settings map[string]string, chai
 			return nil, fmt.Errorf("cannot use watch only wallet for DEX trade")
 		}
 
+		// The DEX wallets of BTC and LTC query the neutrino chain service.
+		switch wallet.ChainBackend() {
+		case sharedW.RPCBackend:
+			return nil, fmt.Errorf("cannot use a wallet syncing with a full node for DEX trade")
+		case sharedW.ElectrumBackend:
+			return nil, fmt.Errorf("cannot use a wallet syncing with an electrum server for DEX trade")
+		}
+
 		// Ensure the wallet account exists.
 		accountNumberStr := settings[dexc.WalletAccountNumberConfigKey]
 		acctNum, err := strconv.ParseInt(accountNumberStr, 10, 64)
@@ -0,0 +1,99 @@
+package libwallet
+
+import (
+	"fmt"
+	"sync"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+const dustWatchIdentifier = "dust_watch"
+
+// DustListener is called with the outputs of a wallet that were flagged as
+// dust since the last call. The outputs are frozen if the wallet freezes dust
+// automatically.
+type DustListener func(wallet sharedW.Asset, dust []*sharedW.UnspentOutput)
+
+// dustWatch holds the outputs already reported to the DustListener.
+type dustWatch struct {
+	mu       sync.Mutex
+	reported map[string]bool
+}
+
+// WatchDust looks for outputs flagged as dust whenever a wallet receives or
+// confirms a transaction, freezes them if the wallet freezes dust
+// automatically and calls listen with the outputs that were not frozen or
+// reported yet.
+func (mgr *AssetsManager) WatchDust(listen DustListener) {
+	txAndBlockNotificationListener := &sharedW.TxAndBlockNotificationListener{
+		OnTransaction: func(walletID int, _ *sharedW.Transaction) {
+			go mgr.checkDust(walletID, listen)
+		},
+		OnTransactionConfirmed: func(walletID int, _ string, _ int32) {
+			go mgr.checkDust(walletID, listen)
+		},
+	}
+
+	for _, wallet := range mgr.AllWallets() {
+		if !wallet.IsNotificationListenerExist(dustWatchIdentifier) {
+			if err := wallet.AddTxAndBlockNotificationListener(txAndBlockNotificationListener, dustWatchIdentifier); err != nil {
+				log.Errorf("Can't listen tx notification for %s wallet", wallet.GetWalletName())
+			}
+		}
+		go mgr.checkDust(wallet.GetWalletID(), listen)
+	}
+}
+
+// RemoveDustWatch stops the dust checks started by WatchDust.
+func (mgr *AssetsManager) RemoveDustWatch() {
+	for _, wallet := range mgr.AllWallets() {
+		wallet.RemoveTxAndBlockNotificationListener(dustWatchIdentifier)
+	}
+}
+
+// checkDust reports the outputs of the wallet that are flagged as dust, are
+// not frozen and were not reported before.
+func (mgr *AssetsManager) checkDust(walletID int, listen DustListener) {
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil || !wallet.IsSynced() {
+		return
+	}
+
+	// Checks of the same wallet must not freeze and report outputs twice.
+	mgr.dustWatch.mu.Lock()
+	defer mgr.dustWatch.mu.Unlock()
+
+	dust, err := sharedW.FindWalletDust(wallet)
+	if err != nil {
+		log.Errorf("Error looking for dust in %s wallet: %v", wallet.GetWalletName(), err)
+		return
+	}
+
+	if mgr.dustWatch.reported == nil {
+		mgr.dustWatch.reported = make(map[string]bool)
+	}
+
+	newDust := make([]*sharedW.UnspentOutput, 0)
+	for _, utxo := range dust {
+		key := fmt.Sprintf("%d:%s:%d", walletID, utxo.TxID, utxo.Vout)
+		if mgr.dustWatch.reported[key] || utxo.Frozen {
+			// Frozen outputs were already handled.
+			continue
+		}
+
+		if wallet.AutoFreezeDust() {
+			if err := wallet.SetOutputFrozen(utxo.TxID, utxo.Vout, true); err != nil {
+				log.Errorf("Error freezing dust output %s:%d: %v", utxo.TxID, utxo.Vout, err)
+				continue
+			}
+			utxo.Frozen = true
+		}
+
+		mgr.dustWatch.reported[key] = true
+		newDust = append(newDust, utxo)
+	}
+
+	if len(newDust) > 0 {
+		listen(wallet, newDust)
+	}
+}
@@ -0,0 +1,965 @@
+package electrum
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btclog"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// headersChunkSize is the number of headers fetched at once: a retarget
+	// period, which is also the most servers return per request.
+	headersChunkSize = 2016
+
+	// maxCachedHeaderChunks bounds the headers held in memory, about 1.3MB.
+	maxCachedHeaderChunks = 8
+
+	// reorgDepth is the depth of the blocks checked for a reorg when the tip
+	// of the server doesn't extend the known tip.
+	reorgDepth = 100
+
+	// maxTipAge is the age of the tip after which the server isn't
+	// considered current, as for the other chain clients.
+	maxTipAge = 2 * time.Hour
+
+	// pingInterval keeps the session with the server alive, servers close
+	// sessions idle for too long.
+	pingInterval = time.Minute
+
+	// reconnectInterval is the delay between the attempts to reconnect once
+	// the connection to the server is lost.
+	reconnectInterval = 10 * time.Second
+
+	// maxConcurrentRequests bounds the requests in flight when querying many
+	// script hashes at once.
+	maxConcurrentRequests = 8
+)
+
+// Block is a block of the chain.
+type Block struct {
+	Hash   Hash
+	Height int32
+	Time   time.Time
+}
+
+// RelevantTx is a transaction paying to or spending from a watched script.
+// Height is the height in the history of the script, Pos the position of the
+// transaction in its block. Block is nil for mempool transactions.
+type RelevantTx[Tx any] struct {
+	Tx     Tx
+	Hash   Hash
+	Height int32
+	Pos    int
+	Block  *Block
+}
+
+// Notifier receives the notifications of a Chain, in order.
+type Notifier[Tx any] interface {
+	// Connected is called once the chain connected to a server.
+	Connected()
+	// BlockConnected and BlockDisconnected are only called once
+	// NotifyBlocks is called.
+	BlockConnected(block *Block)
+	BlockDisconnected(block *Block)
+	RelevantTx(tx *RelevantTx[Tx])
+	RescanFinished(tip *Block)
+}
+
+// ChainConfig is the configuration of a Chain.
+type ChainConfig[Tx any] struct {
+	Params *ChainParams
+	// Servers are tried in order.
+	Servers []*Server
+	// DecodeTx decodes a serialized transaction and returns its hash.
+	DecodeTx func(raw []byte) (Tx, Hash, error)
+	Notifier Notifier[Tx]
+	Log      btclog.Logger
+}
+
+// Chain syncs a wallet with an Electrum server. The history of the watched
+// scripts is queried from the index of the server instead of scanning
+// blocks. Headers are verified against the proof of work and the checkpoints
+// of the network, and transactions against the merkle root of the verified
+// headers. Chain is not tied to a coin, the asset decodes the transactions
+// and turns the notifications into those of its wallet.
+type Chain[Tx any] struct {
+	params   *ChainParams
+	decodeTx func(raw []byte) (Tx, Hash, error)
+	notifier Notifier[Tx]
+	log      btclog.Logger
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	quit     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+
+	clientMu sync.RWMutex
+	client   *Client
+	servers  []*Server
+
+	// headersMu protects the verified headers, cached by chunks of
+	// headersChunkSize.
+	headersMu sync.Mutex
+	chunks    map[int32][]*Header
+	chunkLRU  []int32
+	heights   map[Hash]int32
+	tip       *Header
+	tipHeight int32
+
+	// watchMu protects the subscribed script hashes and the transactions
+	// notified.
+	watchMu      sync.Mutex
+	watched      map[string]struct{}
+	statuses     map[string]string
+	historyCache map[string][]*HistoryItem
+	notifiedTxs  map[Hash]int32
+	notifyBlocks bool
+}
+
+// NewChain returns a chain connecting to the servers of the config once
+// started.
+func NewChain[Tx any](cfg *ChainConfig[Tx]) *Chain[Tx] {
+	logger := cfg.Log
+	if logger == nil {
+		logger = btclog.Disabled
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Chain[Tx]{
+		params:       cfg.Params,
+		decodeTx:     cfg.DecodeTx,
+		notifier:     cfg.Notifier,
+		log:          logger,
+		ctx:          ctx,
+		cancel:       cancel,
+		quit:         make(chan struct{}),
+		servers:      cfg.Servers,
+		chunks:       make(map[int32][]*Header),
+		heights:      make(map[Hash]int32),
+		watched:      make(map[string]struct{}),
+		statuses:     make(map[string]string),
+		historyCache: make(map[string][]*HistoryItem),
+		notifiedTxs:  make(map[Hash]int32),
+	}
+}
+
+// Start connects to the first reachable server. The chain can't be restarted
+// once stopped.
+func (c *Chain[Tx]) Start() error {
+	client, err := c.connect()
+	if err != nil {
+		return err
+	}
+
+	c.notifier.Connected()
+
+	c.wg.Add(1)
+	go c.run(client)
+	return nil
+}
+
+// Stop disconnects from the server.
+func (c *Chain[Tx]) Stop() {
+	c.stopOnce.Do(func() {
+		c.cancel()
+		close(c.quit)
+
+		c.clientMu.Lock()
+		client := c.client
+		c.client = nil
+		c.clientMu.Unlock()
+		if client != nil {
+			client.Close()
+		}
+	})
+}
+
+// WaitForShutdown blocks until the chain is stopped.
+func (c *Chain[Tx]) WaitForShutdown() {
+	c.wg.Wait()
+}
+
+// Servers returns the servers of the chain, the last lost server last.
+func (c *Chain[Tx]) Servers() []*Server {
+	c.clientMu.RLock()
+	defer c.clientMu.RUnlock()
+	return c.servers
+}
+
+// ServerInfo returns the address and software of the server the chain is
+// connected to, empty if disconnected.
+func (c *Chain[Tx]) ServerInfo() (addr, software string) {
+	client, err := c.currentClient()
+	if err != nil {
+		return "", ""
+	}
+	return client.Server().String(), client.Software()
+}
+
+// connect connects to a server, subscribes to its tip and to the scripts
+// already watched.
+func (c *Chain[Tx]) connect() (*Client, error) {
+	client, err := DialAny(c.ctx, c.Servers())
+	if err != nil {
+		return nil, err
+	}
+
+	c.clientMu.Lock()
+	c.client = client
+	c.clientMu.Unlock()
+
+	tip, err := client.SubscribeHeaders(c.ctx)
+	if err == nil {
+		err = c.connectTip(tip)
+	}
+	if err != nil {
+		c.clientMu.Lock()
+		c.client = nil
+		c.clientMu.Unlock()
+		client.Close()
+		return nil, fmt.Errorf("unable to sync headers with %s: %w", client.Server(), err)
+	}
+	c.log.Infof("Connected to electrum server %s (%s)", client.Server(), client.Software())
+
+	if err := c.resubscribe(client); err != nil {
+		c.log.Errorf("Unable to watch the wallet addresses on %s: %v", client.Server(), err)
+	}
+	return client, nil
+}
+
+// reconnect connects to a server again after the connection is lost, until
+// the chain is stopped. The lost server is tried last.
+func (c *Chain[Tx]) reconnect(lost *Client) *Client {
+	c.log.Warnf("Lost connection to electrum server %s: %v", lost.Server(), lost.Err())
+	c.clientMu.Lock()
+	c.client = nil
+	for i, server := range c.servers {
+		if server == lost.Server() {
+			c.servers = append(append(c.servers[:i:i], c.servers[i+1:]...), server)
+			break
+		}
+	}
+	c.clientMu.Unlock()
+
+	for {
+		select {
+		case <-c.quit:
+			return nil
+		case <-time.After(reconnectInterval):
+		}
+
+		client, err := c.connect()
+		if err == nil {
+			return client
+		}
+		c.log.Errorf("Unable to reconnect to an electrum server: %v", err)
+	}
+}
+
+// run handles the notifications of the server until the chain is stopped.
+func (c *Chain[Tx]) run(client *Client) {
+	defer c.wg.Done()
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case tip, ok := <-client.HeaderNotifications():
+			if !ok {
+				if client = c.reconnect(client); client == nil {
+					return
+				}
+				continue
+			}
+			if err := c.connectTip(tip); err != nil {
+				// A server serving invalid headers isn't trusted for the
+				// history either.
+				c.log.Errorf("Dropping electrum server %s: %v", client.Server(), err)
+				client.Close()
+			}
+
+		case status, ok := <-client.ScripthashNotifications():
+			if !ok {
+				if client = c.reconnect(client); client == nil {
+					return
+				}
+				continue
+			}
+			if err := c.updateScripthash(status.Scripthash, status.Status); err != nil {
+				c.log.Errorf("Unable to process the transactions of a watched address: %v", err)
+			}
+
+		case <-ping.C:
+			if err := client.Ping(c.ctx); err != nil {
+				c.log.Debugf("Electrum server %s ping failed: %v", client.Server(), err)
+			}
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+func (c *Chain[Tx]) currentClient() (*Client, error) {
+	c.clientMu.RLock()
+	defer c.clientMu.RUnlock()
+
+	if c.client == nil {
+		return nil, errors.New("not connected to an electrum server")
+	}
+	return c.client, nil
+}
+
+// connectTip moves the tip to the header notified by the server. Blocks from
+// the fork point with the previous tip are disconnected and connected again
+// if the server switched chains or skipped blocks.
+func (c *Chain[Tx]) connectTip(tip *HeaderNotification) error {
+	raw, err := hex.DecodeString(tip.Hex)
+	if err != nil {
+		return err
+	}
+	headers, err := DecodeHeaders(raw, 1)
+	if err != nil {
+		return err
+	}
+	header := headers[0]
+
+	c.headersMu.Lock()
+	prevTip, prevTipHeight := c.tip, c.tipHeight
+	switch {
+	case prevTip != nil && tip.Height == prevTipHeight && header.Hash == prevTip.Hash:
+		c.headersMu.Unlock()
+		return nil
+
+	case prevTip != nil && tip.Height == prevTipHeight+1 && header.PrevBlock == prevTip.Hash:
+		if err := c.params.VerifyHeaders(tip.Height, prevTip, headers); err != nil {
+			c.headersMu.Unlock()
+			return err
+		}
+		c.appendHeader(tip.Height, header)
+		c.tip, c.tipHeight = header, tip.Height
+		c.headersMu.Unlock()
+
+		c.notifyBlockConnected(tip.Height, header)
+		return nil
+	}
+
+	if err := c.params.VerifyHeaders(tip.Height, nil, headers); err != nil {
+		c.headersMu.Unlock()
+		return err
+	}
+
+	// The recent headers are fetched again, keeping their previous hashes to
+	// find the fork point.
+	checkFrom := prevTipHeight - reorgDepth
+	staleHashes := make(map[int32]Hash)
+	for height := checkFrom; prevTip != nil && height <= prevTipHeight; height++ {
+		if header := c.cachedHeader(height); header != nil {
+			staleHashes[height] = header.Hash
+		}
+	}
+	if prevTip != nil {
+		// The tip is known even if the chunk holding it isn't cached.
+		staleHashes[prevTipHeight] = prevTip.Hash
+	}
+	c.dropHeadersFrom(checkFrom)
+	c.tip, c.tipHeight = header, tip.Height
+	c.headersMu.Unlock()
+
+	if prevTip == nil {
+		return nil
+	}
+
+	fork := prevTipHeight
+	if fork > tip.Height {
+		fork = tip.Height
+	}
+	for ; fork > checkFrom; fork-- {
+		stale, ok := staleHashes[fork]
+		if !ok {
+			break
+		}
+		header, err := c.Header(fork)
+		if err != nil {
+			return err
+		}
+		if header.Hash == stale {
+			break
+		}
+	}
+
+	if !c.blockNotificationsEnabled() {
+		return nil
+	}
+	for height := prevTipHeight; height > fork; height-- {
+		if hash, ok := staleHashes[height]; ok {
+			c.notifier.BlockDisconnected(&Block{Hash: hash, Height: height})
+		}
+	}
+	for height := fork + 1; height <= tip.Height; height++ {
+		header, err := c.Header(height)
+		if err != nil {
+			return err
+		}
+		c.notifyBlockConnected(height, header)
+	}
+	return nil
+}
+
+func (c *Chain[Tx]) blockNotificationsEnabled() bool {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+	return c.notifyBlocks
+}
+
+func (c *Chain[Tx]) notifyBlockConnected(height int32, header *Header) {
+	if c.blockNotificationsEnabled() {
+		c.notifier.BlockConnected(&Block{Hash: header.Hash, Height: height, Time: header.Timestamp})
+	}
+}
+
+// cachedHeader returns the header at the height if cached. It must be called
+// with headersMu held.
+func (c *Chain[Tx]) cachedHeader(height int32) *Header {
+	if height < 0 {
+		return nil
+	}
+	chunk := c.chunks[height/headersChunkSize]
+	if i := int(height % headersChunkSize); i < len(chunk) {
+		return chunk[i]
+	}
+	return nil
+}
+
+// appendHeader extends the cached chunk ending right before the header. It
+// must be called with headersMu held.
+func (c *Chain[Tx]) appendHeader(height int32, header *Header) {
+	index := height / headersChunkSize
+	chunk, ok := c.chunks[index]
+	if !ok || len(chunk) != int(height%headersChunkSize) {
+		return
+	}
+	c.chunks[index] = append(chunk, header)
+	c.heights[header.Hash] = height
+}
+
+// storeChunk caches the chunk, evicting the least recently fetched chunk if
+// the cache is full. It must be called with headersMu held.
+func (c *Chain[Tx]) storeChunk(index int32, headers []*Header) {
+	c.dropChunk(index)
+	c.chunks[index] = headers
+	for i, header := range headers {
+		c.heights[header.Hash] = index*headersChunkSize + int32(i)
+	}
+	c.chunkLRU = append(c.chunkLRU, index)
+	if len(c.chunkLRU) > maxCachedHeaderChunks {
+		c.dropChunk(c.chunkLRU[0])
+	}
+}
+
+// dropChunk must be called with headersMu held.
+func (c *Chain[Tx]) dropChunk(index int32) {
+	for _, header := range c.chunks[index] {
+		delete(c.heights, header.Hash)
+	}
+	delete(c.chunks, index)
+	for i, cached := range c.chunkLRU {
+		if cached == index {
+			c.chunkLRU = append(c.chunkLRU[:i], c.chunkLRU[i+1:]...)
+			break
+		}
+	}
+}
+
+// dropHeadersFrom drops the cached chunks holding headers from the height.
+// It must be called with headersMu held.
+func (c *Chain[Tx]) dropHeadersFrom(height int32) {
+	if height < 0 {
+		height = 0
+	}
+	for index := range c.chunks {
+		if index >= height/headersChunkSize {
+			c.dropChunk(index)
+		}
+	}
+}
+
+// Header returns the verified header at the height, fetching its chunk from
+// the server if it isn't cached.
+func (c *Chain[Tx]) Header(height int32) (*Header, error) {
+	c.headersMu.Lock()
+	defer c.headersMu.Unlock()
+
+	if header := c.cachedHeader(height); header != nil {
+		return header, nil
+	}
+	if height < 0 || height > c.tipHeight {
+		return nil, fmt.Errorf("no block at height %d", height)
+	}
+
+	client, err := c.currentClient()
+	if err != nil {
+		return nil, err
+	}
+	index := height / headersChunkSize
+	start := index * headersChunkSize
+	raw, count, err := client.BlockHeaders(c.ctx, start, headersChunkSize)
+	if err != nil {
+		return nil, err
+	}
+	headers, err := DecodeHeaders(raw, count)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.params.VerifyHeaders(start, c.cachedHeader(start-1), headers); err != nil {
+		return nil, err
+	}
+	c.storeChunk(index, headers)
+
+	if header := c.cachedHeader(height); header != nil {
+		return header, nil
+	}
+	return nil, fmt.Errorf("no block at height %d", height)
+}
+
+// BlockHeight returns the height of the block with the hash. Only recent
+// blocks and blocks of recently fetched headers are known.
+func (c *Chain[Tx]) BlockHeight(hash Hash) (int32, error) {
+	c.headersMu.Lock()
+	defer c.headersMu.Unlock()
+
+	if height, ok := c.heights[hash]; ok {
+		return height, nil
+	}
+	if c.tip != nil && c.tip.Hash == hash {
+		return c.tipHeight, nil
+	}
+	return -1, fmt.Errorf("block %v not found", hash)
+}
+
+// Tip returns the tip of the server.
+func (c *Chain[Tx]) Tip() (*Block, error) {
+	c.headersMu.Lock()
+	defer c.headersMu.Unlock()
+
+	if c.tip == nil {
+		return nil, errors.New("no tip received from the electrum server")
+	}
+	return &Block{Hash: c.tip.Hash, Height: c.tipHeight, Time: c.tip.Timestamp}, nil
+}
+
+// IsCurrent returns true if the tip of the server is recent.
+func (c *Chain[Tx]) IsCurrent() bool {
+	tip, err := c.Tip()
+	return err == nil && time.Since(tip.Time) < maxTipAge
+}
+
+// forEach runs f for each script hash with a bounded number of concurrent
+// requests.
+func (c *Chain[Tx]) forEach(scripthashes []string, f func(scripthash string) error) error {
+	g, _ := errgroup.WithContext(c.ctx)
+	g.SetLimit(maxConcurrentRequests)
+	for _, scripthash := range scripthashes {
+		scripthash := scripthash
+		g.Go(func() error {
+			return f(scripthash)
+		})
+	}
+	return g.Wait()
+}
+
+// watch subscribes to the script hashes of the output scripts not already
+// watched and returns the script hashes of all the scripts.
+func (c *Chain[Tx]) watch(pkScripts [][]byte) ([]string, error) {
+	scripthashes := make([]string, 0, len(pkScripts))
+	var unwatched []string
+	seen := make(map[string]struct{}, len(pkScripts))
+
+	c.watchMu.Lock()
+	for _, pkScript := range pkScripts {
+		scripthash := ScriptHash(pkScript)
+		if _, ok := seen[scripthash]; ok {
+			continue
+		}
+		seen[scripthash] = struct{}{}
+		scripthashes = append(scripthashes, scripthash)
+		if _, ok := c.watched[scripthash]; !ok {
+			c.watched[scripthash] = struct{}{}
+			unwatched = append(unwatched, scripthash)
+		}
+	}
+	c.watchMu.Unlock()
+
+	client, err := c.currentClient()
+	if err == nil {
+		err = c.forEach(unwatched, func(scripthash string) error {
+			return c.subscribe(client, scripthash)
+		})
+	}
+	if err != nil {
+		// The scripts are subscribed to again on the next attempt.
+		c.watchMu.Lock()
+		for _, scripthash := range unwatched {
+			delete(c.watched, scripthash)
+			delete(c.statuses, scripthash)
+		}
+		c.watchMu.Unlock()
+		return nil, err
+	}
+	return scripthashes, nil
+}
+
+func (c *Chain[Tx]) subscribe(client *Client, scripthash string) error {
+	status, err := client.SubscribeScripthash(c.ctx, scripthash)
+	if err != nil {
+		return err
+	}
+
+	c.watchMu.Lock()
+	c.statuses[scripthash] = status
+	c.watchMu.Unlock()
+	return nil
+}
+
+// resubscribe subscribes to the watched script hashes on a new connection,
+// the transactions of the scripts whose status changed in the meantime are
+// notified.
+func (c *Chain[Tx]) resubscribe(client *Client) error {
+	c.watchMu.Lock()
+	scripthashes := make([]string, 0, len(c.watched))
+	for scripthash := range c.watched {
+		scripthashes = append(scripthashes, scripthash)
+	}
+	c.watchMu.Unlock()
+
+	return c.forEach(scripthashes, func(scripthash string) error {
+		status, err := client.SubscribeScripthash(c.ctx, scripthash)
+		if err != nil {
+			return err
+		}
+		return c.updateScripthash(scripthash, status)
+	})
+}
+
+// updateScripthash notifies the new transactions of the watched script hash
+// if its status changed.
+func (c *Chain[Tx]) updateScripthash(scripthash, status string) error {
+	c.watchMu.Lock()
+	_, watched := c.watched[scripthash]
+	if !watched || c.statuses[scripthash] == status {
+		c.watchMu.Unlock()
+		return nil
+	}
+	c.statuses[scripthash] = status
+	delete(c.historyCache, scripthash)
+	c.watchMu.Unlock()
+
+	history, err := c.history(scripthash)
+	if err != nil {
+		return err
+	}
+	return c.notifyRelevantTxs(history, 0, false)
+}
+
+// history returns the history of the watched script hash, cached until its
+// status changes.
+func (c *Chain[Tx]) history(scripthash string) ([]*HistoryItem, error) {
+	c.watchMu.Lock()
+	history, cached := c.historyCache[scripthash]
+	status, subscribed := c.statuses[scripthash]
+	c.watchMu.Unlock()
+	if cached {
+		return history, nil
+	}
+	if subscribed && status == "" {
+		// The script has no history.
+		return nil, nil
+	}
+
+	client, err := c.currentClient()
+	if err != nil {
+		return nil, err
+	}
+	history, err = client.ScripthashHistory(c.ctx, scripthash)
+	if err != nil {
+		return nil, err
+	}
+
+	c.watchMu.Lock()
+	if subscribed && c.statuses[scripthash] == status {
+		c.historyCache[scripthash] = history
+	}
+	c.watchMu.Unlock()
+	return history, nil
+}
+
+// histories watches the output scripts and returns their history.
+func (c *Chain[Tx]) histories(pkScripts [][]byte) (map[string][]*HistoryItem, []string, error) {
+	scripthashes, err := c.watch(pkScripts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var mu sync.Mutex
+	histories := make(map[string][]*HistoryItem, len(scripthashes))
+	err = c.forEach(scripthashes, func(scripthash string) error {
+		history, err := c.history(scripthash)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		histories[scripthash] = history
+		mu.Unlock()
+		return nil
+	})
+	return histories, scripthashes, err
+}
+
+// transaction fetches the transaction and, if mined, verifies its inclusion
+// in the block at the height.
+func (c *Chain[Tx]) transaction(txHash Hash, height int32) (*RelevantTx[Tx], error) {
+	client, err := c.currentClient()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := client.Transaction(c.ctx, txHash.String())
+	if err != nil {
+		return nil, err
+	}
+	tx, hash, err := c.decodeTx(raw)
+	if err != nil {
+		return nil, err
+	}
+	if hash != txHash {
+		return nil, fmt.Errorf("server returned transaction %v instead of %v", hash, txHash)
+	}
+
+	rtx := &RelevantTx[Tx]{Tx: tx, Hash: hash, Height: height}
+	if height <= 0 {
+		return rtx, nil
+	}
+
+	header, err := c.Header(height)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := client.TransactionMerkle(c.ctx, txHash.String(), height)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyMerkleProof(txHash, proof, header.MerkleRoot); err != nil {
+		return nil, err
+	}
+
+	rtx.Pos = proof.Pos
+	rtx.Block = &Block{Hash: header.Hash, Height: height, Time: header.Timestamp}
+	return rtx, nil
+}
+
+// historyOrder sorts mined transactions by height, before the mempool
+// transactions with confirmed inputs then those with unconfirmed inputs.
+func historyOrder(height int32) int32 {
+	switch {
+	case height > 0:
+		return height
+	case height == 0:
+		return math.MaxInt32 - 1
+	default:
+		return math.MaxInt32
+	}
+}
+
+// notifyRelevantTxs notifies each transaction of the history mined from the
+// start height or in the mempool, in blockchain order. Transactions already
+// notified at the same height are skipped unless renotify is set.
+func (c *Chain[Tx]) notifyRelevantTxs(history []*HistoryItem, startHeight int32, renotify bool) error {
+	var txs []*RelevantTx[Tx]
+	seen := make(map[Hash]struct{})
+	for _, item := range history {
+		if item.Confirmed() && item.Height < startHeight {
+			continue
+		}
+		txHash, err := NewHashFromStr(item.TxHash)
+		if err != nil {
+			return err
+		}
+		if _, ok := seen[txHash]; ok {
+			continue
+		}
+		seen[txHash] = struct{}{}
+
+		height := item.Height
+		if !item.Confirmed() {
+			height = 0
+		}
+		c.watchMu.Lock()
+		notifiedHeight, notified := c.notifiedTxs[txHash]
+		c.watchMu.Unlock()
+		if notified && notifiedHeight == height && !renotify {
+			continue
+		}
+
+		rtx, err := c.transaction(txHash, height)
+		if err != nil {
+			return err
+		}
+		rtx.Height = item.Height
+		txs = append(txs, rtx)
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		if txs[i].Height != txs[j].Height {
+			return historyOrder(txs[i].Height) < historyOrder(txs[j].Height)
+		}
+		return txs[i].Pos < txs[j].Pos
+	})
+
+	for _, rtx := range txs {
+		c.notifier.RelevantTx(rtx)
+
+		height := int32(0)
+		if rtx.Block != nil {
+			height = rtx.Block.Height
+		}
+		c.watchMu.Lock()
+		c.notifiedTxs[rtx.Hash] = height
+		c.watchMu.Unlock()
+	}
+	return nil
+}
+
+// FilterBlocks returns the index of the first of the blocks holding a
+// transaction paying to or spending from the output scripts, found in the
+// history of the scripts, with the transactions of the block in block order.
+// The index is -1 if none of the blocks holds a transaction of the scripts.
+func (c *Chain[Tx]) FilterBlocks(blocks []*Block, pkScripts [][]byte) (int, []*RelevantTx[Tx], error) {
+	if len(blocks) == 0 {
+		return -1, nil, nil
+	}
+
+	blockIndexes := make(map[int32]int, len(blocks))
+	for i, block := range blocks {
+		blockIndexes[block.Height] = i
+	}
+
+	histories, _, err := c.histories(pkScripts)
+	if err != nil {
+		return -1, nil, err
+	}
+
+	batchIndex := -1
+	for _, history := range histories {
+		for _, item := range history {
+			if i, ok := blockIndexes[item.Height]; ok && (batchIndex < 0 || i < batchIndex) {
+				batchIndex = i
+			}
+		}
+	}
+	if batchIndex < 0 {
+		return -1, nil, nil
+	}
+	block := blocks[batchIndex]
+
+	var txs []*RelevantTx[Tx]
+	seen := make(map[string]struct{})
+	for _, history := range histories {
+		for _, item := range history {
+			if _, ok := seen[item.TxHash]; ok || item.Height != block.Height {
+				continue
+			}
+			seen[item.TxHash] = struct{}{}
+			txHash, err := NewHashFromStr(item.TxHash)
+			if err != nil {
+				return -1, nil, err
+			}
+			rtx, err := c.transaction(txHash, item.Height)
+			if err != nil {
+				return -1, nil, err
+			}
+			if rtx.Block.Hash != block.Hash {
+				return -1, nil, fmt.Errorf("block %d is %v, expected %v", block.Height, rtx.Block.Hash, block.Hash)
+			}
+			txs = append(txs, rtx)
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].Pos < txs[j].Pos
+	})
+	return batchIndex, txs, nil
+}
+
+// Rescan notifies the transactions of the output scripts mined from the start
+// block or in the mempool, and watches the scripts for new transactions. The
+// history of the scripts is queried from the server, no block is scanned.
+func (c *Chain[Tx]) Rescan(startHash Hash, pkScripts [][]byte) error {
+	startHeight, err := c.BlockHeight(startHash)
+	if err != nil {
+		// Heights are only known for the cached headers, the whole history
+		// is notified if the start block is older.
+		c.log.Debugf("Rescanning the whole history, start block %v not cached", startHash)
+		startHeight = 0
+	}
+
+	if err := c.notifyHistory(pkScripts, startHeight, true); err != nil {
+		return err
+	}
+
+	tip, err := c.Tip()
+	if err != nil {
+		return err
+	}
+	c.notifier.RescanFinished(tip)
+	return nil
+}
+
+// NotifyReceived watches the output scripts for new transactions.
+func (c *Chain[Tx]) NotifyReceived(pkScripts [][]byte) error {
+	return c.notifyHistory(pkScripts, 0, false)
+}
+
+// notifyHistory watches the output scripts and notifies their transactions,
+// see notifyRelevantTxs.
+func (c *Chain[Tx]) notifyHistory(pkScripts [][]byte, startHeight int32, renotify bool) error {
+	histories, scripthashes, err := c.histories(pkScripts)
+	if err != nil {
+		return err
+	}
+
+	var history []*HistoryItem
+	for _, scripthash := range scripthashes {
+		history = append(history, histories[scripthash]...)
+	}
+	return c.notifyRelevantTxs(history, startHeight, renotify)
+}
+
+// NotifyBlocks starts sending notifications of the blocks connected and
+// disconnected.
+func (c *Chain[Tx]) NotifyBlocks() {
+	c.watchMu.Lock()
+	c.notifyBlocks = true
+	c.watchMu.Unlock()
+}
+
+// Broadcast relays the serialized transaction through the server and returns
+// its hash.
+func (c *Chain[Tx]) Broadcast(tx []byte) (Hash, error) {
+	client, err := c.currentClient()
+	if err != nil {
+		return Hash{}, err
+	}
+	txid, err := client.Broadcast(c.ctx, tx)
+	if err != nil {
+		return Hash{}, err
+	}
+	return NewHashFromStr(txid)
+}
@@ -0,0 +1,482 @@
+// Package electrum implements a client of the Electrum protocol served by
+// ElectrumX and Fulcrum servers, and a chain syncing wallets with them. Both
+// are shared by the Bitcoin and Litecoin assets: the chain verifies headers
+// with the proof of work function of the asset, and transactions are returned
+// for the asset to decode.
+package electrum
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// clientName is the name the client introduces itself with to servers.
+	clientName = "cryptopower"
+
+	// protocolVersion is the version of the Electrum protocol the client
+	// speaks.
+	protocolVersion = "1.4"
+
+	// dialTimeout bounds the time taken to connect to a server and complete
+	// the TLS handshake.
+	dialTimeout = 15 * time.Second
+
+	// requestTimeout bounds the time a request waits for its response when
+	// the caller's context has no deadline.
+	requestTimeout = 30 * time.Second
+
+	// maxMessageSize is the largest message read from a server, enough for
+	// a full chunk of 2016 headers.
+	maxMessageSize = 4 << 20
+)
+
+// ErrClientClosed is returned by requests made on a closed client or
+// interrupted by the client being closed.
+var ErrClientClosed = errors.New("electrum client closed")
+
+// RPCError is an error returned by the server in response to a request.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("electrum server error %d: %s", e.Code, e.Message)
+}
+
+// HeaderNotification describes the chain tip of the server.
+type HeaderNotification struct {
+	Height int32  `json:"height"`
+	Hex    string `json:"hex"`
+}
+
+// ScripthashStatus is the status of a subscribed script hash. Status changes
+// whenever a transaction paying to or spending from the script is added to
+// the mempool or mined, it is empty if the script has no history.
+type ScripthashStatus struct {
+	Scripthash string
+	Status     string
+}
+
+// HistoryItem is a transaction in the history of a script hash. Height is 0
+// for mempool transactions and -1 for mempool transactions with unconfirmed
+// inputs.
+type HistoryItem struct {
+	Height int32  `json:"height"`
+	TxHash string `json:"tx_hash"`
+	Fee    int64  `json:"fee,omitempty"`
+}
+
+// Confirmed returns true if the transaction is mined.
+func (h *HistoryItem) Confirmed() bool {
+	return h.Height > 0
+}
+
+// MerkleProof is the merkle branch proving the inclusion of a transaction in
+// the block at BlockHeight, Pos is the position of the transaction in the
+// block.
+type MerkleProof struct {
+	BlockHeight int32    `json:"block_height"`
+	Merkle      []string `json:"merkle"`
+	Pos         int      `json:"pos"`
+}
+
+type request struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// message is either a response to a request or a notification from the
+// server, notifications have no ID.
+type message struct {
+	ID     *uint64         `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// Client is a connection to an Electrum server. Requests may be made
+// concurrently, notifications of the subscriptions made are delivered in
+// order on the channels returned by HeaderNotifications and
+// ScripthashNotifications.
+type Client struct {
+	conn   net.Conn
+	server *Server
+
+	nextID  uint64
+	writeMu sync.Mutex
+
+	pendingMu sync.Mutex
+	pending   map[uint64]chan *message
+	closed    bool
+
+	headers      *Queue[*HeaderNotification]
+	scripthashes *Queue[*ScripthashStatus]
+
+	done    chan struct{}
+	doneErr error
+
+	software string
+}
+
+// Dial connects to the server and negotiates the protocol version.
+func Dial(ctx context.Context, server *Server) (*Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+
+	conn, err := server.dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to %s: %w", server, err)
+	}
+
+	c := &Client{
+		conn:         conn,
+		server:       server,
+		pending:      make(map[uint64]chan *message),
+		headers:      NewQueue[*HeaderNotification](),
+		scripthashes: NewQueue[*ScripthashStatus](),
+		done:         make(chan struct{}),
+	}
+	go c.readLoop()
+
+	var version []string
+	if err := c.request(ctx, &version, "server.version", clientName, protocolVersion); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("unable to negotiate the protocol with %s: %w", server, err)
+	}
+	if len(version) > 0 {
+		c.software = version[0]
+	}
+	return c, nil
+}
+
+// DialAny connects to the first reachable server of the list, trying them in
+// order. The errors of all the servers are returned if none is reachable.
+func DialAny(ctx context.Context, servers []*Server) (*Client, error) {
+	if len(servers) == 0 {
+		return nil, errors.New("no electrum server to connect to")
+	}
+
+	var errs []error
+	for _, server := range servers {
+		c, err := Dial(ctx, server)
+		if err == nil {
+			return c, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// Server returns the server the client is connected to.
+func (c *Client) Server() *Server {
+	return c.server
+}
+
+// Software returns the server software and version reported by the server.
+func (c *Client) Software() string {
+	return c.software
+}
+
+// Close closes the connection to the server. Pending requests fail with
+// ErrClientClosed.
+func (c *Client) Close() error {
+	err := c.conn.Close()
+	<-c.done
+	return err
+}
+
+// Done returns a channel closed when the connection to the server is lost or
+// closed.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns the reason the connection was lost, once Done is closed.
+func (c *Client) Err() error {
+	select {
+	case <-c.done:
+		return c.doneErr
+	default:
+		return nil
+	}
+}
+
+// HeaderNotifications returns the channel on which the new chain tips of the
+// server are delivered after SubscribeHeaders is called. The channel is
+// closed when the connection is lost.
+func (c *Client) HeaderNotifications() <-chan *HeaderNotification {
+	return c.headers.Out()
+}
+
+// ScripthashNotifications returns the channel on which the status changes of
+// the script hashes subscribed with SubscribeScripthash are delivered. The
+// channel is closed when the connection is lost.
+func (c *Client) ScripthashNotifications() <-chan *ScripthashStatus {
+	return c.scripthashes.Out()
+}
+
+func (c *Client) readLoop() {
+	reader := bufio.NewReaderSize(c.conn, 64<<10)
+	var err error
+	for {
+		var line []byte
+		line, err = readLine(reader)
+		if err != nil {
+			break
+		}
+
+		msg := new(message)
+		if err = json.Unmarshal(line, msg); err != nil {
+			err = fmt.Errorf("invalid message from server: %w", err)
+			break
+		}
+		if msg.ID == nil {
+			c.handleNotification(msg)
+			continue
+		}
+
+		c.pendingMu.Lock()
+		respChan, ok := c.pending[*msg.ID]
+		delete(c.pending, *msg.ID)
+		c.pendingMu.Unlock()
+		if ok {
+			respChan <- msg
+		}
+	}
+
+	c.conn.Close()
+	c.doneErr = err
+
+	c.pendingMu.Lock()
+	c.closed = true
+	for id, respChan := range c.pending {
+		close(respChan)
+		delete(c.pending, id)
+	}
+	c.pendingMu.Unlock()
+
+	c.headers.Close()
+	c.scripthashes.Close()
+	close(c.done)
+}
+
+func readLine(reader *bufio.Reader) ([]byte, error) {
+	var line []byte
+	for {
+		chunk, isPrefix, err := reader.ReadLine()
+		if err != nil {
+			return nil, err
+		}
+		line = append(line, chunk...)
+		if len(line) > maxMessageSize {
+			return nil, errors.New("message from server too large")
+		}
+		if !isPrefix {
+			return line, nil
+		}
+	}
+}
+
+func (c *Client) handleNotification(msg *message) {
+	switch msg.Method {
+	case "blockchain.headers.subscribe":
+		var params []*HeaderNotification
+		if err := json.Unmarshal(msg.Params, &params); err != nil || len(params) == 0 {
+			return
+		}
+		c.headers.Push(params[0])
+
+	case "blockchain.scripthash.subscribe":
+		var params []*string
+		if err := json.Unmarshal(msg.Params, &params); err != nil || len(params) != 2 || params[0] == nil {
+			return
+		}
+		status := &ScripthashStatus{Scripthash: *params[0]}
+		if params[1] != nil {
+			status.Status = *params[1]
+		}
+		c.scripthashes.Push(status)
+	}
+}
+
+// request sends the request and decodes its result into result.
+func (c *Client) request(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+	}
+
+	if params == nil {
+		params = []interface{}{}
+	}
+	id := atomic.AddUint64(&c.nextID, 1)
+	b, err := json.Marshal(&request{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	respChan := make(chan *message, 1)
+	c.pendingMu.Lock()
+	if c.closed {
+		c.pendingMu.Unlock()
+		return ErrClientClosed
+	}
+	c.pending[id] = respChan
+	c.pendingMu.Unlock()
+
+	c.writeMu.Lock()
+	deadline, _ := ctx.Deadline()
+	_ = c.conn.SetWriteDeadline(deadline)
+	_, err = c.conn.Write(append(b, '\n'))
+	c.writeMu.Unlock()
+	if err != nil {
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+		return err
+	}
+
+	select {
+	case resp, ok := <-respChan:
+		if !ok {
+			return ErrClientClosed
+		}
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result == nil {
+			return nil
+		}
+		return json.Unmarshal(resp.Result, result)
+
+	case <-ctx.Done():
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Ping keeps the connection alive, servers drop idle sessions.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.request(ctx, nil, "server.ping")
+}
+
+// SubscribeHeaders subscribes to the chain tip of the server and returns the
+// current tip.
+func (c *Client) SubscribeHeaders(ctx context.Context) (*HeaderNotification, error) {
+	tip := new(HeaderNotification)
+	if err := c.request(ctx, tip, "blockchain.headers.subscribe"); err != nil {
+		return nil, err
+	}
+	return tip, nil
+}
+
+// BlockHeader returns the serialized header of the block at the height.
+func (c *Client) BlockHeader(ctx context.Context, height int32) ([]byte, error) {
+	var headerHex string
+	if err := c.request(ctx, &headerHex, "blockchain.block.header", height); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(headerHex)
+}
+
+// BlockHeaders returns the serialized headers of up to count blocks from the
+// start height, concatenated. Servers return at most 2016 headers per request
+// and fewer if the chain tip is reached.
+func (c *Client) BlockHeaders(ctx context.Context, start int32, count int) ([]byte, int, error) {
+	var resp struct {
+		Count int    `json:"count"`
+		Hex   string `json:"hex"`
+		Max   int    `json:"max"`
+	}
+	if err := c.request(ctx, &resp, "blockchain.block.headers", start, count); err != nil {
+		return nil, 0, err
+	}
+	headers, err := hex.DecodeString(resp.Hex)
+	if err != nil {
+		return nil, 0, err
+	}
+	return headers, resp.Count, nil
+}
+
+// SubscribeScripthash subscribes to the status changes of the script hash and
+// returns its current status, empty if the script has no history.
+func (c *Client) SubscribeScripthash(ctx context.Context, scripthash string) (string, error) {
+	var status *string
+	if err := c.request(ctx, &status, "blockchain.scripthash.subscribe", scripthash); err != nil {
+		return "", err
+	}
+	if status == nil {
+		return "", nil
+	}
+	return *status, nil
+}
+
+// ScripthashHistory returns the mined and mempool transactions paying to or
+// spending from the script hash, mined transactions first in blockchain
+// order.
+func (c *Client) ScripthashHistory(ctx context.Context, scripthash string) ([]*HistoryItem, error) {
+	var history []*HistoryItem
+	if err := c.request(ctx, &history, "blockchain.scripthash.get_history", scripthash); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// Transaction returns the serialized transaction with the hash.
+func (c *Client) Transaction(ctx context.Context, txHash string) ([]byte, error) {
+	var txHex string
+	if err := c.request(ctx, &txHex, "blockchain.transaction.get", txHash); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(txHex)
+}
+
+// TransactionMerkle returns the proof of inclusion of the transaction in the
+// block at the height.
+func (c *Client) TransactionMerkle(ctx context.Context, txHash string, height int32) (*MerkleProof, error) {
+	proof := new(MerkleProof)
+	if err := c.request(ctx, proof, "blockchain.transaction.get_merkle", txHash, height); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// Broadcast relays the serialized transaction to the network and returns its
+// hash.
+func (c *Client) Broadcast(ctx context.Context, tx []byte) (string, error) {
+	var txHash string
+	if err := c.request(ctx, &txHash, "blockchain.transaction.broadcast", hex.EncodeToString(tx)); err != nil {
+		return "", err
+	}
+	return txHash, nil
+}
+
+// ScriptHash returns the script hash identifying the output script in the
+// Electrum protocol: the reversed sha256 hash of the script, hex encoded.
+func ScriptHash(pkScript []byte) string {
+	hash := sha256.Sum256(pkScript)
+	for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+		hash[i], hash[j] = hash[j], hash[i]
+	}
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,261 @@
+package electrum
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/internal/electrum/electrumtest"
+)
+
+func dialTestServer(t *testing.T, srv *electrumtest.Server, fingerprint string) *Client {
+	t.Helper()
+	scheme := "tcp://"
+	if fingerprint != "" {
+		scheme = "ssl://"
+	}
+	server, err := ParseServer(scheme+srv.Addr, fingerprint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := Dial(context.Background(), server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestParseServer(t *testing.T) {
+	fingerprint := strings.Repeat("ab", 32)
+	tests := []struct {
+		name        string
+		server      string
+		fingerprint string
+		want        string
+		wantErr     bool
+	}{
+		{name: "default tls", server: "electrum.example.com:50002", want: "ssl://electrum.example.com:50002"},
+		{name: "ssl scheme", server: "ssl://electrum.example.com:50002", want: "ssl://electrum.example.com:50002"},
+		{name: "tcp scheme", server: "tcp://electrum.example.com:50001", want: "tcp://electrum.example.com:50001"},
+		{name: "electrum tls notation", server: "electrum.example.com:50002:s", want: "ssl://electrum.example.com:50002"},
+		{name: "electrum tcp notation", server: "electrum.example.com:50001:t", want: "tcp://electrum.example.com:50001"},
+		{name: "pinned", server: "electrum.example.com:50002", fingerprint: fingerprint, want: "ssl://electrum.example.com:50002"},
+		{name: "colon separated pin", server: "electrum.example.com:50002", fingerprint: strings.Repeat("ab:", 31) + "ab", want: "ssl://electrum.example.com:50002"},
+		{name: "pinned tcp", server: "tcp://electrum.example.com:50001", fingerprint: fingerprint, wantErr: true},
+		{name: "short pin", server: "electrum.example.com:50002", fingerprint: "abcd", wantErr: true},
+		{name: "unknown scheme", server: "http://electrum.example.com:50002", wantErr: true},
+		{name: "unknown notation", server: "electrum.example.com:50002:x", wantErr: true},
+		{name: "missing port", server: "electrum.example.com", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, err := ParseServer(test.server, test.fingerprint)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got server %v", server)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if server.String() != test.want {
+				t.Fatalf("expected %s, got %s", test.want, server)
+			}
+			if (test.fingerprint != "") != (len(server.CertFingerprint) > 0) {
+				t.Fatalf("unexpected certificate fingerprint %x", server.CertFingerprint)
+			}
+		})
+	}
+}
+
+func TestRequests(t *testing.T) {
+	srv := electrumtest.NewServer(t)
+	srv.Handle("blockchain.block.headers", func(params []json.RawMessage) (interface{}, error) {
+		var start, count int
+		if err := json.Unmarshal(params[0], &start); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(params[1], &count); err != nil {
+			return nil, err
+		}
+		if start != 10 || count != 2 {
+			return nil, errors.New("unexpected params")
+		}
+		return map[string]interface{}{"count": 2, "hex": "0102", "max": 2016}, nil
+	})
+	srv.Handle("blockchain.scripthash.get_history", func([]json.RawMessage) (interface{}, error) {
+		return []map[string]interface{}{
+			{"height": 100, "tx_hash": "aa"},
+			{"height": 0, "tx_hash": "bb", "fee": 200},
+		}, nil
+	})
+	srv.Handle("blockchain.transaction.broadcast", func([]json.RawMessage) (interface{}, error) {
+		return nil, &electrumtest.Error{Code: 1, Message: "txn-already-known"}
+	})
+
+	client := dialTestServer(t, srv, "")
+	ctx := context.Background()
+	if client.Software() != electrumtest.Software {
+		t.Fatalf("unexpected server software %q", client.Software())
+	}
+
+	headers, count, err := client.BlockHeaders(ctx, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 || len(headers) != 2 || headers[0] != 1 || headers[1] != 2 {
+		t.Fatalf("unexpected headers %x (count %d)", headers, count)
+	}
+
+	history, err := client.ScripthashHistory(ctx, "00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || !history[0].Confirmed() || history[1].Confirmed() || history[1].Fee != 200 {
+		t.Fatalf("unexpected history %+v %+v", history[0], history[1])
+	}
+
+	_, err = client.Broadcast(ctx, []byte{1})
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Message != "txn-already-known" {
+		t.Fatalf("expected the server error, got %v", err)
+	}
+
+	if err := client.Ping(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Transaction(ctx, "aa"); err == nil {
+		t.Fatal("expected an error for a method unknown to the server")
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	srv := electrumtest.NewServer(t)
+	srv.Handle("blockchain.headers.subscribe", func([]json.RawMessage) (interface{}, error) {
+		return &HeaderNotification{Height: 1, Hex: "01"}, nil
+	})
+	srv.Handle("blockchain.scripthash.subscribe", func([]json.RawMessage) (interface{}, error) {
+		return nil, nil
+	})
+
+	client := dialTestServer(t, srv, "")
+	ctx := context.Background()
+	tip, err := client.SubscribeHeaders(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tip.Height != 1 {
+		t.Fatalf("unexpected tip height %d", tip.Height)
+	}
+	status, err := client.SubscribeScripthash(ctx, "aa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != "" {
+		t.Fatalf("expected no history, got status %q", status)
+	}
+
+	srv.Notify("blockchain.headers.subscribe", &HeaderNotification{Height: 2, Hex: "02"})
+	srv.Notify("blockchain.scripthash.subscribe", "aa", "status")
+
+	select {
+	case tip := <-client.HeaderNotifications():
+		if tip.Height != 2 || tip.Hex != "02" {
+			t.Fatalf("unexpected tip %+v", tip)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("header notification not received")
+	}
+	select {
+	case status := <-client.ScripthashNotifications():
+		if status.Scripthash != "aa" || status.Status != "status" {
+			t.Fatalf("unexpected status %+v", status)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("scripthash notification not received")
+	}
+
+	srv.CloseClientConnections()
+	select {
+	case <-client.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("lost connection not detected")
+	}
+	if _, ok := <-client.HeaderNotifications(); ok {
+		t.Fatal("header notifications not closed with the connection")
+	}
+	if err := client.Ping(ctx); !errors.Is(err, ErrClientClosed) {
+		t.Fatalf("expected ErrClientClosed, got %v", err)
+	}
+}
+
+func TestCertificatePinning(t *testing.T) {
+	srv := electrumtest.NewTLSServer(t)
+	dialTestServer(t, srv, srv.CertFingerprint())
+
+	ctx := context.Background()
+	wrongPin := strings.Repeat("00", 32)
+	server, err := ParseServer("ssl://"+srv.Addr, wrongPin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client, err := Dial(ctx, server); err == nil {
+		client.Close()
+		t.Fatal("connected with a mismatched certificate pin")
+	}
+
+	// Self-signed certificates are rejected unless pinned.
+	server, err = ParseServer("ssl://"+srv.Addr, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client, err := Dial(ctx, server); err == nil {
+		client.Close()
+		t.Fatal("connected to a server with an unverified certificate")
+	}
+}
+
+func TestDialAny(t *testing.T) {
+	down := electrumtest.NewServer(t)
+	downServer, err := ParseServer("tcp://"+down.Addr, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	down.Close()
+
+	up := electrumtest.NewServer(t)
+	upServer, err := ParseServer("tcp://"+up.Addr, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := DialAny(context.Background(), []*Server{downServer, upServer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if client.Server() != upServer {
+		t.Fatalf("connected to %v instead of %v", client.Server(), upServer)
+	}
+
+	if _, err := DialAny(context.Background(), []*Server{downServer}); err == nil {
+		t.Fatal("expected an error when no server is reachable")
+	}
+}
+
+func TestScriptHash(t *testing.T) {
+	// The script hash of the P2PKH script of the genesis block coinbase
+	// address 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa, per the protocol docs.
+	pkScript := []byte{0x76, 0xa9, 0x14, 0x62, 0xe9, 0x07, 0xb1, 0x5c, 0xbf, 0x27, 0xd5, 0x42, 0x53, 0x99, 0xeb, 0xf6,
+		0xf0, 0xfb, 0x50, 0xeb, 0xb8, 0x8f, 0x18, 0x88, 0xac}
+	want := "8b01df4e368ea28f8dc0423bcf7a4923e3a12d307c875e47a0cfbf90b5c39161"
+	if got := ScriptHash(pkScript); got != want {
+		t.Fatalf("expected script hash %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,268 @@
+// Package electrumtest provides a fake Electrum server for tests. The server
+// listens on the loopback interface and answers each request with the handler
+// registered for its method.
+package electrumtest
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Software is the server software reported in response to server.version.
+const Software = "ElectrumTest 1.0"
+
+// Handler returns the result of a request from its params. An error is sent
+// to the client as an RPC error, with the code of an *Error or 1 otherwise.
+type Handler func(params []json.RawMessage) (interface{}, error)
+
+// Error is an RPC error returned by a handler.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+type request struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type conn struct {
+	net.Conn
+	writeMu sync.Mutex
+}
+
+func (c *conn) send(msg interface{}) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err = c.Write(append(b, '\n'))
+	return err
+}
+
+// Server is a fake Electrum server.
+type Server struct {
+	// Addr is the host:port the server listens on.
+	Addr string
+
+	listener net.Listener
+	cert     []byte
+	wg       sync.WaitGroup
+
+	mu       sync.Mutex
+	handlers map[string]Handler
+	conns    map[*conn]struct{}
+	closed   bool
+}
+
+// NewServer starts a server reached over plain TCP, closed when the test
+// ends.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("electrumtest: unable to listen: %v", err)
+	}
+	return start(t, listener, nil)
+}
+
+// NewTLSServer starts a server reached over TLS with a self-signed
+// certificate, closed when the test ends.
+func NewTLSServer(t testing.TB) *Server {
+	t.Helper()
+	cert, err := selfSignedCert()
+	if err != nil {
+		t.Fatalf("electrumtest: unable to create a certificate: %v", err)
+	}
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	})
+	if err != nil {
+		t.Fatalf("electrumtest: unable to listen: %v", err)
+	}
+	return start(t, listener, cert.Certificate[0])
+}
+
+func start(t testing.TB, listener net.Listener, cert []byte) *Server {
+	s := &Server{
+		Addr:     listener.Addr().String(),
+		listener: listener,
+		cert:     cert,
+		handlers: make(map[string]Handler),
+		conns:    make(map[*conn]struct{}),
+	}
+	s.Handle("server.version", func([]json.RawMessage) (interface{}, error) {
+		return []string{Software, "1.4"}, nil
+	})
+	s.Handle("server.ping", func([]json.RawMessage) (interface{}, error) {
+		return nil, nil
+	})
+
+	s.wg.Add(1)
+	go s.accept()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// CertFingerprint returns the hex encoded sha256 fingerprint of the
+// certificate of a TLS server.
+func (s *Server) CertFingerprint() string {
+	hash := sha256.Sum256(s.cert)
+	return hex.EncodeToString(hash[:])
+}
+
+// Handle sets the handler of the method, replacing the previous one.
+func (s *Server) Handle(method string, handler Handler) {
+	s.mu.Lock()
+	s.handlers[method] = handler
+	s.mu.Unlock()
+}
+
+// Notify sends the notification to all the connected clients.
+func (s *Server) Notify(method string, params ...interface{}) {
+	s.mu.Lock()
+	conns := make([]*conn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.mu.Unlock()
+
+	for _, c := range conns {
+		_ = c.send(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  method,
+			"params":  params,
+		})
+	}
+}
+
+// CloseClientConnections drops the connections of the clients, the server
+// keeps accepting new ones.
+func (s *Server) CloseClientConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		c.Close()
+	}
+}
+
+// Close stops the server and drops the connections of the clients.
+func (s *Server) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	s.listener.Close()
+	for c := range s.conns {
+		c.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *Server) accept() {
+	defer s.wg.Done()
+	for {
+		netConn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		c := &conn{Conn: netConn}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			netConn.Close()
+			return
+		}
+		s.conns[c] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go s.serve(c)
+	}
+}
+
+func (s *Server) serve(c *conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		c.Close()
+	}()
+
+	scanner := bufio.NewScanner(c)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		req := new(request)
+		if err := json.Unmarshal(scanner.Bytes(), req); err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		handler, ok := s.handlers[req.Method]
+		s.mu.Unlock()
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if !ok {
+			resp["error"] = &Error{Code: -32601, Message: fmt.Sprintf("unknown method %q", req.Method)}
+		} else if result, err := handler(req.Params); err != nil {
+			rpcErr, ok := err.(*Error)
+			if !ok {
+				rpcErr = &Error{Code: 1, Message: err.Error()}
+			}
+			resp["error"] = rpcErr
+		} else {
+			resp["result"] = result
+		}
+		if err := c.send(resp); err != nil {
+			return
+		}
+	}
+}
+
+func selfSignedCert() (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "electrumtest"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
@@ -0,0 +1,208 @@
+package electrum
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// HeaderSize is the size of a serialized block header.
+const HeaderSize = 80
+
+// Hash is a double sha256 hash, of a block header or a transaction.
+type Hash [sha256.Size]byte
+
+// DoubleHash returns the double sha256 hash of the data.
+func DoubleHash(b []byte) Hash {
+	first := sha256.Sum256(b)
+	return sha256.Sum256(first[:])
+}
+
+// NewHashFromStr decodes a hash displayed in byte-reversed hex, as block and
+// transaction hashes are.
+func NewHashFromStr(s string) (Hash, error) {
+	var hash Hash
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(hash) {
+		return hash, fmt.Errorf("invalid hash %q", s)
+	}
+	for i := range b {
+		hash[len(hash)-1-i] = b[i]
+	}
+	return hash, nil
+}
+
+// String returns the hash in byte-reversed hex.
+func (h Hash) String() string {
+	for i, j := 0, len(h)-1; i < j; i, j = i+1, j-1 {
+		h[i], h[j] = h[j], h[i]
+	}
+	return hex.EncodeToString(h[:])
+}
+
+// Header is a decoded block header.
+type Header struct {
+	Hash       Hash
+	PrevBlock  Hash
+	MerkleRoot Hash
+	Timestamp  time.Time
+	Bits       uint32
+	// Raw is the serialized header, for the asset to decode.
+	Raw []byte
+}
+
+// DecodeHeaders decodes count concatenated serialized headers.
+func DecodeHeaders(raw []byte, count int) ([]*Header, error) {
+	if len(raw) != count*HeaderSize {
+		return nil, fmt.Errorf("expected %d headers, got %d bytes", count, len(raw))
+	}
+
+	headers := make([]*Header, count)
+	for i := range headers {
+		b := raw[i*HeaderSize : (i+1)*HeaderSize : (i+1)*HeaderSize]
+		header := &Header{
+			Hash:      DoubleHash(b),
+			Timestamp: time.Unix(int64(binary.LittleEndian.Uint32(b[68:72])), 0),
+			Bits:      binary.LittleEndian.Uint32(b[72:76]),
+			Raw:       b,
+		}
+		copy(header.PrevBlock[:], b[4:36])
+		copy(header.MerkleRoot[:], b[36:68])
+		headers[i] = header
+	}
+	return headers, nil
+}
+
+// Checkpoint is a block the chain must go through.
+type Checkpoint struct {
+	Height int32
+	Hash   Hash
+}
+
+// ChainParams are the consensus rules the headers served are verified
+// against.
+type ChainParams struct {
+	Checkpoints []Checkpoint
+	// PowLimit is the highest target allowed.
+	PowLimit *big.Int
+	// RetargetInterval is the number of blocks between difficulty changes.
+	RetargetInterval int32
+	// RetargetAdjustmentFactor bounds the change of difficulty at each
+	// retarget.
+	RetargetAdjustmentFactor int64
+	// ReduceMinDifficulty is true on networks allowing minimum difficulty
+	// blocks, whose difficulty isn't checked.
+	ReduceMinDifficulty bool
+	// PowHash returns the hash of the serialized header that must meet its
+	// target, the block hash for Bitcoin and the scrypt hash for Litecoin.
+	PowHash func(header []byte) Hash
+}
+
+// VerifyHeaders checks that the headers starting at the height extend the
+// previous header, if known, meet their proof of work and match the
+// checkpoints of the network. On networks without minimum difficulty blocks
+// the difficulty may only change at retarget heights, within the adjustment
+// factor.
+func (params *ChainParams) VerifyHeaders(height int32, prev *Header, headers []*Header) error {
+	for i, header := range headers {
+		height := height + int32(i)
+		hash := header.Hash
+
+		if prev != nil && header.PrevBlock != prev.Hash {
+			return fmt.Errorf("header %d (%v) doesn't connect to the previous header", height, hash)
+		}
+
+		for _, checkpoint := range params.Checkpoints {
+			if checkpoint.Height == height && checkpoint.Hash != hash {
+				return fmt.Errorf("header %d (%v) doesn't match checkpoint %v", height, hash, checkpoint.Hash)
+			}
+		}
+
+		target := compactToBig(header.Bits)
+		if target.Sign() <= 0 || target.Cmp(params.PowLimit) > 0 {
+			return fmt.Errorf("header %d (%v) has an invalid target", height, hash)
+		}
+		if hashToBig(params.PowHash(header.Raw)).Cmp(target) > 0 {
+			return fmt.Errorf("header %d (%v) doesn't meet its proof of work", height, hash)
+		}
+
+		if prev != nil && !params.ReduceMinDifficulty {
+			if height%params.RetargetInterval != 0 {
+				if header.Bits != prev.Bits {
+					return fmt.Errorf("header %d (%v) changes the difficulty before the retarget", height, hash)
+				}
+			} else {
+				prevTarget := compactToBig(prev.Bits)
+				factor := big.NewInt(params.RetargetAdjustmentFactor)
+				maxTarget := new(big.Int).Mul(prevTarget, factor)
+				minTarget := new(big.Int).Div(prevTarget, factor)
+				if target.Cmp(maxTarget) > 0 || target.Cmp(minTarget) < 0 {
+					return fmt.Errorf("header %d (%v) changes the difficulty beyond the adjustment factor", height, hash)
+				}
+			}
+		}
+
+		prev = header
+	}
+	return nil
+}
+
+// compactToBig decodes the target of a header from its compact
+// representation: a sign bit, an 8 bit exponent and a 23 bit mantissa.
+func compactToBig(compact uint32) *big.Int {
+	mantissa := compact & 0x007fffff
+	isNegative := compact&0x00800000 != 0
+	exponent := uint(compact >> 24)
+
+	var n *big.Int
+	if exponent <= 3 {
+		mantissa >>= 8 * (3 - exponent)
+		n = big.NewInt(int64(mantissa))
+	} else {
+		n = big.NewInt(int64(mantissa))
+		n.Lsh(n, 8*(exponent-3))
+	}
+	if isNegative {
+		n.Neg(n)
+	}
+	return n
+}
+
+// hashToBig interprets the little endian hash as a number, to compare it with
+// a target.
+func hashToBig(hash Hash) *big.Int {
+	for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+		hash[i], hash[j] = hash[j], hash[i]
+	}
+	return new(big.Int).SetBytes(hash[:])
+}
+
+// verifyMerkleProof checks that the merkle branch proves the inclusion of the
+// transaction in the block with the merkle root.
+func verifyMerkleProof(txHash Hash, proof *MerkleProof, merkleRoot Hash) error {
+	hash := txHash
+	pos := proof.Pos
+	var pair [2 * sha256.Size]byte
+	for _, branch := range proof.Merkle {
+		sibling, err := NewHashFromStr(branch)
+		if err != nil {
+			return err
+		}
+		if pos&1 == 0 {
+			copy(pair[:sha256.Size], hash[:])
+			copy(pair[sha256.Size:], sibling[:])
+		} else {
+			copy(pair[:sha256.Size], sibling[:])
+			copy(pair[sha256.Size:], hash[:])
+		}
+		hash = DoubleHash(pair[:])
+		pos >>= 1
+	}
+	if hash != merkleRoot {
+		return fmt.Errorf("transaction %v is not in block %d", txHash, proof.BlockHeight)
+	}
+	return nil
+}
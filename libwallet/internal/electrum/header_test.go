@@ -0,0 +1,76 @@
+package electrum
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestHashString(t *testing.T) {
+	// The hash of the Bitcoin genesis block.
+	const genesis = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+	hash, err := NewHashFromStr(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash[0] != 0x6f || hash[31] != 0 {
+		t.Fatalf("the hash is not byte-reversed: %x", hash)
+	}
+	if hash.String() != genesis {
+		t.Fatalf("got hash %s, want %s", hash, genesis)
+	}
+
+	for _, invalid := range []string{"", "00", genesis + "00", "zz" + genesis[2:]} {
+		if _, err := NewHashFromStr(invalid); err == nil {
+			t.Errorf("%q: expected an error", invalid)
+		}
+	}
+}
+
+func TestCompactToBig(t *testing.T) {
+	tests := []struct {
+		compact uint32
+		target  string
+	}{
+		{0x1d00ffff, "ffff0000000000000000000000000000000000000000000000000000"},
+		{0x207fffff, "7fffff0000000000000000000000000000000000000000000000000000000000"},
+		{0x1e0ffff0, "ffff0000000000000000000000000000000000000000000000000000000"},
+		{0x03123456, "123456"},
+		{0x02123456, "1234"},
+		{0x04923456, "-12345600"},
+	}
+	for _, tc := range tests {
+		want, _ := new(big.Int).SetString(tc.target, 16)
+		if got := compactToBig(tc.compact); got.Cmp(want) != 0 {
+			t.Errorf("%#x: got target %x, want %s", tc.compact, got, tc.target)
+		}
+	}
+}
+
+func TestVerifyMerkleProof(t *testing.T) {
+	merkleBranches := func(left, right Hash) Hash {
+		return DoubleHash(append(left[:], right[:]...))
+	}
+	txs := []Hash{{1}, {2}, {3}}
+	// The odd transaction is paired with itself.
+	left := merkleBranches(txs[0], txs[1])
+	right := merkleBranches(txs[2], txs[2])
+	root := merkleBranches(left, right)
+
+	proof := &MerkleProof{Merkle: []string{txs[1].String(), right.String()}, Pos: 0}
+	if err := verifyMerkleProof(txs[0], proof, root); err != nil {
+		t.Fatal(err)
+	}
+	proof = &MerkleProof{Merkle: []string{left.String()}, Pos: 1}
+	if err := verifyMerkleProof(right, proof, root); err != nil {
+		t.Fatal(err)
+	}
+
+	proof = &MerkleProof{Merkle: []string{txs[1].String(), right.String()}, Pos: 1}
+	if err := verifyMerkleProof(txs[0], proof, root); err == nil {
+		t.Fatal("expected an error for a proof at the wrong position")
+	}
+	proof.Pos = 0
+	if err := verifyMerkleProof(txs[1], proof, root); err == nil {
+		t.Fatalf("expected an error for transaction %v not in the block", txs[1])
+	}
+}
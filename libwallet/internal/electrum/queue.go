@@ -0,0 +1,78 @@
+package electrum
+
+import "sync"
+
+// Queue is an unbounded FIFO queue delivering the pushed items on the Out
+// channel. Pushing never blocks: the reader of the connection can't wait on
+// the consumers of the notifications since they make requests themselves,
+// and neither can chain clients wait on the wallet consuming theirs.
+type Queue[T any] struct {
+	out  chan T
+	quit chan struct{}
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	items  []T
+	closed bool
+}
+
+// NewQueue returns a running queue.
+func NewQueue[T any]() *Queue[T] {
+	q := &Queue[T]{
+		out:  make(chan T),
+		quit: make(chan struct{}),
+	}
+	q.cond = sync.NewCond(&q.mu)
+	go q.run()
+	return q
+}
+
+// Push adds the item to the queue, it is dropped if the queue is closed.
+func (q *Queue[T]) Push(item T) {
+	q.mu.Lock()
+	if !q.closed {
+		q.items = append(q.items, item)
+		q.cond.Signal()
+	}
+	q.mu.Unlock()
+}
+
+// Close drops the undelivered items and closes the Out channel.
+func (q *Queue[T]) Close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		q.items = nil
+		close(q.quit)
+		q.cond.Signal()
+	}
+	q.mu.Unlock()
+}
+
+// Out returns the channel the items are delivered on.
+func (q *Queue[T]) Out() <-chan T {
+	return q.out
+}
+
+func (q *Queue[T]) run() {
+	defer close(q.out)
+	for {
+		q.mu.Lock()
+		for len(q.items) == 0 && !q.closed {
+			q.cond.Wait()
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return
+		}
+		item := q.items[0]
+		q.items = q.items[1:]
+		q.mu.Unlock()
+
+		select {
+		case q.out <- item:
+		case <-q.quit:
+			return
+		}
+	}
+}
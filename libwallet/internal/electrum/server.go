@@ -0,0 +1,139 @@
+package electrum
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+const (
+	tlsScheme = "ssl"
+	tcpScheme = "tcp"
+)
+
+// Server is the address of an Electrum server and how to connect to it.
+type Server struct {
+	// Address is the host and port of the server.
+	Address string
+	// TLS is true if the server is reached over TLS, plain TCP otherwise.
+	TLS bool
+	// CertFingerprint is the sha256 fingerprint of the TLS certificate of the
+	// server. When set the certificate is pinned: only a certificate matching
+	// the fingerprint is accepted, self-signed or not. Otherwise the
+	// certificate is verified against the system roots.
+	CertFingerprint []byte
+}
+
+// ParseServer parses a server address given as "ssl://host:port" or
+// "tcp://host:port", the Electrum "host:port:s" and "host:port:t" notation
+// is also accepted. Servers are reached over TLS if no scheme is given. The
+// fingerprint of the certificate to pin is optional.
+func ParseServer(server, fingerprint string) (*Server, error) {
+	server = strings.TrimSpace(server)
+	useTLS := true
+	if scheme, addr, ok := strings.Cut(server, "://"); ok {
+		switch strings.ToLower(scheme) {
+		case tlsScheme:
+		case tcpScheme:
+			useTLS = false
+		default:
+			return nil, fmt.Errorf("unsupported electrum server scheme %q", scheme)
+		}
+		server = addr
+	} else if i := strings.LastIndex(server, ":"); i > 0 && strings.Count(server, ":") == 2 {
+		switch server[i+1:] {
+		case "s":
+		case "t":
+			useTLS = false
+		default:
+			return nil, fmt.Errorf("invalid electrum server address %q", server)
+		}
+		server = server[:i]
+	}
+
+	host, port, err := net.SplitHostPort(server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid electrum server address %q: %w", server, err)
+	}
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("invalid electrum server address %q", server)
+	}
+
+	s := &Server{Address: server, TLS: useTLS}
+	if fingerprint != "" {
+		if !useTLS {
+			return nil, errors.New("certificate pinning requires a TLS server")
+		}
+		if s.CertFingerprint, err = ParseFingerprint(fingerprint); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// ParseFingerprint decodes a hex encoded sha256 certificate fingerprint, the
+// bytes may be separated by colons as printed by openssl.
+func ParseFingerprint(fingerprint string) ([]byte, error) {
+	fingerprint = strings.ReplaceAll(strings.TrimSpace(fingerprint), ":", "")
+	b, err := hex.DecodeString(fingerprint)
+	if err != nil || len(b) != sha256.Size {
+		return nil, fmt.Errorf("invalid sha256 certificate fingerprint %q", fingerprint)
+	}
+	return b, nil
+}
+
+// Fingerprint returns the sha256 fingerprint of the DER encoded certificate,
+// hex encoded.
+func Fingerprint(cert []byte) string {
+	hash := sha256.Sum256(cert)
+	return hex.EncodeToString(hash[:])
+}
+
+func (s *Server) String() string {
+	if s.TLS {
+		return tlsScheme + "://" + s.Address
+	}
+	return tcpScheme + "://" + s.Address
+}
+
+func (s *Server) dial(ctx context.Context) (net.Conn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.Address)
+	if err != nil || !s.TLS {
+		return conn, err
+	}
+
+	host, _, _ := net.SplitHostPort(s.Address)
+	tlsConfig := &tls.Config{
+		ServerName: host,
+		MinVersion: tls.VersionTLS12,
+	}
+	if len(s.CertFingerprint) > 0 {
+		// The pinned certificate replaces the verification of the chain, most
+		// Electrum servers use self-signed certificates.
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return errors.New("no certificate presented by the server")
+			}
+			hash := sha256.Sum256(state.PeerCertificates[0].Raw)
+			if !bytes.Equal(hash[:], s.CertFingerprint) {
+				return fmt.Errorf("certificate fingerprint %x doesn't match the pinned fingerprint", hash)
+			}
+			return nil
+		}
+	}
+
+	tlsConn := tls.Client(conn, tlsConfig)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
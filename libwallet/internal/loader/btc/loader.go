@@ -12,6 +12,7 @@ import (
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/btcsuite/btcwallet/wallet"
+	"github.com/btcsuite/btcwallet/walletdb"
 	_ "github.com/btcsuite/btcwallet/walletdb/bdb" // bdb init() registers a driver
 
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
@@ -20,6 +21,10 @@ import (
 
 var log = loader.Log
 
+// waddrmgrNamespaceKey is the bucket of the address manager in the wallet
+// database.
+var waddrmgrNamespaceKey = []byte("waddrmgr")
+
 // btcLoader implements the creating of new and opening of existing btc wallets.
 // This is primarily intended for use by the RPC servers, to enable
 // methods and services which require the wallet when the wallet is loaded by
@@ -135,6 +140,20 @@ func (l *btcLoader) CreateNewWallet(_ context.Context, params *loader.CreateWall
 	return &loader.LoadedWallets{BTC: wal}, nil
 }
 
+// createKeyScope creates the key scope of the loader in a watch-only wallet,
+// btcwallet creates watch-only wallets without any key scope.
+func (l *btcLoader) createKeyScope(wal *wallet.Wallet) error {
+	if _, err := wal.Manager.FetchScopedKeyManager(l.keyscope); err == nil {
+		return nil
+	}
+
+	return walletdb.Update(wal.Database(), func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		_, err := wal.Manager.NewScopedKeyManager(ns, l.keyscope, waddrmgr.ScopeAddrMap[l.keyscope])
+		return err
+	})
+}
+
 // CreateWatchingOnlyWallet creates a new watch-only wallet using the provided
 // walletID, extended public key and public passphrase.
 func (l *btcLoader) CreateWatchingOnlyWallet(_ context.Context, params *loader.WatchOnlyWalletParams) (*loader.LoadedWallets, error) {
@@ -155,6 +174,17 @@ func (l *btcLoader) CreateWatchingOnlyWallet(_ context.Context, params *loader.W
 		return nil, err
 	}
 
+	// Multisig wallets have no account of their own, their addresses are
+	// the witness scripts imported into the imported account of the key
+	// scope.
+	if params.ExtendedPubKey == "" {
+		if err := l.createKeyScope(wal); err != nil {
+			return nil, err
+		}
+		l.wallet = wal
+		return &loader.LoadedWallets{BTC: wal}, nil
+	}
+
 	// Create extended key from the xpub string.
 	extendedKety, err := hdkeychain.NewKeyFromString(params.ExtendedPubKey)
 	if err != nil {
@@ -166,8 +196,7 @@ func (l *btcLoader) CreateWatchingOnlyWallet(_ context.Context, params *loader.W
 	// name, It doesn't matter what the account name use to be on a previous wallet.
 	//  Since the MasterFingerPrint is not provided when inputing the extended
 	// public key, 0 is set instead.
-	addrSchema := waddrmgr.ScopeAddrMap[l.keyscope]
-	_, err = wal.ImportAccountWithScope("default", extendedKety, 0, l.keyscope, addrSchema)
+	_, err = wal.ImportAccountWithScope("default", extendedKety, 0, l.keyscope, waddrmgr.ScopeAddrMap[l.keyscope])
 	if err != nil {
 		return nil, err
 	}
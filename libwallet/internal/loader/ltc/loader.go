@@ -10,6 +10,7 @@ import (
 
 	"github.com/dcrlabs/ltcwallet/waddrmgr"
 	"github.com/dcrlabs/ltcwallet/wallet"
+	"github.com/dcrlabs/ltcwallet/walletdb"
 	_ "github.com/dcrlabs/ltcwallet/walletdb/bdb" // bdb init() registers a driver
 	"github.com/ltcsuite/ltcd/chaincfg"
 	"github.com/ltcsuite/ltcd/ltcutil/hdkeychain"
@@ -20,6 +21,10 @@ import (
 
 var log = loader.Log
 
+// waddrmgrNamespaceKey is the bucket of the address manager in the wallet
+// database.
+var waddrmgrNamespaceKey = []byte("waddrmgr")
+
 // ltcLoader implements the creating of new and opening of existing ltc wallets.
 // This is primarily intended for use by the RPC servers, to enable
 // methods and services which require the wallet when the wallet is loaded by
@@ -155,6 +160,22 @@ func (l *ltcLoader) CreateWatchingOnlyWallet(_ context.Context, params *loader.W
 		return nil, err
 	}
 
+	// Multisig wallets have no account of their own, their addresses are
+	// the witness scripts imported into the imported account of the key
+	// scope.
+	if params.ExtendedPubKey == "" {
+		err = walletdb.Update(wal.Database(), func(tx walletdb.ReadWriteTx) error {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			_, err := wal.Manager.NewScopedKeyManager(ns, l.keyscope, waddrmgr.ScopeAddrMap[l.keyscope])
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		l.wallet = wal
+		return &loader.LoadedWallets{LTC: wal}, nil
+	}
+
 	// Create extended key from the xpub string.
 	extendedKety, err := hdkeychain.NewKeyFromString(params.ExtendedPubKey)
 	if err != nil {
@@ -0,0 +1,120 @@
+// Package peers manages the peers of the neutrino based wallets, BTC and
+// LTC. The neutrino chain services of the assets are forks with their own
+// peer types, the wallets adapt them to ChainService.
+package peers
+
+import (
+	"errors"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// ChainService is the neutrino chain service of a wallet.
+type ChainService interface {
+	// Peers returns the peers the chain service is connected to.
+	Peers() []sharedW.PeerInfo
+	// HasPeer returns true if the chain service is connected to the peer
+	// with the address.
+	HasPeer(addr string) bool
+	// DisconnectNodeByAddr disconnects the peer with the address.
+	DisconnectNodeByAddr(addr string) error
+	// BanPeer disconnects the peer with the address and bans its host.
+	BanPeer(addr string) error
+	// ConnectNode connects to the peer with the address, without
+	// reconnecting to it once it disconnects.
+	ConnectNode(addr string) error
+}
+
+// Wallet is the wallet the peers are managed for.
+type Wallet interface {
+	ChainBackend() sharedW.ChainBackend
+	IsConnectedToNetwork() bool
+	// RemotePeer returns the node or Electrum server of a remote backend,
+	// nil if the wallet syncs with neutrino.
+	RemotePeer() *sharedW.PeerInfo
+	// ChainService returns the neutrino chain service, nil if it isn't
+	// created.
+	ChainService() ChainService
+	// DefaultPort returns the default peer port of the network.
+	DefaultPort() string
+}
+
+// chainService returns the neutrino chain service the peer actions apply to.
+// The node or Electrum server of a remote backend can't be managed.
+func chainService(wallet Wallet) (ChainService, error) {
+	if wallet.ChainBackend() != sharedW.SPVBackend {
+		return nil, utils.ErrPeerActionUnsupported
+	}
+	// Querying the chain service before it is started never returns.
+	if !wallet.IsConnectedToNetwork() {
+		return nil, errors.New(utils.ErrNotConnected)
+	}
+	cs := wallet.ChainService()
+	if cs == nil {
+		return nil, errors.New(utils.ErrNotConnected)
+	}
+	return cs, nil
+}
+
+// Info returns the peers the wallet is connected to. Neutrino doesn't track
+// the ban score of its peers.
+func Info(wallet Wallet) ([]sharedW.PeerInfo, error) {
+	if !wallet.IsConnectedToNetwork() {
+		return nil, errors.New(utils.ErrNotConnected)
+	}
+	// The node or Electrum server is the only peer of a remote backend.
+	if peer := wallet.RemotePeer(); peer != nil {
+		return []sharedW.PeerInfo{*peer}, nil
+	}
+
+	cs, err := chainService(wallet)
+	if err != nil {
+		return nil, err
+	}
+	return cs.Peers(), nil
+}
+
+// Disconnect disconnects the peer with the address, neutrino may connect to
+// it again later.
+func Disconnect(wallet Wallet, addr string) error {
+	cs, err := chainService(wallet)
+	if err != nil {
+		return err
+	}
+	if !cs.HasPeer(addr) {
+		return utils.ErrPeerNotFound
+	}
+	return cs.DisconnectNodeByAddr(addr)
+}
+
+// Ban disconnects the peer with the address and bans its host for
+// neutrino's BanDuration.
+func Ban(wallet Wallet, addr string) error {
+	cs, err := chainService(wallet)
+	if err != nil {
+		return err
+	}
+	if !cs.HasPeer(addr) {
+		return utils.ErrPeerNotFound
+	}
+	return cs.BanPeer(addr)
+}
+
+// Add connects to the peer until it disconnects or the wallet stops syncing.
+// Unlike the specific peer setting, the peer isn't saved and the other peers
+// stay connected.
+func Add(wallet Wallet, addr string) error {
+	cs, err := chainService(wallet)
+	if err != nil {
+		return err
+	}
+	addrs, errs := sharedW.ParseWalletPeers(addr, wallet.DefaultPort())
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	if len(addrs) != 1 {
+		return errors.New(utils.ErrInvalidPeers)
+	}
+	return cs.ConnectNode(addrs[0])
+}
@@ -0,0 +1,113 @@
+package peers
+
+import (
+	"errors"
+	"testing"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+type testChainService struct {
+	peers     map[string]bool
+	banned    map[string]bool
+	connected []string
+}
+
+func (s *testChainService) Peers() []sharedW.PeerInfo {
+	infos := make([]sharedW.PeerInfo, 0, len(s.peers))
+	for addr := range s.peers {
+		infos = append(infos, sharedW.PeerInfo{Addr: addr})
+	}
+	return infos
+}
+
+func (s *testChainService) HasPeer(addr string) bool { return s.peers[addr] }
+
+func (s *testChainService) DisconnectNodeByAddr(addr string) error {
+	delete(s.peers, addr)
+	return nil
+}
+
+func (s *testChainService) BanPeer(addr string) error {
+	delete(s.peers, addr)
+	s.banned[addr] = true
+	return nil
+}
+
+func (s *testChainService) ConnectNode(addr string) error {
+	s.connected = append(s.connected, addr)
+	return nil
+}
+
+type testWallet struct {
+	backend    sharedW.ChainBackend
+	connected  bool
+	remotePeer *sharedW.PeerInfo
+	cs         *testChainService
+}
+
+func (w *testWallet) ChainBackend() sharedW.ChainBackend { return w.backend }
+func (w *testWallet) IsConnectedToNetwork() bool         { return w.connected }
+func (w *testWallet) RemotePeer() *sharedW.PeerInfo      { return w.remotePeer }
+func (w *testWallet) DefaultPort() string                { return "18444" }
+
+func (w *testWallet) ChainService() ChainService {
+	if w.cs == nil {
+		return nil
+	}
+	return w.cs
+}
+
+func TestPeerActions(t *testing.T) {
+	notConnected := errors.New(utils.ErrNotConnected)
+	tests := []struct {
+		name   string
+		wallet *testWallet
+		err    error
+	}{
+		{"not connected", &testWallet{backend: sharedW.SPVBackend}, notConnected},
+		{"no chain service", &testWallet{backend: sharedW.SPVBackend, connected: true}, notConnected},
+		{"remote backend", &testWallet{backend: sharedW.RPCBackend, connected: true,
+			remotePeer: &sharedW.PeerInfo{Addr: "127.0.0.1:18443"}}, utils.ErrPeerActionUnsupported},
+	}
+	for _, tc := range tests {
+		actions := map[string]func(Wallet, string) error{
+			"disconnect": Disconnect,
+			"ban":        Ban,
+			"add":        Add,
+		}
+		for name, action := range actions {
+			err := action(tc.wallet, "127.0.0.1:18444")
+			if err == nil || err.Error() != tc.err.Error() {
+				t.Errorf("%s %s: got error %v, want %v", tc.name, name, err, tc.err)
+			}
+		}
+	}
+
+	remote := tests[2].wallet
+	if infos, err := Info(remote); err != nil || len(infos) != 1 || infos[0].Addr != remote.remotePeer.Addr {
+		t.Errorf("got the remote peers %v (%v), want the node", infos, err)
+	}
+
+	cs := &testChainService{peers: map[string]bool{"10.0.0.1:18444": true, "10.0.0.2:18444": true}, banned: map[string]bool{}}
+	wallet := &testWallet{backend: sharedW.SPVBackend, connected: true, cs: cs}
+	if infos, err := Info(wallet); err != nil || len(infos) != 2 {
+		t.Fatalf("got the peers %v (%v), want 2 peers", infos, err)
+	}
+	if err := Disconnect(wallet, "10.0.0.3:18444"); err != utils.ErrPeerNotFound {
+		t.Errorf("disconnected an unknown peer: %v", err)
+	}
+	if err := Disconnect(wallet, "10.0.0.1:18444"); err != nil || cs.peers["10.0.0.1:18444"] {
+		t.Errorf("the peer wasn't disconnected: %v", err)
+	}
+	if err := Ban(wallet, "10.0.0.2:18444"); err != nil || !cs.banned["10.0.0.2:18444"] {
+		t.Errorf("the peer wasn't banned: %v", err)
+	}
+	if err := Add(wallet, "10.0.0.4"); err != nil || len(cs.connected) != 1 || cs.connected[0] != "10.0.0.4:18444" {
+		t.Errorf("got the added peers %v (%v), want the address with the default port", cs.connected, err)
+	}
+	if err := Add(wallet, "10.0.0.4;10.0.0.5"); err == nil {
+		t.Error("added more than one peer")
+	}
+}
@@ -49,6 +49,21 @@ func New(host string, db *storm.DB) (*Politeia, error) {
 		return nil, err
 	}
 
+	if err := db.Init(&ProposalComment{}); err != nil {
+		log.Errorf("Error initializing politeia comments database: %s", err.Error())
+		return nil, err
+	}
+
+	if err := db.Init(&ProposalAttachment{}); err != nil {
+		log.Errorf("Error initializing politeia attachments database: %s", err.Error())
+		return nil, err
+	}
+
+	if err := db.Init(&proposalSearchIndex{}); err != nil {
+		log.Errorf("Error initializing politeia search index: %s", err.Error())
+		return nil, err
+	}
+
 	return &Politeia{
 		host: host,
 		db:   db,
@@ -245,6 +260,17 @@ func (p *Politeia) ClearSavedProposals() error {
 		return translateError(err)
 	}
 
+	for _, data := range []interface{}{&ProposalComment{}, &ProposalAttachment{}, &proposalSearchIndex{}} {
+		err = p.db.Drop(data)
+		if err != nil && err != storm.ErrNotFound {
+			return translateError(err)
+		}
+
+		if err = p.db.Init(data); err != nil {
+			return err
+		}
+	}
+
 	return p.db.Init(&Proposal{})
 }
 
@@ -0,0 +1,405 @@
+package politeia
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+	www "github.com/decred/politeia/politeiawww/api/www/v1"
+)
+
+// Search index weights of the indexed proposal fields.
+const (
+	titleWeight   = 3.0
+	authorWeight  = 2.0
+	bodyWeight    = 1.0
+	commentWeight = 0.5
+
+	// BM25 ranking parameters.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// prefixMatchWeight scales the score of terms that only match the
+	// beginning of an indexed term so that incomplete words still match.
+	prefixMatchWeight = 0.5
+	minPrefixLength   = 3
+)
+
+func attachmentKey(token, name string) string {
+	return fmt.Sprintf("%s:%s", token, name)
+}
+
+// GetProposalAttachmentsRaw returns the saved attachments of the proposal with
+// the provided censorship token.
+func (p *Politeia) GetProposalAttachmentsRaw(token string) ([]ProposalAttachment, error) {
+	var attachments []ProposalAttachment
+	err := p.db.Select(q.Eq("Token", token)).OrderBy("Name").Find(&attachments)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching proposal attachments: %s", err.Error())
+	}
+
+	return attachments, nil
+}
+
+// archiveProposal fetches the full record of the proposal and saves its
+// markdown body and attachments. The body is returned.
+func (p *Politeia) archiveProposal(proposal *Proposal) (string, error) {
+	proposalDetailsReply, err := p.client.proposalDetails(proposal.Token)
+	if err != nil {
+		return "", err
+	}
+
+	var indexFile string
+	var foundIndexFile bool
+	attachments := make([]ProposalAttachment, 0, len(proposalDetailsReply.Proposal.Files))
+	for _, file := range proposalDetailsReply.Proposal.Files {
+		b, err := base64.StdEncoding.DecodeString(file.Payload)
+		if err != nil {
+			return "", err
+		}
+
+		if file.Name == "index.md" {
+			indexFile = string(b)
+			foundIndexFile = true
+			continue
+		}
+
+		attachments = append(attachments, ProposalAttachment{
+			Key:     attachmentKey(proposal.Token, file.Name),
+			Token:   proposal.Token,
+			Name:    file.Name,
+			MIME:    file.MIME,
+			Digest:  file.Digest,
+			Payload: b,
+		})
+	}
+
+	if !foundIndexFile {
+		return "", errors.New(ErrNotExist)
+	}
+
+	tx, err := p.db.Begin(true)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	err = tx.Select(q.Eq("Token", proposal.Token)).Delete(&ProposalAttachment{})
+	if err != nil && err != storm.ErrNotFound {
+		return "", fmt.Errorf("error deleting old proposal attachments: %s", err.Error())
+	}
+
+	for i := range attachments {
+		if err = tx.Save(&attachments[i]); err != nil {
+			return "", fmt.Errorf("error saving proposal attachment: %s", err.Error())
+		}
+	}
+
+	// index file version will be used to determine if the saved file is out
+	// of date when compared to version.
+	err = tx.Update(&Proposal{
+		ID:               proposal.ID,
+		IndexFile:        indexFile,
+		IndexFileVersion: proposal.Version,
+		ArchivedVersion:  proposal.Version,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error saving proposal body: %s", err.Error())
+	}
+
+	return indexFile, tx.Commit()
+}
+
+// syncProposalArchive saves the body and attachments of every proposal whose
+// saved copy is missing or out of date so that proposals can be read offline.
+// Proposals that fail to be archived are logged and tried again on the next
+// sync.
+func (p *Politeia) syncProposalArchive() error {
+	proposals, err := p.getProposalsRaw(ProposalCategoryAll, 0, 0, true, false, "")
+	if err != nil {
+		return err
+	}
+
+	var archived, failed int
+	for i := range proposals {
+		// Check if politeia has been shutdown and exit if true.
+		if p.ctx.Err() != nil {
+			return p.ctx.Err()
+		}
+
+		proposal := &proposals[i]
+		if proposal.ArchivedVersion == proposal.Version {
+			continue
+		}
+
+		p.mu.RLock()
+		_, err := p.archiveProposal(proposal)
+		p.mu.RUnlock()
+		if err != nil {
+			if p.ctx.Err() != nil {
+				return p.ctx.Err()
+			}
+			// A record without a body, or one the server fails to return,
+			// must not keep the other proposals from being archived and
+			// indexed.
+			log.Errorf("Politeia sync: error saving the body of proposal %s: %v", proposal.Token, err)
+			failed++
+			continue
+		}
+		archived++
+	}
+
+	if archived > 0 {
+		log.Infof("Politeia sync: saved the body of %d proposals", archived)
+	}
+	if failed > 0 {
+		log.Warnf("Politeia sync: failed to save the body of %d proposals", failed)
+	}
+
+	return nil
+}
+
+// searchIndexSignature changes whenever one of the indexed fields of the
+// proposal is updated.
+func searchIndexSignature(proposal *Proposal) string {
+	return fmt.Sprintf("%s:%s:%d:%s", proposal.Name, proposal.IndexFileVersion,
+		proposal.CommentsSyncedAt, proposal.Username)
+}
+
+// updateSearchIndex rebuilds the search index entries of proposals that were
+// added or updated since they were last indexed.
+func (p *Politeia) updateSearchIndex() error {
+	proposals, err := p.getProposalsRaw(ProposalCategoryAll, 0, 0, true, false, "")
+	if err != nil {
+		return err
+	}
+
+	var indexed int
+	for i := range proposals {
+		// Check if politeia has been shutdown and exit if true.
+		if p.ctx.Err() != nil {
+			return p.ctx.Err()
+		}
+
+		proposal := &proposals[i]
+		var index proposalSearchIndex
+		err := p.db.One("Token", proposal.Token, &index)
+		if err != nil && err != storm.ErrNotFound {
+			return err
+		}
+
+		if index.Signature == searchIndexSignature(proposal) {
+			continue
+		}
+
+		if err = p.indexProposal(proposal); err != nil {
+			return err
+		}
+		indexed++
+	}
+
+	if indexed > 0 {
+		log.Infof("Politeia sync: indexed %d proposals", indexed)
+	}
+
+	return nil
+}
+
+// indexProposal saves the weighted term frequencies of the proposal title,
+// author, body and comments.
+func (p *Politeia) indexProposal(proposal *Proposal) error {
+	comments, err := p.GetProposalCommentsRaw(proposal.Token)
+	if err != nil {
+		return err
+	}
+
+	index := &proposalSearchIndex{
+		Token:     proposal.Token,
+		Signature: searchIndexSignature(proposal),
+		Terms:     make(map[string]float64),
+	}
+
+	addTerms := func(text string, weight float64) {
+		for _, term := range searchTerms(text) {
+			index.Terms[term] += weight
+			index.Length += weight
+		}
+	}
+
+	addTerms(proposal.Name, titleWeight)
+	addTerms(proposal.Username, authorWeight)
+	addTerms(proposal.IndexFile, bodyWeight)
+	for i := range comments {
+		if !comments[i].Deleted {
+			addTerms(comments[i].Comment, commentWeight)
+		}
+	}
+
+	return p.db.Save(index)
+}
+
+// searchTerms splits text into lower case words, ignoring single characters.
+func searchTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	terms := words[:0]
+	for _, word := range words {
+		if len(word) > 1 {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// SearchProposals returns the saved proposals matching the filter. If a search
+// phrase is provided, proposals are matched against their title, author, body
+// and comments and are ranked by relevance, otherwise they are ordered by
+// publish time. Only saved data is used so search works offline.
+func (p *Politeia) SearchProposals(filter *ProposalFilter) ([]ProposalSearchResult, error) {
+	matchers := []q.Matcher{q.True()}
+	if filter.Category > ProposalCategoryAll {
+		matchers = append(matchers, q.Eq("Category", filter.Category))
+	}
+	if filter.Since > 0 {
+		matchers = append(matchers, q.Gte("PublishedAt", filter.Since))
+	}
+	if filter.Until > 0 {
+		matchers = append(matchers, q.Lte("PublishedAt", filter.Until))
+	}
+
+	voteFinished := q.Eq("VoteStatus", int32(www.PropVoteStatusFinished))
+	switch filter.VoteOutcome {
+	case VoteOutcomeApproved:
+		matchers = append(matchers, voteFinished, q.Eq("VoteApproved", true))
+	case VoteOutcomeRejected:
+		matchers = append(matchers, voteFinished, q.Eq("VoteApproved", false))
+	case VoteOutcomeUndecided:
+		matchers = append(matchers, q.Not(voteFinished))
+	}
+
+	query := p.db.Select(matchers...).OrderBy("PublishedAt")
+	if !filter.OldestFirst || filter.Phrase != "" {
+		query = query.Reverse()
+	}
+
+	var proposals []Proposal
+	err := query.Find(&proposals)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching proposals: %s", err.Error())
+	}
+
+	queryTerms := searchTerms(filter.Phrase)
+	results := make([]ProposalSearchResult, 0, len(proposals))
+	if len(queryTerms) == 0 {
+		for i := range proposals {
+			results = append(results, ProposalSearchResult{Proposal: proposals[i]})
+		}
+		return paginate(results, filter.Offset, filter.Limit), nil
+	}
+
+	var indexes []proposalSearchIndex
+	err = p.db.All(&indexes)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching search index: %s", err.Error())
+	}
+
+	scores := rankProposals(indexes, queryTerms)
+	for i := range proposals {
+		if score := scores[proposals[i].Token]; score > 0 {
+			results = append(results, ProposalSearchResult{Proposal: proposals[i], Score: score})
+		}
+	}
+
+	// Proposals are newest first, a stable sort keeps the newest proposal
+	// first among results with equal scores.
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	return paginate(results, filter.Offset, filter.Limit), nil
+}
+
+// rankProposals scores every indexed proposal against the query terms using
+// BM25. Proposals that match none of the terms are not included.
+func rankProposals(indexes []proposalSearchIndex, queryTerms []string) map[string]float64 {
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	var totalLength float64
+	for i := range indexes {
+		totalLength += indexes[i].Length
+	}
+	avgLength := totalLength / float64(len(indexes))
+	if avgLength == 0 {
+		avgLength = 1
+	}
+
+	// termFrequency returns the weighted frequency of the query term in the
+	// index, counting prefix matches at a reduced weight.
+	termFrequency := func(index *proposalSearchIndex, queryTerm string) float64 {
+		tf := index.Terms[queryTerm]
+		if len(queryTerm) < minPrefixLength {
+			return tf
+		}
+		for term, freq := range index.Terms {
+			if term != queryTerm && strings.HasPrefix(term, queryTerm) {
+				tf += freq * prefixMatchWeight
+			}
+		}
+		return tf
+	}
+
+	scores := make(map[string]float64)
+	for _, queryTerm := range queryTerms {
+		frequencies := make([]float64, len(indexes))
+		var docFrequency float64
+		for i := range indexes {
+			frequencies[i] = termFrequency(&indexes[i], queryTerm)
+			if frequencies[i] > 0 {
+				docFrequency++
+			}
+		}
+		if docFrequency == 0 {
+			continue
+		}
+
+		n := float64(len(indexes))
+		idf := math.Log(1 + (n-docFrequency+0.5)/(docFrequency+0.5))
+		for i := range indexes {
+			tf := frequencies[i]
+			if tf == 0 {
+				continue
+			}
+			norm := bm25K1 * (1 - bm25B + bm25B*indexes[i].Length/avgLength)
+			scores[indexes[i].Token] += idf * tf * (bm25K1 + 1) / (tf + norm)
+		}
+	}
+
+	return scores
+}
+
+func paginate(results []ProposalSearchResult, offset, limit int32) []ProposalSearchResult {
+	if offset > 0 {
+		if int(offset) >= len(results) {
+			return nil
+		}
+		results = results[offset:]
+	}
+
+	if limit > 0 && int(limit) < len(results) {
+		results = results[:limit]
+	}
+
+	return results
+}
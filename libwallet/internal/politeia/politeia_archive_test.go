@@ -0,0 +1,255 @@
+package politeia
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	www "github.com/decred/politeia/politeiawww/api/www/v1"
+)
+
+func TestSearchTerms(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"Decred Marketing", []string{"decred", "marketing"}},
+		{"dcrdata v2.0: a new UI!", []string{"dcrdata", "v2", "new", "ui"}},
+		{"Q3-2024 budget (USD)", []string{"q3", "2024", "budget", "usd"}},
+		{"Ünïcode wörds", []string{"ünïcode", "wörds"}},
+	}
+	for _, tc := range tests {
+		got := searchTerms(tc.text)
+		if len(got) != len(tc.want) {
+			t.Errorf("%q: got terms %q, want %q", tc.text, got, tc.want)
+			continue
+		}
+		for i := range tc.want {
+			if got[i] != tc.want[i] {
+				t.Errorf("%q: got terms %q, want %q", tc.text, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+// saveTestProposals saves proposals and comments and builds their search
+// index.
+func saveTestProposals(t *testing.T, p *Politeia, proposals []Proposal, comments []ProposalComment) {
+	t.Helper()
+	for i := range proposals {
+		if err := p.db.Save(&proposals[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := range comments {
+		comments[i].Key = commentKey(comments[i].Token, comments[i].CommentID)
+		if err := p.db.Save(&comments[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p.ctx = context.Background()
+	if err := p.updateSearchIndex(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func searchTokens(t *testing.T, p *Politeia, filter *ProposalFilter) []string {
+	t.Helper()
+	results, err := p.SearchProposals(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := make([]string, len(results))
+	for i := range results {
+		tokens[i] = results[i].Proposal.Token
+	}
+	return tokens
+}
+
+func TestSearchProposals(t *testing.T) {
+	p, _ := newTestPoliteia(t)
+
+	finished := int32(www.PropVoteStatusFinished)
+	saveTestProposals(t, p, []Proposal{
+		{Token: "title", Name: "Decred marketing campaign", Username: "alice", PublishedAt: 1,
+			IndexFile: "A plan to promote the project.", Category: ProposalCategoryApproved,
+			VoteStatus: finished, VoteApproved: true},
+		{Token: "body", Name: "Website redesign", Username: "bob", PublishedAt: 2,
+			IndexFile: "The redesign includes a marketing page.", Category: ProposalCategoryRejected,
+			VoteStatus: finished},
+		{Token: "comment", Name: "Infrastructure budget", Username: "carol", PublishedAt: 3,
+			IndexFile: "Servers for dcrdata.", Category: ProposalCategoryActive},
+		{Token: "author", Name: "Translations", Username: "translatordao", PublishedAt: 4,
+			IndexFile: "Translate the docs.", Category: ProposalCategoryPre},
+	}, []ProposalComment{
+		{Token: "comment", CommentID: 1, Comment: "Is marketing part of this?"},
+		{Token: "comment", CommentID: 2, Comment: "marketing marketing", Deleted: true},
+	})
+
+	tests := []struct {
+		name   string
+		filter ProposalFilter
+		want   []string
+	}{
+		{"newest first", ProposalFilter{}, []string{"author", "comment", "body", "title"}},
+		{"oldest first", ProposalFilter{OldestFirst: true}, []string{"title", "body", "comment", "author"}},
+		// The title outweighs the body, which outweighs comments. Comments
+		// that were deleted are not indexed.
+		{"ranked by field", ProposalFilter{Phrase: "marketing"}, []string{"title", "body", "comment"}},
+		{"author", ProposalFilter{Phrase: "alice"}, []string{"title"}},
+		{"unknown term", ProposalFilter{Phrase: "lightning"}, []string{}},
+		{"any matching term", ProposalFilter{Phrase: "redesign dcrdata"}, []string{"body", "comment"}},
+		{"prefix", ProposalFilter{Phrase: "transl"}, []string{"author"}},
+		{"short prefix", ProposalFilter{Phrase: "tr"}, []string{}},
+		{"category", ProposalFilter{Phrase: "marketing", Category: ProposalCategoryRejected}, []string{"body"}},
+		{"approved", ProposalFilter{VoteOutcome: VoteOutcomeApproved}, []string{"title"}},
+		{"rejected", ProposalFilter{VoteOutcome: VoteOutcomeRejected}, []string{"body"}},
+		{"undecided", ProposalFilter{VoteOutcome: VoteOutcomeUndecided}, []string{"author", "comment"}},
+		{"published range", ProposalFilter{Since: 2, Until: 3}, []string{"comment", "body"}},
+		{"page", ProposalFilter{Phrase: "marketing", Offset: 1, Limit: 1}, []string{"body"}},
+		{"page past the end", ProposalFilter{Offset: 10}, []string{}},
+	}
+	for _, tc := range tests {
+		got := searchTokens(t, p, &tc.filter)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: got proposals %v, want %v", tc.name, got, tc.want)
+			continue
+		}
+		for i := range tc.want {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: got proposals %v, want %v", tc.name, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestUpdateSearchIndex(t *testing.T) {
+	p, _ := newTestPoliteia(t)
+	proposals := []Proposal{{Token: "token", Name: "Bug bounty", PublishedAt: 1}}
+	saveTestProposals(t, p, proposals, nil)
+
+	if got := searchTokens(t, p, &ProposalFilter{Phrase: "hackerone"}); len(got) != 0 {
+		t.Fatalf("got proposals %v before the body was saved", got)
+	}
+
+	// Archiving a new version of the body changes the index signature and
+	// the proposal is indexed again.
+	proposal, err := p.GetProposalRaw("token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposal.IndexFile = "Run the program on HackerOne."
+	proposal.IndexFileVersion = "2"
+	if err = p.db.Save(proposal); err != nil {
+		t.Fatal(err)
+	}
+	if err = p.updateSearchIndex(); err != nil {
+		t.Fatal(err)
+	}
+	if got := searchTokens(t, p, &ProposalFilter{Phrase: "hackerone"}); len(got) != 1 {
+		t.Fatalf("got proposals %v after the body was saved, want the proposal", got)
+	}
+}
+
+func TestArchiveProposal(t *testing.T) {
+	p, server := newTestPoliteia(t)
+	if err := p.getClient(); err != nil {
+		t.Fatal(err)
+	}
+
+	const token = "0123456789abcdef"
+	proposal := &Proposal{Token: token, Version: "2"}
+	if err := p.db.Save(proposal); err != nil {
+		t.Fatal(err)
+	}
+
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	server.records[token] = www.ProposalRecord{
+		Files: []www.File{
+			{Name: "index.md", MIME: "text/plain; charset=utf-8", Payload: encode("# Proposal")},
+			{Name: "chart.png", MIME: "image/png", Digest: "digest", Payload: encode("png")},
+		},
+	}
+
+	body, err := p.archiveProposal(proposal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != "# Proposal" {
+		t.Errorf("got body %q, want %q", body, "# Proposal")
+	}
+
+	saved, err := p.GetProposalRaw(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.IndexFile != body || saved.ArchivedVersion != "2" || saved.IndexFileVersion != "2" {
+		t.Errorf("got saved body %q of version %q archived at version %q, want the body of version 2",
+			saved.IndexFile, saved.IndexFileVersion, saved.ArchivedVersion)
+	}
+
+	attachments, err := p.GetProposalAttachmentsRaw(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attachments) != 1 || attachments[0].Name != "chart.png" || string(attachments[0].Payload) != "png" {
+		t.Fatalf("got attachments %+v, want chart.png", attachments)
+	}
+
+	// A record without a body is not archived.
+	server.records[token] = www.ProposalRecord{Files: []www.File{{Name: "chart.png", Payload: encode("png")}}}
+	if _, err = p.archiveProposal(proposal); err == nil {
+		t.Error("archived a proposal without a body")
+	}
+}
+
+func TestSyncProposalArchive(t *testing.T) {
+	p, server := newTestPoliteia(t)
+	if err := p.getClient(); err != nil {
+		t.Fatal(err)
+	}
+	p.ctx = context.Background()
+
+	// The first proposal has no body and the server has no record of the
+	// second one, the third is archived regardless.
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	proposals := []Proposal{
+		{Token: "nobody", Version: "1", PublishedAt: 3},
+		{Token: "missing", Version: "1", PublishedAt: 2},
+		{Token: "archived", Version: "1", PublishedAt: 1},
+	}
+	for i := range proposals {
+		if err := p.db.Save(&proposals[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	server.records["nobody"] = www.ProposalRecord{Files: []www.File{{Name: "chart.png", Payload: encode("png")}}}
+	server.records["archived"] = www.ProposalRecord{Files: []www.File{{Name: "index.md", Payload: encode("Audit")}}}
+
+	if err := p.syncProposalArchive(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		token    string
+		archived bool
+	}{{"nobody", false}, {"missing", false}, {"archived", true}} {
+		proposal, err := p.GetProposalRaw(tc.token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if archived := proposal.ArchivedVersion == proposal.Version; archived != tc.archived {
+			t.Errorf("%s: got archived %v, want %v", tc.token, archived, tc.archived)
+		}
+	}
+
+	// The skipped proposals don't keep the archived one out of the index.
+	if err := p.updateSearchIndex(); err != nil {
+		t.Fatal(err)
+	}
+	if got := searchTokens(t, p, &ProposalFilter{Phrase: "audit"}); len(got) != 1 || got[0] != "archived" {
+		t.Fatalf("got proposals %v, want the archived proposal", got)
+	}
+}
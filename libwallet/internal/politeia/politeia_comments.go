@@ -0,0 +1,255 @@
+package politeia
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+)
+
+func commentKey(token string, commentID uint32) string {
+	return fmt.Sprintf("%s:%d", token, commentID)
+}
+
+// GetProposalCommentsRaw returns the cached comments of the proposal with the
+// provided censorship token, oldest first.
+func (p *Politeia) GetProposalCommentsRaw(token string) ([]ProposalComment, error) {
+	var comments []ProposalComment
+	err := p.db.Select(q.Eq("Token", token)).OrderBy("CommentID").Find(&comments)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching proposal comments: %s", err.Error())
+	}
+
+	return comments, nil
+}
+
+// GetProposalComments returns the result of GetProposalCommentsRaw as a JSON
+// string.
+func (p *Politeia) GetProposalComments(token string) (string, error) {
+	return p.marshalResult(p.GetProposalCommentsRaw(token))
+}
+
+// ProposalCommentThreads returns the cached comments of the proposal with the
+// provided censorship token arranged into threads.
+func (p *Politeia) ProposalCommentThreads(token string) ([]*CommentThread, error) {
+	comments, err := p.GetProposalCommentsRaw(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCommentThreads(comments), nil
+}
+
+// FetchProposalComments fetches the comments of the proposal with the provided
+// censorship token from the server and caches them. The comments are returned
+// oldest first.
+func (p *Politeia) FetchProposalComments(token string) ([]ProposalComment, error) {
+	if p.ctx == nil {
+		p.ctx = context.Background()
+	}
+
+	// Check if politeia has been shutdown and exit if true.
+	if p.ctx.Err() != nil {
+		return nil, p.ctx.Err()
+	}
+
+	proposal, err := p.GetProposalRaw(token)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	err = p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.fetchProposalComments(proposal)
+}
+
+// fetchProposalComments replaces the cached comments of the proposal with the
+// comments returned by the server.
+func (p *Politeia) fetchProposalComments(proposal *Proposal) ([]ProposalComment, error) {
+	serverComments, err := p.client.comments(proposal.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]ProposalComment, len(serverComments))
+	for i, c := range serverComments {
+		comments[i] = ProposalComment{
+			Key:       commentKey(c.Token, c.CommentID),
+			Token:     c.Token,
+			CommentID: c.CommentID,
+			ParentID:  c.ParentID,
+			UserID:    c.UserID,
+			Username:  c.Username,
+			Comment:   c.Comment,
+			Version:   c.Version,
+			CreatedAt: c.CreatedAt,
+			Timestamp: c.Timestamp,
+			Upvotes:   c.Upvotes,
+			Downvotes: c.Downvotes,
+			Deleted:   c.Deleted,
+			Reason:    c.Reason,
+			IsAuthor:  c.UserID == proposal.UserID,
+		}
+	}
+	sort.Slice(comments, func(i, j int) bool {
+		return comments[i].CommentID < comments[j].CommentID
+	})
+
+	tx, err := p.db.Begin(true)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	err = tx.Select(q.Eq("Token", proposal.Token)).Delete(&ProposalComment{})
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error deleting old proposal comments: %s", err.Error())
+	}
+
+	for i := range comments {
+		if err = tx.Save(&comments[i]); err != nil {
+			return nil, fmt.Errorf("error saving proposal comment: %s", err.Error())
+		}
+	}
+
+	err = tx.UpdateField(&Proposal{ID: proposal.ID}, "CommentsSyncedAt", time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.UpdateField(&Proposal{ID: proposal.ID}, "SyncedNumComments", proposal.NumComments)
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, tx.Commit()
+}
+
+// syncProposalComments refreshes the cached comments of proposals that gained
+// new comments since they were last fetched. Comments of proposals in
+// discussion or voting are fetched even if they were never requested. A
+// proposal whose comments fail to be fetched is logged and skipped.
+func (p *Politeia) syncProposalComments() error {
+	proposals, err := p.getProposalsRaw(ProposalCategoryAll, 0, 0, true, true, "")
+	if err != nil {
+		return err
+	}
+
+	var synced, failed int
+	for i := range proposals {
+		// Check if politeia has been shutdown and exit if true.
+		if p.ctx.Err() != nil {
+			return p.ctx.Err()
+		}
+
+		proposal := &proposals[i]
+		if !needsCommentsSync(proposal) {
+			continue
+		}
+
+		p.mu.RLock()
+		_, err := p.fetchProposalComments(proposal)
+		p.mu.RUnlock()
+		if err != nil {
+			if p.ctx.Err() != nil {
+				return p.ctx.Err()
+			}
+			// The comments are fetched again on the next sync, the other
+			// proposals are not held back by this one.
+			log.Errorf("Politeia sync: error fetching comments of proposal %s: %v", proposal.Token, err)
+			failed++
+			continue
+		}
+		synced++
+	}
+
+	if synced > 0 {
+		log.Infof("Politeia sync: fetched comments of %d proposals", synced)
+	}
+	if failed > 0 {
+		log.Warnf("Politeia sync: failed to fetch comments of %d proposals", failed)
+	}
+
+	return nil
+}
+
+func needsCommentsSync(proposal *Proposal) bool {
+	if proposal.CommentsSyncedAt > 0 {
+		return proposal.SyncedNumComments != proposal.NumComments
+	}
+
+	if proposal.NumComments == 0 {
+		return false
+	}
+
+	return proposal.Category == ProposalCategoryPre || proposal.Category == ProposalCategoryActive
+}
+
+// buildCommentThreads arranges comments into threads. Replies whose parent is
+// missing are treated as top level comments. Comments are expected to be
+// ordered oldest first.
+func buildCommentThreads(comments []ProposalComment) []*CommentThread {
+	threads := make(map[uint32]*CommentThread, len(comments))
+	for i := range comments {
+		threads[comments[i].CommentID] = &CommentThread{Comment: &comments[i]}
+	}
+
+	var roots []*CommentThread
+	for i := range comments {
+		thread := threads[comments[i].CommentID]
+		parent, ok := threads[comments[i].ParentID]
+		if comments[i].ParentID == 0 || !ok || parent == thread {
+			roots = append(roots, thread)
+			continue
+		}
+		parent.Replies = append(parent.Replies, thread)
+	}
+
+	var setDepth func(threads []*CommentThread, depth int)
+	setDepth = func(threads []*CommentThread, depth int) {
+		for _, t := range threads {
+			t.Depth = depth
+			setDepth(t.Replies, depth+1)
+		}
+	}
+	setDepth(roots, 0)
+
+	return roots
+}
+
+// ProposalAuthorRaw returns metadata about the author with the provided user
+// ID computed from the saved proposals.
+func (p *Politeia) ProposalAuthorRaw(userID string) (*ProposalAuthor, error) {
+	var proposals []Proposal
+	err := p.db.Select(q.Eq("UserID", userID)).Find(&proposals)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, fmt.Errorf("error fetching author proposals: %s", err.Error())
+	}
+
+	author := &ProposalAuthor{UserID: userID}
+	for i := range proposals {
+		author.Username = proposals[i].Username
+		author.Proposals++
+		switch proposals[i].Category {
+		case ProposalCategoryApproved:
+			author.Approved++
+		case ProposalCategoryRejected:
+			author.Rejected++
+		case ProposalCategoryAbandoned:
+			author.Abandoned++
+		}
+	}
+
+	return author, nil
+}
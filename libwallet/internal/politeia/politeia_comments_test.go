@@ -0,0 +1,250 @@
+package politeia
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/asdine/storm"
+	cmv1 "github.com/decred/politeia/politeiawww/api/comments/v1"
+	www "github.com/decred/politeia/politeiawww/api/www/v1"
+)
+
+// fakeServer serves the politeia routes used by the tests. Comments and
+// proposal records are returned from the maps, keyed by proposal token, the
+// routes fail for tokens missing from the maps.
+type fakeServer struct {
+	mu       sync.Mutex
+	comments map[string][]cmv1.Comment
+	records  map[string]www.ProposalRecord
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var reply interface{}
+	switch r.URL.Path {
+	case "/api" + www.PoliteiaWWWAPIRoute + www.RouteVersion:
+		reply = www.VersionReply{Version: www.PoliteiaWWWAPIVersion}
+	case "/api" + www.PoliteiaWWWAPIRoute + www.RoutePolicy:
+		reply = www.PolicyReply{}
+	case "/api" + cmv1.APIRoute + cmv1.RouteComments:
+		var req cmv1.Comments
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		comments, ok := s.comments[req.Token]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		reply = cmv1.CommentsReply{Comments: comments}
+	default:
+		token, ok := strings.CutPrefix(r.URL.Path, "/api"+www.PoliteiaWWWAPIRoute+proposalDetailsPath)
+		record, found := s.records[token]
+		if !ok || !found {
+			http.NotFound(w, r)
+			return
+		}
+		reply = www.ProposalDetailsReply{Proposal: record}
+	}
+	_ = json.NewEncoder(w).Encode(reply)
+}
+
+func (s *fakeServer) setComments(token string, comments []cmv1.Comment) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.comments[token] = comments
+}
+
+// newTestPoliteia returns a politeia instance with a database in a temporary
+// directory, connected to a fake server.
+func newTestPoliteia(t *testing.T) (*Politeia, *fakeServer) {
+	t.Helper()
+	db, err := storm.Open(filepath.Join(t.TempDir(), "politeia.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	server := &fakeServer{
+		comments: make(map[string][]cmv1.Comment),
+		records:  make(map[string]www.ProposalRecord),
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	p, err := New(httpServer.URL+"/api", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p, server
+}
+
+func TestBuildCommentThreads(t *testing.T) {
+	comments := []ProposalComment{
+		{CommentID: 1},
+		{CommentID: 2, ParentID: 1},
+		{CommentID: 3, ParentID: 2},
+		{CommentID: 4},
+		{CommentID: 5, ParentID: 1},
+		// The parent of a reply may have been censored and not returned.
+		{CommentID: 6, ParentID: 42},
+	}
+	threads := buildCommentThreads(comments)
+
+	type flatComment struct {
+		id    uint32
+		depth int
+	}
+	var got []flatComment
+	var walk func(threads []*CommentThread)
+	walk = func(threads []*CommentThread) {
+		for _, thread := range threads {
+			got = append(got, flatComment{thread.Comment.CommentID, thread.Depth})
+			walk(thread.Replies)
+		}
+	}
+	walk(threads)
+
+	want := []flatComment{{1, 0}, {2, 1}, {3, 2}, {5, 1}, {4, 0}, {6, 0}}
+	if len(got) != len(want) {
+		t.Fatalf("got comments %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got comments %v, want %v", got, want)
+		}
+	}
+	if len(threads) != 3 {
+		t.Errorf("got %d top level threads, want 3", len(threads))
+	}
+}
+
+func TestNeedsCommentsSync(t *testing.T) {
+	tests := []struct {
+		name     string
+		proposal Proposal
+		want     bool
+	}{
+		{"never synced in discussion", Proposal{Category: ProposalCategoryPre, NumComments: 3}, true},
+		{"never synced in voting", Proposal{Category: ProposalCategoryActive, NumComments: 3}, true},
+		{"never synced without comments", Proposal{Category: ProposalCategoryActive}, false},
+		{"never synced after the vote", Proposal{Category: ProposalCategoryApproved, NumComments: 3}, false},
+		{"synced without new comments", Proposal{Category: ProposalCategoryApproved, NumComments: 3,
+			CommentsSyncedAt: 1, SyncedNumComments: 3}, false},
+		{"synced with new comments", Proposal{Category: ProposalCategoryApproved, NumComments: 4,
+			CommentsSyncedAt: 1, SyncedNumComments: 3}, true},
+	}
+	for _, tc := range tests {
+		if got := needsCommentsSync(&tc.proposal); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestFetchProposalComments(t *testing.T) {
+	p, server := newTestPoliteia(t)
+
+	const token = "0123456789abcdef"
+	proposal := &Proposal{Token: token, UserID: "author", Category: ProposalCategoryActive, NumComments: 2}
+	if err := p.db.Save(proposal); err != nil {
+		t.Fatal(err)
+	}
+
+	// Comments are returned out of order and cached oldest first.
+	server.setComments(token, []cmv1.Comment{
+		{Token: token, CommentID: 2, ParentID: 1, UserID: "author", Comment: "reply", Upvotes: 1},
+		{Token: token, CommentID: 1, UserID: "reader", Comment: "question", Upvotes: 3, Downvotes: 5},
+	})
+	if _, err := p.FetchProposalComments(token); err != nil {
+		t.Fatal(err)
+	}
+
+	comments, err := p.GetProposalCommentsRaw(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 2 || comments[0].CommentID != 1 || comments[1].CommentID != 2 {
+		t.Fatalf("got cached comments %+v, want comments 1 and 2", comments)
+	}
+	if comments[0].IsAuthor || !comments[1].IsAuthor {
+		t.Errorf("got author flags %v and %v, want false and true", comments[0].IsAuthor, comments[1].IsAuthor)
+	}
+	if score := comments[0].Score(); score != -2 {
+		t.Errorf("got score %d, want -2", score)
+	}
+
+	saved, err := p.GetProposalRaw(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.CommentsSyncedAt == 0 || saved.SyncedNumComments != 2 {
+		t.Errorf("got comments synced at %d with %d comments, want a sync time with 2 comments",
+			saved.CommentsSyncedAt, saved.SyncedNumComments)
+	}
+	if needsCommentsSync(saved) {
+		t.Error("the comments need a sync right after being fetched")
+	}
+
+	// A refetch replaces the cache, comments removed by the server are
+	// dropped.
+	server.setComments(token, []cmv1.Comment{{Token: token, CommentID: 1, UserID: "reader", Comment: "question"}})
+	if _, err = p.FetchProposalComments(token); err != nil {
+		t.Fatal(err)
+	}
+	comments, err = p.GetProposalCommentsRaw(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 1 {
+		t.Fatalf("got %d cached comments after the refetch, want 1", len(comments))
+	}
+
+	if _, err = p.FetchProposalComments("unknown"); err == nil {
+		t.Error("fetched the comments of an unknown proposal")
+	}
+}
+
+func TestSyncProposalComments(t *testing.T) {
+	p, server := newTestPoliteia(t)
+	if err := p.getClient(); err != nil {
+		t.Fatal(err)
+	}
+	p.ctx = context.Background()
+
+	// The server fails to return the comments of the first proposal.
+	proposals := []Proposal{
+		{Token: "failing", Category: ProposalCategoryActive, NumComments: 1, PublishedAt: 2},
+		{Token: "synced", Category: ProposalCategoryActive, NumComments: 1, PublishedAt: 1},
+	}
+	for i := range proposals {
+		if err := p.db.Save(&proposals[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	server.setComments("synced", []cmv1.Comment{{Token: "synced", CommentID: 1, Comment: "comment"}})
+
+	if err := p.syncProposalComments(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		token  string
+		synced bool
+	}{{"failing", false}, {"synced", true}} {
+		proposal, err := p.GetProposalRaw(tc.token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if synced := !needsCommentsSync(proposal); synced != tc.synced {
+			t.Errorf("%s: got comments synced %v, want %v", tc.token, synced, tc.synced)
+		}
+	}
+}
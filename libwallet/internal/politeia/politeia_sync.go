@@ -2,7 +2,6 @@ package politeia
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -142,7 +141,17 @@ func (p *Politeia) checkForUpdates() error {
 		return err
 	}
 
-	return nil
+	err = p.syncProposalComments()
+	if err != nil {
+		return err
+	}
+
+	err = p.syncProposalArchive()
+	if err != nil {
+		return err
+	}
+
+	return p.updateSearchIndex()
 }
 
 func (p *Politeia) handleNewProposals(proposals []Proposal) error {
@@ -187,6 +196,7 @@ func (p *Politeia) handleProposalsUpdate(proposals []Proposal) error {
 			batchProposals[i].PassPercentage = int32(voteSummary.PassPercentage)
 			batchProposals[i].EligibleTickets = int32(voteSummary.EligibleTickets)
 			batchProposals[i].QuorumPercentage = int32(voteSummary.QuorumPercentage)
+			batchProposals[i].EndBlockHeight = int32(voteSummary.EndHeight)
 			batchProposals[i].YesVotes, batchProposals[i].NoVotes = getVotesCount(voteSummary.Results)
 		}
 
@@ -208,6 +218,13 @@ func (p *Politeia) handleProposalsUpdate(proposals []Proposal) error {
 
 func (p *Politeia) updateProposalDetails(oldProposal, updatedProposal Proposal) error {
 	updatedProposal.ID = oldProposal.ID
+	updatedProposal.CommentsSyncedAt = oldProposal.CommentsSyncedAt
+	updatedProposal.SyncedNumComments = oldProposal.SyncedNumComments
+	updatedProposal.ArchivedVersion = oldProposal.ArchivedVersion
+	if updatedProposal.IndexFile == "" {
+		updatedProposal.IndexFile = oldProposal.IndexFile
+		updatedProposal.IndexFileVersion = oldProposal.IndexFileVersion
+	}
 
 	if reflect.DeepEqual(oldProposal, updatedProposal) {
 		return nil
@@ -340,6 +357,7 @@ func (p *Politeia) fetchBatchProposals(category int32, tokens []string, broadcas
 				proposals[i].PassPercentage = int32(voteSummary.PassPercentage)
 				proposals[i].EligibleTickets = int32(voteSummary.EligibleTickets)
 				proposals[i].QuorumPercentage = int32(voteSummary.QuorumPercentage)
+				proposals[i].EndBlockHeight = int32(voteSummary.EndHeight)
 				proposals[i].YesVotes, proposals[i].NoVotes = getVotesCount(voteSummary.Results)
 			}
 
@@ -361,21 +379,28 @@ func (p *Politeia) fetchBatchProposals(category int32, tokens []string, broadcas
 	return nil
 }
 
+// FetchProposalDescription returns the markdown body of the proposal with the
+// provided censorship token. The saved body is returned if it is up to date,
+// otherwise the proposal body and attachments are fetched and saved.
 func (p *Politeia) FetchProposalDescription(token string) (string, error) {
 	if p.ctx == nil {
 		p.ctx = context.Background()
 	}
 
-	// Check if politeia has been shutdown and exit if true.
-	if p.ctx.Err() != nil {
-		return "", p.ctx.Err()
-	}
-
 	proposal, err := p.GetProposalRaw(token)
 	if err != nil {
 		return "", err
 	}
 
+	if proposal.IndexFile != "" && proposal.IndexFileVersion == proposal.Version {
+		return proposal.IndexFile, nil
+	}
+
+	// Check if politeia has been shutdown and exit if true.
+	if p.ctx.Err() != nil {
+		return "", p.ctx.Err()
+	}
+
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
@@ -384,33 +409,7 @@ func (p *Politeia) FetchProposalDescription(token string) (string, error) {
 		return "", err
 	}
 
-	proposalDetailsReply, err := p.client.proposalDetails(token)
-	if err != nil {
-		return "", err
-	}
-
-	for _, file := range proposalDetailsReply.Proposal.Files {
-		if file.Name == "index.md" {
-			b, err := base64.StdEncoding.DecodeString(file.Payload)
-			if err != nil {
-				return "", err
-			}
-
-			// save file to db
-			proposal.IndexFile = string(b)
-			// index file version will be used to determine if the
-			// saved file is out of date when compared to version.
-			proposal.IndexFileVersion = proposal.Version
-			err = p.saveOrOverwiteProposal(proposal)
-			if err != nil {
-				log.Errorf("error saving new proposal: %s", err.Error())
-			}
-
-			return proposal.IndexFile, nil
-		}
-	}
-
-	return "", errors.New(ErrNotExist)
+	return p.archiveProposal(proposal)
 }
 
 func (p *Politeia) ProposalVoteDetailsRaw(ctx context.Context, wallet *wallet.Wallet, token string) (*ProposalVoteDetails, error) {
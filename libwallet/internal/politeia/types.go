@@ -32,7 +32,123 @@ type Proposal struct {
 	EligibleTickets  int32  `json:"eligibletickets"`
 	QuorumPercentage int32  `json:"quorumpercentage"`
 	PassPercentage   int32  `json:"passpercentage"`
+	EndBlockHeight   int32  `json:"endblockheight"`
 	Type             ProposalType
+
+	// CommentsSyncedAt is the unix timestamp when the proposal comments were
+	// last fetched, it is zero if the comments have never been fetched.
+	// SyncedNumComments holds NumComments at that time and is used to detect
+	// new comments during sync.
+	CommentsSyncedAt  int64 `json:"commentssyncedat"`
+	SyncedNumComments int32 `json:"syncednumcomments"`
+
+	// ArchivedVersion is the proposal version whose body and attachments are
+	// saved in the database, it is empty if they were never fetched.
+	ArchivedVersion string `json:"archivedversion"`
+}
+
+// ProposalAttachment is a file other than the markdown body that was
+// submitted with a proposal, usually an image referenced by the body.
+type ProposalAttachment struct {
+	ID      int    `storm:"id,increment"`
+	Key     string `storm:"unique"` // Token and Name joined by ':'.
+	Token   string `json:"token" storm:"index"`
+	Name    string `json:"name"`
+	MIME    string `json:"mime"`
+	Digest  string `json:"digest"`
+	Payload []byte `json:"payload"`
+}
+
+// proposalSearchIndex holds the weighted term frequencies of a proposal's
+// title, author, body and comments. Signature changes whenever any of the
+// indexed fields are updated.
+type proposalSearchIndex struct {
+	Token     string `storm:"id"`
+	Signature string
+	Terms     map[string]float64
+	Length    float64
+}
+
+// ProposalVoteOutcome filters proposals by the result of their vote.
+type ProposalVoteOutcome int32
+
+const (
+	VoteOutcomeAny ProposalVoteOutcome = iota
+	VoteOutcomeApproved
+	VoteOutcomeRejected
+	// VoteOutcomeUndecided matches proposals whose vote has not finished.
+	VoteOutcomeUndecided
+)
+
+// ProposalFilter selects the proposals returned by SearchProposals. Zero
+// values and ProposalCategoryAll disable the corresponding filter. Since and Until are unix
+// timestamps compared against the proposal publish time.
+type ProposalFilter struct {
+	Phrase      string
+	Category    int32
+	Since       int64
+	Until       int64
+	VoteOutcome ProposalVoteOutcome
+	// OldestFirst orders proposals by publish time, oldest first, when no
+	// search phrase is provided.
+	OldestFirst bool
+	Offset      int32
+	Limit       int32
+}
+
+// ProposalSearchResult is a proposal matching a search with its relevance
+// score. Score is zero when no search phrase was provided.
+type ProposalSearchResult struct {
+	Proposal Proposal
+	Score    float64
+}
+
+// ProposalComment is a comment made on a proposal. Comments are cached in the
+// politeia database and are only fetched for proposals that are being
+// discussed or voted on, or whose comments were previously requested.
+type ProposalComment struct {
+	ID        int    `storm:"id,increment"`
+	Key       string `storm:"unique"` // Token and CommentID joined by ':'.
+	Token     string `json:"token" storm:"index"`
+	CommentID uint32 `json:"commentid"`
+	ParentID  uint32 `json:"parentid"`
+	UserID    string `json:"userid"`
+	Username  string `json:"username"`
+	Comment   string `json:"comment"`
+	Version   uint32 `json:"version"`
+	CreatedAt int64  `json:"createdat"`
+	Timestamp int64  `json:"timestamp"`
+	Upvotes   uint64 `json:"upvotes"`
+	Downvotes uint64 `json:"downvotes"`
+	Deleted   bool   `json:"deleted"`
+	Reason    string `json:"reason"`
+
+	// IsAuthor is true if the comment was made by the proposal author.
+	IsAuthor bool `json:"isauthor"`
+}
+
+// Score returns the difference between the comment upvotes and downvotes.
+func (c *ProposalComment) Score() int64 {
+	return int64(c.Upvotes) - int64(c.Downvotes)
+}
+
+// CommentThread is a comment together with its replies. Depth is zero for top
+// level comments.
+type CommentThread struct {
+	Comment *ProposalComment
+	Replies []*CommentThread
+	Depth   int
+}
+
+// ProposalAuthor holds metadata about a proposal author computed from the
+// proposals saved in the database.
+type ProposalAuthor struct {
+	UserID    string
+	Username  string
+	Proposals int32
+	Approved  int32
+	Rejected  int32
+	Abandoned int32
 }
 
 type ProposalOverview struct {
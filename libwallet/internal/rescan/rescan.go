@@ -0,0 +1,256 @@
+// Package rescan runs the rescans requested on the btcwallet based wallets,
+// BTC and LTC. btcwallet neither reports the progress of a rescan job nor
+// lets one be canceled: the progress is read from the height the wallet is
+// synced to, which follows the blocks the job goes through, and the job is
+// canceled by restarting the wallet.
+package rescan
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/btcsuite/btclog"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// progressInterval is the delay between the reads of the height the rescan
+// got to.
+var progressInterval = 2 * time.Second
+
+// Wallet is the wallet the rescans are recorded in, a *sharedW.Wallet.
+type Wallet interface {
+	GetWalletID() int
+	GetWalletName() string
+	SaveRescanCheckpoint(startHeight, height int32)
+	SaveRescanScope(scope *sharedW.RescanScope)
+	ClearRescanCheckpoint()
+	SaveRescanDuration(blocks int32, elapsed time.Duration)
+}
+
+// Chain is the wallet and chain backend of a rescan job.
+type Chain interface {
+	// Submit marks the wallet synced to the block the rescan starts from
+	// and submits the rescan job. The result of the job is sent on the
+	// returned channel once the chain client went through the blocks.
+	Submit() (<-chan error, error)
+	// SyncedTo returns the height the wallet is synced to. The wallet marks
+	// the blocks the job goes through as synced and the rescan progress
+	// notifications of the chain client move it forward.
+	SyncedTo() int32
+	// BestHeight returns the height of the best block of the chain.
+	BestHeight() int32
+	// Stop stops the wallet and its chain client, which drops the job, marks
+	// the wallet synced to the block it was synced to before the rescan and
+	// starts them again.
+	Stop() error
+}
+
+// Rescanner runs the rescans of a wallet, one at a time.
+type Rescanner struct {
+	wallet Wallet
+	log    btclog.Logger
+
+	mu       sync.Mutex
+	listener *sharedW.BlocksRescanProgressListener
+	running  bool
+	cancel   chan struct{}
+	done     chan struct{}
+}
+
+// New returns the rescanner of the wallet.
+func New(wallet Wallet, logger btclog.Logger) *Rescanner {
+	if logger == nil {
+		logger = btclog.Disabled
+	}
+	return &Rescanner{wallet: wallet, log: logger}
+}
+
+// SetListener sets the listener notified of the progress of the rescans.
+func (r *Rescanner) SetListener(listener *sharedW.BlocksRescanProgressListener) {
+	r.mu.Lock()
+	r.listener = listener
+	r.mu.Unlock()
+}
+
+// Listener returns the listener notified of the progress of the rescans.
+func (r *Rescanner) Listener() *sharedW.BlocksRescanProgressListener {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.listener
+}
+
+// IsRunning returns true while a rescan runs.
+func (r *Rescanner) IsRunning() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.running
+}
+
+// Rescan submits the rescan job of chain, which rescans the blocks from
+// fromHeight, and follows it until it finishes, it's canceled or ctx is done.
+// startHeight is the height the rescan was first started from, it's before
+// fromHeight if an interrupted rescan is resumed. The rescan is recorded in
+// the sync checkpoint of the wallet until it finishes or is canceled, to be
+// resumed if ctx is done first, along with scope, the addresses the job looks
+// for.
+func (r *Rescanner) Rescan(ctx context.Context, chain Chain, startHeight, fromHeight int32, scope *sharedW.RescanScope) error {
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return errors.E(utils.ErrSyncAlreadyInProgress)
+	}
+	r.running = true
+	r.cancel = make(chan struct{})
+	r.done = make(chan struct{})
+	cancel, done := r.cancel, r.done
+	r.mu.Unlock()
+
+	r.wallet.SaveRescanCheckpoint(startHeight, fromHeight)
+	r.wallet.SaveRescanScope(scope)
+	errChan, err := chain.Submit()
+	if err != nil {
+		r.wallet.ClearRescanCheckpoint()
+		r.ended()
+		close(done)
+		return err
+	}
+
+	if listener := r.Listener(); listener != nil && listener.OnBlocksRescanStarted != nil {
+		listener.OnBlocksRescanStarted(r.wallet.GetWalletID())
+	}
+
+	go func() {
+		defer close(done)
+		err := r.follow(ctx, chain, startHeight, fromHeight, errChan, cancel)
+		r.ended()
+		if listener := r.Listener(); listener != nil && listener.OnBlocksRescanEnded != nil {
+			listener.OnBlocksRescanEnded(r.wallet.GetWalletID(), err)
+		}
+	}()
+	return nil
+}
+
+// follow reports the progress of the rescan job until it finishes, it's
+// canceled or ctx is done.
+func (r *Rescanner) follow(ctx context.Context, chain Chain, startHeight, fromHeight int32, errChan <-chan error, cancel <-chan struct{}) error {
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	startTime := time.Now()
+	var submitted bool
+	for {
+		select {
+		case <-ctx.Done():
+			// The checkpoint is kept for the rescan to be resumed.
+			r.log.Infof("(%v) Rescan interrupted", r.wallet.GetWalletName())
+			return nil
+
+		case <-cancel:
+			if ctx.Err() != nil {
+				// The wallet is shutting down, it's not restarted and the
+				// rescan is resumed on the next sync.
+				r.log.Infof("(%v) Rescan interrupted", r.wallet.GetWalletName())
+				return nil
+			}
+			err := chain.Stop()
+			if err != nil {
+				r.log.Errorf("(%v) Stopping the rescan job failed: %v", r.wallet.GetWalletName(), err)
+			}
+			// A canceled rescan isn't resumed on the next sync.
+			r.wallet.ClearRescanCheckpoint()
+			r.log.Infof("(%v) Rescan canceled", r.wallet.GetWalletName())
+			return err
+
+		case err := <-errChan:
+			if err != nil {
+				r.log.Errorf("(%v) Rescan job failed: %v", r.wallet.GetWalletName(), err)
+				return err
+			}
+			submitted = true
+			errChan = nil
+
+		case <-ticker.C:
+		}
+
+		height, bestHeight := chain.SyncedTo(), chain.BestHeight()
+		if height > fromHeight {
+			// An interrupted rescan resumes from the last block it went
+			// through.
+			r.wallet.SaveRescanCheckpoint(startHeight, height)
+			r.reportProgress(fromHeight, height, bestHeight, startTime)
+		}
+		if submitted && height >= bestHeight {
+			r.wallet.ClearRescanCheckpoint()
+			r.wallet.SaveRescanDuration(bestHeight-fromHeight, time.Since(startTime))
+			return nil
+		}
+	}
+}
+
+// ended records that the rescan isn't running anymore.
+func (r *Rescanner) ended() {
+	r.mu.Lock()
+	r.running = false
+	r.cancel = nil
+	r.mu.Unlock()
+}
+
+// Cancel stops the running rescan job and returns once it's stopped.
+func (r *Rescanner) Cancel() {
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel = nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	close(cancel)
+	<-done
+}
+
+func (r *Rescanner) reportProgress(startHeight, height, bestHeight int32, startTime time.Time) {
+	listener := r.Listener()
+	if listener == nil || listener.OnBlocksRescanProgress == nil {
+		return
+	}
+	listener.OnBlocksRescanProgress(ProgressReport(r.wallet.GetWalletID(), startHeight, height, bestHeight, startTime))
+}
+
+// ProgressReport returns the progress of a rescan started at startTime from
+// startHeight that got to height, out of bestHeight.
+func ProgressReport(walletID int, startHeight, height, bestHeight int32, startTime time.Time) *sharedW.HeadersRescanProgressReport {
+	headersFetchedSoFar := float64(height - startHeight)
+	if headersFetchedSoFar < 1 {
+		headersFetchedSoFar = 1
+	}
+
+	remainingHeaders := float64(bestHeight - height)
+	if remainingHeaders < 1 {
+		remainingHeaders = 1
+	}
+
+	allHeadersToFetch := headersFetchedSoFar + remainingHeaders
+
+	report := &sharedW.HeadersRescanProgressReport{
+		CurrentRescanHeight: height,
+		TotalHeadersToScan:  int32(allHeadersToFetch),
+		WalletID:            walletID,
+	}
+
+	elapsedRescanTime := time.Since(startTime)
+	rescanRate := headersFetchedSoFar / allHeadersToFetch
+
+	report.RescanProgress = int32((headersFetchedSoFar * 100) / allHeadersToFetch)
+	estimatedTotalRescanTime := time.Duration(float64(elapsedRescanTime) / rescanRate)
+	report.RescanTimeRemaining = (estimatedTotalRescanTime - elapsedRescanTime).Round(time.Second)
+
+	report.GeneralSyncProgress = &sharedW.GeneralSyncProgress{
+		TotalSyncProgress:  report.RescanProgress,
+		TotalTimeRemaining: report.RescanTimeRemaining,
+	}
+	return report
+}
@@ -0,0 +1,222 @@
+package rescan
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// testWallet records the rescan checkpoint like sharedW.Wallet.
+type testWallet struct {
+	mu                  sync.Mutex
+	rescanning          bool
+	startHeight, height int32
+	scope               *sharedW.RescanScope
+	rescannedBlocks     int32
+}
+
+func (w *testWallet) GetWalletID() int      { return 1 }
+func (w *testWallet) GetWalletName() string { return "test" }
+func (w *testWallet) SaveRescanCheckpoint(startHeight, height int32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rescanning, w.startHeight, w.height = true, startHeight, height
+}
+func (w *testWallet) SaveRescanScope(scope *sharedW.RescanScope) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.scope = scope
+}
+func (w *testWallet) ClearRescanCheckpoint() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rescanning, w.startHeight, w.height, w.scope = false, 0, 0, nil
+}
+func (w *testWallet) SaveRescanDuration(blocks int32, _ time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rescannedBlocks = blocks
+}
+
+func (w *testWallet) checkpoint() (bool, int32, int32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rescanning, w.startHeight, w.height
+}
+
+// testChain is a chain whose rescan job goes through the blocks as the test
+// moves syncedTo.
+type testChain struct {
+	mu         sync.Mutex
+	syncedTo   int32
+	bestHeight int32
+	errChan    chan error
+	stopped    bool
+}
+
+func newTestChain(syncedTo, bestHeight int32) *testChain {
+	return &testChain{syncedTo: syncedTo, bestHeight: bestHeight, errChan: make(chan error, 1)}
+}
+
+func (c *testChain) Submit() (<-chan error, error) { return c.errChan, nil }
+func (c *testChain) BestHeight() int32             { return c.bestHeight }
+func (c *testChain) SyncedTo() int32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.syncedTo
+}
+func (c *testChain) Stop() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+	return nil
+}
+
+func (c *testChain) setSyncedTo(height int32) {
+	c.mu.Lock()
+	c.syncedTo = height
+	c.mu.Unlock()
+}
+
+// newTestRescanner returns a rescanner of a test wallet with the channel the
+// error of each ended rescan is sent on.
+func newTestRescanner(t *testing.T) (*Rescanner, *testWallet, <-chan error) {
+	t.Helper()
+	progressInterval = time.Millisecond
+	t.Cleanup(func() { progressInterval = 2 * time.Second })
+
+	w := new(testWallet)
+	r := New(w, nil)
+	ended := make(chan error, 1)
+	r.SetListener(&sharedW.BlocksRescanProgressListener{
+		OnBlocksRescanStarted:  func(int) {},
+		OnBlocksRescanProgress: func(*sharedW.HeadersRescanProgressReport) {},
+		OnBlocksRescanEnded: func(_ int, err error) {
+			ended <- err
+		},
+	})
+	return r, w, ended
+}
+
+// waitFor waits until the condition is met.
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	for start := time.Now(); !condition(); time.Sleep(time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+	}
+}
+
+func TestRescan(t *testing.T) {
+	r, w, ended := newTestRescanner(t)
+	chain := newTestChain(100, 300)
+
+	// A resumed rescan keeps the height it was first started from and the
+	// addresses it looks for.
+	scope := &sharedW.RescanScope{Accounts: []int32{1}, GapLimit: 50}
+	if err := r.Rescan(context.Background(), chain, 50, 100, scope); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Rescan(context.Background(), newTestChain(0, 300), 0, 0, nil); err == nil || err.Error() != utils.ErrSyncAlreadyInProgress {
+		t.Fatalf("started a second rescan with error %v, want %v", err, utils.ErrSyncAlreadyInProgress)
+	}
+
+	if rescanning, startHeight, height := w.checkpoint(); !rescanning || startHeight != 50 || height != 100 || w.scope != scope {
+		t.Fatalf("got checkpoint rescanning %v from %d started at %d", rescanning, height, startHeight)
+	}
+
+	// The checkpoint follows the blocks the job goes through.
+	chain.setSyncedTo(200)
+	waitFor(t, "the checkpoint at block 200", func() bool {
+		rescanning, startHeight, height := w.checkpoint()
+		return rescanning && startHeight == 50 && height == 200
+	})
+
+	// The job sends its result once the chain client went through the
+	// blocks, the rescan finishes once the wallet is synced to the tip.
+	chain.errChan <- nil
+	chain.setSyncedTo(300)
+	if err := <-ended; err != nil {
+		t.Fatal(err)
+	}
+	if r.IsRunning() {
+		t.Fatal("the finished rescan is running")
+	}
+	if rescanning, _, _ := w.checkpoint(); rescanning || w.scope != nil || w.rescannedBlocks != 200 {
+		t.Fatalf("got checkpoint rescanning %v and %d rescanned blocks after the rescan finished", rescanning, w.rescannedBlocks)
+	}
+}
+
+func TestCancelRescan(t *testing.T) {
+	r, w, ended := newTestRescanner(t)
+	chain := newTestChain(0, 300)
+	if err := r.Rescan(context.Background(), chain, 0, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	chain.errChan <- nil
+
+	r.Cancel()
+	if !chain.stopped {
+		t.Fatal("the rescan job wasn't stopped")
+	}
+	if err := <-ended; err != nil {
+		t.Fatal(err)
+	}
+	if rescanning, _, _ := w.checkpoint(); rescanning || r.IsRunning() {
+		t.Fatal("the canceled rescan is still recorded")
+	}
+	// Canceling without a rescan does nothing.
+	r.Cancel()
+
+	// Another rescan can start once the canceled one stopped.
+	if err := r.Rescan(context.Background(), newTestChain(0, 300), 0, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	r.Cancel()
+	<-ended
+}
+
+func TestInterruptedRescan(t *testing.T) {
+	r, w, ended := newTestRescanner(t)
+	chain := newTestChain(0, 300)
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := r.Rescan(ctx, chain, 0, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	chain.setSyncedTo(120)
+	waitFor(t, "the checkpoint at block 120", func() bool {
+		_, _, height := w.checkpoint()
+		return height == 120
+	})
+
+	// The rescan stops with the sync, the checkpoint is kept for it to be
+	// resumed from the last block it went through. Canceling it while the wallet shuts down doesn't restart the
+	// wallet.
+	cancel()
+	r.Cancel()
+	if err := <-ended; err != nil {
+		t.Fatal(err)
+	}
+	if rescanning, _, height := w.checkpoint(); !rescanning || height != 120 || chain.stopped {
+		t.Fatalf("got checkpoint rescanning %v at block %d after the sync stopped", rescanning, height)
+	}
+
+	// A job that fails keeps the checkpoint too.
+	chain = newTestChain(120, 300)
+	if err := r.Rescan(context.Background(), chain, 0, 120, nil); err != nil {
+		t.Fatal(err)
+	}
+	chain.errChan <- errors.New("rescan failed")
+	if err := <-ended; err == nil {
+		t.Fatal("the failed rescan ended without an error")
+	}
+	if rescanning, _, _ := w.checkpoint(); !rescanning {
+		t.Fatal("the failed rescan isn't resumed")
+	}
+}
@@ -0,0 +1,102 @@
+package slip39
+
+// expTable and logTable hold the powers of the generator x + 1 of GF(256)
+// reduced by the Rijndael polynomial x^8 + x^4 + x^3 + x + 1.
+var expTable, logTable = func() ([255]byte, [256]byte) {
+	var exp [255]byte
+	var log [256]byte
+	poly := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(poly)
+		log[poly] = byte(i)
+		// Multiply poly by x + 1 and reduce it.
+		poly = poly<<1 ^ poly
+		if poly&0x100 != 0 {
+			poly ^= 0x11b
+		}
+	}
+	return exp, log
+}()
+
+// interpolate returns the value at x of the polynomial that goes through the
+// shares, using Lagrange interpolation over GF(256). The shares must have
+// distinct x coordinates and values of the same length.
+func interpolate(shares []rawShare, x byte) []byte {
+	for _, share := range shares {
+		if share.x == x {
+			return share.data
+		}
+	}
+
+	var logProd int
+	for _, share := range shares {
+		logProd += int(logTable[share.x^x])
+	}
+
+	result := make([]byte, len(shares[0].data))
+	for _, share := range shares {
+		// The log of the Lagrange basis polynomial of the share evaluated at
+		// x. The share's own term is log(0), which is zero in the table.
+		logBasisEval := logProd - int(logTable[share.x^x])
+		for _, other := range shares {
+			logBasisEval -= int(logTable[share.x^other.x])
+		}
+		logBasisEval = (logBasisEval%255 + 255) % 255
+
+		for i, value := range share.data {
+			if value != 0 {
+				result[i] ^= expTable[(int(logTable[value])+logBasisEval)%255]
+			}
+		}
+	}
+	return result
+}
+
+// rs1024Polymod computes the Reed-Solomon checksum polynomial of the values.
+func rs1024Polymod(values []int) int {
+	gen := [10]int{
+		0xe0e040, 0x1c1c080, 0x3838100, 0x7070200, 0xe0e0009,
+		0x1c0c2412, 0x38086c24, 0x3090fc48, 0x21b1f890, 0x3f3f120,
+	}
+	chk := 1
+	for _, v := range values {
+		b := chk >> 20
+		chk = (chk&0xfffff)<<10 ^ v
+		for i := 0; i < 10; i++ {
+			if (b>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func checksumValues(data []int, extendable bool) []int {
+	customization := customizationString
+	if extendable {
+		customization = extendableCustomizationString
+	}
+
+	values := make([]int, 0, len(customization)+len(data)+checksumLengthWords)
+	for _, c := range customization {
+		values = append(values, int(c))
+	}
+	return append(values, data...)
+}
+
+// createChecksum returns the checksum words of the mnemonic data.
+func createChecksum(data []int, extendable bool) []int {
+	values := append(checksumValues(data, extendable), make([]int, checksumLengthWords)...)
+	polymod := rs1024Polymod(values) ^ 1
+
+	checksum := make([]int, checksumLengthWords)
+	for i := range checksum {
+		checksum[i] = (polymod >> (radixBits * (checksumLengthWords - 1 - i))) & (1<<radixBits - 1)
+	}
+	return checksum
+}
+
+// verifyChecksum returns true if the mnemonic data ends with a valid checksum.
+func verifyChecksum(data []int, extendable bool) bool {
+	return rs1024Polymod(checksumValues(data, extendable)) == 1
+}
@@ -0,0 +1,64 @@
+package slip39
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSplitSecret(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+	shares, err := splitSecret(3, 5, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every subset of threshold shares recovers the secret.
+	for i := 0; i < len(shares); i++ {
+		for j := i + 1; j < len(shares); j++ {
+			for k := j + 1; k < len(shares); k++ {
+				recovered, err := recoverSecret(3, []rawShare{shares[i], shares[j], shares[k]})
+				if err != nil {
+					t.Fatalf("shares %d, %d and %d: %v", i, j, k, err)
+				}
+				if !bytes.Equal(recovered, secret) {
+					t.Fatalf("shares %d, %d and %d: got secret %x, want %x", i, j, k, recovered, secret)
+				}
+			}
+		}
+	}
+
+	// Fewer shares interpolate another secret, which fails the digest check.
+	if _, err = recoverSecret(3, shares[:2]); err == nil {
+		t.Error("recovered the secret from 2 of 3 shares")
+	}
+
+	// A threshold of 1 copies the secret.
+	shares, err = splitSecret(1, 1, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(shares[0].data, secret) {
+		t.Errorf("got share %x with a threshold of 1, want the secret", shares[0].data)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for _, extendable := range []bool{false, true} {
+		checked := append(append([]int{}, data...), createChecksum(data, extendable)...)
+		if !verifyChecksum(checked, extendable) {
+			t.Fatalf("extendable %v: the checksum does not verify", extendable)
+		}
+		// The customization string differs with the extendable flag.
+		if verifyChecksum(checked, !extendable) {
+			t.Errorf("extendable %v: the checksum verifies with the other flag", extendable)
+		}
+		for i := range checked {
+			checked[i] ^= 1
+			if verifyChecksum(checked, extendable) {
+				t.Errorf("extendable %v: the checksum verifies with word %d changed", extendable, i)
+			}
+			checked[i] ^= 1
+		}
+	}
+}
@@ -0,0 +1,433 @@
+// Package slip39 implements SLIP-39 Shamir secret sharing of a master secret
+// into share mnemonics, see
+// https://github.com/satoshilabs/slips/blob/master/slip-0039.md.
+package slip39
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/wordlist"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// MaxShareCount is the maximum number of groups and of member shares in
+	// a group.
+	MaxShareCount = 16
+	// MinSecretLength is the minimum length in bytes of the master secret.
+	MinSecretLength = 16
+
+	radixBits           = 10
+	idLengthBits        = 15
+	checksumLengthWords = 3
+	digestLengthBytes   = 4
+	// The header holds the identifier, extendable flag, iteration exponent,
+	// group index, group threshold, group count, member index and member
+	// threshold.
+	headerLengthWords  = 4
+	minMnemonicLength  = headerLengthWords + checksumLengthWords + (MinSecretLength*8+radixBits-1)/radixBits
+	baseIterationCount = 10000
+	roundCount         = 4
+	digestIndex        = 254
+	secretIndex        = 255
+)
+
+var (
+	customizationString           = []byte("shamir")
+	extendableCustomizationString = []byte("shamir_extendable")
+
+	// ErrInvalidMnemonic is returned for share mnemonics that can not be
+	// decoded.
+	ErrInvalidMnemonic = errors.New("invalid share mnemonic")
+	// ErrInvalidChecksum is returned for share mnemonics with a wrong word.
+	ErrInvalidChecksum = errors.New("invalid share mnemonic checksum")
+	// ErrMismatchedShares is returned when shares of different secrets are
+	// combined.
+	ErrMismatchedShares = errors.New("shares belong to different secrets")
+	// ErrInsufficientShares is returned when fewer shares than required are
+	// combined.
+	ErrInsufficientShares = errors.New("insufficient number of shares")
+	// ErrInvalidDigest is returned when the combined shares do not recover
+	// the shared secret.
+	ErrInvalidDigest = errors.New("invalid digest of the shared secret")
+)
+
+var wordIndex = func() map[string]int {
+	words := wordlist.SLIP39WordList()
+	index := make(map[string]int, len(words))
+	for i, word := range words {
+		index[word] = i
+	}
+	return index
+}()
+
+// Group is the number of member shares generated for a group and the number
+// of those shares required to recover the group secret.
+type Group struct {
+	MemberThreshold int
+	MemberCount     int
+}
+
+// Share is a decoded share mnemonic.
+type Share struct {
+	Identifier        uint16
+	Extendable        bool
+	IterationExponent int
+	GroupIndex        int
+	GroupThreshold    int
+	GroupCount        int
+	MemberIndex       int
+	MemberThreshold   int
+	Value             []byte
+}
+
+// rawShare is a point of the shared polynomial.
+type rawShare struct {
+	x    byte
+	data []byte
+}
+
+// GenerateMnemonics splits the master secret into share mnemonics, grouped by
+// the provided groups. Any groupThreshold groups are required to recover the
+// master secret, every group is recovered from its member threshold of
+// shares. The master secret is encrypted with the passphrase, which may be
+// empty.
+func GenerateMnemonics(groupThreshold int, groups []Group, masterSecret, passphrase []byte) ([][]string, error) {
+	if len(masterSecret) < MinSecretLength || len(masterSecret)%2 != 0 {
+		return nil, fmt.Errorf("the master secret must be an even number of at least %d bytes", MinSecretLength)
+	}
+	if groupThreshold < 1 || groupThreshold > len(groups) {
+		return nil, fmt.Errorf("the group threshold must be between 1 and the number of groups (%d)", len(groups))
+	}
+	if len(groups) > MaxShareCount {
+		return nil, fmt.Errorf("the number of groups must not exceed %d", MaxShareCount)
+	}
+	for _, group := range groups {
+		if group.MemberThreshold < 1 || group.MemberThreshold > group.MemberCount || group.MemberCount > MaxShareCount {
+			return nil, fmt.Errorf("invalid %d-of-%d group, groups can have up to %d shares",
+				group.MemberThreshold, group.MemberCount, MaxShareCount)
+		}
+		if group.MemberThreshold == 1 && group.MemberCount > 1 {
+			return nil, errors.New("groups with a threshold of 1 must have a single share")
+		}
+	}
+
+	var id [2]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	identifier := binary.BigEndian.Uint16(id[:]) & (1<<idLengthBits - 1)
+
+	const iterationExponent = 1
+	encryptedSecret := encrypt(masterSecret, passphrase, iterationExponent, identifier, true)
+
+	groupShares, err := splitSecret(groupThreshold, len(groups), encryptedSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonics := make([][]string, len(groups))
+	for i, groupShare := range groupShares {
+		group := groups[i]
+		memberShares, err := splitSecret(group.MemberThreshold, group.MemberCount, groupShare.data)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, memberShare := range memberShares {
+			share := &Share{
+				Identifier:        identifier,
+				Extendable:        true,
+				IterationExponent: iterationExponent,
+				GroupIndex:        int(groupShare.x),
+				GroupThreshold:    groupThreshold,
+				GroupCount:        len(groups),
+				MemberIndex:       int(memberShare.x),
+				MemberThreshold:   group.MemberThreshold,
+				Value:             memberShare.data,
+			}
+			mnemonics[i] = append(mnemonics[i], share.Mnemonic())
+		}
+	}
+
+	return mnemonics, nil
+}
+
+// CombineMnemonics recovers the master secret from the share mnemonics. The
+// shares of at least group threshold groups are required, with at least the
+// member threshold of shares of each group. Extra shares are ignored.
+func CombineMnemonics(mnemonics []string, passphrase []byte) ([]byte, error) {
+	if len(mnemonics) == 0 {
+		return nil, ErrInsufficientShares
+	}
+
+	shares := make([]*Share, 0, len(mnemonics))
+	for _, mnemonic := range mnemonics {
+		share, err := DecodeMnemonic(mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+
+	first := shares[0]
+	groups := make(map[int][]*Share)
+	for _, share := range shares {
+		if share.Identifier != first.Identifier || share.Extendable != first.Extendable ||
+			share.IterationExponent != first.IterationExponent || share.GroupThreshold != first.GroupThreshold ||
+			share.GroupCount != first.GroupCount || len(share.Value) != len(first.Value) {
+			return nil, ErrMismatchedShares
+		}
+
+		group := groups[share.GroupIndex]
+		if len(group) > 0 && group[0].MemberThreshold != share.MemberThreshold {
+			return nil, ErrMismatchedShares
+		}
+		duplicate := false
+		for _, member := range group {
+			if member.MemberIndex == share.MemberIndex {
+				if string(member.Value) != string(share.Value) {
+					return nil, ErrMismatchedShares
+				}
+				duplicate = true
+			}
+		}
+		if !duplicate {
+			groups[share.GroupIndex] = append(group, share)
+		}
+	}
+
+	// Only groups with enough shares are recovered.
+	groupIndexes := make([]int, 0, len(groups))
+	for groupIndex, group := range groups {
+		if len(group) >= group[0].MemberThreshold {
+			groupIndexes = append(groupIndexes, groupIndex)
+		}
+	}
+	if len(groupIndexes) < first.GroupThreshold {
+		return nil, ErrInsufficientShares
+	}
+	sort.Ints(groupIndexes)
+
+	groupShares := make([]rawShare, 0, first.GroupThreshold)
+	for _, groupIndex := range groupIndexes[:first.GroupThreshold] {
+		group := groups[groupIndex]
+		memberShares := make([]rawShare, 0, group[0].MemberThreshold)
+		for _, member := range group[:group[0].MemberThreshold] {
+			memberShares = append(memberShares, rawShare{x: byte(member.MemberIndex), data: member.Value})
+		}
+
+		groupSecret, err := recoverSecret(group[0].MemberThreshold, memberShares)
+		if err != nil {
+			return nil, err
+		}
+		groupShares = append(groupShares, rawShare{x: byte(groupIndex), data: groupSecret})
+	}
+
+	encryptedSecret, err := recoverSecret(first.GroupThreshold, groupShares)
+	if err != nil {
+		return nil, err
+	}
+
+	return decrypt(encryptedSecret, passphrase, first.IterationExponent, first.Identifier, first.Extendable), nil
+}
+
+// DecodeMnemonic decodes and verifies the checksum of a share mnemonic.
+func DecodeMnemonic(mnemonic string) (*Share, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) < minMnemonicLength {
+		return nil, fmt.Errorf("%w: a share has at least %d words", ErrInvalidMnemonic, minMnemonicLength)
+	}
+
+	data := make([]int, len(words))
+	for i, word := range words {
+		index, ok := wordIndex[word]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %q", ErrInvalidMnemonic, word)
+		}
+		data[i] = index
+	}
+
+	paddingLength := (radixBits * (len(words) - headerLengthWords - checksumLengthWords)) % 16
+	if paddingLength > 8 {
+		return nil, fmt.Errorf("%w: invalid number of words", ErrInvalidMnemonic)
+	}
+
+	var header uint64
+	for _, value := range data[:headerLengthWords] {
+		header = header<<radixBits | uint64(value)
+	}
+	extendable := header>>24&1 == 1
+	if !verifyChecksum(data, extendable) {
+		return nil, ErrInvalidChecksum
+	}
+
+	share := &Share{
+		Identifier:        uint16(header >> 25),
+		Extendable:        extendable,
+		IterationExponent: int(header >> 20 & 0xf),
+		GroupIndex:        int(header >> 16 & 0xf),
+		GroupThreshold:    int(header>>12&0xf) + 1,
+		GroupCount:        int(header>>8&0xf) + 1,
+		MemberIndex:       int(header >> 4 & 0xf),
+		MemberThreshold:   int(header&0xf) + 1,
+	}
+	if share.GroupThreshold > share.GroupCount {
+		return nil, fmt.Errorf("%w: the group threshold exceeds the group count", ErrInvalidMnemonic)
+	}
+
+	// The value is left padded with zero bits to a multiple of the word
+	// size.
+	value := new(big.Int)
+	for _, index := range data[headerLengthWords : len(data)-checksumLengthWords] {
+		value.Lsh(value, radixBits).Or(value, big.NewInt(int64(index)))
+	}
+	valueBits := radixBits*(len(data)-headerLengthWords-checksumLengthWords) - paddingLength
+	if value.BitLen() > valueBits {
+		return nil, fmt.Errorf("%w: invalid padding", ErrInvalidMnemonic)
+	}
+	share.Value = value.FillBytes(make([]byte, valueBits/8))
+
+	return share, nil
+}
+
+// Mnemonic encodes the share as a mnemonic.
+func (share *Share) Mnemonic() string {
+	var extendable uint64
+	if share.Extendable {
+		extendable = 1
+	}
+	header := uint64(share.Identifier)<<25 | extendable<<24 | uint64(share.IterationExponent)<<20 |
+		uint64(share.GroupIndex)<<16 | uint64(share.GroupThreshold-1)<<12 | uint64(share.GroupCount-1)<<8 |
+		uint64(share.MemberIndex)<<4 | uint64(share.MemberThreshold-1)
+
+	valueWords := (len(share.Value)*8 + radixBits - 1) / radixBits
+	data := make([]int, 0, headerLengthWords+valueWords+checksumLengthWords)
+	for i := headerLengthWords - 1; i >= 0; i-- {
+		data = append(data, int(header>>(radixBits*i)&(1<<radixBits-1)))
+	}
+
+	// The value is left padded with zero bits to a multiple of the word
+	// size.
+	value := new(big.Int).SetBytes(share.Value)
+	mask := big.NewInt(1<<radixBits - 1)
+	for i := valueWords - 1; i >= 0; i-- {
+		word := new(big.Int).Rsh(value, uint(radixBits*i))
+		data = append(data, int(word.And(word, mask).Int64()))
+	}
+
+	data = append(data, createChecksum(data, share.Extendable)...)
+
+	words := wordlist.SLIP39WordList()
+	mnemonic := make([]string, len(data))
+	for i, index := range data {
+		mnemonic[i] = words[index]
+	}
+	return strings.Join(mnemonic, " ")
+}
+
+// splitSecret splits the secret into shareCount shares, threshold of which
+// are required to recover it.
+func splitSecret(threshold, shareCount int, secret []byte) ([]rawShare, error) {
+	if threshold == 1 {
+		shares := make([]rawShare, shareCount)
+		for i := range shares {
+			shares[i] = rawShare{x: byte(i), data: secret}
+		}
+		return shares, nil
+	}
+
+	randomShareCount := threshold - 2
+	shares := make([]rawShare, 0, shareCount)
+	for i := 0; i < randomShareCount; i++ {
+		data := make([]byte, len(secret))
+		if _, err := rand.Read(data); err != nil {
+			return nil, err
+		}
+		shares = append(shares, rawShare{x: byte(i), data: data})
+	}
+
+	randomPart := make([]byte, len(secret)-digestLengthBytes)
+	if _, err := rand.Read(randomPart); err != nil {
+		return nil, err
+	}
+	digest := append(createDigest(randomPart, secret), randomPart...)
+
+	baseShares := append(shares[:randomShareCount:randomShareCount],
+		rawShare{x: digestIndex, data: digest}, rawShare{x: secretIndex, data: secret})
+	for i := randomShareCount; i < shareCount; i++ {
+		shares = append(shares, rawShare{x: byte(i), data: interpolate(baseShares, byte(i))})
+	}
+	return shares, nil
+}
+
+// recoverSecret recovers the secret of the shares and verifies its digest.
+func recoverSecret(threshold int, shares []rawShare) ([]byte, error) {
+	if threshold == 1 {
+		return shares[0].data, nil
+	}
+
+	secret := interpolate(shares, secretIndex)
+	digestShare := interpolate(shares, digestIndex)
+	if !hmac.Equal(digestShare[:digestLengthBytes], createDigest(digestShare[digestLengthBytes:], secret)) {
+		return nil, ErrInvalidDigest
+	}
+	return secret, nil
+}
+
+func createDigest(randomData, secret []byte) []byte {
+	mac := hmac.New(sha256.New, randomData)
+	mac.Write(secret)
+	return mac.Sum(nil)[:digestLengthBytes]
+}
+
+// encrypt encrypts the master secret with a four round Feistel network.
+func encrypt(masterSecret, passphrase []byte, iterationExponent int, identifier uint16, extendable bool) []byte {
+	half := len(masterSecret) / 2
+	l, r := masterSecret[:half], masterSecret[half:]
+	salt := feistelSalt(identifier, extendable)
+	for i := 0; i < roundCount; i++ {
+		l, r = r, xor(l, roundFunction(i, passphrase, iterationExponent, salt, r))
+	}
+	return append(append([]byte{}, r...), l...)
+}
+
+// decrypt reverses encrypt.
+func decrypt(encryptedSecret, passphrase []byte, iterationExponent int, identifier uint16, extendable bool) []byte {
+	half := len(encryptedSecret) / 2
+	l, r := encryptedSecret[:half], encryptedSecret[half:]
+	salt := feistelSalt(identifier, extendable)
+	for i := roundCount - 1; i >= 0; i-- {
+		l, r = r, xor(l, roundFunction(i, passphrase, iterationExponent, salt, r))
+	}
+	return append(append([]byte{}, r...), l...)
+}
+
+func feistelSalt(identifier uint16, extendable bool) []byte {
+	if extendable {
+		return nil
+	}
+	return binary.BigEndian.AppendUint16(append([]byte{}, customizationString...), identifier)
+}
+
+func roundFunction(i int, passphrase []byte, iterationExponent int, salt, r []byte) []byte {
+	password := append([]byte{byte(i)}, passphrase...)
+	iterations := (baseIterationCount << iterationExponent) / roundCount
+	return pbkdf2.Key(password, append(append([]byte{}, salt...), r...), iterations, len(r), sha256.New)
+}
+
+func xor(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
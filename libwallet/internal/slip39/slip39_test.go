@@ -0,0 +1,259 @@
+package slip39
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// vectorPassphrase is the passphrase of the SLIP-39 test vectors.
+var vectorPassphrase = []byte("TREZOR")
+
+// vectors are cases of the official SLIP-39 test vectors,
+// https://github.com/trezor/python-shamir-mnemonic/blob/master/vectors.json.
+var vectors = []struct {
+	name      string
+	mnemonics []string
+	secret    string
+	err       error
+}{
+	{
+		name:      "1. Valid mnemonic without sharing (128 bits)",
+		mnemonics: []string{"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboard"},
+		secret:    "bb54aac4b89dc868ba37d9cc21b2cece",
+	},
+	{
+		name:      "2. Mnemonic with invalid checksum (128 bits)",
+		mnemonics: []string{"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision kidney"},
+		err:       ErrInvalidChecksum,
+	},
+	{
+		name:      "3. Mnemonic with invalid padding (128 bits)",
+		mnemonics: []string{"duckling enlarge academic academic email result length solution fridge kidney coal piece deal husband erode duke ajar music cargo fitness"},
+		err:       ErrInvalidMnemonic,
+	},
+	{
+		name: "4. Basic sharing 2-of-3 (128 bits)",
+		mnemonics: []string{
+			"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed",
+			"shadow pistol academic acid actress prayer class unknown daughter sweater depict flip twice unkind craft early superior advocate guest smoking",
+		},
+		secret: "b43ceb7e57a0ea8766221624d01b0864",
+	},
+	{
+		name:      "5. Basic sharing 2-of-3 (128 bits)",
+		mnemonics: []string{"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed"},
+		err:       ErrInsufficientShares,
+	},
+	{
+		name: "6. Mnemonics with different identifiers (128 bits)",
+		mnemonics: []string{
+			"adequate smoking academic acid debut wine petition glen cluster slow rhyme slow simple epidemic rumor junk tracks treat olympic tolerate",
+			"adequate stay academic agency agency formal party ting frequent learn upstairs remember smear leaf damage anatomy ladle market hush corner",
+		},
+		err: ErrMismatchedShares,
+	},
+	{
+		name: "7. Mnemonics with different iteration exponents (128 bits)",
+		mnemonics: []string{
+			"peasant leaves academic acid desert exact olympic math alive axle trial tackle drug deny decent smear dominant desert bucket remind",
+			"peasant leader academic agency cultural blessing percent network envelope medal junk primary human pumps jacket fragment payroll ticket evoke voice",
+		},
+		err: ErrMismatchedShares,
+	},
+
+	{
+		name: "9. Mnemonics with mismatching group counts (128 bits)",
+		mnemonics: []string{
+			"average senior academic leaf broken teacher expect surface hour capture obesity desire negative dynamic dominant pistol mineral mailman iris aide",
+			"average senior academic agency curious pants blimp spew clothes slice script dress wrap firm shaft regular slavery negative theater roster",
+		},
+		err: ErrMismatchedShares,
+	},
+	{
+		name: "10. Mnemonics with greater group threshold than group counts (128 bits)",
+		mnemonics: []string{
+			"music husband acrobat acid artist finance center either graduate swimming object bike medical clothes station aspect spider maiden bulb welcome",
+			"music husband acrobat agency advance hunting bike corner density careful material civil evil tactics remind hawk discuss hobo voice rainbow",
+		},
+		err: ErrInvalidMnemonic,
+	},
+
+	{
+		name: "12. Mnemonics with mismatching member thresholds (128 bits)",
+		mnemonics: []string{
+			"hour painting academic academic device formal evoke guitar random modern justice filter withdraw trouble identify mailman insect general cover oven",
+			"hour painting academic agency artist again daisy capital beaver fiber much enjoy suitable symbolic identify photo editor romp float echo",
+		},
+		err: ErrMismatchedShares,
+	},
+	{
+		name: "13. Mnemonics giving an invalid digest (128 bits)",
+		mnemonics: []string{
+			"guilt walnut academic acid deliver remove equip listen vampire tactics nylon rhythm failure husband fatigue alive blind enemy teaspoon rebound",
+			"guilt walnut academic agency brave hamster hobo declare herd taste alpha slim criminal mild arcade formal romp branch pink ambition",
+		},
+		err: ErrInvalidDigest,
+	},
+	{
+		name:      "14. Insufficient number of groups (128 bits, case 1)",
+		mnemonics: []string{"eraser senior beard romp adorn nuclear spill corner cradle style ancient family general leader ambition exchange unusual garlic promise voice"},
+		err:       ErrInsufficientShares,
+	},
+	{
+		name: "15. Insufficient number of groups (128 bits, case 2)",
+		mnemonics: []string{
+			"eraser senior ceramic snake clay various huge numb argue hesitate auction category timber browser greatest hanger petition script leaf pickup",
+			"eraser senior ceramic shaft dynamic become junior wrist silver peasant force math alto coal amazing segment yelp velvet image paces",
+		},
+		err: ErrInsufficientShares,
+	},
+	{
+		name: "16. Threshold number of groups, but insufficient number of members in one group (128 bits)",
+		mnemonics: []string{
+			"eraser senior decision shadow artist work morning estate greatest pipeline plan ting petition forget hormone flexible general goat admit surface",
+			"eraser senior beard romp adorn nuclear spill corner cradle style ancient family general leader ambition exchange unusual garlic promise voice",
+		},
+		err: ErrInsufficientShares,
+	},
+	{
+		// The shares of vectors 14 to 19: groups 0 and 1 need one member
+		// share, group 2 three and group 3 two.
+		name: "Threshold number of groups and members in each group (128 bits)",
+		mnemonics: []string{
+			"eraser senior decision roster beard treat identify grumpy salt index fake aviation theater cubic bike cause research dragon emphasis counter",
+			"eraser senior ceramic snake clay various huge numb argue hesitate auction category timber browser greatest hanger petition script leaf pickup",
+			"eraser senior decision shadow artist work morning estate greatest pipeline plan ting petition forget hormone flexible general goat admit surface",
+			"eraser senior beard romp adorn nuclear spill corner cradle style ancient family general leader ambition exchange unusual garlic promise voice",
+		},
+		secret: "7c3397a292a5941682d7a4ae2d898d11",
+	},
+
+	{
+		name: "19. Threshold number of groups and members in each group (128 bits, case 3)",
+		mnemonics: []string{
+			"eraser senior beard romp adorn nuclear spill corner cradle style ancient family general leader ambition exchange unusual garlic promise voice",
+			"eraser senior acrobat romp bishop medical gesture pumps secret alive ultimate quarter priest subject class dictate spew material endless market",
+		},
+		secret: "7c3397a292a5941682d7a4ae2d898d11",
+	},
+	{
+		name:      "21. Valid mnemonic without sharing (256 bits)",
+		mnemonics: []string{"theory painting academic academic armed sweater year military elder discuss acne wildlife boring employer fused large satoshi bundle carbon diagnose anatomy hamster leaves tracks paces beyond phantom capital marvel lips brave detect luck"},
+		secret:    "989baf9dcaad5b10ca33dfd8cc75e42477025dce88ae83e75a230086a0e00e92",
+	},
+	{
+		name:      "41. Valid extendable mnemonic without sharing (128 bits)",
+		mnemonics: []string{"testify swimming academic academic column loyalty smear include exotic bedroom exotic wrist lobe cover grief golden smart junior estimate learn"},
+		secret:    "1679b4516e0ee5954351d288a838f45e",
+	},
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range vectors {
+		secret, err := CombineMnemonics(v.mnemonics, vectorPassphrase)
+		if v.err != nil {
+			if !errors.Is(err, v.err) {
+				t.Errorf("%s: got error %v, want %v", v.name, err, v.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", v.name, err)
+			continue
+		}
+		if hex.EncodeToString(secret) != v.secret {
+			t.Errorf("%s: got master secret %x, want %s", v.name, secret, v.secret)
+		}
+	}
+}
+
+func TestGenerateMnemonics(t *testing.T) {
+	secret128, _ := hex.DecodeString("bb54aac4b89dc868ba37d9cc21b2cece")
+	secret256, _ := hex.DecodeString("989baf9dcaad5b10ca33dfd8cc75e42477025dce88ae83e75a230086a0e00e92")
+
+	tests := []struct {
+		name           string
+		groupThreshold int
+		groups         []Group
+		secret         []byte
+	}{
+		{"single share", 1, []Group{{1, 1}}, secret128},
+		{"3 of 5", 1, []Group{{3, 5}}, secret128},
+		{"16 of 16", 1, []Group{{16, 16}}, secret128},
+		{"2 of 3 groups", 2, []Group{{1, 1}, {2, 3}, {3, 5}}, secret128},
+		{"256 bits", 2, []Group{{2, 3}, {2, 3}}, secret256},
+	}
+	for _, tc := range tests {
+		mnemonics, err := GenerateMnemonics(tc.groupThreshold, tc.groups, tc.secret, vectorPassphrase)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if len(mnemonics) != len(tc.groups) {
+			t.Fatalf("%s: got %d groups, want %d", tc.name, len(mnemonics), len(tc.groups))
+		}
+
+		// The threshold of shares of the last groups recover the secret.
+		var shares []string
+		for i := len(tc.groups) - tc.groupThreshold; i < len(tc.groups); i++ {
+			if len(mnemonics[i]) != tc.groups[i].MemberCount {
+				t.Fatalf("%s: got %d shares in group %d, want %d", tc.name, len(mnemonics[i]), i, tc.groups[i].MemberCount)
+			}
+			group := mnemonics[i]
+			shares = append(shares, group[len(group)-tc.groups[i].MemberThreshold:]...)
+		}
+		secret, err := CombineMnemonics(shares, vectorPassphrase)
+		if err != nil {
+			t.Fatalf("%s: unexpected error combining the shares: %v", tc.name, err)
+		}
+		if !bytes.Equal(secret, tc.secret) {
+			t.Errorf("%s: got master secret %x, want %x", tc.name, secret, tc.secret)
+		}
+
+		// Another passphrase decrypts another secret, there is no way to
+		// tell that the passphrase is wrong.
+		secret, err = CombineMnemonics(shares, []byte("other"))
+		if err != nil || bytes.Equal(secret, tc.secret) {
+			t.Errorf("%s: got master secret %x (%v) with another passphrase", tc.name, secret, err)
+		}
+
+		if _, err = CombineMnemonics(shares[1:], vectorPassphrase); !errors.Is(err, ErrInsufficientShares) {
+			t.Errorf("%s: got error %v with a missing share, want %v", tc.name, err, ErrInsufficientShares)
+		}
+
+		for _, share := range shares {
+			decoded, err := DecodeMnemonic(share)
+			if err != nil {
+				t.Fatalf("%s: unexpected error decoding a share: %v", tc.name, err)
+			}
+			if !decoded.Extendable || decoded.Mnemonic() != share {
+				t.Errorf("%s: share %q is encoded as %q", tc.name, share, decoded.Mnemonic())
+			}
+		}
+	}
+}
+
+func TestGenerateMnemonicsInvalid(t *testing.T) {
+	secret := make([]byte, 16)
+	tests := []struct {
+		name           string
+		groupThreshold int
+		groups         []Group
+		secret         []byte
+	}{
+		{"short secret", 1, []Group{{1, 1}}, make([]byte, 14)},
+		{"odd secret length", 1, []Group{{1, 1}}, make([]byte, 17)},
+		{"no groups", 1, nil, secret},
+		{"group threshold above the group count", 2, []Group{{1, 1}}, secret},
+		{"zero group threshold", 0, []Group{{1, 1}}, secret},
+		{"member threshold above the member count", 1, []Group{{3, 2}}, secret},
+		{"too many members", 1, []Group{{2, 17}}, secret},
+		{"threshold of 1 with several members", 1, []Group{{1, 2}}, secret},
+	}
+	for _, tc := range tests {
+		if _, err := GenerateMnemonics(tc.groupThreshold, tc.groups, tc.secret, nil); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
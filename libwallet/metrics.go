@@ -0,0 +1,342 @@
+package libwallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/client/comms"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/ext"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	metricsIdentifier = "metrics"
+	metricsPath       = "/metrics"
+)
+
+// walletMetrics holds the metrics of a wallet that are fed by its listeners.
+type walletMetrics struct {
+	syncStage      utils.SyncStage
+	syncProgress   int32
+	rescanProgress int32
+	syncErrors     uint64
+	lastBlockTime  time.Time
+}
+
+// metricsCollector holds the metrics fed by the wallet and rate listeners
+// between scrapes.
+type metricsCollector struct {
+	// watchMu serializes adding the listeners.
+	watchMu sync.Mutex
+
+	mu              sync.Mutex
+	includeBalances bool
+	wallets         map[int]*walletMetrics
+	rateUpdated     time.Time
+}
+
+func (c *metricsCollector) wallet(walletID int) *walletMetrics {
+	m, ok := c.wallets[walletID]
+	if !ok {
+		m = &walletMetrics{syncStage: utils.InvalidSyncStage}
+		c.wallets[walletID] = m
+	}
+	return m
+}
+
+// update runs fn on the metrics of the wallet under the collector lock.
+func (c *metricsCollector) update(walletID int, fn func(m *walletMetrics)) {
+	c.mu.Lock()
+	fn(c.wallet(walletID))
+	c.mu.Unlock()
+}
+
+// ServeMetrics serves the sync and app metrics in the Prometheus text format
+// at /metrics on addr until the assets manager shuts down. The balances of the
+// wallets are only exported if includeBalances is true. The endpoint isn't
+// authenticated and should only listen on a loopback address.
+func (mgr *AssetsManager) ServeMetrics(addr string, includeBalances bool) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("unable to listen for metrics on %s: %w", addr, err)
+	}
+
+	mgr.metrics = &metricsCollector{
+		includeBalances: includeBalances,
+		wallets:         make(map[int]*walletMetrics),
+	}
+	mgr.watchMetrics()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(metricsPath, mgr.handleMetrics)
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr.cancelFuncs = append(mgr.cancelFuncs, cancel)
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	go func() {
+		log.Infof("Serving metrics on http://%s%s", listener.Addr(), metricsPath)
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("Metrics server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// watchMetrics adds the metrics listeners to the rate source and the wallets
+// that don't have them yet, i.e. wallets created since the last scrape.
+func (mgr *AssetsManager) watchMetrics() {
+	c := mgr.metrics
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	if mgr.RateSource != nil && !mgr.RateSource.IsRateListenerExist(metricsIdentifier) {
+		_ = mgr.RateSource.AddRateListener(&ext.RateListener{
+			OnRateUpdated: func() {
+				c.mu.Lock()
+				c.rateUpdated = time.Now()
+				c.mu.Unlock()
+			},
+		}, metricsIdentifier)
+	}
+
+	for _, wallet := range mgr.AllWallets() {
+		if wallet.IsNotificationListenerExist(metricsIdentifier) {
+			continue
+		}
+
+		walletID := wallet.GetWalletID()
+		stage := func(stage utils.SyncStage, progress *sharedW.GeneralSyncProgress) {
+			c.update(walletID, func(m *walletMetrics) {
+				m.syncStage = stage
+				if progress != nil {
+					m.syncProgress = progress.TotalSyncProgress
+				}
+			})
+		}
+		syncEnded := func(m *walletMetrics) {
+			m.syncStage = utils.InvalidSyncStage
+		}
+
+		_ = wallet.AddSyncProgressListener(&sharedW.SyncProgressListener{
+			OnSyncStarted: func() {
+				c.update(walletID, func(m *walletMetrics) {
+					m.syncStage = utils.InvalidSyncStage
+					m.syncProgress = 0
+				})
+			},
+			OnCFiltersFetchProgress: func(report *sharedW.CFiltersFetchProgressReport) {
+				stage(utils.CFiltersFetchSyncStage, report.GeneralSyncProgress)
+			},
+			OnHeadersFetchProgress: func(report *sharedW.HeadersFetchProgressReport) {
+				stage(utils.HeadersFetchSyncStage, report.GeneralSyncProgress)
+			},
+			OnAddressDiscoveryProgress: func(report *sharedW.AddressDiscoveryProgressReport) {
+				stage(utils.AddressDiscoverySyncStage, report.GeneralSyncProgress)
+			},
+			OnHeadersRescanProgress: func(report *sharedW.HeadersRescanProgressReport) {
+				stage(utils.HeadersRescanSyncStage, report.GeneralSyncProgress)
+				c.update(walletID, func(m *walletMetrics) {
+					m.rescanProgress = report.RescanProgress
+				})
+			},
+			OnSyncCompleted: func() {
+				c.update(walletID, func(m *walletMetrics) {
+					syncEnded(m)
+					m.syncProgress = 100
+				})
+			},
+			OnSyncCanceled: func(bool) {
+				c.update(walletID, syncEnded)
+			},
+			OnSyncEndedWithError: func(error) {
+				c.update(walletID, func(m *walletMetrics) {
+					syncEnded(m)
+					m.syncErrors++
+				})
+			},
+		}, metricsIdentifier)
+
+		err := wallet.AddTxAndBlockNotificationListener(&sharedW.TxAndBlockNotificationListener{
+			OnBlockAttached: func(walletID int, _ int32) {
+				c.update(walletID, func(m *walletMetrics) {
+					m.lastBlockTime = time.Now()
+				})
+			},
+		}, metricsIdentifier)
+		if err != nil {
+			log.Errorf("Can't listen block notification for %s wallet", wallet.GetWalletName())
+		}
+	}
+}
+
+// metric is a metric family in the Prometheus text format.
+type metric struct {
+	name, help, typ string
+	samples         []sample
+}
+
+type sample struct {
+	labels string
+	value  float64
+}
+
+func (m *metric) add(labels string, value float64) {
+	m.samples = append(m.samples, sample{labels, value})
+}
+
+func (m *metric) write(w io.Writer) {
+	if len(m.samples) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.typ)
+	for _, s := range m.samples {
+		fmt.Fprintf(w, "%s%s %s\n", m.name, s.labels, strconv.FormatFloat(s.value, 'g', -1, 64))
+	}
+}
+
+// metricLabels formats the label pairs of a sample, escaping their values.
+func metricLabels(pairs ...string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	labels := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, pairs[i], escaper.Replace(pairs[i+1])))
+	}
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+func boolMetric(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func unixMetric(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+	return float64(t.Unix())
+}
+
+// dcrWalletActivity is implemented by the DCR wallets that run the account
+// mixer and the ticket buyer.
+type dcrWalletActivity interface {
+	CurrentSyncStage() utils.SyncStage
+	IsAccountMixerActive() bool
+	IsAutoTicketsPurchaseActive() bool
+}
+
+func (mgr *AssetsManager) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	mgr.watchMetrics()
+
+	var (
+		bestHeight     = &metric{name: "cryptopower_wallet_best_block_height", help: "Height of the best block of the wallet.", typ: "gauge"}
+		bestBlockTime  = &metric{name: "cryptopower_wallet_best_block_timestamp_seconds", help: "Timestamp of the best block of the wallet.", typ: "gauge"}
+		lastBlockTime  = &metric{name: "cryptopower_wallet_last_block_attached_timestamp_seconds", help: "Time the wallet last attached a block, 0 if none since startup.", typ: "gauge"}
+		peers          = &metric{name: "cryptopower_wallet_connected_peers", help: "Number of peers the wallet is connected to.", typ: "gauge"}
+		syncing        = &metric{name: "cryptopower_wallet_syncing", help: "Whether the wallet is syncing.", typ: "gauge"}
+		synced         = &metric{name: "cryptopower_wallet_synced", help: "Whether the wallet is synced.", typ: "gauge"}
+		syncStage      = &metric{name: "cryptopower_wallet_sync_stage", help: "Current sync stage: -1 none, 0 cfilters fetch, 1 headers fetch, 2 address discovery, 3 headers rescan.", typ: "gauge"}
+		syncProgress   = &metric{name: "cryptopower_wallet_sync_progress_percent", help: "Progress of the current sync.", typ: "gauge"}
+		syncErrors     = &metric{name: "cryptopower_wallet_sync_errors_total", help: "Number of syncs that ended with an error since startup.", typ: "counter"}
+		rescanning     = &metric{name: "cryptopower_wallet_rescanning", help: "Whether the wallet is rescanning blocks.", typ: "gauge"}
+		rescanProgress = &metric{name: "cryptopower_wallet_rescan_progress_percent", help: "Progress of the last headers rescan.", typ: "gauge"}
+		unmined        = &metric{name: "cryptopower_wallet_unmined_transactions", help: "Number of unmined transactions of the wallet.", typ: "gauge"}
+		balance        = &metric{name: "cryptopower_wallet_balance", help: "Total balance of the wallet in coins.", typ: "gauge"}
+		spendable      = &metric{name: "cryptopower_wallet_spendable_balance", help: "Spendable balance of the wallet in coins.", typ: "gauge"}
+		mixerRunning   = &metric{name: "cryptopower_wallet_account_mixer_running", help: "Whether the account mixer of the DCR wallet is running.", typ: "gauge"}
+		ticketBuyer    = &metric{name: "cryptopower_wallet_ticket_buyer_running", help: "Whether the ticket buyer of the DCR wallet is running.", typ: "gauge"}
+		rateUpdate     = &metric{name: "cryptopower_rate_source_last_update_timestamp_seconds", help: "Time the exchange rates were last updated, 0 if never.", typ: "gauge"}
+		dexInitialized = &metric{name: "cryptopower_dex_initialized", help: "Whether the DEX client is running.", typ: "gauge"}
+		dexConnected   = &metric{name: "cryptopower_dex_server_connected", help: "Whether the DEX client is connected to the server.", typ: "gauge"}
+	)
+
+	c := mgr.metrics
+	wallets := mgr.AllWallets()
+	sort.Slice(wallets, func(i, j int) bool { return wallets[i].GetWalletID() < wallets[j].GetWalletID() })
+	for _, wallet := range wallets {
+		labels := metricLabels("wallet_id", strconv.Itoa(wallet.GetWalletID()),
+			"wallet", wallet.GetWalletName(), "asset", wallet.GetAssetType().String())
+
+		c.mu.Lock()
+		m := *c.wallet(wallet.GetWalletID())
+		c.mu.Unlock()
+
+		stage := m.syncStage
+		if !wallet.IsSyncing() {
+			stage = utils.InvalidSyncStage
+		}
+
+		bestHeight.add(labels, float64(wallet.GetBestBlockHeight()))
+		bestBlockTime.add(labels, float64(wallet.GetBestBlockTimeStamp()))
+		lastBlockTime.add(labels, unixMetric(m.lastBlockTime))
+		peers.add(labels, float64(wallet.ConnectedPeers()))
+		syncing.add(labels, boolMetric(wallet.IsSyncing()))
+		synced.add(labels, boolMetric(wallet.IsSynced()))
+		syncProgress.add(labels, float64(m.syncProgress))
+		syncErrors.add(labels, float64(m.syncErrors))
+		rescanning.add(labels, boolMetric(wallet.IsRescanning()))
+		rescanProgress.add(labels, float64(m.rescanProgress))
+
+		if count, err := wallet.CountTransactions(utils.TxFilterUnmined); err == nil {
+			unmined.add(labels, float64(count))
+		}
+
+		if c.includeBalances {
+			if bal, err := wallet.GetWalletBalance(); err == nil && bal.Total != nil && bal.Spendable != nil {
+				balance.add(labels, bal.Total.ToCoin())
+				spendable.add(labels, bal.Spendable.ToCoin())
+			}
+		}
+
+		if dcrWallet, ok := wallet.(dcrWalletActivity); ok {
+			stage = dcrWallet.CurrentSyncStage()
+			mixerRunning.add(labels, boolMetric(dcrWallet.IsAccountMixerActive()))
+			ticketBuyer.add(labels, boolMetric(dcrWallet.IsAutoTicketsPurchaseActive()))
+		}
+		syncStage.add(labels, float64(stage))
+	}
+
+	if mgr.RateSource != nil {
+		c.mu.Lock()
+		updated := c.rateUpdated
+		c.mu.Unlock()
+		if last := mgr.RateSource.LastUpdate(); last.After(updated) {
+			updated = last
+		}
+		rateUpdate.add(metricLabels("source", mgr.RateSource.Name()), unixMetric(updated))
+	}
+
+	dexInitialized.add("", boolMetric(mgr.DEXCInitialized()))
+	if mgr.DEXCInitialized() {
+		for host, exchange := range mgr.DexClient().Exchanges() {
+			dexConnected.add(metricLabels("host", host), boolMetric(exchange.ConnectionStatus == comms.Connected))
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	for _, m := range []*metric{
+		bestHeight, bestBlockTime, lastBlockTime, peers, syncing, synced, syncStage, syncProgress,
+		syncErrors, rescanning, rescanProgress, unmined, balance, spendable, mixerRunning, ticketBuyer,
+		rateUpdate, dexInitialized, dexConnected,
+	} {
+		m.write(w)
+	}
+}
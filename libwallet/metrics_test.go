@@ -0,0 +1,208 @@
+package libwallet
+
+import (
+	"bufio"
+	"errors"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// metricsTestWallet is a BTC wallet reporting fixed sync state and balances.
+// It keeps the listeners added by the metrics collector for the tests to
+// feed them.
+type metricsTestWallet struct {
+	sharedW.Asset
+	id   int
+	name string
+
+	syncListener  *sharedW.SyncProgressListener
+	blockListener *sharedW.TxAndBlockNotificationListener
+}
+
+func (w *metricsTestWallet) GetWalletID() int                     { return w.id }
+func (w *metricsTestWallet) GetWalletName() string                { return w.name }
+func (w *metricsTestWallet) GetAssetType() utils.AssetType        { return utils.BTCWalletAsset }
+func (w *metricsTestWallet) IsSyncing() bool                      { return true }
+func (w *metricsTestWallet) IsSynced() bool                       { return false }
+func (w *metricsTestWallet) IsRescanning() bool                   { return false }
+func (w *metricsTestWallet) IsWatchingOnlyWallet() bool           { return false }
+func (w *metricsTestWallet) GetBestBlockHeight() int32            { return 2500 }
+func (w *metricsTestWallet) GetBestBlockTimeStamp() int64         { return 1700000000 }
+func (w *metricsTestWallet) ConnectedPeers() int32                { return 8 }
+func (w *metricsTestWallet) CountTransactions(int32) (int, error) { return 3, nil }
+
+func (w *metricsTestWallet) GetWalletBalance() (*sharedW.Balance, error) {
+	return &sharedW.Balance{Total: btc.Amount(150000000), Spendable: btc.Amount(50000000)}, nil
+}
+
+func (w *metricsTestWallet) IsNotificationListenerExist(string) bool {
+	return w.blockListener != nil
+}
+
+func (w *metricsTestWallet) AddSyncProgressListener(listener *sharedW.SyncProgressListener, _ string) error {
+	w.syncListener = listener
+	return nil
+}
+
+func (w *metricsTestWallet) AddTxAndBlockNotificationListener(listener *sharedW.TxAndBlockNotificationListener, _ string) error {
+	w.blockListener = listener
+	return nil
+}
+
+func TestMetricLabels(t *testing.T) {
+	tests := []struct {
+		pairs  []string
+		labels string
+	}{
+		{[]string{"wallet_id", "1"}, `{wallet_id="1"}`},
+		{[]string{"wallet", "main", "asset", "BTC"}, `{wallet="main",asset="BTC"}`},
+		{[]string{"wallet", `my "main" \ wallet` + "\n"}, `{wallet="my \"main\" \\ wallet\n"}`},
+		// A label without value is dropped.
+		{[]string{"wallet", "main", "asset"}, `{wallet="main"}`},
+	}
+	for _, tc := range tests {
+		if labels := metricLabels(tc.pairs...); labels != tc.labels {
+			t.Errorf("%q: got labels %s, want %s", tc.pairs, labels, tc.labels)
+		}
+	}
+}
+
+func TestMetricWrite(t *testing.T) {
+	m := &metric{name: "cryptopower_test", help: "Test metric.", typ: "gauge"}
+	var b strings.Builder
+	m.write(&b)
+	if b.Len() != 0 {
+		t.Fatalf("a metric without samples was written: %q", b.String())
+	}
+
+	m.add(metricLabels("wallet_id", "1"), 2500)
+	m.add(metricLabels("wallet_id", "2"), 0.5)
+	m.add("", 1e21)
+	m.write(&b)
+	want := "# HELP cryptopower_test Test metric.\n" +
+		"# TYPE cryptopower_test gauge\n" +
+		"cryptopower_test{wallet_id=\"1\"} 2500\n" +
+		"cryptopower_test{wallet_id=\"2\"} 0.5\n" +
+		"cryptopower_test 1e+21\n"
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+// sampleLine matches a sample line of the Prometheus text format.
+var sampleLine = regexp.MustCompile(`^([a-z_]+)(\{[a-z_]+="(?:[^"\\]|\\.)*"(?:,[a-z_]+="(?:[^"\\]|\\.)*")*\})? (\S+)$`)
+
+// scrapeMetrics scrapes the metrics of the assets manager and checks that
+// every sample follows the HELP and TYPE lines of its metric. It returns the
+// sample values keyed by the metric name and labels.
+func scrapeMetrics(t *testing.T, mgr *AssetsManager) map[string]string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	mgr.handleMetrics(rec, httptest.NewRequest("GET", metricsPath, nil))
+	if contentType := rec.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain; version=0.0.4") {
+		t.Fatalf("got content type %q", contentType)
+	}
+
+	samples := make(map[string]string)
+	var help, typ string
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "# HELP "):
+			help = strings.Fields(line)[2]
+		case strings.HasPrefix(line, "# TYPE "):
+			fields := strings.Fields(line)
+			if len(fields) != 4 || fields[2] != help || (fields[3] != "gauge" && fields[3] != "counter") {
+				t.Fatalf("invalid TYPE line %q after the HELP of %s", line, help)
+			}
+			typ = fields[2]
+		default:
+			match := sampleLine.FindStringSubmatch(line)
+			if match == nil {
+				t.Fatalf("invalid sample line %q", line)
+			}
+			if match[1] != typ {
+				t.Fatalf("sample %q doesn't follow the HELP and TYPE of its metric", line)
+			}
+			samples[match[1]+match[2]] = match[3]
+		}
+	}
+	return samples
+}
+
+func TestHandleMetrics(t *testing.T) {
+	mgr := newTestAssetsManager(t)
+	wallet := &metricsTestWallet{id: 1000, name: `cold "storage"`}
+	mgr.Assets.Wallets[utils.BTCWalletAsset][wallet.id] = wallet
+	t.Cleanup(func() { delete(mgr.Assets.Wallets[utils.BTCWalletAsset], wallet.id) })
+	mgr.metrics = &metricsCollector{includeBalances: true, wallets: make(map[int]*walletMetrics)}
+
+	labels := `{wallet_id="1000",wallet="cold \"storage\"",asset="BTC"}`
+	samples := scrapeMetrics(t, mgr)
+	if wallet.syncListener == nil || wallet.blockListener == nil {
+		t.Fatal("the metrics listeners were not added to the wallet")
+	}
+	want := map[string]string{
+		"cryptopower_wallet_best_block_height" + labels:            "2500",
+		"cryptopower_wallet_best_block_timestamp_seconds" + labels: "1.7e+09",
+		"cryptopower_wallet_connected_peers" + labels:              "8",
+		"cryptopower_wallet_syncing" + labels:                      "1",
+		"cryptopower_wallet_synced" + labels:                       "0",
+		"cryptopower_wallet_sync_stage" + labels:                   "-1",
+		"cryptopower_wallet_unmined_transactions" + labels:         "3",
+		"cryptopower_wallet_balance" + labels:                      "1.5",
+		"cryptopower_wallet_spendable_balance" + labels:            "0.5",
+		"cryptopower_dex_initialized":                              "0",
+	}
+	for key, value := range want {
+		if samples[key] != value {
+			t.Errorf("%s: got %q, want %q", key, samples[key], value)
+		}
+	}
+	// Only the DCR wallets run the mixer and the ticket buyer.
+	if _, ok := samples["cryptopower_wallet_account_mixer_running"+labels]; ok {
+		t.Error("got the mixer state of a BTC wallet")
+	}
+
+	// The listeners feed the sync stage, progress and errors.
+	wallet.syncListener.OnHeadersRescanProgress(&sharedW.HeadersRescanProgressReport{
+		GeneralSyncProgress: &sharedW.GeneralSyncProgress{TotalSyncProgress: 80},
+		RescanProgress:      40,
+	})
+	wallet.blockListener.OnBlockAttached(wallet.id, 2501)
+	samples = scrapeMetrics(t, mgr)
+	want = map[string]string{
+		"cryptopower_wallet_sync_stage" + labels:              "3",
+		"cryptopower_wallet_sync_progress_percent" + labels:   "80",
+		"cryptopower_wallet_rescan_progress_percent" + labels: "40",
+		"cryptopower_wallet_sync_errors_total" + labels:       "0",
+	}
+	for key, value := range want {
+		if samples[key] != value {
+			t.Errorf("%s: got %q, want %q", key, samples[key], value)
+		}
+	}
+	if samples["cryptopower_wallet_last_block_attached_timestamp_seconds"+labels] == "0" {
+		t.Error("the attached block wasn't recorded")
+	}
+
+	wallet.syncListener.OnSyncEndedWithError(errors.New("sync failed"))
+	samples = scrapeMetrics(t, mgr)
+	if samples["cryptopower_wallet_sync_errors_total"+labels] != "1" || samples["cryptopower_wallet_sync_stage"+labels] != "-1" {
+		t.Errorf("got %s sync errors in stage %s after a failed sync, want 1 in stage -1",
+			samples["cryptopower_wallet_sync_errors_total"+labels], samples["cryptopower_wallet_sync_stage"+labels])
+	}
+
+	// The balances are only exported when enabled.
+	mgr.metrics.includeBalances = false
+	if samples = scrapeMetrics(t, mgr); samples["cryptopower_wallet_balance"+labels] != "" {
+		t.Error("the balance was exported")
+	}
+}
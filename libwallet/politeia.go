@@ -71,6 +71,28 @@ type ProposalVote struct {
 	politeia.ProposalVote
 }
 
+// The comment types are aliased rather than wrapped because they are nested
+// within each other, wrapping them would hide the inner types.
+type (
+	ProposalComment = politeia.ProposalComment
+	CommentThread   = politeia.CommentThread
+	ProposalAuthor  = politeia.ProposalAuthor
+)
+
+type (
+	ProposalAttachment   = politeia.ProposalAttachment
+	ProposalFilter       = politeia.ProposalFilter
+	ProposalSearchResult = politeia.ProposalSearchResult
+	ProposalVoteOutcome  = politeia.ProposalVoteOutcome
+)
+
+const (
+	VoteOutcomeAny       = politeia.VoteOutcomeAny
+	VoteOutcomeApproved  = politeia.VoteOutcomeApproved
+	VoteOutcomeRejected  = politeia.VoteOutcomeRejected
+	VoteOutcomeUndecided = politeia.VoteOutcomeUndecided
+)
+
 // WrapVote, wraps vote type of politeia.ProposalVote into libwallet.ProposalVote
 func WrapVote(hash, address, bit string) *ProposalVote {
 	return &ProposalVote{
@@ -0,0 +1,245 @@
+package libwallet
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/asdine/storm"
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	proposalRemindersIdentifier = "proposal_vote_reminders"
+	// firedRemindersBucketName holds the reminders that were already sent so
+	// that they are not repeated after a restart.
+	firedRemindersBucketName = "fired_proposal_reminders"
+)
+
+// DefaultProposalVoteReminderThresholds are the number of blocks left in a
+// proposal vote at which reminders are sent by default, roughly three days,
+// one day and two hours on mainnet.
+var DefaultProposalVoteReminderThresholds = []int32{864, 288, 24}
+
+// ProposalVoteReminder is sent when the vote of a proposal is about to end and
+// a DCR wallet still holds tickets that are eligible to vote on it.
+type ProposalVoteReminder struct {
+	Token           string
+	ProposalName    string
+	WalletID        int
+	WalletName      string
+	EligibleTickets int
+	BlocksLeft      int32
+	Threshold       int32
+}
+
+// ProposalVoteReminderListener receives proposal vote reminders.
+type ProposalVoteReminderListener func(reminder *ProposalVoteReminder)
+
+// proposalReminders guards the checks run for proposal vote reminders.
+type proposalReminders struct {
+	mu       sync.Mutex
+	checking bool
+}
+
+// SetProposalVoteRemindersEnabled sets whether reminders are sent before the
+// vote of an active proposal ends.
+func (mgr *AssetsManager) SetProposalVoteRemindersEnabled(enabled bool) {
+	mgr.SaveAppConfigValue(sharedW.ProposalVoteRemindersConfigKey, enabled)
+}
+
+// ProposalVoteRemindersEnabled returns true if proposal vote reminders are
+// enabled. Reminders are enabled by default.
+func (mgr *AssetsManager) ProposalVoteRemindersEnabled() bool {
+	enabled := true
+	mgr.ReadAppConfigValue(sharedW.ProposalVoteRemindersConfigKey, &enabled)
+	return enabled
+}
+
+// SetProposalVoteReminderThresholds sets the number of blocks before the end of
+// a proposal vote at which reminders are sent.
+func (mgr *AssetsManager) SetProposalVoteReminderThresholds(thresholds []int32) error {
+	for _, threshold := range thresholds {
+		if threshold <= 0 {
+			return fmt.Errorf("invalid reminder threshold: %d", threshold)
+		}
+	}
+	mgr.SaveAppConfigValue(sharedW.ProposalVoteReminderThresholdsConfigKey, thresholds)
+	return nil
+}
+
+// ProposalVoteReminderThresholds returns the number of blocks before the end
+// of a proposal vote at which reminders are sent, largest first.
+func (mgr *AssetsManager) ProposalVoteReminderThresholds() []int32 {
+	var thresholds []int32
+	mgr.ReadAppConfigValue(sharedW.ProposalVoteReminderThresholdsConfigKey, &thresholds)
+	if len(thresholds) == 0 {
+		thresholds = append(thresholds, DefaultProposalVoteReminderThresholds...)
+	}
+
+	sort.Slice(thresholds, func(i, j int) bool { return thresholds[i] > thresholds[j] })
+	return thresholds
+}
+
+// WatchProposalVoteDeadlines checks the active proposals for approaching vote
+// deadlines whenever a DCR wallet attaches a block or politeia finishes a sync
+// and calls listen for every wallet that has eligible tickets which have not
+// been voted yet. Each reminder is only sent once per wallet and threshold.
+func (mgr *AssetsManager) WatchProposalVoteDeadlines(listen ProposalVoteReminderListener) {
+	txAndBlockNotificationListener := &sharedW.TxAndBlockNotificationListener{
+		OnBlockAttached: func(_ int, _ int32) {
+			mgr.checkProposalVoteDeadlines(listen)
+		},
+	}
+
+	for _, wallet := range mgr.AllDCRWallets() {
+		if !wallet.IsNotificationListenerExist(proposalRemindersIdentifier) {
+			if err := wallet.AddTxAndBlockNotificationListener(txAndBlockNotificationListener, proposalRemindersIdentifier); err != nil {
+				log.Errorf("Can't listen block notification for %s wallet", wallet.GetWalletName())
+			}
+		}
+	}
+
+	// An error is only returned if the callback is already registered.
+	_ = mgr.Politeia.AddSyncCallback(func(_ string, status utils.ProposalStatus) {
+		if status == utils.ProposalStatusSynced {
+			go mgr.checkProposalVoteDeadlines(listen)
+		}
+	}, proposalRemindersIdentifier)
+
+	go mgr.checkProposalVoteDeadlines(listen)
+}
+
+// RemoveProposalVoteDeadlinesWatch stops the proposal vote deadline checks
+// started by WatchProposalVoteDeadlines.
+func (mgr *AssetsManager) RemoveProposalVoteDeadlinesWatch() {
+	for _, wallet := range mgr.AllDCRWallets() {
+		wallet.RemoveTxAndBlockNotificationListener(proposalRemindersIdentifier)
+	}
+	mgr.Politeia.RemoveSyncCallback(proposalRemindersIdentifier)
+}
+
+// checkProposalVoteDeadlines sends a reminder for every active proposal whose
+// vote ends within one of the configured thresholds, for every synced DCR
+// wallet with eligible tickets. Concurrent calls return immediately.
+func (mgr *AssetsManager) checkProposalVoteDeadlines(listen ProposalVoteReminderListener) {
+	if !mgr.ProposalVoteRemindersEnabled() || !mgr.IsHTTPAPIPrivacyModeOff(utils.GovernanceHTTPAPI) {
+		return
+	}
+
+	mgr.proposalReminders.mu.Lock()
+	if mgr.proposalReminders.checking {
+		mgr.proposalReminders.mu.Unlock()
+		return
+	}
+	mgr.proposalReminders.checking = true
+	mgr.proposalReminders.mu.Unlock()
+
+	defer func() {
+		mgr.proposalReminders.mu.Lock()
+		mgr.proposalReminders.checking = false
+		mgr.proposalReminders.mu.Unlock()
+	}()
+
+	var wallets []*dcr.Asset
+	var bestBlockHeight int32
+	for _, wallet := range mgr.AllDCRWallets() {
+		asset, ok := wallet.(*dcr.Asset)
+		if !ok || !asset.WalletOpened() || !asset.IsSynced() || asset.IsWatchingOnlyWallet() {
+			continue
+		}
+		wallets = append(wallets, asset)
+		if height := asset.GetBestBlockHeight(); height > bestBlockHeight {
+			bestBlockHeight = height
+		}
+	}
+	if len(wallets) == 0 {
+		return
+	}
+
+	proposals, err := mgr.Politeia.GetProposalsRaw(ProposalCategoryActive, 0, 0, true, "")
+	if err != nil {
+		log.Errorf("Error fetching active proposals: %v", err)
+		return
+	}
+
+	thresholds := mgr.ProposalVoteReminderThresholds()
+	for i := range proposals {
+		proposal := &proposals[i]
+		if proposal.EndBlockHeight == 0 {
+			continue
+		}
+
+		blocksLeft := proposal.EndBlockHeight - bestBlockHeight
+		threshold := reminderThreshold(thresholds, blocksLeft)
+		if threshold == 0 {
+			continue
+		}
+
+		for _, wallet := range wallets {
+			key := firedReminderKey(proposal.Token, wallet.ID, threshold)
+			if mgr.reminderFired(key) {
+				continue
+			}
+
+			ctx, cancel := wallet.ShutdownContextWithCancel()
+			voteDetails, err := mgr.Politeia.ProposalVoteDetailsRaw(ctx, wallet.Internal().DCR, proposal.Token)
+			cancel()
+			if err != nil {
+				log.Errorf("[%d] Error fetching vote details of proposal %s: %v", wallet.ID, proposal.Token, err)
+				continue
+			}
+
+			// Ticket eligibility is fixed when the vote starts, a wallet
+			// without eligible tickets will not gain any before the vote ends.
+			if len(voteDetails.EligibleTickets) > 0 {
+				listen(&ProposalVoteReminder{
+					Token:           proposal.Token,
+					ProposalName:    proposal.Name,
+					WalletID:        wallet.ID,
+					WalletName:      wallet.GetWalletName(),
+					EligibleTickets: len(voteDetails.EligibleTickets),
+					BlocksLeft:      blocksLeft,
+					Threshold:       threshold,
+				})
+			}
+
+			if err = mgr.params.DB.Set(firedRemindersBucketName, key, true); err != nil {
+				log.Errorf("Error saving fired proposal reminder: %v", err)
+			}
+		}
+	}
+}
+
+// reminderThreshold returns the smallest threshold that blocksLeft is within,
+// or zero if the vote has ended or no threshold was crossed. Thresholds are
+// expected to be sorted largest first. Larger thresholds that were skipped,
+// e.g. while the app was closed, are not reported.
+func reminderThreshold(thresholds []int32, blocksLeft int32) int32 {
+	if blocksLeft <= 0 {
+		return 0
+	}
+
+	var crossed int32
+	for _, threshold := range thresholds {
+		if blocksLeft <= threshold {
+			crossed = threshold
+		}
+	}
+	return crossed
+}
+
+func firedReminderKey(token string, walletID int, threshold int32) string {
+	return fmt.Sprintf("%s:%d:%d", token, walletID, threshold)
+}
+
+func (mgr *AssetsManager) reminderFired(key string) bool {
+	var fired bool
+	err := mgr.params.DB.Get(firedRemindersBucketName, key, &fired)
+	if err != nil && err != storm.ErrNotFound {
+		log.Errorf("Error reading fired proposal reminder: %v", err)
+	}
+	return fired
+}
@@ -0,0 +1,98 @@
+package libwallet
+
+import (
+	"testing"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// newTestAssetsManager returns an assets manager of the simulation network
+// with its data in a temporary directory. It is shut down when the test ends.
+func newTestAssetsManager(t *testing.T) *AssetsManager {
+	t.Helper()
+	dir := t.TempDir()
+	mgr, err := NewAssetsManager(dir, dir, utils.Simulation, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(mgr.Shutdown)
+	return mgr
+}
+
+func TestReminderThreshold(t *testing.T) {
+	thresholds := []int32{864, 288, 24}
+	tests := []struct {
+		blocksLeft int32
+		want       int32
+	}{
+		{1000, 0},
+		{865, 0},
+		{864, 864},
+		{500, 864},
+		{288, 288},
+		{25, 288},
+		{24, 24},
+		{1, 24},
+		{0, 0},
+		{-5, 0},
+	}
+	for _, tc := range tests {
+		if got := reminderThreshold(thresholds, tc.blocksLeft); got != tc.want {
+			t.Errorf("%d blocks left: got threshold %d, want %d", tc.blocksLeft, got, tc.want)
+		}
+	}
+}
+
+func TestProposalVoteReminderThresholds(t *testing.T) {
+	mgr := newTestAssetsManager(t)
+
+	got := mgr.ProposalVoteReminderThresholds()
+	if len(got) != len(DefaultProposalVoteReminderThresholds) {
+		t.Fatalf("got default thresholds %v, want %v", got, DefaultProposalVoteReminderThresholds)
+	}
+
+	if err := mgr.SetProposalVoteReminderThresholds([]int32{10, 0}); err == nil {
+		t.Fatal("a zero threshold was accepted")
+	}
+
+	if err := mgr.SetProposalVoteReminderThresholds([]int32{10, 100, 50}); err != nil {
+		t.Fatal(err)
+	}
+	got = mgr.ProposalVoteReminderThresholds()
+	want := []int32{100, 50, 10}
+	if len(got) != len(want) {
+		t.Fatalf("got thresholds %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got thresholds %v, want %v", got, want)
+		}
+	}
+
+	if !mgr.ProposalVoteRemindersEnabled() {
+		t.Error("reminders are disabled by default")
+	}
+	mgr.SetProposalVoteRemindersEnabled(false)
+	if mgr.ProposalVoteRemindersEnabled() {
+		t.Error("reminders are enabled after being disabled")
+	}
+}
+
+func TestReminderFired(t *testing.T) {
+	mgr := newTestAssetsManager(t)
+
+	key := firedReminderKey("token", 1, 288)
+	if mgr.reminderFired(key) {
+		t.Fatal("a reminder was fired before being saved")
+	}
+	if err := mgr.params.DB.Set(firedRemindersBucketName, key, true); err != nil {
+		t.Fatal(err)
+	}
+	if !mgr.reminderFired(key) {
+		t.Fatal("a saved reminder was not fired")
+	}
+	// Reminders are tracked per wallet and threshold.
+	if mgr.reminderFired(firedReminderKey("token", 2, 288)) || mgr.reminderFired(firedReminderKey("token", 1, 24)) {
+		t.Fatal("a reminder of another wallet or threshold was fired")
+	}
+}
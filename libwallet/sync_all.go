@@ -0,0 +1,217 @@
+package libwallet
+
+import (
+	"sync"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const aggregateSyncIdentifier = "aggregate_sync"
+
+// AggregateSyncProgress is the sync progress of all the wallets combined.
+type AggregateSyncProgress struct {
+	// SyncingWallets and SyncedWallets count the wallets syncing and synced.
+	// Wallets with sync off aren't part of the progress.
+	SyncingWallets int
+	SyncedWallets  int
+	// TotalSyncProgress is the average sync progress of the syncing and
+	// synced wallets, in percent.
+	TotalSyncProgress int32
+	// TotalTimeRemaining is the longest time remaining of the syncing
+	// wallets.
+	TotalTimeRemaining time.Duration
+	// WalletsProgress is the sync progress of each syncing or synced wallet,
+	// keyed by wallet ID.
+	WalletsProgress map[int]int32
+}
+
+// AllSynced returns true if all the wallets with sync on are synced.
+func (p *AggregateSyncProgress) AllSynced() bool {
+	return p.SyncingWallets == 0 && p.SyncedWallets > 0
+}
+
+// aggregateSync combines the sync progress reported by the wallets for the
+// aggregate sync progress listeners.
+type aggregateSync struct {
+	// watchMu serializes adding the wallet listeners.
+	watchMu sync.Mutex
+
+	mu        sync.Mutex
+	listeners map[string]func(*AggregateSyncProgress)
+	wallets   map[int]*sharedW.GeneralSyncProgress
+}
+
+// SyncAllWallets requests the sync of every wallet with auto sync on that
+// isn't connected yet, through RequestSync so the sync policy applies.
+// Restored wallets that must be unlocked to resume account discovery, and
+// wallets syncing with a full node whose RPC password is still encrypted, are
+// returned for the caller to unlock and sync.
+func (mgr *AssetsManager) SyncAllWallets() (needUnlock []sharedW.Asset) {
+	for _, wallet := range mgr.AllWallets() {
+		if !wallet.ReadBoolConfigValueForKey(sharedW.AutoSyncConfigKey, false) || wallet.IsConnectedToNetwork() {
+			continue
+		}
+
+		if NeedsUnlockToSync(wallet) {
+			needUnlock = append(needUnlock, wallet)
+			continue
+		}
+
+		mgr.RequestSync(wallet)
+	}
+	return needUnlock
+}
+
+// NeedsUnlockToSync returns true if the wallet must be unlocked before it
+// syncs: restored wallets resume account discovery once unlocked, and wallets
+// syncing with a full node decrypt its RPC password, or ask for it again if
+// they are watching only.
+func NeedsUnlockToSync(wallet sharedW.Asset) bool {
+	if wallet.ChainBackend() == sharedW.RPCBackend && wallet.RPCPassLocked() {
+		return true
+	}
+	return !wallet.ContainsDiscoveredAccounts() && wallet.IsLocked() && !wallet.IsWatchingOnlyWallet()
+}
+
+// AddAggregateSyncProgressListener registers listener to receive the combined
+// sync progress of all the wallets whenever the sync of any of them moves on.
+func (mgr *AssetsManager) AddAggregateSyncProgressListener(listener func(*AggregateSyncProgress), uniqueIdentifier string) error {
+	s := &mgr.aggregateSync
+	s.mu.Lock()
+	if _, ok := s.listeners[uniqueIdentifier]; ok {
+		s.mu.Unlock()
+		return errors.New(utils.ErrListenerAlreadyExist)
+	}
+	if s.listeners == nil {
+		s.listeners = make(map[string]func(*AggregateSyncProgress))
+		s.wallets = make(map[int]*sharedW.GeneralSyncProgress)
+	}
+	s.listeners[uniqueIdentifier] = listener
+	s.mu.Unlock()
+
+	mgr.watchAggregateSync()
+	return nil
+}
+
+// RemoveAggregateSyncProgressListener unregisters an aggregate sync progress
+// listener.
+func (mgr *AssetsManager) RemoveAggregateSyncProgressListener(uniqueIdentifier string) {
+	mgr.aggregateSync.mu.Lock()
+	delete(mgr.aggregateSync.listeners, uniqueIdentifier)
+	mgr.aggregateSync.mu.Unlock()
+}
+
+// AggregateSyncProgress returns the current sync progress of all the wallets
+// combined.
+func (mgr *AssetsManager) AggregateSyncProgress() *AggregateSyncProgress {
+	s := &mgr.aggregateSync
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	progress := &AggregateSyncProgress{WalletsProgress: make(map[int]int32)}
+	var totalProgress int32
+	for _, wallet := range mgr.AllWallets() {
+		walletID := wallet.GetWalletID()
+		switch {
+		case wallet.IsSynced() && !wallet.IsRescanning():
+			progress.SyncedWallets++
+			progress.WalletsProgress[walletID] = 100
+		case wallet.IsSyncing() || wallet.IsRescanning():
+			progress.SyncingWallets++
+			var walletProgress int32
+			if p := s.wallets[walletID]; p != nil {
+				walletProgress = p.TotalSyncProgress
+				if p.TotalTimeRemaining > progress.TotalTimeRemaining {
+					progress.TotalTimeRemaining = p.TotalTimeRemaining
+				}
+			}
+			progress.WalletsProgress[walletID] = walletProgress
+		default:
+			continue
+		}
+		totalProgress += progress.WalletsProgress[walletID]
+	}
+
+	if n := progress.SyncingWallets + progress.SyncedWallets; n > 0 {
+		progress.TotalSyncProgress = totalProgress / int32(n)
+	}
+	return progress
+}
+
+// watchAggregateSync adds the listeners feeding the aggregate sync progress
+// to the wallets that don't have them yet. It's called again whenever a sync
+// is requested so that wallets created later are included.
+func (mgr *AssetsManager) watchAggregateSync() {
+	s := &mgr.aggregateSync
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+
+	s.mu.Lock()
+	listening := len(s.listeners) > 0
+	s.mu.Unlock()
+	if !listening {
+		return
+	}
+
+	for _, wallet := range mgr.AllWallets() {
+		walletID := wallet.GetWalletID()
+		// The wallets call their listeners while holding their sync data
+		// lock, publish from another goroutine as the aggregate progress
+		// reads the wallets sync state.
+		progressed := func(progress *sharedW.GeneralSyncProgress) {
+			if progress == nil {
+				return
+			}
+			s.mu.Lock()
+			s.wallets[walletID] = progress
+			s.mu.Unlock()
+			go mgr.publishAggregateSyncProgress()
+		}
+		ended := func() {
+			s.mu.Lock()
+			delete(s.wallets, walletID)
+			s.mu.Unlock()
+			go mgr.publishAggregateSyncProgress()
+		}
+
+		// An error means the listener was added before.
+		_ = wallet.AddSyncProgressListener(&sharedW.SyncProgressListener{
+			OnSyncStarted: func() {
+				progressed(&sharedW.GeneralSyncProgress{})
+			},
+			OnCFiltersFetchProgress: func(report *sharedW.CFiltersFetchProgressReport) {
+				progressed(report.GeneralSyncProgress)
+			},
+			OnHeadersFetchProgress: func(report *sharedW.HeadersFetchProgressReport) {
+				progressed(report.GeneralSyncProgress)
+			},
+			OnAddressDiscoveryProgress: func(report *sharedW.AddressDiscoveryProgressReport) {
+				progressed(report.GeneralSyncProgress)
+			},
+			OnHeadersRescanProgress: func(report *sharedW.HeadersRescanProgressReport) {
+				progressed(report.GeneralSyncProgress)
+			},
+			OnSyncCompleted:      ended,
+			OnSyncCanceled:       func(bool) { ended() },
+			OnSyncEndedWithError: func(error) { ended() },
+		}, aggregateSyncIdentifier)
+	}
+}
+
+func (mgr *AssetsManager) publishAggregateSyncProgress() {
+	progress := mgr.AggregateSyncProgress()
+
+	mgr.aggregateSync.mu.Lock()
+	listeners := make([]func(*AggregateSyncProgress), 0, len(mgr.aggregateSync.listeners))
+	for _, listener := range mgr.aggregateSync.listeners {
+		listeners = append(listeners, listener)
+	}
+	mgr.aggregateSync.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(progress)
+	}
+}
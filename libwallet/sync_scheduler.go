@@ -0,0 +1,277 @@
+package libwallet
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/crypto-power/cryptopower/appos"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	syncSchedulerIdentifier = "sync_scheduler"
+	// syncSchedulerInterval is how often the sync policy is checked against
+	// the time of day and the network connection.
+	syncSchedulerInterval = 30 * time.Second
+)
+
+// SyncPolicy restricts when wallets sync and how many sync at once. The zero
+// value lets all wallets sync at any time.
+type SyncPolicy struct {
+	// StartHour and EndHour limit syncing to the hours from StartHour up to
+	// EndHour, in local time and wrapping around midnight. Wallets sync at
+	// any hour if both are equal.
+	StartHour int `json:"start_hour"`
+	EndHour   int `json:"end_hour"`
+	// PauseOnMeteredConnection pauses syncing while the device is on a
+	// metered connection, e.g. cellular data. It has no effect on the
+	// platforms that can't tell, see AppOS.CanDetectMeteredConnection.
+	PauseOnMeteredConnection bool `json:"pause_on_metered_connection"`
+	// MaxConcurrentSyncs is how many wallets may sync at the same time, zero
+	// for no limit. Synced wallets don't count towards the limit.
+	MaxConcurrentSyncs int `json:"max_concurrent_syncs"`
+}
+
+// HasSyncHours returns true if syncing is limited to some hours of the day.
+func (p *SyncPolicy) HasSyncHours() bool {
+	return p.StartHour != p.EndHour
+}
+
+// allowsSyncAt returns true if the sync hours include t.
+func (p *SyncPolicy) allowsSyncAt(t time.Time) bool {
+	if !p.HasSyncHours() {
+		return true
+	}
+	hour := t.Hour()
+	if p.StartHour < p.EndHour {
+		return hour >= p.StartHour && hour < p.EndHour
+	}
+	return hour >= p.StartHour || hour < p.EndHour
+}
+
+type walletSyncState int
+
+const (
+	syncWaiting walletSyncState = iota
+	syncRunning
+	syncDone
+)
+
+// syncScheduler starts the syncs requested through RequestSync as the sync
+// policy allows.
+type syncScheduler struct {
+	mu sync.Mutex
+	// requested holds the wallets to keep synced, in the order their sync
+	// was requested.
+	requested []int
+	states    map[int]walletSyncState
+	wake      chan struct{}
+}
+
+// SetSyncPolicy saves the sync policy and applies it to the wallets synced
+// through RequestSync.
+func (mgr *AssetsManager) SetSyncPolicy(policy SyncPolicy) error {
+	if policy.StartHour < 0 || policy.StartHour > 23 || policy.EndHour < 0 || policy.EndHour > 23 {
+		return fmt.Errorf("invalid sync hours: %d-%d", policy.StartHour, policy.EndHour)
+	}
+	if policy.MaxConcurrentSyncs < 0 {
+		return fmt.Errorf("invalid number of concurrent syncs: %d", policy.MaxConcurrentSyncs)
+	}
+	mgr.SaveAppConfigValue(sharedW.SyncPolicyConfigKey, policy)
+	mgr.wakeSyncScheduler()
+	return nil
+}
+
+// SyncPolicy returns the saved sync policy.
+func (mgr *AssetsManager) SyncPolicy() SyncPolicy {
+	var policy SyncPolicy
+	mgr.ReadAppConfigValue(sharedW.SyncPolicyConfigKey, &policy)
+	return policy
+}
+
+// SyncPaused returns the reason the sync policy currently holds off syncing,
+// or nil if wallets may sync.
+func (mgr *AssetsManager) SyncPaused() error {
+	policy := mgr.SyncPolicy()
+	return syncPauseReason(&policy)
+}
+
+func syncPauseReason(policy *SyncPolicy) error {
+	if !policy.allowsSyncAt(time.Now()) {
+		return utils.ErrSyncOutsideHours
+	}
+	if policy.PauseOnMeteredConnection && appos.Current().IsMeteredConnection() {
+		return utils.ErrSyncMeteredConnection
+	}
+	return nil
+}
+
+// RequestSync queues the wallet to be synced as soon as the sync policy
+// allows, and keeps it synced until the sync is canceled. Syncs paused by the
+// policy are resumed once it allows syncing again.
+func (mgr *AssetsManager) RequestSync(wallet sharedW.Asset) {
+	s := &mgr.syncScheduler
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.wake == nil {
+		s.states = make(map[int]walletSyncState)
+		s.wake = make(chan struct{}, 1)
+		ctx, cancel := context.WithCancel(context.Background())
+		mgr.cancelFuncs = append(mgr.cancelFuncs, cancel)
+		go mgr.runSyncScheduler(ctx)
+	}
+
+	walletID := wallet.GetWalletID()
+	if _, ok := s.states[walletID]; ok {
+		return
+	}
+	s.requested = append(s.requested, walletID)
+	s.states[walletID] = syncWaiting
+
+	// Start the next sync as soon as one ends.
+	syncEnded := func() { mgr.wakeSyncScheduler() }
+	_ = wallet.AddSyncProgressListener(&sharedW.SyncProgressListener{
+		OnSyncCompleted:      syncEnded,
+		OnSyncCanceled:       func(bool) { syncEnded() },
+		OnSyncEndedWithError: func(error) { syncEnded() },
+	}, syncSchedulerIdentifier)
+	go mgr.watchAggregateSync()
+
+	mgr.wakeSyncScheduler()
+}
+
+// CancelSyncRequest stops keeping the wallet synced. A sync in progress is
+// left for the caller to cancel.
+func (mgr *AssetsManager) CancelSyncRequest(walletID int) {
+	s := &mgr.syncScheduler
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeRequest(walletID)
+
+	if wallet := mgr.WalletWithID(walletID); wallet != nil {
+		wallet.RemoveSyncProgressListener(syncSchedulerIdentifier)
+	}
+}
+
+// IsSyncPending returns true if the wallet is waiting for the sync policy to
+// allow it to sync.
+func (mgr *AssetsManager) IsSyncPending(walletID int) bool {
+	s := &mgr.syncScheduler
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[walletID]
+	return ok && state == syncWaiting
+}
+
+// removeRequest drops the wallet from the requested syncs. The mu lock must
+// be held.
+func (s *syncScheduler) removeRequest(walletID int) {
+	for i, id := range s.requested {
+		if id == walletID {
+			s.requested = append(s.requested[:i], s.requested[i+1:]...)
+			break
+		}
+	}
+	delete(s.states, walletID)
+}
+
+func (mgr *AssetsManager) wakeSyncScheduler() {
+	mgr.syncScheduler.mu.Lock()
+	wake := mgr.syncScheduler.wake
+	mgr.syncScheduler.mu.Unlock()
+	if wake == nil {
+		return
+	}
+
+	select {
+	case wake <- struct{}{}:
+	default: // A check is already due.
+	}
+}
+
+func (mgr *AssetsManager) runSyncScheduler(ctx context.Context) {
+	ticker := time.NewTicker(syncSchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-mgr.syncScheduler.wake:
+		}
+		mgr.scheduleSyncs()
+	}
+}
+
+// scheduleSyncs pauses the requested syncs if the sync policy doesn't allow
+// syncing, otherwise it starts the waiting syncs while the number of wallets
+// syncing is under the limit.
+func (mgr *AssetsManager) scheduleSyncs() {
+	policy := mgr.SyncPolicy()
+	pauseErr := syncPauseReason(&policy)
+
+	s := &mgr.syncScheduler
+	s.mu.Lock()
+	var toPause, toStart []sharedW.Asset
+	var running int
+	for _, walletID := range append([]int(nil), s.requested...) {
+		wallet := mgr.WalletWithID(walletID)
+		if wallet == nil { // deleted
+			s.removeRequest(walletID)
+			continue
+		}
+
+		state := s.states[walletID]
+		active := wallet.IsSyncing() || wallet.IsSynced()
+		switch {
+		case state != syncWaiting && !active:
+			// The sync was canceled by the user or failed.
+			s.removeRequest(walletID)
+			continue
+		case pauseErr != nil:
+			if active {
+				toPause = append(toPause, wallet)
+			}
+			state = syncWaiting
+		case wallet.IsSynced():
+			state = syncDone
+		case active:
+			state = syncRunning
+		}
+		s.states[walletID] = state
+		if state == syncRunning {
+			running++
+		}
+	}
+
+	if pauseErr == nil {
+		for _, walletID := range s.requested {
+			if policy.MaxConcurrentSyncs > 0 && running >= policy.MaxConcurrentSyncs {
+				break
+			}
+			if s.states[walletID] == syncWaiting {
+				toStart = append(toStart, mgr.WalletWithID(walletID))
+				s.states[walletID] = syncRunning
+				running++
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	for _, wallet := range toPause {
+		log.Infof("Pausing the sync of wallet %s: %v", wallet.GetWalletName(), pauseErr)
+		wallet.CancelSync()
+	}
+	for _, wallet := range toStart {
+		log.Infof("Starting the scheduled sync of wallet %s", wallet.GetWalletName())
+		if err := wallet.SpvSync(); err != nil {
+			log.Errorf("Error starting the sync of wallet %s: %v", wallet.GetWalletName(), err)
+			mgr.CancelSyncRequest(wallet.GetWalletID())
+		}
+	}
+}
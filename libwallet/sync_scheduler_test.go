@@ -0,0 +1,220 @@
+package libwallet
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// schedulerTestWallet is a wallet that only records the syncs started and
+// canceled by the sync scheduler.
+type schedulerTestWallet struct {
+	sharedW.Asset
+	id int
+
+	mu      sync.Mutex
+	syncing bool
+	synced  bool
+	syncs   int
+	cancels int
+}
+
+func (w *schedulerTestWallet) GetWalletID() int                  { return w.id }
+func (w *schedulerTestWallet) GetWalletName() string             { return fmt.Sprintf("wallet %d", w.id) }
+func (w *schedulerTestWallet) RemoveSyncProgressListener(string) {}
+
+func (w *schedulerTestWallet) IsSyncing() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.syncing
+}
+
+func (w *schedulerTestWallet) IsSynced() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.synced
+}
+
+func (w *schedulerTestWallet) SpvSync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.syncing = true
+	w.syncs++
+	return nil
+}
+
+func (w *schedulerTestWallet) CancelSync() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.syncing, w.synced = false, false
+	w.cancels++
+}
+
+// setState sets whether the wallet is syncing or synced.
+func (w *schedulerTestWallet) setState(syncing, synced bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.syncing, w.synced = syncing, synced
+}
+
+// newSchedulerTestWallets adds n test wallets to the assets manager and
+// requests their syncs, in order. The scheduler isn't started, the tests run
+// scheduleSyncs.
+func newSchedulerTestWallets(t *testing.T, mgr *AssetsManager, n int) []*schedulerTestWallet {
+	t.Helper()
+	wallets := make([]*schedulerTestWallet, n)
+	s := &mgr.syncScheduler
+	s.states = make(map[int]walletSyncState)
+	for i := range wallets {
+		wallets[i] = &schedulerTestWallet{id: 1000 + i}
+		mgr.Assets.Wallets[utils.DCRWalletAsset][wallets[i].id] = wallets[i]
+		s.requested = append(s.requested, wallets[i].id)
+		s.states[wallets[i].id] = syncWaiting
+	}
+	// The assets manager doesn't shut the test wallets down.
+	t.Cleanup(func() {
+		for _, w := range wallets {
+			delete(mgr.Assets.Wallets[utils.DCRWalletAsset], w.id)
+		}
+	})
+	return wallets
+}
+
+func TestSyncHours(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2024, 1, 1, hour, 30, 0, 0, time.Local)
+	}
+	tests := []struct {
+		start, end int
+		hour       int
+		allowed    bool
+	}{
+		{0, 0, 12, true},
+		{5, 5, 4, true},
+		{1, 6, 0, false},
+		{1, 6, 1, true},
+		{1, 6, 5, true},
+		{1, 6, 6, false},
+		// Wrapping around midnight.
+		{22, 6, 21, false},
+		{22, 6, 22, true},
+		{22, 6, 23, true},
+		{22, 6, 0, true},
+		{22, 6, 5, true},
+		{22, 6, 6, false},
+		{22, 6, 12, false},
+	}
+	for _, tc := range tests {
+		policy := &SyncPolicy{StartHour: tc.start, EndHour: tc.end}
+		if allowed := policy.allowsSyncAt(at(tc.hour)); allowed != tc.allowed {
+			t.Errorf("hours %d-%d at %d:30: got allowed %v, want %v", tc.start, tc.end, tc.hour, allowed, tc.allowed)
+		}
+	}
+}
+
+func TestSetSyncPolicy(t *testing.T) {
+	mgr := newTestAssetsManager(t)
+
+	invalid := []SyncPolicy{
+		{StartHour: -1},
+		{EndHour: 24},
+		{MaxConcurrentSyncs: -1},
+	}
+	for _, policy := range invalid {
+		if err := mgr.SetSyncPolicy(policy); err == nil {
+			t.Errorf("%+v: expected an error", policy)
+		}
+	}
+
+	policy := SyncPolicy{StartHour: 22, EndHour: 6, PauseOnMeteredConnection: true, MaxConcurrentSyncs: 1}
+	if err := mgr.SetSyncPolicy(policy); err != nil {
+		t.Fatal(err)
+	}
+	if saved := mgr.SyncPolicy(); saved != policy {
+		t.Errorf("got policy %+v, want %+v", saved, policy)
+	}
+}
+
+func TestScheduleSyncsOneAtATime(t *testing.T) {
+	mgr := newTestAssetsManager(t)
+	if err := mgr.SetSyncPolicy(SyncPolicy{MaxConcurrentSyncs: 1}); err != nil {
+		t.Fatal(err)
+	}
+	wallets := newSchedulerTestWallets(t, mgr, 3)
+
+	checkSyncing := func(step string, want ...bool) {
+		t.Helper()
+		for i, w := range wallets {
+			if w.IsSyncing() != want[i] {
+				t.Errorf("%s: wallet %d syncing %v, want %v", step, i, w.IsSyncing(), want[i])
+			}
+		}
+	}
+
+	mgr.scheduleSyncs()
+	checkSyncing("first check", true, false, false)
+	if mgr.IsSyncPending(wallets[0].id) || !mgr.IsSyncPending(wallets[1].id) || !mgr.IsSyncPending(wallets[2].id) {
+		t.Error("the waiting syncs are not pending")
+	}
+	mgr.scheduleSyncs()
+	checkSyncing("second check", true, false, false)
+	if wallets[0].syncs != 1 {
+		t.Errorf("the first wallet was synced %d times, want once", wallets[0].syncs)
+	}
+
+	// A synced wallet no longer counts towards the limit.
+	wallets[0].setState(false, true)
+	mgr.scheduleSyncs()
+	checkSyncing("first wallet synced", false, true, false)
+
+	// The request of a failed sync is dropped.
+	wallets[1].setState(false, false)
+	mgr.scheduleSyncs()
+	checkSyncing("second wallet failed", false, false, true)
+	if mgr.IsSyncPending(wallets[1].id) || wallets[1].syncs != 1 {
+		t.Errorf("the failed sync was scheduled again, %d syncs", wallets[1].syncs)
+	}
+}
+
+func TestScheduleSyncsOutsideHours(t *testing.T) {
+	mgr := newTestAssetsManager(t)
+	now := time.Now().Hour()
+	policy := SyncPolicy{StartHour: (now + 1) % 24, EndHour: (now + 2) % 24}
+	if err := mgr.SetSyncPolicy(policy); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.SyncPaused(); !errors.Is(err, utils.ErrSyncOutsideHours) {
+		t.Fatalf("got pause reason %v, want %v", err, utils.ErrSyncOutsideHours)
+	}
+
+	wallets := newSchedulerTestWallets(t, mgr, 2)
+	wallets[0].setState(true, false)
+	mgr.syncScheduler.states[wallets[0].id] = syncRunning
+
+	// The running sync is paused and no sync starts.
+	mgr.scheduleSyncs()
+	for i, w := range wallets {
+		if w.IsSyncing() || !mgr.IsSyncPending(w.id) {
+			t.Errorf("wallet %d: got syncing %v, pending %v outside the sync hours", i, w.IsSyncing(), mgr.IsSyncPending(w.id))
+		}
+	}
+	if wallets[0].cancels != 1 || wallets[1].syncs != 0 {
+		t.Errorf("got %d canceled and %d started syncs, want 1 and 0", wallets[0].cancels, wallets[1].syncs)
+	}
+
+	// Both syncs resume within the sync hours.
+	if err := mgr.SetSyncPolicy(SyncPolicy{}); err != nil {
+		t.Fatal(err)
+	}
+	mgr.scheduleSyncs()
+	for i, w := range wallets {
+		if !w.IsSyncing() {
+			t.Errorf("wallet %d: the sync didn't resume", i)
+		}
+	}
+}
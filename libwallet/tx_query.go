@@ -0,0 +1,58 @@
+package libwallet
+
+import (
+	"sort"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+// WalletTransaction is a transaction returned by a query across wallets, with
+// the ID of the wallet it belongs to.
+type WalletTransaction struct {
+	*sharedW.Transaction
+	WalletID int
+}
+
+// QueryTransactions returns the transactions of every wallet of the query's
+// asset types that match the query, ordered by time. Wallets that are not
+// synced are skipped.
+func (mgr *AssetsManager) QueryTransactions(query *sharedW.TxQuery) ([]*WalletTransaction, error) {
+	// Each wallet returns enough transactions to fill the requested page
+	// once the transactions of all the wallets are merged.
+	walletQuery := *query
+	walletQuery.Offset = 0
+	if query.Limit > 0 {
+		walletQuery.Limit = query.Offset + query.Limit
+	}
+
+	txs := make([]*WalletTransaction, 0)
+	for _, wallet := range mgr.AllWallets() {
+		if !query.HasAssetType(wallet.GetAssetType()) || !wallet.IsSynced() {
+			continue
+		}
+
+		walletTxs, err := sharedW.QueryTransactions(wallet, &walletQuery)
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range walletTxs {
+			txs = append(txs, &WalletTransaction{Transaction: tx, WalletID: wallet.GetWalletID()})
+		}
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		if query.NewestFirst {
+			return txs[i].Timestamp > txs[j].Timestamp
+		}
+		return txs[i].Timestamp < txs[j].Timestamp
+	})
+
+	if query.Offset >= len(txs) {
+		return txs[:0], nil
+	}
+	txs = txs[query.Offset:]
+	if query.Limit > 0 && query.Limit < len(txs) {
+		txs = txs[:query.Limit]
+	}
+	return txs, nil
+}
@@ -0,0 +1,105 @@
+package utils
+
+import "fmt"
+
+// AssetInfo describes an asset that wallets can be created for. Assets are
+// registered with RegisterAsset, DCR, BTC and LTC are registered by this
+// package.
+type AssetInfo struct {
+	Type AssetType
+	// FullName is the name of the asset's network e.g. Bitcoin.
+	FullName string
+	// ChainParams returns the chain parameters of the asset on the network.
+	ChainParams func(NetworkType) (*ChainsParams, error)
+	// Colors is the gradient the wallets of the asset are drawn with.
+	Colors GradientColorScheme
+}
+
+var (
+	registeredAssets = make(map[AssetType]*AssetInfo)
+	// registeredAssetTypes keeps the registration order.
+	registeredAssetTypes []AssetType
+)
+
+func init() {
+	RegisterAsset(&AssetInfo{
+		Type:     DCRWalletAsset,
+		FullName: "Decred",
+		ChainParams: func(netType NetworkType) (*ChainsParams, error) {
+			params, err := DCRChainParams(netType)
+			if err != nil {
+				return nil, err
+			}
+			return &ChainsParams{DCR: params}, nil
+		},
+		Colors: GradientColorScheme{
+			Color1: ColorScheme{R: 41, G: 112, B: 255, O: 0.3}, // rgba(41, 112, 255, 0.3)
+			Blend1: 34.76,                                      // 34.76%
+			Color2: ColorScheme{R: 45, G: 216, B: 163, O: 0.3}, // rgba(45, 216, 163, 0.3)
+			Blend2: 65.88,                                      // 65.88 %
+		},
+	})
+
+	RegisterAsset(&AssetInfo{
+		Type:     BTCWalletAsset,
+		FullName: "Bitcoin",
+		ChainParams: func(netType NetworkType) (*ChainsParams, error) {
+			params, err := BTCChainParams(netType)
+			if err != nil {
+				return nil, err
+			}
+			return &ChainsParams{BTC: params}, nil
+		},
+		Colors: GradientColorScheme{
+			Color1: ColorScheme{R: 196, G: 203, B: 210, O: 0.3}, // rgba(196, 203, 210, 0.3)
+			Blend1: 34.76,                                       // 34.76%
+			Color2: ColorScheme{R: 248, G: 152, B: 36, O: 0.3},  // rgba(248, 152, 36, 0.3)
+			Blend2: 65.88,                                       // 65.88 %
+		},
+	})
+
+	RegisterAsset(&AssetInfo{
+		Type:     LTCWalletAsset,
+		FullName: "Litecoin",
+		ChainParams: func(netType NetworkType) (*ChainsParams, error) {
+			params, err := LTCChainParams(netType)
+			if err != nil {
+				return nil, err
+			}
+			return &ChainsParams{LTC: params}, nil
+		},
+		Colors: GradientColorScheme{
+			Color1: ColorScheme{R: 224, G: 224, B: 224, O: 0.3}, // rgba(224, 224, 224, 0.3)
+			Blend1: 34.76,                                       // 34.76%
+			Color2: ColorScheme{R: 56, G: 115, B: 223, O: 0.3},  // rgba(56, 115, 223, 0.3)
+			Blend2: 65.88,                                       // 65.88 %
+		},
+	})
+}
+
+// RegisterAsset adds an asset to the registered assets. It must be called
+// from an init function, it panics if the asset is registered twice or
+// misses its chain parameters.
+func RegisterAsset(info *AssetInfo) {
+	if _, ok := registeredAssets[info.Type]; ok {
+		panic(fmt.Sprintf("asset %s is already registered", info.Type))
+	}
+	if info.ChainParams == nil {
+		panic(fmt.Sprintf("asset %s has no chain parameters", info.Type))
+	}
+
+	registeredAssets[info.Type] = info
+	registeredAssetTypes = append(registeredAssetTypes, info.Type)
+}
+
+// RegisteredAsset returns the registered asset of the type, nil if there is
+// none.
+func RegisteredAsset(assetType AssetType) *AssetInfo {
+	return registeredAssets[assetType]
+}
+
+// RegisteredAssetTypes returns the types of the registered assets in the order
+// they were registered.
+func RegisteredAssetTypes() []AssetType {
+	return append([]AssetType(nil), registeredAssetTypes...)
+}
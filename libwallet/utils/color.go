@@ -27,25 +27,11 @@ func (c *ColorScheme) NRGBAColor() color.NRGBA {
 	}
 }
 
+// GradientColorSchemes returns the gradients of the registered assets.
 func GradientColorSchemes() map[AssetType]GradientColorScheme {
-	return map[AssetType]GradientColorScheme{
-		BTCWalletAsset: {
-			Color1: ColorScheme{R: 196, G: 203, B: 210, O: 0.3}, // rgba(196, 203, 210, 0.3)
-			Blend1: 34.76,                                       // 34.76%
-			Color2: ColorScheme{R: 248, G: 152, B: 36, O: 0.3},  // rgba(248, 152, 36, 0.3)
-			Blend2: 65.88,                                       // 65.88 %
-		},
-		DCRWalletAsset: {
-			Color1: ColorScheme{R: 41, G: 112, B: 255, O: 0.3}, // rgba(41, 112, 255, 0.3)
-			Blend1: 34.76,                                      // 34.76%
-			Color2: ColorScheme{R: 45, G: 216, B: 163, O: 0.3}, // rgba(45, 216, 163, 0.3)
-			Blend2: 65.88,                                      // 65.88 %
-		},
-		LTCWalletAsset: {
-			Color1: ColorScheme{R: 224, G: 224, B: 224, O: 0.3}, // rgba(224, 224, 224, 0.3)
-			Blend1: 34.76,                                       // 34.76%
-			Color2: ColorScheme{R: 56, G: 115, B: 223, O: 0.3},  // rgba(56, 115, 223, 0.3)
-			Blend2: 65.88,                                       // 65.88 %
-		},
+	schemes := make(map[AssetType]GradientColorScheme, len(registeredAssets))
+	for assetType, info := range registeredAssets {
+		schemes[assetType] = info.Colors
 	}
+	return schemes
 }
@@ -90,16 +90,10 @@ func (str AssetType) ToStringLower() string {
 
 // ToFull returns the full network name of the provided asset.
 func (str AssetType) ToFull() string {
-	switch str {
-	case BTCWalletAsset:
-		return "Bitcoin"
-	case DCRWalletAsset:
-		return "Decred"
-	case LTCWalletAsset:
-		return "Litecoin"
-	default:
-		return "Unknown"
+	if info := RegisteredAsset(str); info != nil {
+		return info.FullName
 	}
+	return "Unknown"
 }
 
 func (str AssetType) String() string {
@@ -61,7 +61,23 @@ var (
 	ErrPeerConnectionRejected  = errors.New("Peer connection rejected")
 	ErrStakingAccountsMissing  = errors.New("Mixing and Unmixing Accounts are not set")
 
+	ErrPeerNotFound          = errors.New("peer not connected")
+	ErrPeerActionUnsupported = errors.New("peer action not supported by the chain backend")
+
+	ErrRPCCertUnsupported = errors.New("only dcrd serves RPC over TLS, bitcoind and litecoind don't use a certificate")
+	ErrRPCPassLocked      = errors.New("unlock the wallet to decrypt the RPC password of the full node")
+
+	ErrSyncOutsideHours      = errors.New("sync paused outside of the sync hours")
+	ErrSyncMeteredConnection = errors.New("sync paused on a metered connection")
+
 	ErrTicketPurchaseAccMissing = errors.New("ticket purchase account is not set")
+
+	ErrSoloVotingDisabled      = errors.New("solo voting is not enabled for this wallet")
+	ErrSoloVotingNotSupported  = errors.New("solo voting requires the dcrd RPC backend, SPV sync does not provide winning ticket notifications")
+	ErrSoloVoterAlreadyRunning = errors.New("solo voter already running")
+
+	ErrSeedPassphraseUnsupported = errors.New("seed passphrases are only supported for BIP-39 seeds")
+	ErrSeedSharesMismatch        = errors.New("the seed shares recover a seed of a different length")
 )
 
 // todo, should update this method to translate more error kinds.
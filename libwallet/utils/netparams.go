@@ -159,26 +159,9 @@ func LTCChainParams(netType NetworkType) (*ltccfg.Params, error) {
 // GetChainParams returns the network parameters of a chain provided its
 // asset type and network type.
 func GetChainParams(assetType AssetType, netType NetworkType) (*ChainsParams, error) {
-	switch assetType {
-	case BTCWalletAsset:
-		params, err := BTCChainParams(netType)
-		if err != nil {
-			return nil, err
-		}
-		return &ChainsParams{BTC: params}, nil
-	case DCRWalletAsset:
-		params, err := DCRChainParams(netType)
-		if err != nil {
-			return nil, err
-		}
-		return &ChainsParams{DCR: params}, nil
-	case LTCWalletAsset:
-		params, err := LTCChainParams(netType)
-		if err != nil {
-			return nil, err
-		}
-		return &ChainsParams{LTC: params}, nil
-	default:
+	info := RegisteredAsset(assetType)
+	if info == nil {
 		return nil, fmt.Errorf("%v: (%v)", ErrAssetUnknown, assetType)
 	}
+	return info.ChainParams(netType)
 }
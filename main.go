@@ -74,6 +74,12 @@ func main() {
 			return nil, err
 		}
 
+		if cfg.MetricsListen != "" {
+			if err := assetsManager.ServeMetrics(cfg.MetricsListen, cfg.MetricsBalances); err != nil {
+				log.Errorf("Error starting metrics server: %v", err)
+			}
+		}
+
 		// if debuglevel is passed at commandLine persist the option.
 		if cfg.DebugLevel != "" {
 			assetsManager.SetLogLevels(cfg.DebugLevel)
@@ -13,7 +13,8 @@ type Icons struct {
 	NavigationArrowForward, ActionCheck, NavigationCancel, NavMoreIcon,
 	DotIcon, ContentClear, DropDownIcon, Cached, ContentRemove, SearchIcon, PlayIcon,
 	ActionSettings, ActionSwapHoriz, ActionSwapVertical, NavigationRefresh, ContentCopy, MenuIcon, CopyIcon, ArrowDropDown, ArrowDropUp,
-	ChevronLeft, ChevronRight, ChevronUp, ChevronDown, DeleteIcon, VisibilityIcon, VisibilityOffIcon *widget.Icon
+	ChevronLeft, ChevronRight, ChevronUp, ChevronDown, DeleteIcon, VisibilityIcon, VisibilityOffIcon, ContactsIcon,
+	LockIcon, LockOpenIcon *widget.Icon
 
 	OverviewIcon, OverviewIconInactive, WalletIcon, WalletIconInactive, TradeIconActive, TradeIconInactive, RedAlert, AlertIcon,
 	ReceiveIcon, Transferred, TransactionsIcon, TransactionsIconInactive, SendIcon,
@@ -72,6 +73,9 @@ func (i *Icons) StandardMaterialIcons() *Icons {
 	i.DeleteIcon = MustIcon(widget.NewIcon(icons.ActionDelete))
 	i.VisibilityIcon = MustIcon(widget.NewIcon(icons.ActionVisibility))
 	i.VisibilityOffIcon = MustIcon(widget.NewIcon(icons.ActionVisibilityOff))
+	i.ContactsIcon = MustIcon(widget.NewIcon(icons.CommunicationContacts))
+	i.LockIcon = MustIcon(widget.NewIcon(icons.ActionLock))
+	i.LockOpenIcon = MustIcon(widget.NewIcon(icons.ActionLockOpen))
 	return i
 }
 
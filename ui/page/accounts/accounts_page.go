@@ -7,6 +7,7 @@ import (
 	"gioui.org/layout"
 	"gioui.org/widget"
 	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
@@ -39,6 +40,7 @@ type Page struct {
 	addAccountBtn *cryptomaterial.Clickable
 	accountsList  *cryptomaterial.ClickableList
 	accounts      []*sharedW.Account
+	taprootSwitch *cryptomaterial.Switch
 
 	exchangeRate   float64
 	usdExchangeSet bool
@@ -53,6 +55,7 @@ func NewAccountPage(l *load.Load, wallet sharedW.Asset) *Page {
 		},
 		addAccountBtn: l.Theme.NewClickable(false),
 		accountsList:  l.Theme.NewClickableList(layout.Vertical),
+		taprootSwitch: l.Theme.Switch(),
 		wallet:        wallet,
 	}
 	pg.accountsList.Radius = cryptomaterial.Radius(8)
@@ -251,6 +254,15 @@ func (pg *Page) accountBalanceLayout(title string, bal sharedW.AssetAmount, balA
 	}
 }
 
+func (pg *Page) taprootSwitchLayout(gtx C) D {
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, pg.taprootSwitch.Layout)
+		}),
+		layout.Rigid(pg.Theme.Body1(values.String(values.StrTaprootAccount)).Layout),
+	)
+}
+
 // HandleUserInteractions is called just before Layout() to determine
 // if any user interaction recently occurred on the page and may be
 // used to update the page's UI components shortly before they are
@@ -258,6 +270,11 @@ func (pg *Page) accountBalanceLayout(title string, bal sharedW.AssetAmount, balA
 // Part of the load.Page interface.
 func (pg *Page) HandleUserInteractions(gtx C) {
 	if pg.addAccountBtn.Clicked(gtx) {
+		// BTC wallets can create Taproot accounts with bc1p addresses.
+		btcAsset, isBTC := pg.wallet.(*btc.Asset)
+		supportsTaproot := isBTC && btcAsset.SupportsTaprootAccounts()
+		pg.taprootSwitch.SetChecked(false)
+
 		createAccountModal := modal.NewCreatePasswordModal(pg.Load).
 			Title(values.String(values.StrCreateNewAccount)).
 			EnableName(true).
@@ -265,7 +282,12 @@ func (pg *Page) HandleUserInteractions(gtx C) {
 			EnableConfirmPassword(false).
 			PasswordHint(values.String(values.StrSpendingPassword)).
 			SetPositiveButtonCallback(func(accountName, password string, m *modal.CreatePasswordModal) bool {
-				_, err := pg.wallet.CreateNewAccount(accountName, password)
+				var err error
+				if supportsTaproot && pg.taprootSwitch.IsChecked() {
+					_, err = btcAsset.CreateTaprootAccount(accountName, password)
+				} else {
+					_, err = pg.wallet.CreateNewAccount(accountName, password)
+				}
 				if err != nil {
 					m.SetError(err.Error())
 					return false
@@ -278,6 +300,9 @@ func (pg *Page) HandleUserInteractions(gtx C) {
 				pg.ParentWindow().ShowModal(info)
 				return true
 			})
+		if supportsTaproot {
+			createAccountModal.UseCustomWidget(pg.taprootSwitchLayout)
+		}
 		pg.ParentWindow().ShowModal(createAccountModal)
 	}
 
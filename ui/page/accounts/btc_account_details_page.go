@@ -84,7 +84,7 @@ func (pg *BTCAcctDetailsPage) OnNavigatedTo() {
 	pg.spendableBalance = pg.account.Balance.Spendable.String()
 	pg.lockedBalance = pg.account.Balance.Locked.String()
 
-	pg.hdPath = pg.AssetsManager.BTCHDPrefix() + strconv.Itoa(int(pg.account.AccountNumber)) + "'"
+	pg.hdPath = pg.AssetsManager.HDPrefix(pg.wallet.GetAssetType()) + strconv.Itoa(int(pg.account.AccountNumber)) + "'"
 
 	ext := pg.account.ExternalKeyCount
 	internal := pg.account.InternalKeyCount
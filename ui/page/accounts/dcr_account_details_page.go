@@ -91,7 +91,7 @@ func (pg *AcctDetailsPage) OnNavigatedTo() {
 	pg.immatureBalance = pg.wallet.ToAmount(bal.ImmatureReward.ToInt() + bal.ImmatureStakeGeneration.ToInt()).String()
 	pg.votingAuthority = bal.VotingAuthority.String()
 
-	pg.hdPath = pg.AssetsManager.DCRHDPrefix() + strconv.Itoa(int(pg.account.Number)) + "'"
+	pg.hdPath = pg.AssetsManager.HDPrefix(pg.wallet.GetAssetType()) + strconv.Itoa(int(pg.account.Number)) + "'"
 
 	ext := pg.account.ExternalKeyCount
 	internal := pg.account.InternalKeyCount
@@ -301,13 +301,15 @@ func LayoutNoProposalsFound(gtx C, l *load.Load, syncing bool, category int32) D
 	})
 }
 
-func LoadProposals(l *load.Load, category, offset, pageSize int32, newestFirst bool, key string) []*ProposalItem {
+// LoadProposals returns the proposals selected by the filter. Search results
+// are ranked by relevance when the filter has a search phrase.
+func LoadProposals(l *load.Load, filter *libwallet.ProposalFilter) []*ProposalItem {
 	proposalItems := make([]*ProposalItem, 0)
 
-	proposals, err := l.AssetsManager.Politeia.GetProposalsRaw(category, offset, pageSize, newestFirst, key)
+	proposals, err := l.AssetsManager.Politeia.SearchProposals(filter)
 	if err == nil {
 		for i := 0; i < len(proposals); i++ {
-			proposal := proposals[i]
+			proposal := proposals[i].Proposal
 			item := &ProposalItem{
 				Proposal: libwallet.Proposal{Proposal: proposal},
 				voteBar:  NewVoteBar(l),
@@ -318,7 +318,7 @@ func (pg *Restore) restoreFromSeedEditor() {
 		return
 	}
 
-	walletWithSameSeed, err := pg.AssetsManager.WalletWithSeed(pg.walletType, seedOrHex, wordSeedType)
+	walletWithSameSeed, err := pg.AssetsManager.WalletWithSeed(pg.walletType, seedOrHex, wordSeedType, "")
 	if err != nil {
 		log.Error(err)
 		errMsg := values.String(values.StrInvalidHex)
@@ -344,7 +344,7 @@ func (pg *Restore) restoreFromSeedEditor() {
 		ShowWalletInfoTip(true).
 		SetParent(pg).
 		SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
-			_, err := pg.AssetsManager.RestoreWallet(pg.walletType, pg.walletName, seedOrHex, password, sharedW.PassphraseTypePass, wordSeedType)
+			_, err := pg.AssetsManager.RestoreWallet(pg.walletType, pg.walletName, seedOrHex, password, sharedW.PassphraseTypePass, wordSeedType, "")
 			if err != nil {
 				errString := err.Error()
 				if err.Error() == libutils.ErrExist {
@@ -76,6 +76,14 @@ type SeedRestore struct {
 
 	walletType      libutils.AssetType
 	getWordSeedType func() sharedW.WordSeedType
+
+	seedPassphraseToggle *cryptomaterial.Switch
+	seedPassphraseEditor cryptomaterial.Editor
+
+	scanLegacyKeyScopesToggle *cryptomaterial.Switch
+
+	seedSharesToggle *cryptomaterial.Switch
+	seedSharesEditor cryptomaterial.Editor
 }
 
 func NewSeedRestorePage(l *load.Load, walletName string, walletType libutils.AssetType, onRestoreComplete func(), getWordSeedType func() sharedW.WordSeedType) *SeedRestore {
@@ -99,6 +107,15 @@ func NewSeedRestorePage(l *load.Load, walletName string, walletType libutils.Ass
 	pg.resetSeedFields = l.Theme.OutlineButton(values.String(values.StrClearAll))
 	pg.resetSeedFields.Font.Weight = font.Medium
 
+	pg.seedPassphraseToggle = l.Theme.Switch()
+	pg.seedPassphraseEditor = l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSeedPassphrase))
+	pg.seedPassphraseEditor.Editor.SingleLine = true
+
+	pg.scanLegacyKeyScopesToggle = l.Theme.Switch()
+
+	pg.seedSharesToggle = l.Theme.Switch()
+	pg.seedSharesEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrEnterSeedShares))
+
 	for i := 0; i <= defaultNumberOfSeeds; i++ {
 		widgetEditor := new(widget.Editor)
 		widgetEditor.SingleLine, widgetEditor.Submit = true, true
@@ -179,9 +196,17 @@ func (pg *SeedRestore) restore(gtx C) D {
 				Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(14)},
 				Padding:     layout.UniformInset(values.MarginPadding15),
 			}.Layout(gtx,
-				layout.Rigid(pg.seedEditorViewDesktop),
+				layout.Rigid(pg.seedSharesSection),
+				layout.Rigid(func(gtx C) D {
+					if pg.seedSharesToggle.IsChecked() {
+						return pg.seedSharesEditor.Layout(gtx)
+					}
+					return pg.seedEditorViewDesktop(gtx)
+				}),
 				layout.Rigid(layout.Spacer{Height: values.MarginPadding5}.Layout),
 				layout.Rigid(pg.resetSeedFields.Layout),
+				layout.Rigid(pg.seedPassphraseSection),
+				layout.Rigid(pg.scanLegacyKeyScopesSection),
 			)
 		}),
 		layout.Stacked(func(gtx C) D {
@@ -193,6 +218,106 @@ func (pg *SeedRestore) restore(gtx C) D {
 	)
 }
 
+// seedSharesSection lays out the toggle that restores the wallet from SLIP-39
+// shares instead of the seed words.
+func (pg *SeedRestore) seedSharesSection(gtx C) D {
+	textSize14 := values.TextSizeTransform(pg.IsMobileView(), values.TextSize14)
+	return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, pg.seedSharesToggle.Layout)
+			}),
+			layout.Rigid(pg.Theme.Label(textSize14, values.String(values.StrRestoreFromShares)).Layout),
+		)
+	})
+}
+
+// seedShares returns the SLIP-39 shares entered by the user, one per line.
+func (pg *SeedRestore) seedShares() []string {
+	var shares []string
+	for _, line := range strings.Split(pg.seedSharesEditor.Editor.Text(), "\n") {
+		if share := strings.Join(strings.Fields(strings.ToLower(line)), " "); share != "" {
+			shares = append(shares, share)
+		}
+	}
+	return shares
+}
+
+// seedPassphraseSection lays out the advanced toggle and editor for the
+// optional BIP-39 seed passphrase. 33 word seeds do not support passphrases.
+func (pg *SeedRestore) seedPassphraseSection(gtx C) D {
+	if pg.getWordSeedType() == sharedW.WordSeed33 {
+		return D{}
+	}
+
+	textSize14 := values.TextSizeTransform(pg.IsMobileView(), values.TextSize14)
+	return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, pg.seedPassphraseToggle.Layout)
+					}),
+					layout.Rigid(pg.Theme.Label(textSize14, values.String(values.StrUseSeedPassphrase)).Layout),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if !pg.seedPassphraseToggle.IsChecked() {
+					return D{}
+				}
+
+				info := pg.Theme.Caption(values.String(values.StrSeedPassphraseInfo))
+				info.Color = pg.Theme.Color.GrayText2
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(layout.Spacer{Height: values.MarginPadding8}.Layout),
+					layout.Rigid(pg.seedPassphraseEditor.Layout),
+					layout.Rigid(info.Layout),
+				)
+			}),
+		)
+	})
+}
+
+// seedPassphrase returns the BIP-39 seed passphrase entered by the user, it
+// is empty unless the advanced seed passphrase toggle is on.
+func (pg *SeedRestore) seedPassphrase() string {
+	if !pg.seedPassphraseToggle.IsChecked() || pg.getWordSeedType() == sharedW.WordSeed33 {
+		return ""
+	}
+	return pg.seedPassphraseEditor.Editor.Text()
+}
+
+// scanLegacyKeyScopesSection lays out the toggle that imports the BIP-44 and
+// BIP-49 accounts of BTC and LTC seeds that were used by other wallets.
+func (pg *SeedRestore) scanLegacyKeyScopesSection(gtx C) D {
+	if pg.walletType != libutils.BTCWalletAsset && pg.walletType != libutils.LTCWalletAsset {
+		return D{}
+	}
+
+	textSize14 := values.TextSizeTransform(pg.IsMobileView(), values.TextSize14)
+	return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, pg.scanLegacyKeyScopesToggle.Layout)
+					}),
+					layout.Rigid(pg.Theme.Label(textSize14, values.String(values.StrScanLegacyKeyScopes)).Layout),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if !pg.scanLegacyKeyScopesToggle.IsChecked() {
+					return D{}
+				}
+
+				info := pg.Theme.Caption(values.String(values.StrScanLegacyKeyScopesInfo))
+				info.Color = pg.Theme.Color.GrayText2
+				return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, info.Layout)
+			}),
+		)
+	})
+}
+
 func (pg *SeedRestore) restoreButtonSection(gtx C) D {
 	card := pg.Theme.Card()
 	card.Radius = cryptomaterial.Radius(0)
@@ -420,6 +545,9 @@ func (pg SeedRestore) suggestionSeeds(text string) []string {
 }
 
 func (pg *SeedRestore) updateSeedResetBtn() bool {
+	if pg.seedSharesToggle.IsChecked() {
+		return pg.seedSharesEditor.Editor.Text() != ""
+	}
 	for _, editor := range pg.seedEditors.editors {
 		return editor.Edit.Editor.Text() != ""
 	}
@@ -427,6 +555,10 @@ func (pg *SeedRestore) updateSeedResetBtn() bool {
 }
 
 func (pg *SeedRestore) validateSeeds() (bool, string) {
+	if pg.seedSharesToggle.IsChecked() {
+		return len(pg.seedShares()) > 0, ""
+	}
+
 	seedPhrase := ""
 	allSuggestedWords := strings.Join(pg.getWordSeedType().AllWords(), " ")
 	numberOfSeed := pg.getWordSeedType().ToInt()
@@ -448,7 +580,16 @@ func (pg *SeedRestore) validateSeeds() (bool, string) {
 
 func (pg *SeedRestore) verifySeeds() bool {
 	isValid, seedphrase := pg.validateSeeds()
-	if isValid {
+	if isValid && pg.seedSharesToggle.IsChecked() {
+		// The seed is rebuilt from the shares, it is never displayed.
+		seed, err := sharedW.SeedFromShares(pg.seedShares(), pg.getWordSeedType())
+		if err != nil {
+			errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+			pg.window.ShowModal(errModal)
+			return false
+		}
+		pg.seedPhrase = seed
+	} else if isValid {
 		pg.seedPhrase = seedphrase
 		if !sharedW.VerifyMnemonic(pg.seedPhrase, pg.walletType, pg.getWordSeedType()) {
 			errModal := modal.NewErrorModal(pg.Load, values.String(values.StrInvalidSeedPhrase), modal.DefaultClickFunc())
@@ -459,7 +600,7 @@ func (pg *SeedRestore) verifySeeds() bool {
 
 	// Compare seed with existing wallets seed. On positive match abort import
 	// to prevent duplicate wallet. walletWithSameSeed >= 0 if there is a match.
-	walletWithSameSeed, err := pg.AssetsManager.WalletWithSeed(pg.walletType, pg.seedPhrase, pg.getWordSeedType())
+	walletWithSameSeed, err := pg.AssetsManager.WalletWithSeed(pg.walletType, pg.seedPhrase, pg.getWordSeedType(), pg.seedPassphrase())
 	if err != nil {
 		log.Error(err)
 		return false
@@ -479,6 +620,10 @@ func (pg *SeedRestore) resetSeeds() {
 	for i := 0; i < len(pg.seedEditors.editors); i++ {
 		pg.seedEditors.editors[i].Edit.Editor.SetText("")
 	}
+	pg.seedPassphraseEditor.Editor.SetText("")
+	pg.seedPassphraseToggle.SetChecked(false)
+	pg.scanLegacyKeyScopesToggle.SetChecked(false)
+	pg.seedSharesEditor.Editor.SetText("")
 }
 
 // switchSeedEditors sets focus on the next seed phrase after moving the
@@ -532,7 +677,7 @@ func (pg *SeedRestore) HandleUserInteractions(gtx C) {
 			ShowWalletInfoTip(true).
 			SetParent(pg).
 			SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
-				_, err := pg.AssetsManager.RestoreWallet(pg.walletType, pg.walletName, pg.seedPhrase, password, sharedW.PassphraseTypePass, pg.getWordSeedType())
+				wal, err := pg.AssetsManager.RestoreWallet(pg.walletType, pg.walletName, pg.seedPhrase, password, sharedW.PassphraseTypePass, pg.getWordSeedType(), pg.seedPassphrase())
 				if err != nil {
 					errString := err.Error()
 					if err.Error() == libutils.ErrExist {
@@ -543,6 +688,12 @@ func (pg *SeedRestore) HandleUserInteractions(gtx C) {
 					return false
 				}
 
+				// The legacy key scopes are imported once address discovery
+				// completes.
+				if pg.scanLegacyKeyScopesToggle.IsChecked() {
+					wal.SetBoolConfigValueForKey(sharedW.ScanLegacyKeyScopesConfigKey, true)
+				}
+
 				infoModal := modal.NewSuccessModal(pg.Load, values.String(values.StrWalletRestored), modal.DefaultClickFunc())
 				pg.window.ShowModal(infoModal)
 				pg.resetSeeds()
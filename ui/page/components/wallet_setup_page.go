@@ -2,7 +2,6 @@ package components
 
 import (
 	"errors"
-	"strings"
 
 	"gioui.org/font"
 	"gioui.org/layout"
@@ -442,28 +441,12 @@ func (pg *CreateWallet) handleEditorEvents(gtx C) {
 		pg.showLoader = true
 		var err error
 		go func() {
-			switch strings.ToLower(pg.assetTypeDropdown.Selected()) {
-			case libutils.DCRWalletAsset.ToStringLower():
-				var walletWithXPub int
-				walletWithXPub, err = pg.AssetsManager.DCRWalletWithXPub(pg.watchOnlyWalletHex.Editor.Text())
+			assetType := libutils.AssetType(pg.assetTypeDropdown.Selected())
+			var walletWithXPub int
+			walletWithXPub, err = pg.AssetsManager.WalletWithXPub(assetType, pg.watchOnlyWalletHex.Editor.Text())
+			if err == nil {
 				if walletWithXPub == -1 {
-					_, err = pg.AssetsManager.CreateNewDCRWatchOnlyWallet(pg.walletName.Editor.Text(), pg.watchOnlyWalletHex.Editor.Text())
-				} else {
-					err = errors.New(values.String(values.StrXpubWalletExist))
-				}
-			case libutils.BTCWalletAsset.ToStringLower():
-				var walletWithXPub int
-				walletWithXPub, err = pg.AssetsManager.BTCWalletWithXPub(pg.watchOnlyWalletHex.Editor.Text())
-				if walletWithXPub == -1 {
-					_, err = pg.AssetsManager.CreateNewBTCWatchOnlyWallet(pg.walletName.Editor.Text(), pg.watchOnlyWalletHex.Editor.Text())
-				} else {
-					err = errors.New(values.String(values.StrXpubWalletExist))
-				}
-			case libutils.LTCWalletAsset.ToStringLower():
-				var walletWithXPub int
-				walletWithXPub, err = pg.AssetsManager.LTCWalletWithXPub(pg.watchOnlyWalletHex.Editor.Text())
-				if walletWithXPub == -1 {
-					_, err = pg.AssetsManager.CreateNewLTCWatchOnlyWallet(pg.walletName.Editor.Text(), pg.watchOnlyWalletHex.Editor.Text())
+					_, err = pg.AssetsManager.CreateNewWatchOnlyWallet(assetType, pg.walletName.Editor.Text(), pg.watchOnlyWalletHex.Editor.Text())
 				} else {
 					err = errors.New(values.String(values.StrXpubWalletExist))
 				}
@@ -491,45 +474,17 @@ func (pg *CreateWallet) createWallet() {
 	walletName := pg.walletName.Editor.Text()
 	pass := pg.passwordEditor.Editor.Text()
 	seedType := GetWordSeedType(pg.seedTypeDropdown.Selected())
-	switch strings.ToLower(pg.assetTypeDropdown.Selected()) {
-	case libutils.DCRWalletAsset.ToStringLower():
-		_, err := pg.AssetsManager.CreateNewDCRWallet(walletName, pass, sharedW.PassphraseTypePass, seedType)
-		if err != nil {
-			if err.Error() == libutils.ErrExist {
-				pg.walletName.SetError(values.StringF(values.StrWalletExist, walletName))
-				return
-			}
-
-			errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
-			pg.ParentWindow().ShowModal(errModal)
+	assetType := libutils.AssetType(pg.assetTypeDropdown.Selected())
+	_, err := pg.AssetsManager.CreateNewWallet(assetType, walletName, pass, sharedW.PassphraseTypePass, seedType, "")
+	if err != nil {
+		if err.Error() == libutils.ErrExist {
+			pg.walletName.SetError(values.StringF(values.StrWalletExist, walletName))
 			return
 		}
 
-	case libutils.BTCWalletAsset.ToStringLower():
-		_, err := pg.AssetsManager.CreateNewBTCWallet(walletName, pass, sharedW.PassphraseTypePass, seedType)
-		if err != nil {
-			if err.Error() == libutils.ErrExist {
-				pg.walletName.SetError(values.StringF(values.StrWalletExist, walletName))
-				return
-			}
-
-			errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
-			pg.ParentWindow().ShowModal(errModal)
-			return
-		}
-
-	case libutils.LTCWalletAsset.ToStringLower():
-		_, err := pg.AssetsManager.CreateNewLTCWallet(walletName, pass, sharedW.PassphraseTypePass, seedType)
-		if err != nil {
-			if err.Error() == libutils.ErrExist {
-				pg.walletName.SetError(values.StringF(values.StrWalletExist, walletName))
-				return
-			}
-
-			errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
-			pg.ParentWindow().ShowModal(errModal)
-			return
-		}
+		errModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+		pg.ParentWindow().ShowModal(errModal)
+		return
 	}
 
 	pg.walletCreationSuccessCallback()
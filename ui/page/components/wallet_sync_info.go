@@ -441,6 +441,13 @@ func (wsi *WalletSyncInfo) syncBoxTitleRow(gtx C) D {
 					if !wsi.safeIsStatusConnected() {
 						return wsi.labelSize(textSize14, values.String(values.StrNoInternet)).Layout(gtx)
 					}
+					if wsi.AssetsManager.IsSyncPending(wsi.wallet.GetWalletID()) {
+						pending := values.String(values.StrSyncPending)
+						if err := wsi.AssetsManager.SyncPaused(); err != nil {
+							pending = values.TranslateErr(err.Error())
+						}
+						return wsi.labelSize(textSize14, pending).Layout(gtx)
+					}
 					return wsi.labelSize(textSize14, values.String(values.StrNoConnectedPeer)).Layout(gtx)
 				}),
 			)
@@ -501,7 +508,8 @@ func (wsi *WalletSyncInfo) progressStatusDetails() (progress int, timeLeft strin
 func (wsi *WalletSyncInfo) layoutAutoSyncSection(gtx C) D {
 	return layout.Flex{}.Layout(gtx,
 		layout.Rigid(func(gtx C) D {
-			wsi.syncSwitch.SetChecked(wsi.wallet.IsSyncing() || wsi.wallet.IsSynced())
+			wsi.syncSwitch.SetChecked(wsi.wallet.IsSyncing() || wsi.wallet.IsSynced() ||
+				wsi.AssetsManager.IsSyncPending(wsi.wallet.GetWalletID()))
 			return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, wsi.syncSwitch.Layout)
 		}),
 		layout.Rigid(wsi.Theme.Body2(values.String(values.StrSync)).Layout),
@@ -652,12 +660,13 @@ func (wsi *WalletSyncInfo) HandleUserInteractions(gtx C) {
 	isSyncShutting := wsi.wallet.IsSyncShuttingDown()
 	wsi.syncSwitch.SetEnabled(!isSyncShutting)
 	if wsi.syncSwitch.Changed(gtx) {
-		if wsi.wallet.IsRescanning() {
-			wsi.wallet.CancelRescan()
-		}
-
 		// Toggling switch states is handled in the layout() method.
 		go func() {
+			// Canceling a rescan restarts the wallet, it's not done on the
+			// UI thread.
+			if wsi.wallet.IsRescanning() {
+				wsi.wallet.CancelRescan()
+			}
 			wsi.ToggleSync(wsi.wallet, func(b bool) {
 				wsi.wallet.SaveUserConfigValue(sharedW.AutoSyncConfigKey, b)
 				wsi.reload()
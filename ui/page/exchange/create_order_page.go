@@ -665,23 +665,14 @@ func (pg *CreateOrderPage) swapCurrency() {
 // functionality is disable till different asset type wallets are created.
 func (pg *CreateOrderPage) isMultipleAssetTypeWalletAvailable() bool {
 	pg.errMsg = values.String(values.StrMinimumAssetType)
-	allWallets := len(pg.AssetsManager.AllWallets())
-	btcWallets := len(pg.AssetsManager.AllBTCWallets())
-	dcrWallets := len(pg.AssetsManager.AllDCRWallets())
-	ltcWallets := len(pg.AssetsManager.AllLTCWallets())
-	if allWallets == 0 {
-		// no wallets exist
-		return false
+	walletAssetTypes := 0
+	for _, assetType := range pg.AssetsManager.AllAssetTypes() {
+		if len(pg.AssetsManager.AssetWallets(assetType)) > 0 {
+			walletAssetTypes++
+		}
 	}
-
-	switch {
-	case allWallets > btcWallets && btcWallets > 0:
-		// BTC and some other wallets exists
-	case allWallets > dcrWallets && dcrWallets > 0:
-		// DCR and some other wallets exists
-	case allWallets > ltcWallets && ltcWallets > 0:
-		// LTC and some other wallets exists
-	default:
+	if walletAssetTypes < 2 {
+		// wallets of a single asset type or no wallets exist
 		return false
 	}
 	pg.errMsg = ""
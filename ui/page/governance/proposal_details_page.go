@@ -9,6 +9,7 @@ import (
 	"gioui.org/font"
 	"gioui.org/io/clipboard"
 	"gioui.org/layout"
+	"gioui.org/unit"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
 
@@ -33,6 +34,13 @@ const (
 	testnetBaseHost = "http://45.32.108.164:3000/record/"
 )
 
+// proposalComment is a comment prepared for display in the threaded comments
+// view. Depth is the nesting level of the comment in its thread.
+type proposalComment struct {
+	*libwallet.ProposalComment
+	depth int
+}
+
 type proposalItemWidgets struct {
 	widgets    []layout.Widget
 	clickables map[string]*widget.Clickable
@@ -72,6 +80,11 @@ type ProposalDetails struct {
 
 	voteBar            *components.VoteBar
 	loadingDescription bool
+
+	comments        []*proposalComment
+	commentsLoaded  bool
+	loadingComments bool
+	authorInfo      *libwallet.ProposalAuthor
 }
 
 func NewProposalDetailsPage(l *load.Load, proposal *libwallet.Proposal) *ProposalDetails {
@@ -118,6 +131,7 @@ func NewProposalDetailsPage(l *load.Load, proposal *libwallet.Proposal) *Proposa
 func (pg *ProposalDetails) OnNavigatedTo() {
 	pg.initWalletSelector()
 	pg.loadProposalDescription()
+	pg.loadProposalComments()
 	pg.listenForSyncNotifications() // listener is stopped in OnNavigatedFrom()
 }
 
@@ -165,6 +179,55 @@ func (pg *ProposalDetails) loadProposalDescription() {
 	}
 }
 
+// loadProposalComments loads the cached proposal comments, fetching them from
+// the server first if new comments were made since they were last fetched.
+func (pg *ProposalDetails) loadProposalComments() {
+	if pg.loadingComments {
+		return
+	}
+
+	pg.loadingComments = true
+	go func() {
+		defer func() {
+			pg.loadingComments = false
+			pg.ParentWindow().Reload()
+		}()
+
+		politeia := pg.AssetsManager.Politeia
+		proposal := pg.proposal
+		if proposal.CommentsSyncedAt == 0 || proposal.SyncedNumComments != proposal.NumComments {
+			if _, err := politeia.FetchProposalComments(proposal.Token); err != nil {
+				log.Errorf("Error fetching proposal comments: %v", err)
+			}
+		}
+
+		threads, err := politeia.ProposalCommentThreads(proposal.Token)
+		if err != nil {
+			log.Errorf("Error loading proposal comments: %v", err)
+			return
+		}
+
+		var comments []*proposalComment
+		var flatten func(threads []*libwallet.CommentThread)
+		flatten = func(threads []*libwallet.CommentThread) {
+			for _, t := range threads {
+				comments = append(comments, &proposalComment{ProposalComment: t.Comment, depth: t.Depth})
+				flatten(t.Replies)
+			}
+		}
+		flatten(threads)
+
+		author, err := politeia.ProposalAuthorRaw(proposal.UserID)
+		if err != nil {
+			log.Errorf("Error loading proposal author: %v", err)
+		}
+
+		pg.comments = comments
+		pg.authorInfo = author
+		pg.commentsLoaded = true
+	}()
+}
+
 // Layout draws the page UI components into the provided layout context
 // to be eventually drawn on screen.
 // Part of the load.Page interface.
@@ -290,6 +353,7 @@ func (pg *ProposalDetails) listenForSyncNotifications() {
 			proposal, err := pg.AssetsManager.Politeia.GetProposalRaw(pg.proposal.Token)
 			if err == nil {
 				pg.proposal = &libwallet.Proposal{Proposal: *proposal}
+				pg.loadProposalComments()
 				pg.ParentWindow().Reload()
 			}
 		}
@@ -611,6 +675,13 @@ func (pg *ProposalDetails) layoutDescription(gtx C) D {
 	publishedLabel.TextSize = pg.ConvertTextSize(values.TextSize14)
 	updatedLabel.TextSize = pg.ConvertTextSize(values.TextSize14)
 
+	authorLabel := pg.Theme.Body2("")
+	if pg.authorInfo != nil {
+		authorLabel.Text = values.StringF(values.StrAuthorProposals, pg.authorInfo.Proposals, pg.authorInfo.Approved)
+	}
+	authorLabel.Color = grayCol
+	authorLabel.TextSize = pg.ConvertTextSize(values.TextSize14)
+
 	w := []layout.Widget{
 		func(gtx C) D {
 			lbl := pg.Theme.H5(proposal.Name)
@@ -635,6 +706,13 @@ func (pg *ProposalDetails) layoutDescription(gtx C) D {
 							return layout.Inset{Top: values.MarginPaddingMinus22}.Layout(gtx, dotLabel.Layout)
 						}),
 						layout.Rigid(versionLabel.Layout),
+						layout.Rigid(func(gtx C) D {
+							if authorLabel.Text == "" {
+								return D{}
+							}
+							return layout.Inset{Top: values.MarginPaddingMinus22}.Layout(gtx, dotLabel.Layout)
+						}),
+						layout.Rigid(authorLabel.Layout),
 					)
 				}),
 				layout.Rigid(func(gtx C) D {
@@ -669,6 +747,9 @@ func (pg *ProposalDetails) layoutDescription(gtx C) D {
 		w = append(w, loading)
 	}
 
+	w = append(w, pg.lineSeparator(layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding16}))
+	w = append(w, pg.commentsWidgets()...)
+
 	return pg.descriptionCard.Layout(gtx, func(gtx C) D {
 		return pg.Theme.List(pg.scrollbarList).Layout(gtx, 1, func(gtx C, _ int) D {
 			mpSize := values.MarginPadding16
@@ -684,6 +765,82 @@ func (pg *ProposalDetails) layoutDescription(gtx C) D {
 	})
 }
 
+// commentsWidgets returns the widgets of the threaded comments view, replies
+// are indented according to their depth in the thread.
+func (pg *ProposalDetails) commentsWidgets() []layout.Widget {
+	title := func(gtx C) D {
+		lbl := pg.Theme.H6(values.StringF(values.StrProposalComments, pg.proposal.NumComments))
+		lbl.Font.Weight = font.SemiBold
+		return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+	}
+
+	if !pg.commentsLoaded {
+		return []layout.Widget{title, func(gtx C) D {
+			return layout.Center.Layout(gtx, material.Loader(pg.Theme.Base).Layout)
+		}}
+	}
+
+	if len(pg.comments) == 0 {
+		return []layout.Widget{title, pg.Theme.Body2(values.String(values.StrNoComments)).Layout}
+	}
+
+	w := []layout.Widget{title}
+	for _, comment := range pg.comments {
+		comment := comment
+		w = append(w, func(gtx C) D {
+			return pg.layoutComment(gtx, comment)
+		})
+	}
+	return w
+}
+
+func (pg *ProposalDetails) layoutComment(gtx C, comment *proposalComment) D {
+	grayCol := pg.Theme.Color.GrayText2
+	indent := values.MarginPadding16 * unit.Dp(comment.depth)
+	if pg.IsMobileView() {
+		indent = values.MarginPadding8 * unit.Dp(comment.depth)
+	}
+
+	return layout.Inset{Left: indent, Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				usernameLabel := pg.Theme.Body2(comment.Username)
+				usernameLabel.Font.Weight = font.SemiBold
+				usernameLabel.TextSize = pg.ConvertTextSize(values.TextSize14)
+
+				metaLabel := pg.Theme.Body2(" · " + pageutils.TimeAgo(comment.CreatedAt) +
+					" · " + values.StringF(values.StrCommentScore, comment.Score()))
+				metaLabel.Color = grayCol
+				metaLabel.TextSize = pg.ConvertTextSize(values.TextSize14)
+
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(usernameLabel.Layout),
+					layout.Rigid(func(gtx C) D {
+						if !comment.IsAuthor {
+							return D{}
+						}
+						lbl := pg.Theme.Body2(" (" + values.String(values.StrCommentAuthor) + ")")
+						lbl.Color = pg.Theme.Color.Primary
+						lbl.TextSize = pg.ConvertTextSize(values.TextSize14)
+						return lbl.Layout(gtx)
+					}),
+					layout.Rigid(metaLabel.Layout),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if comment.Deleted {
+					lbl := pg.Theme.Body2(values.String(values.StrCommentDeleted))
+					lbl.Color = grayCol
+					return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lbl.Layout)
+				}
+				lbl := pg.Theme.Body1(comment.Comment)
+				lbl.TextSize = pg.ConvertTextSize(values.TextSize14)
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lbl.Layout)
+			}),
+		)
+	})
+}
+
 func (pg *ProposalDetails) layoutRedirect(text string, icon *cryptomaterial.Image, btn *cryptomaterial.Clickable) layout.Widget {
 	return func(gtx C) D {
 		return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
@@ -41,16 +41,21 @@ type ProposalsPage struct {
 	// and the root WindowNavigator.
 	*app.GenericPageModal
 
-	scroll         *components.Scroll[*components.ProposalItem]
-	statusDropDown *cryptomaterial.DropDown
-	orderDropDown  *cryptomaterial.DropDown
-	walletDropDown *cryptomaterial.DropDown
-	filterBtn      *cryptomaterial.Clickable
-	isFilterOpen   bool
+	scroll          *components.Scroll[*components.ProposalItem]
+	statusDropDown  *cryptomaterial.DropDown
+	outcomeDropDown *cryptomaterial.DropDown
+	orderDropDown   *cryptomaterial.DropDown
+	walletDropDown  *cryptomaterial.DropDown
+	filterBtn       *cryptomaterial.Clickable
+	isFilterOpen    bool
 
 	proposalsList *cryptomaterial.ClickableList
 	syncButton    *widget.Clickable
 	searchEditor  cryptomaterial.Editor
+	// fromDateEditor and toDateEditor limit the proposals to those
+	// published in the date range.
+	fromDateEditor,
+	toDateEditor cryptomaterial.Editor
 
 	infoButton  cryptomaterial.IconButton
 	updatedIcon *cryptomaterial.Icon
@@ -72,6 +77,8 @@ func NewProposalsPage(l *load.Load) *ProposalsPage {
 	pg.searchEditor = l.Theme.SearchEditor(new(widget.Editor), values.String(values.StrSearch), l.Theme.Icons.SearchIcon)
 	pg.searchEditor.Editor.SingleLine = true
 	pg.searchEditor.TextSize = pg.ConvertTextSize(l.Theme.TextSize)
+	pg.fromDateEditor = pg.dateEditor(values.StrFromDate)
+	pg.toDateEditor = pg.dateEditor(values.StrToDate)
 
 	pg.updatedIcon = cryptomaterial.NewIcon(pg.Theme.Icons.NavigationCheck)
 	pg.updatedIcon.Color = pg.Theme.Color.Success
@@ -95,6 +102,14 @@ func NewProposalsPage(l *load.Load) *ProposalsPage {
 		{Text: values.String(values.StrAbandoned)},
 	}, values.ProposalDropdownGroup, 1, 0, false)
 
+	// The items are in the order of the vote outcome filters.
+	pg.outcomeDropDown = l.Theme.DropdownWithCustomPos([]cryptomaterial.DropDownItem{
+		{Text: values.String(values.StrAnyOutcome)},
+		{Text: values.String(values.StrApproved)},
+		{Text: values.String(values.StrRejected)},
+		{Text: values.String(values.StrUndecided)},
+	}, values.ProposalDropdownGroup, 1, 0, false)
+
 	pg.orderDropDown = l.Theme.DropdownWithCustomPos([]cryptomaterial.DropDownItem{
 		{Text: values.String(values.StrNewest)},
 		{Text: values.String(values.StrOldest)},
@@ -106,17 +121,28 @@ func NewProposalsPage(l *load.Load) *ProposalsPage {
 		pg.statusDropDown.ExpandedLayoutInset.Left = values.MarginPadding10
 	}
 	pg.statusDropDown.CollapsedLayoutTextDirection = layout.E
+	pg.outcomeDropDown.CollapsedLayoutTextDirection = layout.E
 	pg.orderDropDown.CollapsedLayoutTextDirection = layout.E
 	pg.orderDropDown.Width = values.MarginPadding100
 	pg.statusDropDown.Width = values.MarginPadding150
+	pg.outcomeDropDown.Width = values.MarginPadding150
 	settingCommonDropdown(pg.Theme, pg.statusDropDown)
+	settingCommonDropdown(pg.Theme, pg.outcomeDropDown)
 	settingCommonDropdown(pg.Theme, pg.orderDropDown)
 	pg.statusDropDown.SetConvertTextSize(pg.ConvertTextSize)
+	pg.outcomeDropDown.SetConvertTextSize(pg.ConvertTextSize)
 	pg.orderDropDown.SetConvertTextSize(pg.ConvertTextSize)
 
 	return pg
 }
 
+func (pg *ProposalsPage) dateEditor(hint string) cryptomaterial.Editor {
+	editor := pg.Theme.Editor(new(widget.Editor), values.String(hint))
+	editor.Editor.SingleLine, editor.Editor.Submit = true, true
+	editor.TextSize = pg.ConvertTextSize(pg.Theme.TextSize)
+	return editor
+}
+
 // OnNavigatedTo is called when the page is about to be displayed and
 // may be used to initialize page features that are only relevant when
 // the page is displayed.
@@ -158,9 +184,16 @@ func (pg *ProposalsPage) fetchProposals(offset, pageSize int32) ([]*components.P
 		proposalFilter = libwallet.ProposalCategoryAll
 	}
 
-	orderNewest := pg.orderDropDown.Selected() != values.String(values.StrOldest)
-	searchKey := pg.searchEditor.Editor.Text()
-	proposalItems := components.LoadProposals(pg.Load, proposalFilter, offset, pageSize, orderNewest, strings.TrimSpace(searchKey))
+	proposalItems := components.LoadProposals(pg.Load, &libwallet.ProposalFilter{
+		Phrase:      strings.TrimSpace(pg.searchEditor.Editor.Text()),
+		Category:    proposalFilter,
+		Since:       pg.parseDate(&pg.fromDateEditor, 0),
+		Until:       pg.parseDate(&pg.toDateEditor, 24*time.Hour-time.Second), // The end date includes the whole day.
+		VoteOutcome: libwallet.ProposalVoteOutcome(pg.outcomeDropDown.SelectedIndex()),
+		OldestFirst: pg.orderDropDown.Selected() == values.String(values.StrOldest),
+		Offset:      offset,
+		Limit:       pageSize,
+	})
 	listItems := make([]*components.ProposalItem, 0)
 
 	if selectedType == values.String(values.StrUnderReview) {
@@ -178,6 +211,23 @@ func (pg *ProposalsPage) fetchProposals(offset, pageSize int32) ([]*components.P
 	return listItems, len(listItems), true, nil
 }
 
+// parseDate returns the unix timestamp of the local date entered in the
+// editor plus the offset, or zero if no valid date is entered.
+func (pg *ProposalsPage) parseDate(editor *cryptomaterial.Editor, offset time.Duration) int64 {
+	editor.SetError("")
+	text := strings.TrimSpace(editor.Editor.Text())
+	if text == "" {
+		return 0
+	}
+
+	date, err := time.ParseInLocation(time.DateOnly, text, time.Local)
+	if err != nil {
+		editor.SetError(values.String(values.StrInvalidDate))
+		return 0
+	}
+	return date.Add(offset).Unix()
+}
+
 func (pg *ProposalsPage) handleEditorEvents(gtx C) {
 	for {
 		event, ok := pg.searchEditor.Editor.Update(gtx)
@@ -192,6 +242,25 @@ func (pg *ProposalsPage) handleEditorEvents(gtx C) {
 			}
 		}
 	}
+
+	for _, editor := range []*cryptomaterial.Editor{&pg.fromDateEditor, &pg.toDateEditor} {
+		for {
+			event, ok := editor.Editor.Update(gtx)
+			if !ok {
+				break
+			}
+			// Partially entered dates are not queried, the proposals are
+			// reloaded when the date is submitted or cleared.
+			switch event.(type) {
+			case widget.SubmitEvent:
+				pg.scroll.FetchScrollData(false, pg.ParentWindow(), true)
+			case widget.ChangeEvent:
+				if editor.Editor.Text() == "" {
+					pg.scroll.FetchScrollData(false, pg.ParentWindow(), true)
+				}
+			}
+		}
+	}
 }
 
 // HandleUserInteractions is called just before Layout() to determine
@@ -200,7 +269,7 @@ func (pg *ProposalsPage) handleEditorEvents(gtx C) {
 // displayed.
 // Part of the load.Page interface.
 func (pg *ProposalsPage) HandleUserInteractions(gtx C) {
-	if pg.statusDropDown.Changed(gtx) {
+	if pg.statusDropDown.Changed(gtx) || pg.outcomeDropDown.Changed(gtx) {
 		pg.scroll.FetchScrollData(false, pg.ParentWindow(), true)
 	}
 
@@ -331,6 +400,12 @@ func (pg *ProposalsPage) Layout(gtx C) D {
 											Top: topInset,
 										}.Layout(gtx, pg.searchEditor.Layout)
 									}),
+									layout.Rigid(func(gtx C) D {
+										if !pg.isFilterOpen && pg.IsMobileView() {
+											return D{}
+										}
+										return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.dateEditorsLayout)
+									}),
 									layout.Rigid(pg.layoutContent),
 								)
 							}),
@@ -396,11 +471,21 @@ func (pg *ProposalsPage) rightDropdown(gtx C) D {
 	return layout.E.Layout(gtx, func(gtx C) D {
 		return layout.Flex{}.Layout(gtx,
 			layout.Rigid(pg.statusDropDown.Layout),
+			layout.Rigid(pg.outcomeDropDown.Layout),
 			layout.Rigid(pg.orderDropDown.Layout),
 		)
 	})
 }
 
+func (pg *ProposalsPage) dateEditorsLayout(gtx C) D {
+	return layout.Flex{}.Layout(gtx,
+		layout.Flexed(1, func(gtx C) D {
+			return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pg.fromDateEditor.Layout)
+		}),
+		layout.Flexed(1, pg.toDateEditor.Layout),
+	)
+}
+
 func (pg *ProposalsPage) layoutContent(gtx C) D {
 	return pg.scroll.List().Layout(gtx, 1, func(gtx C, _ int) D {
 		return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
@@ -18,11 +18,13 @@ import (
 
 	"github.com/crypto-power/cryptopower/app"
 	"github.com/crypto-power/cryptopower/appos"
+	"github.com/crypto-power/cryptopower/libwallet"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
 	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/notification"
 	"github.com/crypto-power/cryptopower/ui/page/components"
 	"github.com/crypto-power/cryptopower/ui/page/exchange"
 	"github.com/crypto-power/cryptopower/ui/page/governance"
@@ -101,7 +103,12 @@ func NewHomePage(dexCtx context.Context, l *load.Load) *HomePage {
 		if wallet == nil {
 			return
 		}
-		if wallet.IsConnectedToNetwork() { // True if asset is synced or already synced.
+		if hp.AssetsManager.IsSyncPending(wallet.GetWalletID()) {
+			// Waiting for the sync policy, there is no sync to cancel yet.
+			hp.AssetsManager.CancelSyncRequest(wallet.GetWalletID())
+			unlock(false)
+		} else if wallet.IsConnectedToNetwork() { // True if asset is synced or already synced.
+			hp.AssetsManager.CancelSyncRequest(wallet.GetWalletID())
 			wallet.EnableSyncShuttingDown() // Initiate sync shutdown process
 
 			go wallet.CancelSync()
@@ -186,12 +193,19 @@ func (hp *HomePage) OnNavigatedTo() {
 		hp.CurrentPage().OnNavigatedTo()
 	}
 
-	// Initiate the auto sync for all wallets with autosync set.
-	allWallets := hp.AssetsManager.AllWallets()
-	for _, wallet := range allWallets {
-		if wallet.ReadBoolConfigValueForKey(sharedW.AutoSyncConfigKey, false) {
+	// Initiate the auto sync for all wallets with autosync set. Wallets
+	// that need unlocking, or all of them while offline, go through
+	// startSyncing which asks for the password and waits for the network.
+	if hp.isConnected.Load() {
+		for _, wallet := range hp.AssetsManager.SyncAllWallets() {
 			hp.startSyncing(wallet, func(_ bool) {})
 		}
+	} else {
+		for _, wallet := range hp.AssetsManager.AllWallets() {
+			if wallet.ReadBoolConfigValueForKey(sharedW.AutoSyncConfigKey, false) {
+				hp.startSyncing(wallet, func(_ bool) {})
+			}
+		}
 	}
 
 	if hp.AssetsManager.ExchangeRateFetchingEnabled() {
@@ -206,6 +220,46 @@ func (hp *HomePage) OnNavigatedTo() {
 	hp.AssetsManager.WatchBalanceChange(func() {
 		go hp.CalculateAssetsUSDBalance()
 	})
+
+	hp.AssetsManager.WatchProposalVoteDeadlines(hp.postProposalVoteReminder)
+	hp.AssetsManager.WatchDust(hp.postDustWarning)
+}
+
+// postProposalVoteReminder notifies the user through a system notification
+// and a toast that the vote of a proposal is about to end while a wallet still
+// has eligible tickets.
+func (hp *HomePage) postProposalVoteReminder(reminder *libwallet.ProposalVoteReminder) {
+	message := values.StringF(values.StrProposalVoteReminder, reminder.ProposalName,
+		reminder.BlocksLeft, reminder.WalletName, reminder.EligibleTickets)
+
+	systemNotification, err := notification.NewSystemNotification()
+	if err == nil {
+		err = systemNotification.Notify(message)
+	}
+	if err != nil {
+		log.Infof("could not initiate desktop notification, reason: %v", err)
+	}
+
+	hp.Toast.Notify(message, true)
+}
+
+// postDustWarning notifies the user through a system notification and a
+// toast that a wallet received outputs that may be a dust attack.
+func (hp *HomePage) postDustWarning(wallet sharedW.Asset, dust []*sharedW.UnspentOutput) {
+	message := values.StringF(values.StrDustReceived, wallet.GetWalletName(), len(dust))
+	if dust[0].Frozen {
+		message = values.StringF(values.StrDustFrozen, wallet.GetWalletName(), len(dust))
+	}
+
+	systemNotification, err := notification.NewSystemNotification()
+	if err == nil {
+		err = systemNotification.Notify(message)
+	}
+	if err != nil {
+		log.Infof("could not initiate desktop notification, reason: %v", err)
+	}
+
+	hp.Toast.Notify(message, true)
 }
 
 // initDEX initializes a new dex client if dex is not ready.
@@ -332,10 +386,7 @@ func (hp *HomePage) initDEX() {
 					hp.Toast.NotifyError(values.String(values.StrNotConnected))
 				} else {
 					for _, w := range walletsToSync {
-						err := w.SpvSync()
-						if err != nil {
-							log.Error(err)
-						}
+						hp.AssetsManager.RequestSync(w)
 					}
 				}
 
@@ -577,6 +628,8 @@ func (hp *HomePage) OnNavigatedFrom() {
 	}
 
 	hp.AssetsManager.RemoveAssetChange()
+	hp.AssetsManager.RemoveProposalVoteDeadlinesWatch()
+	hp.AssetsManager.RemoveDustWatch()
 	hp.ctxCancel()
 }
 
@@ -972,7 +1025,7 @@ func (hp *HomePage) notificationSettingsLayout(gtx C) D {
 
 func (hp *HomePage) startSyncing(wallet sharedW.Asset, unlock load.NeedUnlockRestore) {
 	// Watchonly wallets do not have any password neither need one.
-	if !wallet.ContainsDiscoveredAccounts() && wallet.IsLocked() && !wallet.IsWatchingOnlyWallet() {
+	if libwallet.NeedsUnlockToSync(wallet) {
 		hp.unlockWalletForSyncing(wallet, unlock)
 		return
 	}
@@ -980,10 +1033,8 @@ func (hp *HomePage) startSyncing(wallet sharedW.Asset, unlock load.NeedUnlockRes
 
 	if hp.isConnected.Load() {
 		// once network connection has been established proceed to
-		// start the wallet sync.
-		if err := wallet.SpvSync(); err != nil {
-			log.Debugf("Error starting sync: %v", err)
-		}
+		// start the wallet sync as the sync policy allows.
+		hp.AssetsManager.RequestSync(wallet)
 	}
 
 	if !atomic.CompareAndSwapUint32(&hp.startSpvSync, 0, 1) {
@@ -1007,10 +1058,8 @@ func (hp *HomePage) startSyncing(wallet sharedW.Asset, unlock load.NeedUnlockRes
 				if libutils.IsOnline() {
 					log.Info("Internet connection has been established")
 					// once network connection has been established proceed to
-					// start the wallet sync.
-					if err := wallet.SpvSync(); err != nil {
-						log.Debugf("Error starting sync: %v", err)
-					}
+					// start the wallet sync as the sync policy allows.
+					hp.AssetsManager.RequestSync(wallet)
 
 					// Trigger UI update
 					hp.ParentWindow().Reload()
@@ -1034,11 +1083,20 @@ func (hp *HomePage) startSyncing(wallet sharedW.Asset, unlock load.NeedUnlockRes
 }
 
 func (hp *HomePage) unlockWalletForSyncing(wal sharedW.Asset, unlock load.NeedUnlockRestore) {
+	if wal.IsWatchingOnlyWallet() && wal.ChainBackend() == sharedW.RPCBackend && wal.RPCPassLocked() {
+		hp.enterRPCPassForSyncing(wal, unlock)
+		return
+	}
+
+	description := values.StrResumeAccountDiscoveryInfo
+	if wal.ChainBackend() == sharedW.RPCBackend && wal.RPCPassLocked() {
+		description = values.StrUnlockRPCPasswordInfo
+	}
 	spendingPasswordModal := modal.NewCreatePasswordModal(hp.Load).
 		EnableName(false).
 		EnableConfirmPassword(false).
 		Title(values.String(values.StrUnlockWithPassword)).
-		SetDescription(values.StringF(values.StrResumeAccountDiscoveryInfo, wal.GetAssetType(), wal.GetWalletName())).
+		SetDescription(values.StringF(description, wal.GetAssetType(), wal.GetWalletName())).
 		PasswordHint(values.String(values.StrSpendingPassword)).
 		SetPositiveButtonText(values.String(values.StrUnlock)).
 		SetCancelable(false).
@@ -1051,6 +1109,10 @@ func (hp *HomePage) unlockWalletForSyncing(wal sharedW.Asset, unlock load.NeedUn
 				pm.SetError(err.Error())
 				return false
 			}
+			if wal.ContainsDiscoveredAccounts() {
+				// The wallet was only unlocked to decrypt the RPC password.
+				wal.LockWallet()
+			}
 			unlock(true)
 			pm.Dismiss()
 			hp.startSyncing(wal, unlock)
@@ -1059,6 +1121,33 @@ func (hp *HomePage) unlockWalletForSyncing(wal sharedW.Asset, unlock load.NeedUn
 	hp.ParentWindow().ShowModal(spendingPasswordModal)
 }
 
+// enterRPCPassForSyncing asks for the RPC password of the full node of a
+// watching only wallet, which isn't saved, before syncing the wallet.
+func (hp *HomePage) enterRPCPassForSyncing(wal sharedW.Asset, unlock load.NeedUnlockRestore) {
+	rpcPasswordModal := modal.NewCreatePasswordModal(hp.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrRPCPassword)).
+		SetDescription(values.StringF(values.StrEnterRPCPasswordInfo, wal.GetAssetType(), wal.GetWalletName())).
+		PasswordHint(values.String(values.StrRPCPassword)).
+		SetPositiveButtonText(values.String(values.StrUnlock)).
+		SetCancelable(false).
+		SetNegativeButtonCallback(func() {
+			unlock(false)
+		}).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			if err := wal.SetRPCPass(password); err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+			unlock(true)
+			pm.Dismiss()
+			hp.startSyncing(wal, unlock)
+			return true
+		})
+	hp.ParentWindow().ShowModal(rpcPasswordModal)
+}
+
 func (hp *HomePage) CalculateAssetsUSDBalance() {
 	if hp.AssetsManager.ExchangeRateFetchingEnabled() {
 		assetsBalance, err := hp.AssetsManager.CalculateTotalAssetsBalance(true)
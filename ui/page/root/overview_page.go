@@ -208,7 +208,7 @@ func (pg *OverviewPage) OnNavigatedTo() {
 	}
 	go pg.loadTransactions()
 
-	pg.proposalItems = components.LoadProposals(pg.Load, libwallet.ProposalCategoryAll, 0, 3, true, "")
+	pg.proposalItems = components.LoadProposals(pg.Load, &libwallet.ProposalFilter{Category: libwallet.ProposalCategoryAll, Limit: 3})
 	pg.orders = components.LoadOrders(pg.Load, 0, 3, true, "", "")
 
 	pg.listenForMixerNotifications() // listeners are stopped in OnNavigatedFrom().
@@ -1,9 +1,12 @@
 package root
 
 import (
+	"sync/atomic"
+
 	"gioui.org/font"
 	"gioui.org/layout"
 
+	"github.com/crypto-power/cryptopower/libwallet"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
@@ -37,21 +40,17 @@ func (pg *WalletSelectorPage) loadWallets() {
 	}
 
 	pg.listLock.Lock()
-	pg.walletsList[libutils.DCRWalletAsset] = walletsList[libutils.DCRWalletAsset]
-	pg.walletsList[libutils.BTCWalletAsset] = walletsList[libutils.BTCWalletAsset]
-	pg.walletsList[libutils.LTCWalletAsset] = walletsList[libutils.LTCWalletAsset]
+	for _, assetType := range pg.AssetsManager.AllAssetTypes() {
+		pg.walletsList[assetType] = walletsList[assetType]
+	}
 	pg.listLock.Unlock()
 }
 
 func (pg *WalletSelectorPage) loadBadWallets() {
 	pg.badWalletsList = make(map[libutils.AssetType][]*badWalletListItem)
 
-	dcrBadWallets := pg.AssetsManager.DCRBadWallets()
-	btcBadWallets := pg.AssetsManager.BTCBadWallets()
-	ltcBadWallets := pg.AssetsManager.LTCBadWallets()
-
-	populateBadWallets := func(assetType libutils.AssetType, badWallets map[int]*sharedW.Wallet) {
-		for _, badWallet := range badWallets {
+	for _, assetType := range pg.AssetsManager.AllAssetTypes() {
+		for _, badWallet := range pg.AssetsManager.BadWallets(assetType) {
 			listItem := &badWalletListItem{
 				Wallet:    badWallet,
 				deleteBtn: pg.Theme.OutlineButton(values.String(values.StrDelete)),
@@ -61,10 +60,6 @@ func (pg *WalletSelectorPage) loadBadWallets() {
 			pg.badWalletsList[assetType] = append(pg.badWalletsList[assetType], listItem)
 		}
 	}
-
-	populateBadWallets(libutils.DCRWalletAsset, dcrBadWallets) // dcr bad wallets
-	populateBadWallets(libutils.BTCWalletAsset, btcBadWallets) // btc bad wallets
-	populateBadWallets(libutils.LTCWalletAsset, ltcBadWallets) // ltc bad wallets
 }
 
 func (pg *WalletSelectorPage) deleteBadWallet(badWalletID int) {
@@ -344,25 +339,20 @@ func (pg *WalletSelectorPage) layoutSyncStatus(gtx C, item *walletWithBalance) D
 
 // start sync listener
 func (pg *WalletSelectorPage) listenForSyncProgressNotifications() {
-	syncListener := &sharedW.SyncProgressListener{
-		OnSyncCompleted: func() {
+	// The page only shows whether each wallet is syncing or synced, reload
+	// when the number of synced wallets changes.
+	var syncedWallets atomic.Int32
+	syncedWallets.Store(-1)
+	err := pg.AssetsManager.AddAggregateSyncProgressListener(func(progress *libwallet.AggregateSyncProgress) {
+		if syncedWallets.Swap(int32(progress.SyncedWallets)) != int32(progress.SyncedWallets) {
 			pg.ParentWindow().Reload()
-		},
-	}
-
-	allWallets := pg.AssetsManager.AllWallets()
-	for _, w := range allWallets {
-		err := w.AddSyncProgressListener(syncListener, WalletSelectorPageID)
-		if err != nil {
-			log.Errorf("Error adding sync progress listener: %v", err)
-			return
 		}
+	}, WalletSelectorPageID)
+	if err != nil {
+		log.Errorf("Error adding sync progress listener: %v", err)
 	}
 }
 
 func (pg *WalletSelectorPage) stopSyncProgressListeners() {
-	allWallets := pg.AssetsManager.AllWallets()
-	for _, w := range allWallets {
-		w.RemoveSyncProgressListener(WalletSelectorPageID)
-	}
+	pg.AssetsManager.RemoveAggregateSyncProgressListener(WalletSelectorPageID)
 }
@@ -38,6 +38,10 @@ type BackupInstructionsPage struct {
 	checkBoxes  []cryptomaterial.CheckBoxStyle
 	infoList    *layout.List
 
+	// seedSharesToggle selects a SLIP-39 split backup instead of writing
+	// down the complete seed.
+	seedSharesToggle *cryptomaterial.Switch
+
 	redirectCallback Redirectfunc
 }
 
@@ -47,7 +51,8 @@ func NewBackupInstructionsPage(l *load.Load, wallet sharedW.Asset, redirect Redi
 		GenericPageModal: app.NewGenericPageModal(BackupInstructionsPageID),
 		wallet:           wallet,
 
-		viewSeedBtn: l.Theme.Button(values.String(values.StrViewSeedPhrase)),
+		viewSeedBtn:      l.Theme.Button(values.String(values.StrViewSeedPhrase)),
+		seedSharesToggle: l.Theme.Switch(),
 
 		redirectCallback: redirect,
 	}
@@ -89,6 +94,10 @@ func (pg *BackupInstructionsPage) HandleUserInteractions(gtx C) {
 	if pg.viewSeedBtn.Clicked(gtx) {
 		if pg.verifyCheckBoxes() {
 			// TODO: Will repeat the paint cycle, just queue the next fragment to be displayed
+			if pg.seedSharesToggle.IsChecked() {
+				pg.ParentNavigator().Display(NewSaveSeedSharesPage(pg.Load, pg.wallet, pg.redirectCallback))
+				return
+			}
 			pg.ParentNavigator().Display(NewSaveSeedPage(pg.Load, pg.wallet, pg.redirectCallback))
 		}
 	}
@@ -128,7 +137,10 @@ func (pg *BackupInstructionsPage) Layout(gtx C) D {
 			promptToExit(pg.Load, pg.ParentWindow(), pg.redirectCallback)
 		},
 		Body: func(gtx C) D {
-			return pg.infoList.Layout(gtx, len(pg.checkBoxes), func(gtx C, i int) D {
+			return pg.infoList.Layout(gtx, len(pg.checkBoxes)+1, func(gtx C, i int) D {
+				if i == len(pg.checkBoxes) {
+					return pg.seedSharesToggleLayout(gtx)
+				}
 				return layout.Inset{Bottom: values.MarginPadding20}.Layout(gtx, pg.checkBoxes[i].Layout)
 			})
 		},
@@ -143,6 +155,16 @@ func (pg *BackupInstructionsPage) Layout(gtx C) D {
 	return container(gtx, isMobile, *pg.Theme, layout, "", pg.viewSeedBtn, true)
 }
 
+func (pg *BackupInstructionsPage) seedSharesToggleLayout(gtx C) D {
+	textSize16 := values.TextSizeTransform(pg.IsMobileView(), values.TextSize16)
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, pg.seedSharesToggle.Layout)
+		}),
+		layout.Rigid(pg.Theme.Label(textSize16, values.String(values.StrBackupAsSeedShares)).Layout),
+	)
+}
+
 func (pg *BackupInstructionsPage) verifyCheckBoxes() bool {
 	for _, cb := range pg.checkBoxes {
 		if !cb.CheckBox.Value {
@@ -0,0 +1,425 @@
+package seedbackup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const SaveSeedSharesPageID = "save_seed_shares"
+
+// maxShareCount is the largest number of shares and groups supported by
+// SLIP-39.
+const maxShareCount = 16
+
+// seedShare is a SLIP-39 share mnemonic and its position in the backup.
+type seedShare struct {
+	mnemonic   string
+	group      int
+	groupCount int
+}
+
+// SaveSeedSharesPage splits the wallet seed into SLIP-39 shares and shows the
+// shares one at a time. Every share is verified before the next one is shown
+// so that no complete copy of the seed is ever written down.
+type SaveSeedSharesPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet        sharedW.Asset
+	pageContainer *widget.List
+	seedList      *widget.List
+
+	backButton   cryptomaterial.IconButton
+	actionButton cryptomaterial.Button
+
+	sharesRequiredEditor cryptomaterial.Editor
+	totalSharesEditor    cryptomaterial.Editor
+	useGroupsToggle      *cryptomaterial.Switch
+	groupsEditor         cryptomaterial.Editor
+	groupsRequiredEditor cryptomaterial.Editor
+
+	shareEditor          cryptomaterial.Editor
+	seedPassphraseEditor cryptomaterial.Editor
+
+	shares     []seedShare
+	shareIndex int
+	verifying  bool
+	rows       []saveSeedRow
+
+	redirectCallback Redirectfunc
+}
+
+func NewSaveSeedSharesPage(l *load.Load, wallet sharedW.Asset, redirect Redirectfunc) *SaveSeedSharesPage {
+	pg := &SaveSeedSharesPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(SaveSeedSharesPageID),
+		wallet:           wallet,
+		actionButton:     l.Theme.Button(values.String(values.StrGenerateShares)),
+		useGroupsToggle:  l.Theme.Switch(),
+		seedList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		pageContainer: &widget.List{
+			List: layout.List{
+				Axis:      layout.Vertical,
+				Alignment: layout.Middle,
+			},
+		},
+
+		redirectCallback: redirect,
+	}
+
+	pg.actionButton.Font.Weight = font.Medium
+
+	pg.backButton = components.GetBackButton(l)
+	pg.backButton.Icon = l.Theme.Icons.ContentClear
+
+	pg.sharesRequiredEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrSharesRequired))
+	pg.sharesRequiredEditor.Editor.SingleLine = true
+	pg.sharesRequiredEditor.Editor.SetText("2")
+	pg.totalSharesEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrTotalShares))
+	pg.totalSharesEditor.Editor.SingleLine = true
+	pg.totalSharesEditor.Editor.SetText("3")
+	pg.groupsEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrShareGroups))
+	pg.groupsEditor.Editor.SingleLine = true
+	pg.groupsRequiredEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrGroupsRequired))
+	pg.groupsRequiredEditor.Editor.SingleLine = true
+
+	pg.shareEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrEnterShareToVerify))
+	pg.seedPassphraseEditor = l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSeedPassphrase))
+	pg.seedPassphraseEditor.Editor.SingleLine = true
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *SaveSeedSharesPage) OnNavigatedTo() {}
+
+// shareGroups parses the share configuration entered by the user.
+func (pg *SaveSeedSharesPage) shareGroups() (int, []sharedW.SeedShareGroup, bool) {
+	if !pg.useGroupsToggle.IsChecked() {
+		group, ok := parseShareGroup(pg.sharesRequiredEditor.Editor.Text(), pg.totalSharesEditor.Editor.Text())
+		if !ok {
+			pg.totalSharesEditor.SetError(values.String(values.StrInvalidShareGroups))
+			return 0, nil, false
+		}
+		return 1, []sharedW.SeedShareGroup{group}, true
+	}
+
+	var groups []sharedW.SeedShareGroup
+	for _, groupText := range strings.Split(pg.groupsEditor.Editor.Text(), ",") {
+		threshold, count, found := strings.Cut(strings.TrimSpace(groupText), "-of-")
+		group, ok := parseShareGroup(threshold, count)
+		if !found || !ok {
+			pg.groupsEditor.SetError(values.String(values.StrInvalidShareGroups))
+			return 0, nil, false
+		}
+		groups = append(groups, group)
+	}
+
+	groupThreshold, err := strconv.Atoi(strings.TrimSpace(pg.groupsRequiredEditor.Editor.Text()))
+	if err != nil || groupThreshold < 1 || groupThreshold > len(groups) || len(groups) > maxShareCount {
+		pg.groupsRequiredEditor.SetError(values.String(values.StrInvalidShareGroups))
+		return 0, nil, false
+	}
+	return groupThreshold, groups, true
+}
+
+func parseShareGroup(threshold, count string) (sharedW.SeedShareGroup, bool) {
+	memberThreshold, err := strconv.Atoi(strings.TrimSpace(threshold))
+	if err != nil {
+		return sharedW.SeedShareGroup{}, false
+	}
+	memberCount, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || memberThreshold < 1 || memberThreshold > memberCount || memberCount > maxShareCount {
+		return sharedW.SeedShareGroup{}, false
+	}
+	return sharedW.SeedShareGroup{MemberThreshold: memberThreshold, MemberCount: memberCount}, true
+}
+
+func (pg *SaveSeedSharesPage) generateShares() {
+	pg.totalSharesEditor.ClearError()
+	pg.groupsEditor.ClearError()
+	pg.groupsRequiredEditor.ClearError()
+	groupThreshold, groups, ok := pg.shareGroups()
+	if !ok {
+		return
+	}
+
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrConfirmShowSeed)).
+		SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
+			shareGroups, err := pg.wallet.SeedShares(password, groupThreshold, groups)
+			if err != nil {
+				m.SetError(err.Error())
+				return false
+			}
+			m.Dismiss()
+
+			pg.shares = nil
+			for i, group := range shareGroups {
+				for _, mnemonic := range group {
+					pg.shares = append(pg.shares, seedShare{mnemonic: mnemonic, group: i + 1, groupCount: len(groups)})
+				}
+			}
+			pg.showShare(0)
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+func (pg *SaveSeedSharesPage) showShare(index int) {
+	pg.shareIndex = index
+	pg.verifying = false
+	pg.shareEditor.Editor.SetText("")
+	pg.shareEditor.ClearError()
+
+	words := strings.Fields(pg.shares[index].mnemonic)
+	if pg.IsMobileView() {
+		pg.rows = divideWordsIntoRows(words, 2)
+	} else {
+		pg.rows = divideWordsIntoRows(words, 3)
+	}
+}
+
+// verifyShare compares the share entered by the user with the share that was
+// shown. The wallet is marked as backed up once the last share is verified.
+func (pg *SaveSeedSharesPage) verifyShare() {
+	share := pg.shares[pg.shareIndex].mnemonic
+	if strings.Join(strings.Fields(strings.ToLower(pg.shareEditor.Editor.Text())), " ") != share {
+		pg.shareEditor.SetError(values.String(values.StrShareMismatch))
+		return
+	}
+
+	if pg.shareIndex < len(pg.shares)-1 {
+		pg.showShare(pg.shareIndex + 1)
+		return
+	}
+
+	mnemonics := make([]string, len(pg.shares))
+	for i := range pg.shares {
+		mnemonics[i] = pg.shares[i].mnemonic
+	}
+
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrConfirmToVerifySeed)).
+		SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
+			_, err := pg.wallet.VerifySeedSharesForWallet(mnemonics, pg.seedPassphraseEditor.Editor.Text(), password)
+			if err != nil {
+				if err.Error() == utils.ErrInvalid {
+					msg := values.String(values.StrSeedValidationFailed)
+					errModal := modal.NewErrorModal(pg.Load, msg, modal.DefaultClickFunc())
+					pg.ParentWindow().ShowModal(errModal)
+					m.Dismiss()
+					return false
+				}
+
+				m.SetError(err.Error())
+				return false
+			}
+
+			pg.shares = nil
+			pg.ParentNavigator().Display(NewBackupSuccessPage(pg.Load, pg.redirectCallback))
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *SaveSeedSharesPage) HandleUserInteractions(gtx C) {
+	if !pg.actionButton.Clicked(gtx) {
+		return
+	}
+
+	switch {
+	case len(pg.shares) == 0:
+		pg.generateShares()
+	case !pg.verifying:
+		pg.verifying = true
+	default:
+		pg.verifyShare()
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *SaveSeedSharesPage) OnNavigatedFrom() {}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *SaveSeedSharesPage) Layout(gtx C) D {
+	var body layout.Widget
+	var infoText string
+	switch {
+	case len(pg.shares) == 0:
+		body = pg.sharesSetupLayout
+		infoText = values.String(values.StrSeedSharesInfo)
+		pg.actionButton.Text = values.String(values.StrGenerateShares)
+	case !pg.verifying:
+		body = pg.shareLayout
+		pg.actionButton.Text = values.String(values.StrWroteShare)
+	default:
+		body = pg.verifyShareLayout
+		pg.actionButton.Text = values.String(values.StrVerify)
+	}
+
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrSeedShares),
+		BackButton: pg.backButton,
+		Back: func() {
+			promptToExit(pg.Load, pg.ParentWindow(), pg.redirectCallback)
+		},
+		Body: func(gtx C) D {
+			return pg.Theme.List(pg.pageContainer).Layout(gtx, 1, func(gtx C, _ int) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(body),
+					layout.Rigid(layout.Spacer{Height: values.MarginPadding130}.Layout),
+				)
+			})
+		},
+	}
+	layout := func(gtx C) D {
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+	return container(gtx, pg.IsMobileView(), *pg.Theme, layout, infoText, pg.actionButton, true)
+}
+
+func (pg *SaveSeedSharesPage) sharesSetupLayout(gtx C) D {
+	textSize16 := values.TextSizeTransform(pg.IsMobileView(), values.TextSize16)
+	editor := func(e cryptomaterial.Editor) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, e.Layout)
+		})
+	}
+
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, pg.useGroupsToggle.Layout)
+				}),
+				layout.Rigid(pg.Theme.Label(textSize16, values.String(values.StrUseShareGroups)).Layout),
+			)
+		}),
+	}
+	if pg.useGroupsToggle.IsChecked() {
+		children = append(children, editor(pg.groupsEditor), editor(pg.groupsRequiredEditor))
+	} else {
+		children = append(children, editor(pg.sharesRequiredEditor), editor(pg.totalSharesEditor))
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+func (pg *SaveSeedSharesPage) shareTitle() string {
+	share := pg.shares[pg.shareIndex]
+	title := values.StringF(values.StrShareXofY, pg.shareIndex+1, len(pg.shares))
+	if share.groupCount > 1 {
+		title = fmt.Sprintf("%s, %s", title, values.StringF(values.StrShareGroupX, share.group))
+	}
+	return title
+}
+
+func (pg *SaveSeedSharesPage) shareLayout(gtx C) D {
+	label := pg.Theme.Label(values.TextSize14, pg.shareTitle())
+	label.Color = pg.Theme.Color.GrayText1
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Orientation: layout.Vertical,
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Margin:      layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding2},
+		Padding:     layout.Inset{Top: values.MarginPadding16, Right: values.MarginPadding16, Bottom: values.MarginPadding8, Left: values.MarginPadding16},
+	}.Layout(gtx,
+		layout.Rigid(label.Layout),
+		layout.Rigid(func(gtx C) D {
+			return pg.Theme.List(pg.seedList).Layout(gtx, len(pg.rows), func(gtx C, index int) D {
+				return pg.shareRow(gtx, pg.rows[index])
+			})
+		}),
+	)
+}
+
+func (pg *SaveSeedSharesPage) shareRow(gtx C, row saveSeedRow) D {
+	topMargin := values.MarginPadding8
+	if row.rowIndex == 0 {
+		topMargin = values.MarginPadding16
+	}
+
+	columns := 3
+	if pg.IsMobileView() {
+		columns = 2
+	}
+	wordCount := len(strings.Fields(pg.shares[pg.shareIndex].mnemonic))
+	numRows := (wordCount + columns - 1) / columns
+	itemWidth := gtx.Constraints.Max.X / columns
+	itemIndex := row.rowIndex + 1
+	flexChils := []layout.FlexChild{
+		seedItem(pg.Theme, itemWidth, itemIndex, row.word1),
+		seedItem(pg.Theme, itemWidth, itemIndex+numRows, row.word2),
+	}
+	if columns == 3 {
+		flexChils = append(flexChils, seedItem(pg.Theme, itemWidth, itemIndex+numRows*2, row.word3))
+	}
+	return cryptomaterial.LinearLayout{
+		Width:  cryptomaterial.MatchParent,
+		Height: cryptomaterial.WrapContent,
+		Margin: layout.Inset{Top: topMargin},
+	}.Layout(gtx, flexChils...)
+}
+
+func (pg *SaveSeedSharesPage) verifyShareLayout(gtx C) D {
+	textSize16 := values.TextSizeTransform(pg.IsMobileView(), values.TextSize16)
+	label := pg.Theme.Label(textSize16, pg.shareTitle())
+	label.Color = pg.Theme.Color.GrayText1
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(label.Layout),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, pg.shareEditor.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			// The seed passphrase is verified together with the last share.
+			if !pg.wallet.WalletHasSeedPassphrase() || pg.shareIndex < len(pg.shares)-1 {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, pg.seedPassphraseEditor.Layout)
+		}),
+	)
+}
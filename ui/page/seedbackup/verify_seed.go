@@ -48,6 +48,8 @@ type VerifySeedPage struct {
 	seedInputEditor  cryptomaterial.Editor
 	verifySeedButton cryptomaterial.Button
 	wordSeedType     sharedW.WordSeedType
+
+	seedPassphraseEditor cryptomaterial.Editor
 }
 
 func NewVerifySeedPage(l *load.Load, wallet sharedW.Asset, seed string, wordSeedType sharedW.WordSeedType, redirect Redirectfunc) *VerifySeedPage {
@@ -78,6 +80,9 @@ func NewVerifySeedPage(l *load.Load, wallet sharedW.Asset, seed string, wordSeed
 	pg.seedInputEditor.Editor.SingleLine = false
 	pg.seedInputEditor.Editor.SetText("")
 
+	pg.seedPassphraseEditor = l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSeedPassphrase))
+	pg.seedPassphraseEditor.Editor.SingleLine = true
+
 	pg.verifySeedButton = l.Theme.Button("")
 	pg.verifySeedButton.Font.Weight = font.Medium
 	pg.verifySeedButton.SetEnabled(false)
@@ -184,7 +189,7 @@ func (pg *VerifySeedPage) verifySeed() {
 			if !pg.toggleSeedInput.IsChecked() {
 				seed = pg.selectedSeedPhrase()
 			}
-			_, err := pg.wallet.VerifySeedForWallet(seed, password)
+			_, err := pg.wallet.VerifySeedForWallet(seed, pg.seedPassphraseEditor.Editor.Text(), password)
 			if err != nil {
 				if err.Error() == utils.ErrInvalid {
 					msg := values.String(values.StrSeedValidationFailed)
@@ -276,6 +281,13 @@ func (pg *VerifySeedPage) Layout(gtx C) D {
 					label.Color = pg.Theme.Color.GrayText1
 					return label.Layout(gtx)
 				}),
+				layout.Rigid(func(gtx C) D {
+					// The seed passphrase is verified together with the seed.
+					if !pg.wallet.WalletHasSeedPassphrase() {
+						return D{}
+					}
+					return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, pg.seedPassphraseEditor.Layout)
+				}),
 				layout.Rigid(layout.Spacer{Height: values.MarginPadding16}.Layout),
 				layout.Rigid(func(gtx C) D {
 					if pg.toggleSeedInput.IsChecked() {
@@ -0,0 +1,178 @@
+package send
+
+import (
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/libwallet"
+	libUtil "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// addressBookModal lists the saved contacts of an asset type so that one can
+// be picked as the destination of a transaction. The destination address can
+// also be saved as a new contact.
+type addressBookModal struct {
+	*load.Load
+	*cryptomaterial.Modal
+
+	assetType libUtil.AssetType
+	address   string
+
+	searchEditor cryptomaterial.Editor
+	contactList  *cryptomaterial.ClickableList
+	contacts     []*libwallet.Contact
+
+	nameEditor cryptomaterial.Editor
+	noteEditor cryptomaterial.Editor
+	saveBtn    cryptomaterial.Button
+
+	contactSelected func(*libwallet.Contact)
+}
+
+func newAddressBookModal(l *load.Load, assetType libUtil.AssetType, address string) *addressBookModal {
+	abm := &addressBookModal{
+		Load:      l,
+		Modal:     l.Theme.ModalFloatTitle("address_book_modal", l.IsMobileView(), nil),
+		assetType: assetType,
+		address:   address,
+
+		searchEditor: l.Theme.SearchEditor(new(widget.Editor), values.String(values.StrSearch), l.Theme.Icons.SearchIcon),
+		contactList:  l.Theme.NewClickableList(layout.Vertical),
+		nameEditor:   l.Theme.Editor(new(widget.Editor), values.String(values.StrContactName)),
+		noteEditor:   l.Theme.Editor(new(widget.Editor), values.String(values.StrNote)),
+		saveBtn:      l.Theme.Button(values.String(values.StrSaveContact)),
+	}
+	abm.searchEditor.Editor.SingleLine = true
+	abm.nameEditor.Editor.SingleLine = true
+	abm.noteEditor.Editor.SingleLine = true
+
+	return abm
+}
+
+func (abm *addressBookModal) onContactSelected(callback func(*libwallet.Contact)) *addressBookModal {
+	abm.contactSelected = callback
+	return abm
+}
+
+func (abm *addressBookModal) OnResume() {
+	abm.searchContacts()
+}
+
+func (abm *addressBookModal) OnDismiss() {}
+
+func (abm *addressBookModal) searchContacts() {
+	contacts, err := abm.AssetsManager.SearchContacts(abm.assetType, abm.searchEditor.Editor.Text())
+	if err != nil {
+		log.Errorf("Error loading contacts: %v", err)
+	}
+	abm.contacts = contacts
+}
+
+// canSaveAddress returns true if the destination address is valid and is not
+// saved yet.
+func (abm *addressBookModal) canSaveAddress() bool {
+	return abm.address != "" && abm.AssetsManager.IsAddressValid(abm.assetType, abm.address) &&
+		abm.AssetsManager.ContactForAddress(abm.assetType, abm.address) == nil
+}
+
+func (abm *addressBookModal) Handle(gtx C) {
+	for {
+		event, ok := abm.searchEditor.Editor.Update(gtx)
+		if !ok {
+			break
+		}
+		if _, ok := event.(widget.ChangeEvent); ok {
+			abm.searchContacts()
+		}
+	}
+
+	abm.saveBtn.SetEnabled(abm.nameEditor.Editor.Text() != "")
+	if abm.saveBtn.Clicked(gtx) {
+		contact, err := abm.AssetsManager.AddContact(abm.assetType, abm.nameEditor.Editor.Text(), abm.address, abm.noteEditor.Editor.Text())
+		if err != nil {
+			abm.nameEditor.SetError(values.TranslateErr(err.Error()))
+			return
+		}
+		abm.contactSelected(contact)
+		abm.Dismiss()
+	}
+
+	if clicked, index := abm.contactList.ItemClicked(); clicked {
+		abm.contactSelected(abm.contacts[index])
+		abm.Dismiss()
+	}
+
+	if abm.Modal.BackdropClicked(gtx, true) {
+		abm.Dismiss()
+	}
+}
+
+func (abm *addressBookModal) Layout(gtx C) D {
+	textSize20 := values.TextSizeTransform(abm.IsMobileView(), values.TextSize20)
+	textSize14 := values.TextSizeTransform(abm.IsMobileView(), values.TextSize14)
+	textSize16 := values.TextSizeTransform(abm.IsMobileView(), values.TextSize16)
+	return abm.Modal.Layout(gtx, []layout.Widget{
+		func(gtx C) D {
+			title := abm.Theme.Label(textSize20, values.String(values.StrAddressBook))
+			title.Font.Weight = font.SemiBold
+			return title.Layout(gtx)
+		},
+		abm.searchEditor.Layout,
+		func(gtx C) D {
+			if len(abm.contacts) == 0 {
+				noContacts := abm.Theme.Label(textSize14, values.String(values.StrNoContacts))
+				noContacts.Color = abm.Theme.Color.GrayText2
+				return noContacts.Layout(gtx)
+			}
+
+			return abm.contactList.Layout(gtx, len(abm.contacts), func(gtx C, i int) D {
+				abm.Modal.ShowScrollbar(true)
+				contact := abm.contacts[i]
+				return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(abm.Theme.Label(textSize16, contact.Name).Layout),
+						layout.Rigid(func(gtx C) D {
+							address := abm.Theme.Label(textSize14, contact.Address)
+							address.Color = abm.Theme.Color.GrayText2
+							return address.Layout(gtx)
+						}),
+						layout.Rigid(func(gtx C) D {
+							if contact.Note == "" {
+								return D{}
+							}
+							note := abm.Theme.Label(textSize14, contact.Note)
+							note.Color = abm.Theme.Color.GrayText3
+							return note.Layout(gtx)
+						}),
+					)
+				})
+			})
+		},
+		func(gtx C) D {
+			if !abm.canSaveAddress() {
+				return D{}
+			}
+
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					txt := abm.Theme.Label(textSize14, abm.address)
+					txt.Color = abm.Theme.Color.GrayText2
+					return txt.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, abm.nameEditor.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, abm.noteEditor.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, abm.saveBtn.Layout)
+				}),
+			)
+		},
+	})
+}
@@ -13,6 +13,7 @@ import (
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/preference"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
 
@@ -39,6 +40,14 @@ func (pg *Page) initLayoutWidgets() {
 	pg.nextButton.SetEnabled(false)
 
 	pg.toCoinSelection = pg.Theme.NewClickable(false)
+
+	strategies := make([]cryptomaterial.DropDownItem, 0, len(preference.CoinSelectionOptions))
+	for _, option := range preference.CoinSelectionOptions {
+		strategies = append(strategies, cryptomaterial.DropDownItem{Text: values.String(option.Value)})
+	}
+	pg.coinSelectionDropDown = pg.Theme.DropDown(strategies, nil, values.WalletsDropdownGroup, false)
+	pg.coinSelectionDropDown.BorderColor = &pg.Theme.Color.Gray2
+	pg.resetCoinSelectionStrategy()
 }
 
 // Layout draws the page UI components into the provided layout context
@@ -261,29 +270,45 @@ func (pg *Page) coinSelectionSection(gtx C) D {
 		CornerRadius: values.MarginPadding10,
 		Width:        values.MarginPadding2,
 	}
-	return border.Layout(gtx, func(gtx C) D {
-		return pg.Theme.Card().Layout(gtx, func(gtx C) D {
-			inset := layout.UniformInset(values.MarginPadding15)
-			return inset.Layout(gtx, func(gtx C) D {
-				textLabel := pg.Theme.Label(values.TextSizeTransform(pg.IsMobileView(), values.TextSize16), selectedOption)
-				textLabel.Font.Weight = font.SemiBold
-				return cryptomaterial.LinearLayout{
-					Width:       cryptomaterial.WrapContent,
-					Height:      cryptomaterial.WrapContent,
-					Orientation: layout.Horizontal,
-					Alignment:   layout.Middle,
-					Clickable:   pg.toCoinSelection,
-				}.Layout2(gtx, func(gtx C) D {
-					gtx.Constraints.Min.X = gtx.Constraints.Max.X
-					return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceBetween}.Layout(gtx,
-						layout.Rigid(textLabel.Layout),
-						layout.Rigid(pg.Theme.NewIcon(pg.Theme.Icons.ChevronRight).Layout20dp),
-					)
-				})
+	selectionMode := func(gtx C) D {
+		return border.Layout(gtx, func(gtx C) D {
+			return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+				inset := layout.UniformInset(values.MarginPadding15)
+				return inset.Layout(gtx, func(gtx C) D {
+					textLabel := pg.Theme.Label(values.TextSizeTransform(pg.IsMobileView(), values.TextSize16), selectedOption)
+					textLabel.Font.Weight = font.SemiBold
+					return cryptomaterial.LinearLayout{
+						Width:       cryptomaterial.WrapContent,
+						Height:      cryptomaterial.WrapContent,
+						Orientation: layout.Horizontal,
+						Alignment:   layout.Middle,
+						Clickable:   pg.toCoinSelection,
+					}.Layout2(gtx, func(gtx C) D {
+						gtx.Constraints.Min.X = gtx.Constraints.Max.X
+						return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceBetween}.Layout(gtx,
+							layout.Rigid(textLabel.Layout),
+							layout.Rigid(pg.Theme.NewIcon(pg.Theme.Icons.ChevronRight).Layout20dp),
+						)
+					})
 
+				})
 			})
 		})
-	})
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(selectionMode),
+		layout.Rigid(func(gtx C) D {
+			// The strategy only applies to the outputs selected automatically.
+			if selectedOption != automaticCoinSelection {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				pg.coinSelectionDropDown.Width = gtx.Metric.PxToDp(gtx.Constraints.Max.X)
+				return pg.coinSelectionDropDown.Layout(gtx)
+			})
+		}),
+	)
 }
 
 func (pg *Page) balanceSection(gtx C) D {
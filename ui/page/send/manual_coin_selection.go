@@ -16,6 +16,7 @@ import (
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
 
@@ -32,6 +33,12 @@ type UTXOInfo struct {
 	*sharedW.UnspentOutput
 	checkbox    cryptomaterial.CheckBoxStyle
 	addressCopy *cryptomaterial.Clickable
+	// editLabel edits the label of the utxo and freeze toggles whether it
+	// is frozen. Frozen utxos cannot be selected.
+	editLabel *cryptomaterial.Clickable
+	freeze    *cryptomaterial.Clickable
+	// dust is true if the utxo may be a dust attack.
+	dust bool
 }
 
 type AccountUTXOInfo struct {
@@ -173,11 +180,12 @@ func NewManualCoinSelectionPage(l *load.Load, sendPage *Page) *ManualCoinSelecti
 		{direction: layout.Center, weight: 0.1}, // Component 1
 		{direction: layout.E, weight: 0.17},     // Component 2
 		{direction: layout.W, weight: 0.02},     // Spacing Column
-		{direction: layout.W, weight: 0.26},     // Component 3
+		{direction: layout.W, weight: 0.21},     // Component 3
 		{direction: layout.W, weight: 0.005},    // Spacing Column
 		{direction: layout.E, weight: 0.18},     // Component 4
 		{direction: layout.W, weight: 0.02},     // Spacing Column
-		{direction: layout.E, weight: 0.22},     // Component 5
+		{direction: layout.E, weight: 0.17},     // Component 5
+		{direction: layout.E, weight: 0.1},      // Component 6
 	}
 
 	// clickables defines the event handlers mapped to an individual title field.
@@ -237,6 +245,15 @@ func (pg *ManualCoinSelectionPage) fetchAccountsInfo() error {
 		pg.selectedUTXOrows = pg.sendPage.selectedUTXOs.selectedUTXOs
 	}
 
+	dust := make(map[*sharedW.UnspentOutput]bool)
+	dustUTXOs, err := sharedW.FindDust(pg.sendPage.selectedWallet, info)
+	if err != nil {
+		log.Errorf("Error looking for dust: %v", err)
+	}
+	for _, utxo := range dustUTXOs {
+		dust[utxo] = true
+	}
+
 	rowInfo := make([]*UTXOInfo, len(info))
 	// create checkboxes and address copy components for all the utxos available.
 	for i, row := range info {
@@ -244,11 +261,19 @@ func (pg *ManualCoinSelectionPage) fetchAccountsInfo() error {
 			UnspentOutput: row,
 			checkbox:      pg.Theme.CheckBox(new(widget.Bool), ""),
 			addressCopy:   pg.Theme.NewClickable(false),
+			editLabel:     pg.Theme.NewClickable(true),
+			freeze:        pg.Theme.NewClickable(true),
+			dust:          dust[row],
 		}
 
 		info.checkbox.CheckBoxStyle.Size = 20
 		// Check if TxID match. If true, set checked to true.
 		_, info.checkbox.CheckBox.Value = previousUTXOs[info.TxID]
+		if info.Frozen && info.checkbox.CheckBox.Value {
+			// The utxo was frozen after it was selected.
+			info.checkbox.CheckBox.Value = false
+			pg.deselectUTXO(info)
+		}
 
 		rowInfo[i] = info
 	}
@@ -322,6 +347,15 @@ func (pg *ManualCoinSelectionPage) HandleUserInteractions(gtx C) {
 		}
 	}
 
+	for _, record := range pg.accountUTXOs.Details {
+		if record.freeze.Clicked(gtx) {
+			pg.toggleFrozen(record)
+		}
+		if record.editLabel.Clicked(gtx) {
+			pg.editLabel(record)
+		}
+	}
+
 	// Update Summary information as the last section when handling events.
 	for i := 0; i < len(pg.accountUTXOs.Details); i++ {
 		record := pg.accountUTXOs.Details[i]
@@ -330,14 +364,7 @@ func (pg *ManualCoinSelectionPage) HandleUserInteractions(gtx C) {
 				pg.selectedUTXOrows = append(pg.selectedUTXOrows, record.UnspentOutput)
 				pg.selectedAmount += record.Amount.ToCoin()
 			} else {
-				for index, item := range pg.selectedUTXOrows {
-					if item.TxID == record.TxID {
-						copy(pg.selectedUTXOrows[index:], pg.selectedUTXOrows[index+1:])
-						pg.selectedUTXOrows = pg.selectedUTXOrows[:len(pg.selectedUTXOrows)-1]
-						break
-					}
-				}
-				pg.selectedAmount -= record.Amount.ToCoin()
+				pg.deselectUTXO(record)
 			}
 
 			pg.updateSummaryInfo()
@@ -345,6 +372,52 @@ func (pg *ManualCoinSelectionPage) HandleUserInteractions(gtx C) {
 	}
 }
 
+func (pg *ManualCoinSelectionPage) deselectUTXO(record *UTXOInfo) {
+	for index, item := range pg.selectedUTXOrows {
+		if item.TxID == record.TxID {
+			copy(pg.selectedUTXOrows[index:], pg.selectedUTXOrows[index+1:])
+			pg.selectedUTXOrows = pg.selectedUTXOrows[:len(pg.selectedUTXOrows)-1]
+			break
+		}
+	}
+	pg.selectedAmount -= record.Amount.ToCoin()
+}
+
+// toggleFrozen freezes or unfreezes the utxo, a utxo that is frozen is
+// removed from the selection.
+func (pg *ManualCoinSelectionPage) toggleFrozen(record *UTXOInfo) {
+	frozen := !record.Frozen
+	if err := pg.sendPage.selectedWallet.SetOutputFrozen(record.TxID, record.Vout, frozen); err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+	record.Frozen = frozen
+
+	if frozen && record.checkbox.CheckBox.Value {
+		record.checkbox.CheckBox.Value = false
+		pg.deselectUTXO(record)
+		pg.updateSummaryInfo()
+	}
+}
+
+func (pg *ManualCoinSelectionPage) editLabel(record *UTXOInfo) {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrDescriptionNote)).
+		SetText(record.Label).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(label string, tim *modal.TextInputModal) bool {
+			if err := pg.sendPage.selectedWallet.SetOutputLabel(record.TxID, record.Vout, label); err != nil {
+				tim.SetError(err.Error())
+				return false
+			}
+			record.Label = strings.TrimSpace(label)
+			return true
+		})
+	textModal.Title(values.String(values.StrEditUTXOLabel)).
+		SetPositiveButtonText(values.String(values.StrSave))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
 func (pg *ManualCoinSelectionPage) updateSummaryInfo() {
 	pg.txSize.Text = pg.computeUTXOsSize()
 	pg.selectedUTXOs.Text = fmt.Sprintf("%d", len(pg.selectedUTXOrows))
@@ -565,7 +638,7 @@ func (pg *ManualCoinSelectionPage) accountListItemsSection(gtx C, utxos []*UTXOI
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 			layout.Rigid(func(gtx C) D {
 				return pg.rowItemsSection(gtx, nil, pg.amountLabel, nil, pg.addressLabel,
-					nil, pg.confirmationsLabel, nil, pg.dateLabel)
+					nil, pg.confirmationsLabel, nil, pg.dateLabel, nil)
 			}),
 			layout.Rigid(func(gtx C) D {
 				gtx.Constraints.Min.X = gtx.Constraints.Max.X
@@ -586,10 +659,37 @@ func (pg *ManualCoinSelectionPage) accountListItemsSection(gtx C, utxos []*UTXOI
 							}
 
 							addressComponent := func(gtx C) D {
-								return v.addressCopy.Layout(gtx, addresslabel.label.Layout)
+								return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+									layout.Rigid(func(gtx C) D {
+										return v.addressCopy.Layout(gtx, addresslabel.label.Layout)
+									}),
+									layout.Rigid(func(gtx C) D {
+										if v.Label == "" {
+											return D{}
+										}
+										lbl := pg.Theme.Label(values.TextSizeTransform(pg.IsMobileView(), values.TextSize12), v.Label)
+										lbl.Color = pg.Theme.Color.GrayText2
+										lbl.MaxLines = 1
+										return lbl.Layout(gtx)
+									}),
+									layout.Rigid(func(gtx C) D {
+										if !v.dust {
+											return D{}
+										}
+										lbl := pg.Theme.Label(values.TextSizeTransform(pg.IsMobileView(), values.TextSize12), values.String(values.StrDust))
+										lbl.Color = pg.Theme.Color.Warning
+										return lbl.Layout(gtx)
+									}),
+								)
+							}
+
+							var selectComponent interface{} = checkButton
+							if v.Frozen {
+								// Frozen utxos cannot be selected.
+								selectComponent = nil
 							}
-							return pg.rowItemsSection(gtx, checkButton, amountLabel, nil, addressComponent,
-								nil, confirmationsLabel, nil, dateLabel)
+							return pg.rowItemsSection(gtx, selectComponent, amountLabel, nil, addressComponent,
+								nil, confirmationsLabel, nil, dateLabel, pg.utxoActions(v))
 						}),
 						layout.Rigid(func(gtx C) D {
 							// No divider for last row
@@ -607,6 +707,29 @@ func (pg *ManualCoinSelectionPage) accountListItemsSection(gtx C, utxos []*UTXOI
 	})
 }
 
+// utxoActions lays out the buttons that edit the label of the utxo and
+// freeze or unfreeze it.
+func (pg *ManualCoinSelectionPage) utxoActions(v *UTXOInfo) func(gtx C) D {
+	return func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+					return v.editLabel.Layout(gtx, pg.Theme.Icons.EditIcon.Layout16dp)
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				icon := pg.Theme.NewIcon(pg.Theme.Icons.LockOpenIcon)
+				icon.Color = pg.Theme.Color.Gray3
+				if v.Frozen {
+					icon = pg.Theme.NewIcon(pg.Theme.Icons.LockIcon)
+					icon.Color = pg.Theme.Color.Danger
+				}
+				return v.freeze.Layout(gtx, icon.Layout16dp)
+			}),
+		)
+	}
+}
+
 func (pg *ManualCoinSelectionPage) rowItemsSection(gtx C, components ...interface{}) D {
 	getRowItem := func(index int) layout.Widget {
 		var widget layout.Widget
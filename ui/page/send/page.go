@@ -15,6 +15,7 @@ import (
 	"github.com/crypto-power/cryptopower/ui/load"
 	"github.com/crypto-power/cryptopower/ui/modal"
 	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/preference"
 	"github.com/crypto-power/cryptopower/ui/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
@@ -69,6 +70,9 @@ type Page struct {
 
 	toCoinSelection *cryptomaterial.Clickable
 	advanceOptions  *cryptomaterial.Collapsible
+	// coinSelectionDropDown selects the strategy used to fund the
+	// transaction when the outputs are not selected manually.
+	coinSelectionDropDown *cryptomaterial.DropDown
 
 	selectedUTXOs      selectedUTXOsInfo
 	navigateToSyncBtn  cryptomaterial.Button
@@ -228,6 +232,9 @@ func (pg *Page) initModalWalletSelector(wallet sharedW.Asset) {
 				go pg.feeRateSelector.UpdatedFeeRate(pg.selectedWallet)
 				pg.setAssetTypeForRecipients()
 			}
+			if pg.coinSelectionDropDown != nil {
+				pg.resetCoinSelectionStrategy()
+			}
 
 		}).
 		Setup()
@@ -248,6 +255,23 @@ func (pg *Page) RestyleWidgets() {
 	}
 }
 
+// resetCoinSelectionStrategy selects the default coin selection strategy of
+// the selected wallet.
+func (pg *Page) resetCoinSelectionStrategy() {
+	strategy := string(pg.selectedWallet.DefaultCoinSelectionStrategy())
+	for _, option := range preference.CoinSelectionOptions {
+		if option.Key == strategy {
+			pg.coinSelectionDropDown.SetSelectedValue(values.String(option.Value))
+		}
+	}
+}
+
+// coinSelectionStrategy returns the coin selection strategy selected for the
+// transaction.
+func (pg *Page) coinSelectionStrategy() sharedW.CoinSelectionStrategy {
+	return sharedW.CoinSelectionStrategy(preference.CoinSelectionOptions[pg.coinSelectionDropDown.SelectedIndex()].Key)
+}
+
 func (pg *Page) UpdateSelectedUTXOs(utxos []*sharedW.UnspentOutput) {
 	pg.selectedUTXOs = selectedUTXOsInfo{
 		selectedUTXOs: utxos,
@@ -351,6 +375,9 @@ func (pg *Page) constructTx() {
 	}
 
 	err := pg.selectedWallet.NewUnsignedTx(sourceAccount.Number, selectedUTXOs)
+	if err == nil {
+		err = pg.selectedWallet.SetCoinSelectionStrategy(pg.coinSelectionStrategy())
+	}
 	if err != nil {
 		pg.setRecipientsAmountErr(err)
 		pg.clearEstimates()
@@ -594,6 +621,10 @@ func (pg *Page) HandleUserInteractions(gtx C) {
 	// 	go pg.fetchExchangeRate()
 	// }
 
+	if pg.coinSelectionDropDown.Changed(gtx) {
+		pg.validateAndConstructTx()
+	}
+
 	if pg.toCoinSelection.Clicked(gtx) {
 		if len(pg.getDestinationAddresses()) == len(pg.recipients) {
 			if pg.modalLayout != nil {
@@ -640,6 +671,14 @@ func (pg *Page) HandleUserInteractions(gtx C) {
 	// handle recipient user interactions
 	for _, re := range pg.recipients {
 		re.HandleUserInteractions(gtx)
+
+		if re.sendDestination.addressBookBtn.Button.Clicked(gtx) {
+			dst := re.sendDestination
+			address := strings.TrimSpace(dst.destinationAddressEditor.Editor.Text())
+			addressBook := newAddressBookModal(pg.Load, pg.selectedWallet.GetAssetType(), address).
+				onContactSelected(dst.selectContact)
+			pg.ParentWindow().ShowModal(addressBook)
+		}
 	}
 }
 
@@ -104,15 +104,7 @@ func (rp *recipient) initializeAccountSelectors(sourceAccount *sharedW.Account)
 
 func (rp *recipient) isShowSendToWallet() bool {
 	sourceWalletSelected := rp.sendDestination.walletDropdown.SelectedWallet()
-	var wallets []sharedW.Asset
-	switch sourceWalletSelected.GetAssetType() {
-	case libUtil.BTCWalletAsset:
-		wallets = append(wallets, rp.AssetsManager.AllBTCWallets()...)
-	case libUtil.DCRWalletAsset:
-		wallets = append(wallets, rp.AssetsManager.AllDCRWallets()...)
-	case libUtil.LTCWalletAsset:
-		wallets = append(wallets, rp.AssetsManager.AllLTCWallets()...)
-	}
+	wallets := rp.AssetsManager.AssetWallets(sourceWalletSelected.GetAssetType())
 
 	if len(wallets) == 1 {
 		account, err := wallets[0].GetAccountsRaw()
@@ -219,7 +211,7 @@ func (rp *recipient) recipientLayout(index int, showIcon bool) layout.Widget {
 			layout.Rigid(func(gtx C) D {
 				layoutBody := func(gtx C) D {
 					txt := fmt.Sprintf("%s %s", values.String(values.StrDestination), values.String(values.StrAddress))
-					return rp.contentWrapper(gtx, txt, rp.sendDestination.destinationAddressEditor.Layout)
+					return rp.contentWrapper(gtx, txt, rp.destinationAddressLayout)
 				}
 
 				if !rp.isShowSendToWallet() {
@@ -238,6 +230,27 @@ func (rp *recipient) recipientLayout(index int, showIcon bool) layout.Widget {
 	}
 }
 
+// destinationAddressLayout lays out the address editor with the address book
+// button, and the name of the contact if the address is saved.
+func (rp *recipient) destinationAddressLayout(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, rp.sendDestination.destinationAddressEditor.Layout),
+				layout.Rigid(rp.sendDestination.addressBookBtn.Layout),
+			)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if rp.sendDestination.contact == nil {
+				return D{}
+			}
+			name := rp.Theme.Label(values.TextSizeTransform(rp.IsMobileView(), values.TextSize14), rp.sendDestination.contact.Name)
+			name.Color = rp.Theme.Color.Success
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, name.Layout)
+		}),
+	)
+}
+
 func (rp *recipient) topLayout(gtx C, index int) D {
 	txt := fmt.Sprintf("%s: %s %v", values.String(values.StrTo), values.String(values.StrRecipient), index)
 	titleTxt := rp.Theme.Label(values.TextSizeTransform(rp.IsMobileView(), values.TextSize16), txt)
@@ -93,6 +93,10 @@ func (scm *sendConfirmModal) broadcastTransaction() {
 			scm.SetError(err.Error())
 			return
 		}
+		for _, address := range scm.destinationAddress {
+			scm.AssetsManager.MarkContactUsed(scm.asset.GetAssetType(), address)
+		}
+
 		successModal := modal.NewSuccessModal(scm.Load, values.String(values.StrTxSent), modal.DefaultClickFunc())
 		scm.ParentWindow().ShowModal(successModal)
 
@@ -357,8 +361,21 @@ func (scm *sendConfirmModal) toDestinationAddressLayout(address string, gtx C) D
 	inset := layout.Inset{
 		Left: values.MarginPadding5,
 	}
+	contact := scm.AssetsManager.ContactForAddress(scm.asset.GetAssetType(), address)
 	return inset.Layout(gtx, func(gtx C) D {
-		return layout.UniformInset(values.MarginPadding2).Layout(gtx, scm.Theme.Body2(address).Layout)
+		return layout.UniformInset(values.MarginPadding2).Layout(gtx, func(gtx C) D {
+			if contact == nil {
+				return scm.Theme.Body2(address).Layout(gtx)
+			}
+			return layout.Flex{Axis: layout.Vertical, Alignment: layout.End}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					name := scm.Theme.Body1(contact.Name)
+					name.Font.Weight = font.Medium
+					return name.Layout(gtx)
+				}),
+				layout.Rigid(scm.Theme.Body2(address).Layout),
+			)
+		})
 	})
 }
 
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"strings"
 
+	"gioui.org/layout"
 	"gioui.org/widget"
 
+	"github.com/crypto-power/cryptopower/libwallet"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	libUtil "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
@@ -26,6 +28,11 @@ type destination struct {
 	destinationAddressEditor cryptomaterial.Editor
 	sourceAccount            *sharedW.Account
 
+	addressBookBtn cryptomaterial.IconButton
+	// contact is the address book contact of the destination address, it is
+	// nil if the address is not saved.
+	contact *libwallet.Contact
+
 	walletDropdown  *components.WalletDropdown
 	accountDropdown *components.AccountDropdown
 
@@ -47,6 +54,10 @@ func newSendDestination(l *load.Load, assetType libUtil.AssetType) *destination
 	dst.destinationAddressEditor.Editor.SetText("")
 	dst.destinationAddressEditor.IsTitleLabel = false
 
+	dst.addressBookBtn = l.Theme.IconButton(l.Theme.Icons.ContactsIcon)
+	dst.addressBookBtn.Size = values.MarginPadding20
+	dst.addressBookBtn.Inset = layout.UniformInset(values.MarginPadding8)
+
 	dst.initDestinationWalletSelector(assetType)
 	return dst
 }
@@ -151,6 +162,20 @@ func (dst *destination) setError(errMsg string) {
 func (dst *destination) clearAddressInput() {
 	dst.destinationAddressEditor.SetError("")
 	dst.destinationAddressEditor.Editor.SetText("")
+	dst.contact = nil
+}
+
+// updateContact looks up the destination address in the address book.
+func (dst *destination) updateContact() {
+	address := strings.TrimSpace(dst.destinationAddressEditor.Editor.Text())
+	dst.contact = dst.AssetsManager.ContactForAddress(dst.walletDropdown.SelectedWallet().GetAssetType(), address)
+}
+
+// selectContact sets the address of the contact as the destination address.
+func (dst *destination) selectContact(contact *libwallet.Contact) {
+	dst.destinationAddressEditor.Editor.SetText(contact.Address)
+	dst.contact = contact
+	dst.addressChanged()
 }
 
 // isSendToAddress returns the current tab selection status without depending
@@ -178,6 +203,7 @@ func (dst *destination) handle(gtx C) {
 		if gtx.Source.Focused(dst.destinationAddressEditor.Editor) {
 			switch event.(type) {
 			case widget.ChangeEvent:
+				dst.updateContact()
 				dst.addressChanged()
 			}
 		}
@@ -1,9 +1,11 @@
 package settings
 
 import (
+	"fmt"
 	"image/color"
 	"io"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +17,8 @@ import (
 	"gioui.org/widget"
 
 	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/appos"
+	"github.com/crypto-power/cryptopower/libwallet"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/logger"
@@ -66,6 +70,9 @@ type AppSettingsPage struct {
 	viewLog                 *cryptomaterial.Clickable
 	deleteDEX               *cryptomaterial.Clickable
 	backupDEX               *cryptomaterial.Clickable
+	syncHours               *cryptomaterial.Clickable
+	concurrentSyncs         *cryptomaterial.Clickable
+	pauseSyncOnMetered      *cryptomaterial.Switch
 	copyDEXSeed             cryptomaterial.Button
 	dexSeed                 dex.Bytes
 
@@ -96,6 +103,7 @@ func NewAppSettingsPage(l *load.Load) *AppSettingsPage {
 		vspAPI:                  l.Theme.Switch(),
 		updateAPI:               l.Theme.Switch(),
 		privacyActive:           l.Theme.Switch(),
+		pauseSyncOnMetered:      l.Theme.Switch(),
 
 		changeStartupPass: l.Theme.NewClickable(false),
 		network:           l.Theme.NewClickable(false),
@@ -108,6 +116,8 @@ func NewAppSettingsPage(l *load.Load) *AppSettingsPage {
 		viewLog:           l.Theme.NewClickable(false),
 		deleteDEX:         l.Theme.NewClickable(false),
 		backupDEX:         l.Theme.NewClickable(false),
+		syncHours:         l.Theme.NewClickable(false),
+		concurrentSyncs:   l.Theme.NewClickable(false),
 		copyDEXSeed:       l.Theme.Button(values.String(values.StrCopy)),
 	}
 
@@ -185,6 +195,7 @@ func (pg *AppSettingsPage) pageHeaderLayout(gtx C) layout.Dimensions {
 func (pg *AppSettingsPage) pageContentLayout(gtx C) D {
 	pageContent := []func(gtx C) D{
 		pg.general(),
+		pg.syncSettings(),
 		pg.networkSettings(),
 		pg.dexSettings(),
 		pg.security(),
@@ -310,6 +321,57 @@ func (pg *AppSettingsPage) general() layout.Widget {
 	}
 }
 
+func (pg *AppSettingsPage) syncSettings() layout.Widget {
+	return func(gtx C) D {
+		policy := pg.AssetsManager.SyncPolicy()
+		return pg.wrapSection(gtx, values.String(values.StrSync), func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					syncHoursRow := row{
+						title:     values.String(values.StrSyncHours),
+						clickable: pg.syncHours,
+						label:     pg.Theme.Body2(values.String(preference.GetKeyValue(syncHoursKey(&policy), preference.SyncHoursOptions))),
+					}
+					return pg.clickableRow(gtx, syncHoursRow)
+				}),
+				layout.Rigid(func(gtx C) D {
+					concurrentSyncsRow := row{
+						title:     values.String(values.StrConcurrentSyncs),
+						clickable: pg.concurrentSyncs,
+						label:     pg.Theme.Body2(values.String(preference.GetKeyValue(strconv.Itoa(policy.MaxConcurrentSyncs), preference.ConcurrentSyncsOptions))),
+					}
+					return pg.clickableRow(gtx, concurrentSyncsRow)
+				}),
+				layout.Rigid(func(gtx C) D {
+					// The option is hidden on the platforms that don't report
+					// metered connections, it would have no effect.
+					if !appos.Current().CanDetectMeteredConnection() {
+						return D{}
+					}
+					return pg.subSectionSwitch(gtx, values.String(values.StrPauseSyncOnMetered), pg.pauseSyncOnMetered)
+				}),
+			)
+		})
+	}
+}
+
+// syncHoursKey returns the key of the sync hours in SyncHoursOptions.
+func syncHoursKey(policy *libwallet.SyncPolicy) string {
+	if !policy.HasSyncHours() {
+		return "0-0"
+	}
+	return fmt.Sprintf("%d-%d", policy.StartHour, policy.EndHour)
+}
+
+// updateSyncPolicy applies update to the saved sync policy.
+func (pg *AppSettingsPage) updateSyncPolicy(update func(policy *libwallet.SyncPolicy)) {
+	policy := pg.AssetsManager.SyncPolicy()
+	update(&policy)
+	if err := pg.AssetsManager.SetSyncPolicy(policy); err != nil {
+		pg.Toast.NotifyError(err.Error())
+	}
+}
+
 func (pg *AppSettingsPage) networkSettings() layout.Widget {
 	return func(gtx C) D {
 		return pg.wrapSection(gtx, values.String(values.StrPrivacySettings), func(gtx C) D {
@@ -551,6 +613,36 @@ func (pg *AppSettingsPage) HandleUserInteractions(gtx C) {
 		pg.AssetsManager.SetHTTPAPIPrivacyMode(libutils.UpdateAPI, pg.updateAPI.IsChecked())
 	}
 
+	if pg.syncHours.Clicked(gtx) {
+		policy := pg.AssetsManager.SyncPolicy()
+		syncHoursModal := preference.NewListPreference(pg.Load, "", syncHoursKey(&policy), preference.SyncHoursOptions).
+			Title(values.StrSyncHours).
+			UpdateValues(func(val string) {
+				pg.updateSyncPolicy(func(policy *libwallet.SyncPolicy) {
+					_, _ = fmt.Sscanf(val, "%d-%d", &policy.StartHour, &policy.EndHour)
+				})
+			})
+		pg.ParentWindow().ShowModal(syncHoursModal)
+	}
+
+	if pg.concurrentSyncs.Clicked(gtx) {
+		policy := pg.AssetsManager.SyncPolicy()
+		concurrentSyncsModal := preference.NewListPreference(pg.Load, "", strconv.Itoa(policy.MaxConcurrentSyncs), preference.ConcurrentSyncsOptions).
+			Title(values.StrConcurrentSyncs).
+			UpdateValues(func(val string) {
+				pg.updateSyncPolicy(func(policy *libwallet.SyncPolicy) {
+					policy.MaxConcurrentSyncs, _ = strconv.Atoi(val)
+				})
+			})
+		pg.ParentWindow().ShowModal(concurrentSyncsModal)
+	}
+
+	if pg.pauseSyncOnMetered.Changed(gtx) {
+		pg.updateSyncPolicy(func(policy *libwallet.SyncPolicy) {
+			policy.PauseOnMeteredConnection = pg.pauseSyncOnMetered.IsChecked()
+		})
+	}
+
 	if pg.privacyActive.Changed(gtx) {
 		pg.AssetsManager.SetPrivacyMode(pg.privacyActive.IsChecked())
 		pg.updatePrivacySettings()
@@ -819,6 +911,7 @@ func (pg *AppSettingsPage) updateSettingOptions() {
 		pg.isStartupPassword = true
 	}
 
+	pg.setInitialSwitchStatus(pg.pauseSyncOnMetered, pg.AssetsManager.SyncPolicy().PauseOnMeteredConnection)
 	pg.updatePrivacySettings()
 }
 
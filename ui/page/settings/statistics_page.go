@@ -124,6 +124,8 @@ func (pg *StatPage) layoutStats(gtx C) D {
 		walletDataSize = fmt.Sprintf("%f GB", float64(v)*1e-9)
 	}
 
+	dataUsage := pg.wallet.DataUsage()
+
 	line := pg.Theme.Separator()
 	line.Color = pg.Theme.Color.Gray2
 
@@ -147,6 +149,9 @@ func (pg *StatPage) layoutStats(gtx C) D {
 		line.Layout,
 		item(values.String(values.StrDateSize), walletDataSize),
 		line.Layout,
+		item(values.String(values.StrDataUsage), values.StringF(values.StrDataUsageValue,
+			pageutils.FormatBytes(dataUsage.BytesReceived), pageutils.FormatBytes(dataUsage.BytesSent))),
+		line.Layout,
 		item(values.String(values.StrTransactions), fmt.Sprintf("%d", len(pg.txs))),
 		line.Layout,
 		item(values.String(values.StrAccount)+"s", fmt.Sprintf("%d", len(pg.accounts.Accounts))),
@@ -62,6 +62,7 @@ type TxDetailsPage struct {
 	associatedTicketClickable *cryptomaterial.Clickable
 	hashClickable             *cryptomaterial.Clickable
 	rebroadcastClickable      *cryptomaterial.Clickable
+	editLabelClickable        *cryptomaterial.Clickable
 	moreOption                *cryptomaterial.Clickable
 	outputsCollapsible        *cryptomaterial.Collapsible
 	inputsCollapsible         *cryptomaterial.Collapsible
@@ -122,6 +123,7 @@ func NewTransactionDetailsPage(l *load.Load, wallet sharedW.Asset, transaction *
 		wallet:                 wallet,
 		rebroadcast:            rebroadcast,
 		rebroadcastClickable:   l.Theme.NewClickable(true),
+		editLabelClickable:     l.Theme.NewClickable(true),
 		rebroadcastIcon:        l.Theme.Icons.Rebroadcast,
 		txDestinationAddresses: make([]string, 0),
 	}
@@ -787,11 +789,20 @@ func (pg *TxDetailsPage) txnTypeAndID(gtx C) D {
 			return pg.keyValue(gtx, values.String(values.StrTransactionID), dim)
 		}),
 		layout.Rigid(func(gtx C) D {
-			if len(pg.transaction.Label) != 0 {
-				txlabel := pg.Theme.Label(values.TextSize14, pg.transaction.Label)
-				return pg.keyValue(gtx, values.String(values.StrDescriptionNote), txlabel.Layout)
-			}
-			return D{}
+			return pg.keyValue(gtx, values.String(values.StrDescriptionNote), func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						if len(pg.transaction.Label) == 0 {
+							return D{}
+						}
+						txlabel := pg.Theme.Label(values.TextSize14, pg.transaction.Label)
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, txlabel.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return pg.editLabelClickable.Layout(gtx, pg.Theme.Icons.EditIcon.Layout16dp)
+					}),
+				)
+			})
 		}),
 	)
 }
@@ -970,6 +981,24 @@ func (pg *TxDetailsPage) HandleUserInteractions(gtx C) {
 		}
 	}
 
+	if pg.editLabelClickable.Clicked(gtx) {
+		textModal := modal.NewTextInputModal(pg.Load).
+			Hint(values.String(values.StrDescriptionNote)).
+			SetText(pg.transaction.Label).
+			PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+			SetPositiveButtonCallback(func(label string, tim *modal.TextInputModal) bool {
+				if err := pg.wallet.SetTransactionLabel(pg.transaction.Hash, label); err != nil {
+					tim.SetError(err.Error())
+					return false
+				}
+				pg.transaction.Label = label
+				return true
+			})
+		textModal.Title(values.String(values.StrEditTxLabel)).
+			SetPositiveButtonText(values.String(values.StrSave))
+		pg.ParentWindow().ShowModal(textModal)
+	}
+
 	if pg.rebroadcastClickable.Clicked(gtx) {
 		go func() {
 			pg.rebroadcastClickable.SetEnabled(false, nil)
@@ -7,7 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
-	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +18,9 @@ import (
 	"gioui.org/widget/material"
 
 	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/libwallet/assets/ltc"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/txhelper"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
@@ -72,6 +75,14 @@ type TransactionsPage struct {
 	isFilterOpen   bool
 	searchEditor   cryptomaterial.Editor
 
+	// The query editors further filter the transactions by amount and
+	// date range while the filter is open.
+	minAmountEditor,
+	maxAmountEditor,
+	fromDateEditor,
+	toDateEditor cryptomaterial.Editor
+	confirmationDropDown *cryptomaterial.DropDown
+
 	transactionList *cryptomaterial.ClickableList
 	txFilter,
 	previousTxFilter int32
@@ -105,6 +116,11 @@ func NewTransactionsPage(l *load.Load, wallet sharedW.Asset) *TransactionsPage {
 	pg.searchEditor.Editor.SingleLine = true
 	pg.searchEditor.TextSize = pg.ConvertTextSize(l.Theme.TextSize)
 
+	pg.minAmountEditor = pg.queryEditor(values.StrMinAmount)
+	pg.maxAmountEditor = pg.queryEditor(values.StrMaxAmount)
+	pg.fromDateEditor = pg.queryEditor(values.StrFromDate)
+	pg.toDateEditor = pg.queryEditor(values.StrToDate)
+
 	// init the wallet selector if no wallet was pre-selected
 	if pg.selectedWallet == nil {
 		pg.multiWalletLayout = true
@@ -128,9 +144,28 @@ func NewTransactionsPage(l *load.Load, wallet sharedW.Asset) *TransactionsPage {
 	settingCommonDropdown(pg.Theme, pg.orderDropDown)
 	pg.orderDropDown.SetConvertTextSize(pg.ConvertTextSize)
 
+	// The items are in the order of the sharedW.TxConfirmationState values.
+	pg.confirmationDropDown = l.Theme.DropdownWithCustomPos([]cryptomaterial.DropDownItem{
+		{Text: values.String(values.StrAnyStatus)},
+		{Text: values.String(values.StrPending)},
+		{Text: values.String(values.StrConfirming)},
+		{Text: values.String(values.StrConfirmed)},
+	}, values.TxDropdownGroup, 1, 0, false)
+	pg.confirmationDropDown.Width = values.DP118
+	pg.confirmationDropDown.CollapsedLayoutTextDirection = layout.E
+	settingCommonDropdown(pg.Theme, pg.confirmationDropDown)
+	pg.confirmationDropDown.SetConvertTextSize(pg.ConvertTextSize)
+
 	return pg
 }
 
+func (pg *TransactionsPage) queryEditor(hint string) cryptomaterial.Editor {
+	editor := pg.Theme.Editor(new(widget.Editor), values.String(hint))
+	editor.Editor.SingleLine, editor.Editor.Submit = true, true
+	editor.TextSize = pg.ConvertTextSize(pg.Theme.TextSize)
+	return editor
+}
+
 func (pg *TransactionsPage) DisableUniformTab() {
 	pg.txCategoryTab.DisableUniform(true)
 }
@@ -259,61 +294,126 @@ func (pg *TransactionsPage) fetchTransactions(offset, pageSize int32) (txs []*mu
 }
 
 func (pg *TransactionsPage) multiWalletTxns(offset, pageSize int32, newestFirst bool) ([]*multiWalletTx, int, error) {
-	allTxs := make([]*multiWalletTx, 0)
+	query, err := pg.txQuery(pg.getAssetType(), offset, pageSize, newestFirst)
+	if err != nil {
+		return nil, -1, err
+	}
 	for _, wal := range pg.assetWallets {
-		if !wal.IsSynced() {
-			continue // skip wallets that are not synced
+		if !query.HasAssetType(wal.GetAssetType()) {
+			query.AssetTypes = append(query.AssetTypes, wal.GetAssetType())
 		}
+	}
 
-		txs, _, err := pg.loadTransactions(wal, offset, pageSize, newestFirst)
-		if err != nil {
-			return nil, 0, err
-		}
-		allTxs = append(allTxs, txs...)
+	walletTxs, err := pg.AssetsManager.QueryTransactions(query)
+	if err != nil {
+		return nil, -1, fmt.Errorf("error loading transactions: %v", err)
 	}
 
-	sort.Slice(allTxs, func(i, j int) bool {
-		if newestFirst {
-			return allTxs[i].Timestamp > allTxs[j].Timestamp
-		}
-		return allTxs[i].Timestamp < allTxs[j].Timestamp
-	})
+	txs := make([]*multiWalletTx, 0, len(walletTxs))
+	for _, tx := range walletTxs {
+		txs = append(txs, &multiWalletTx{tx.Transaction, tx.WalletID})
+	}
+	return txs, len(txs), nil
+}
+
+func (pg *TransactionsPage) loadTransactions(wal sharedW.Asset, offset, pageSize int32, newestFirst bool) ([]*multiWalletTx, int, error) {
+	query, err := pg.txQuery(wal.GetAssetType(), offset, pageSize, newestFirst)
+	if err != nil {
+		return nil, -1, err
+	}
 
-	// TODO: remove after PR 268  // Improve infinite scroll list functionality is merged
-	if len(allTxs) > int(pageSize) {
-		allTxs = allTxs[:int(pageSize)]
+	walletTxs, err := sharedW.QueryTransactions(wal, query)
+	if err != nil {
+		err = fmt.Errorf("error loading transactions: %v", err)
+	}
+
+	txs := make([]*multiWalletTx, 0)
+	for i := range walletTxs {
+		txs = append(txs, &multiWalletTx{walletTxs[i], wal.GetWalletID()})
 	}
 
-	return allTxs, len(allTxs), nil
+	return txs, len(txs), err
 }
 
-func (pg *TransactionsPage) loadTransactions(wal sharedW.Asset, offset, pageSize int32, newestFirst bool) ([]*multiWalletTx, int, error) {
-	mapInfo, _ := components.TxPageDropDownFields(wal.GetAssetType(), pg.selectedTxCategoryTab)
+// txQuery returns the transaction query selected by the filter of the page.
+// Query fields that are not valid are ignored and their editor shows an
+// error.
+func (pg *TransactionsPage) txQuery(assetType utils.AssetType, offset, pageSize int32, newestFirst bool) (*sharedW.TxQuery, error) {
+	mapInfo, _ := components.TxPageDropDownFields(assetType, pg.selectedTxCategoryTab)
 	if len(mapInfo) < 1 {
-		err := fmt.Errorf("unable to resolve asset filters for asset type (%v)", wal.GetAssetType())
-		return nil, -1, err
+		err := fmt.Errorf("unable to resolve asset filters for asset type (%v)", assetType)
+		return nil, err
 	}
 
 	selectedVal, _, _ := strings.Cut(pg.statusDropDown.Selected(), " ")
 	txFilter, ok := mapInfo[selectedVal]
 	if !ok {
 		err := fmt.Errorf("unsupported field(%v) for asset type(%v) and txCategoryTab index(%d) found",
-			selectedVal, wal.GetAssetType(), pg.selectedTxCategoryTab)
-		return nil, -1, err
+			selectedVal, assetType, pg.selectedTxCategoryTab)
+		return nil, err
 	}
 	pg.txFilter = txFilter
-	searchKey := pg.searchEditor.Editor.Text()
-	walletTxs, err := wal.GetTransactionsRaw(offset, pageSize, txFilter, newestFirst, searchKey)
-	if err != nil {
-		err = fmt.Errorf("error loading transactions: %v", err)
+
+	query := &sharedW.TxQuery{
+		TxFilter:     txFilter,
+		Text:         pg.searchEditor.Editor.Text(),
+		Confirmation: sharedW.TxConfirmationState(pg.confirmationDropDown.SelectedIndex()),
+		NewestFirst:  newestFirst,
+		Offset:       int(offset),
+		Limit:        int(pageSize),
+	}
+	if !pg.isFilterOpen {
+		return query, nil
 	}
 
-	txs := make([]*multiWalletTx, 0)
-	for i := range walletTxs {
-		txs = append(txs, &multiWalletTx{walletTxs[i], wal.GetWalletID()})
+	query.MinAmount = pg.parseAmount(assetType, &pg.minAmountEditor)
+	query.MaxAmount = pg.parseAmount(assetType, &pg.maxAmountEditor)
+	query.From = pg.parseDate(&pg.fromDateEditor, 0)
+	// The end date includes the whole day.
+	query.To = pg.parseDate(&pg.toDateEditor, 24*time.Hour-time.Second)
+	return query, nil
+}
+
+// parseAmount returns the amount entered in the editor in the smallest unit
+// of the asset, or zero if no valid amount is entered.
+func (pg *TransactionsPage) parseAmount(assetType utils.AssetType, editor *cryptomaterial.Editor) int64 {
+	editor.SetError("")
+	text := strings.TrimSpace(editor.Editor.Text())
+	if text == "" {
+		return 0
 	}
 
-	return txs, len(txs), err
+	amount, err := strconv.ParseFloat(text, 64)
+	if err != nil || amount < 0 {
+		editor.SetError(values.String(values.StrInvalidAmount))
+		return 0
+	}
+
+	switch assetType {
+	case utils.BTCWalletAsset:
+		return btc.AmountSatoshi(amount)
+	case utils.LTCWalletAsset:
+		return ltc.AmountLitoshi(amount)
+	default:
+		return dcr.AmountAtom(amount)
+	}
+}
+
+// parseDate returns the unix timestamp of the local date entered in the
+// editor plus the offset, or zero if no valid date is entered.
+func (pg *TransactionsPage) parseDate(editor *cryptomaterial.Editor, offset time.Duration) int64 {
+	editor.SetError("")
+	text := strings.TrimSpace(editor.Editor.Text())
+	if text == "" {
+		return 0
+	}
+
+	date, err := time.ParseInLocation(time.DateOnly, text, time.Local)
+	if err != nil {
+		editor.SetError(values.String(values.StrInvalidDate))
+		return 0
+	}
+	return date.Add(offset).Unix()
 }
 
 func settingCommonDropdown(t *cryptomaterial.Theme, dropdown *cryptomaterial.DropDown) {
@@ -450,6 +550,7 @@ func (pg *TransactionsPage) rightDropdown(gtx C) D {
 	}
 	return layout.E.Layout(gtx, func(gtx C) D {
 		return layout.Flex{}.Layout(gtx,
+			layout.Rigid(pg.confirmationDropDown.Layout),
 			layout.Rigid(pg.statusDropDown.Layout),
 			layout.Rigid(pg.orderDropDown.Layout),
 		)
@@ -471,6 +572,12 @@ func (pg *TransactionsPage) txListLayout(gtx C) D {
 					}
 					return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, pg.searchEditor.Layout)
 				}),
+				layout.Rigid(func(gtx C) D {
+					if !pg.isFilterOpen {
+						return D{}
+					}
+					return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, pg.queryEditorsLayout)
+				}),
 				layout.Rigid(func(gtx C) D {
 					itemCount := pg.scroll.ItemsCount()
 					card := pg.Theme.Card()
@@ -555,6 +662,22 @@ func (pg *TransactionsPage) txListLayout(gtx C) D {
 	)
 }
 
+func (pg *TransactionsPage) queryEditorsLayout(gtx C) D {
+	editors := []*cryptomaterial.Editor{&pg.minAmountEditor, &pg.maxAmountEditor, &pg.fromDateEditor, &pg.toDateEditor}
+	children := make([]layout.FlexChild, 0, len(editors))
+	for i, editor := range editors {
+		editor := editor
+		inset := layout.Inset{}
+		if i < len(editors)-1 {
+			inset.Right = values.MarginPadding8
+		}
+		children = append(children, layout.Flexed(1, func(gtx C) D {
+			return inset.Layout(gtx, editor.Layout)
+		}))
+	}
+	return layout.Flex{}.Layout(gtx, children...)
+}
+
 func (pg *TransactionsPage) txAndWallet(mtx *multiWalletTx) (*sharedW.Transaction, sharedW.Asset) {
 	return mtx.Transaction, pg.AssetsManager.WalletWithID(mtx.walletID)
 }
@@ -565,7 +688,7 @@ func (pg *TransactionsPage) txAndWallet(mtx *multiWalletTx) (*sharedW.Transactio
 // displayed.
 // Part of the load.Page interface.
 func (pg *TransactionsPage) HandleUserInteractions(gtx C) {
-	if pg.statusDropDown.Changed(gtx) {
+	if pg.statusDropDown.Changed(gtx) || pg.confirmationDropDown.Changed(gtx) {
 		go pg.scroll.FetchScrollData(false, pg.ParentWindow(), true)
 	}
 
@@ -590,7 +713,7 @@ func (pg *TransactionsPage) HandleUserInteractions(gtx C) {
 		pg.ParentNavigator().Display(NewTransactionDetailsPage(pg.Load, wal, tx))
 	}
 
-	dropDownList := []*cryptomaterial.DropDown{pg.statusDropDown}
+	dropDownList := []*cryptomaterial.DropDown{pg.statusDropDown, pg.confirmationDropDown}
 	if pg.walletDropDown != nil {
 		dropDownList = append(dropDownList, pg.walletDropDown)
 	}
@@ -612,6 +735,8 @@ func (pg *TransactionsPage) HandleUserInteractions(gtx C) {
 
 	if pg.filterBtn.Clicked(gtx) {
 		pg.isFilterOpen = !pg.isFilterOpen
+		// The amount and date range only apply while the filter is open.
+		go pg.scroll.FetchScrollData(false, pg.ParentWindow(), true)
 	}
 
 	if pg.exportBtn.Clicked(gtx) {
@@ -659,6 +784,26 @@ func (pg *TransactionsPage) HandleUserInteractions(gtx C) {
 			}
 		}
 	}
+
+	for _, editor := range []*cryptomaterial.Editor{&pg.minAmountEditor, &pg.maxAmountEditor, &pg.fromDateEditor, &pg.toDateEditor} {
+		for {
+			event, ok := editor.Editor.Update(gtx)
+			if !ok {
+				break
+			}
+			// Partially entered amounts and dates are not queried, the
+			// transactions are reloaded when the entry is submitted or
+			// cleared.
+			switch event.(type) {
+			case widget.SubmitEvent:
+				pg.scroll.FetchScrollData(false, pg.ParentWindow(), true)
+			case widget.ChangeEvent:
+				if editor.Editor.Text() == "" {
+					pg.scroll.FetchScrollData(false, pg.ParentWindow(), true)
+				}
+			}
+		}
+	}
 }
 
 func exportTxs(assets []sharedW.Asset, fileName string) error {
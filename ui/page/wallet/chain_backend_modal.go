@@ -0,0 +1,282 @@
+package wallet
+
+import (
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// chainBackendModal sets whether the wallet syncs with the P2P network, with
+// a full node of the user or with an Electrum server, and the settings of the
+// node or server.
+type chainBackendModal struct {
+	*load.Load
+	*cryptomaterial.Modal
+
+	wallet sharedW.Asset
+
+	fullNode     cryptomaterial.CheckBoxStyle
+	electrum     cryptomaterial.CheckBoxStyle
+	serverEditor cryptomaterial.Editor
+	pinEditor    cryptomaterial.Editor
+	hostEditor   cryptomaterial.Editor
+	userEditor   cryptomaterial.Editor
+	passEditor   cryptomaterial.Editor
+	certEditor   cryptomaterial.Editor
+	cancelBtn    cryptomaterial.Button
+	saveBtn      cryptomaterial.Button
+	isSaving     bool
+	savedHandler func()
+}
+
+func newChainBackendModal(l *load.Load, wallet sharedW.Asset, savedHandler func()) *chainBackendModal {
+	cbm := &chainBackendModal{
+		Load:         l,
+		Modal:        l.Theme.ModalFloatTitle("chain_backend_modal", l.IsMobileView(), nil),
+		wallet:       wallet,
+		fullNode:     l.Theme.CheckBox(new(widget.Bool), values.String(values.StrSyncWithFullNode)),
+		electrum:     l.Theme.CheckBox(new(widget.Bool), values.String(values.StrSyncWithElectrum)),
+		serverEditor: l.Theme.Editor(new(widget.Editor), values.String(values.StrElectrumServer)),
+		pinEditor:    l.Theme.Editor(new(widget.Editor), values.String(values.StrElectrumCertFingerprint)),
+		hostEditor:   l.Theme.Editor(new(widget.Editor), values.String(values.StrRPCHost)),
+		userEditor:   l.Theme.Editor(new(widget.Editor), values.String(values.StrRPCUser)),
+		passEditor:   l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrRPCPassword)),
+		certEditor:   l.Theme.Editor(new(widget.Editor), values.String(values.StrRPCCertPath)),
+		cancelBtn:    l.Theme.OutlineButton(values.String(values.StrCancel)),
+		saveBtn:      l.Theme.Button(values.String(values.StrSave)),
+		savedHandler: savedHandler,
+	}
+
+	for _, editor := range []*cryptomaterial.Editor{&cbm.hostEditor, &cbm.userEditor, &cbm.passEditor, &cbm.certEditor, &cbm.serverEditor, &cbm.pinEditor} {
+		editor.Editor.SingleLine = true
+	}
+
+	return cbm
+}
+
+func (cbm *chainBackendModal) OnResume() {
+	cfg := cbm.wallet.RPCConfig()
+	cbm.fullNode.CheckBox.Value = cbm.wallet.ChainBackend() == sharedW.RPCBackend
+	cbm.hostEditor.Editor.SetText(cfg.Host)
+	cbm.userEditor.Editor.SetText(cfg.User)
+	cbm.passEditor.Editor.SetText(cfg.Pass)
+	cbm.certEditor.Editor.SetText(cfg.CertPath)
+
+	if cbm.supportsElectrum() {
+		electrumCfg := cbm.wallet.ElectrumConfig()
+		cbm.electrum.CheckBox.Value = cbm.wallet.ChainBackend() == sharedW.ElectrumBackend
+		cbm.serverEditor.Editor.SetText(electrumCfg.Server)
+		cbm.pinEditor.Editor.SetText(electrumCfg.CertFingerprint)
+	}
+}
+
+func (cbm *chainBackendModal) OnDismiss() {}
+
+// usesTLS returns true if the RPC server of the wallet's full node serves
+// TLS and takes a certificate.
+func (cbm *chainBackendModal) usesTLS() bool {
+	return cbm.wallet.RPCUsesTLS()
+}
+
+// supportsElectrum returns true if the wallet can sync with an Electrum
+// server, only BTC and LTC wallets can.
+func (cbm *chainBackendModal) supportsElectrum() bool {
+	assetType := cbm.wallet.GetAssetType()
+	return assetType == libutils.BTCWalletAsset || assetType == libutils.LTCWalletAsset
+}
+
+func (cbm *chainBackendModal) setLoading(loading bool) {
+	cbm.isSaving = loading
+	cbm.Modal.SetDisabled(loading)
+}
+
+func (cbm *chainBackendModal) save() {
+	if cbm.isSaving {
+		return
+	}
+
+	backend := sharedW.SPVBackend
+	switch {
+	case cbm.fullNode.CheckBox.Value:
+		backend = sharedW.RPCBackend
+	case cbm.electrum.CheckBox.Value:
+		backend = sharedW.ElectrumBackend
+	}
+	cfg := &sharedW.RPCConfig{
+		Host: cbm.hostEditor.Editor.Text(),
+		User: cbm.userEditor.Editor.Text(),
+		Pass: cbm.passEditor.Editor.Text(),
+	}
+	if cbm.usesTLS() {
+		cfg.CertPath = cbm.certEditor.Editor.Text()
+	}
+
+	// The RPC password is saved encrypted with the private passphrase. A
+	// password left empty while the saved one is locked keeps the saved one.
+	// Watching only wallets don't save it, they ask for it once reloaded.
+	needsPassphrase := cfg.Pass != "" || cbm.wallet.RPCPassLocked()
+	if backend != sharedW.RPCBackend || !needsPassphrase || cbm.wallet.IsWatchingOnlyWallet() {
+		cbm.saveBackend(backend, cfg, "")
+		return
+	}
+
+	passwordModal := modal.NewCreatePasswordModal(cbm.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrConfirmToSave)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			wasLocked := cbm.wallet.IsLocked()
+			if err := cbm.wallet.UnlockWallet(password); err != nil {
+				pm.SetError(values.TranslateErr(err.Error()))
+				return false
+			}
+			if wasLocked {
+				cbm.wallet.LockWallet()
+			}
+			if cfg.Pass == "" {
+				cfg.Pass = cbm.wallet.RPCConfig().Pass
+			}
+
+			pm.Dismiss()
+			cbm.saveBackend(backend, cfg, password)
+			return true
+		})
+	cbm.ParentWindow().ShowModal(passwordModal)
+}
+
+// saveBackend saves the backend and its settings, the private passphrase
+// encrypts the RPC password.
+func (cbm *chainBackendModal) saveBackend(backend sharedW.ChainBackend, cfg *sharedW.RPCConfig, privatePassphrase string) {
+	cbm.setLoading(true)
+	go func() {
+		defer cbm.setLoading(false)
+		if backend == sharedW.ElectrumBackend {
+			electrumCfg := &sharedW.ElectrumConfig{
+				Server:          cbm.serverEditor.Editor.Text(),
+				CertFingerprint: cbm.pinEditor.Editor.Text(),
+			}
+			if err := cbm.wallet.SaveElectrumConfig(electrumCfg); err != nil {
+				cbm.serverEditor.SetError(values.TranslateErr(err.Error()))
+				return
+			}
+		}
+
+		// Restarting an active sync with the new backend may take a while.
+		if err := cbm.wallet.SetChainBackend(backend, cfg, privatePassphrase); err != nil {
+			cbm.hostEditor.SetError(values.TranslateErr(err.Error()))
+			return
+		}
+
+		if cbm.savedHandler != nil {
+			cbm.savedHandler()
+		}
+		successModal := modal.NewSuccessModal(cbm.Load, values.String(values.StrChainBackendUpdated), modal.DefaultClickFunc())
+		cbm.ParentWindow().ShowModal(successModal)
+		cbm.Dismiss()
+	}()
+}
+
+func (cbm *chainBackendModal) Handle(gtx C) {
+	// Syncing with a full node and with an Electrum server are exclusive.
+	if cbm.fullNode.CheckBox.Update(gtx) {
+		cbm.hostEditor.ClearError()
+		if cbm.fullNode.CheckBox.Value {
+			cbm.electrum.CheckBox.Value = false
+		}
+	}
+	if cbm.electrum.CheckBox.Update(gtx) {
+		cbm.serverEditor.ClearError()
+		if cbm.electrum.CheckBox.Value {
+			cbm.fullNode.CheckBox.Value = false
+		}
+	}
+
+	cbm.saveBtn.SetEnabled(!cbm.fullNode.CheckBox.Value || cbm.hostEditor.Editor.Text() != "")
+	if cbm.saveBtn.Clicked(gtx) {
+		cbm.save()
+	}
+
+	if cbm.cancelBtn.Clicked(gtx) && !cbm.isSaving {
+		cbm.Dismiss()
+	}
+}
+
+func (cbm *chainBackendModal) Layout(gtx C) D {
+	textSize14 := values.TextSizeTransform(cbm.IsMobileView(), values.TextSize14)
+	textSize20 := values.TextSizeTransform(cbm.IsMobileView(), values.TextSize20)
+	rpcEditor := func(editor *cryptomaterial.Editor) layout.Widget {
+		return func(gtx C) D {
+			if !cbm.fullNode.CheckBox.Value {
+				return D{}
+			}
+			return editor.Layout(gtx)
+		}
+	}
+
+	electrumWidget := func(w layout.Widget) layout.Widget {
+		return func(gtx C) D {
+			if !cbm.electrum.CheckBox.Value {
+				return D{}
+			}
+			return w(gtx)
+		}
+	}
+
+	return cbm.Modal.Layout(gtx, []layout.Widget{
+		func(gtx C) D {
+			title := cbm.Theme.Label(textSize20, values.String(values.StrChainBackend))
+			title.Font.Weight = font.SemiBold
+			return title.Layout(gtx)
+		},
+		func(gtx C) D {
+			info := cbm.Theme.Label(textSize14, values.String(values.StrChainBackendInfo))
+			info.Color = cbm.Theme.Color.GrayText2
+			return info.Layout(gtx)
+		},
+		cbm.fullNode.Layout,
+		rpcEditor(&cbm.hostEditor),
+		rpcEditor(&cbm.userEditor),
+		rpcEditor(&cbm.passEditor),
+		func(gtx C) D {
+			if !cbm.usesTLS() {
+				return D{}
+			}
+			return rpcEditor(&cbm.certEditor)(gtx)
+		},
+		func(gtx C) D {
+			if !cbm.supportsElectrum() {
+				return D{}
+			}
+			return cbm.electrum.Layout(gtx)
+		},
+		electrumWidget(func(gtx C) D {
+			info := cbm.Theme.Label(textSize14, values.String(values.StrElectrumServerInfo))
+			info.Color = cbm.Theme.Color.GrayText2
+			return info.Layout(gtx)
+		}),
+		electrumWidget(cbm.serverEditor.Layout),
+		electrumWidget(cbm.pinEditor.Layout),
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				if cbm.isSaving {
+					return layout.Inset{Top: unit.Dp(7)}.Layout(gtx, material.Loader(cbm.Theme.Base).Layout)
+				}
+				return layout.Flex{}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, cbm.cancelBtn.Layout)
+					}),
+					layout.Rigid(cbm.saveBtn.Layout),
+				)
+			})
+		},
+	})
+}
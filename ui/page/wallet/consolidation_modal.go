@@ -0,0 +1,367 @@
+package wallet
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strconv"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// canConsolidate returns true if the wallet fetches the fee rate estimates
+// that the consolidation modal picks the fee rates from.
+func canConsolidate(wallet sharedW.Asset) bool {
+	switch wallet.GetAssetType() {
+	case libutils.BTCWalletAsset, libutils.LTCWalletAsset:
+		return true
+	default:
+		return false
+	}
+}
+
+type consolidationUTXO struct {
+	*sharedW.UnspentOutput
+	checkbox cryptomaterial.CheckBoxStyle
+}
+
+// consolidationModal merges the unspent outputs picked by the user into a
+// single output of the same account. The transaction pays one of the fee
+// rate estimates, the slowest by default, and the modal shows the fee saved
+// compared to spending the outputs at the fastest fee rate estimate.
+type consolidationModal struct {
+	*load.Load
+	*cryptomaterial.Modal
+
+	wallet sharedW.Asset
+
+	accountDropdown *components.AccountDropdown
+	feeRateDropdown *cryptomaterial.DropDown
+	feeRates        []sharedW.FeeEstimate
+	fetchingRates   bool
+
+	utxos         []*consolidationUTXO
+	consolidation *sharedW.Consolidation
+	errText       string
+
+	passwordEditor cryptomaterial.Editor
+	cancelBtn      cryptomaterial.Button
+	consolidateBtn cryptomaterial.Button
+	isSending      bool
+}
+
+func newConsolidationModal(l *load.Load, wallet sharedW.Asset) *consolidationModal {
+	cm := &consolidationModal{
+		Load:           l,
+		Modal:          l.Theme.ModalFloatTitle("consolidation_modal", l.IsMobileView(), nil),
+		wallet:         wallet,
+		cancelBtn:      l.Theme.OutlineButton(values.String(values.StrCancel)),
+		consolidateBtn: l.Theme.Button(values.String(values.StrConsolidate)),
+	}
+
+	cm.passwordEditor = l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSpendingPassword))
+	cm.passwordEditor.Editor.SingleLine = true
+	cm.passwordEditor.Editor.Submit = true
+
+	cm.accountDropdown = components.NewAccountDropdown(l).
+		AccountValidator(func(account *sharedW.Account) bool {
+			return account.Number != dcr.ImportedAccountNumber
+		}).
+		SetChangedCallback(func(_ *sharedW.Account) {
+			cm.loadUTXOs()
+		})
+	// Setup selects the first account, the changed callback needs the
+	// dropdown to be set already.
+	cm.accountDropdown.Setup(wallet)
+
+	return cm
+}
+
+func (cm *consolidationModal) OnResume() {
+	cm.loadUTXOs()
+	go cm.fetchFeeRates()
+}
+
+func (cm *consolidationModal) OnDismiss() {}
+
+func (cm *consolidationModal) fetchFeeRates() {
+	cm.fetchingRates = true
+	defer func() {
+		cm.fetchingRates = false
+		cm.ParentWindow().Reload()
+	}()
+
+	feeRates, err := load.GetAPIFeeRate(cm.wallet)
+	if err != nil || len(feeRates) == 0 {
+		cm.errText = values.String(values.StrFetchRateError)
+		return
+	}
+
+	items := make([]cryptomaterial.DropDownItem, len(feeRates))
+	for i, rate := range feeRates {
+		items[i] = cryptomaterial.DropDownItem{
+			Text: fmt.Sprintf("%s/kvB - %d blocks", rate.Feerate.String(), rate.ConfirmedBlocks),
+		}
+	}
+	// The estimate confirmed in the most blocks has the lowest fee rate.
+	dropdown := cm.Theme.DropDown(items, &items[len(items)-1], values.WalletsDropdownGroup, false)
+	dropdown.FontWeight = font.SemiBold
+	dropdown.SelectedItemIconColor = &cm.Theme.Color.Primary
+	dropdown.MakeCollapsedLayoutVisibleWhenExpanded = true
+
+	cm.feeRates = feeRates
+	cm.feeRateDropdown = dropdown
+	cm.prepareConsolidation()
+}
+
+// loadUTXOs lists the outputs of the selected account that are not frozen,
+// the smallest first.
+func (cm *consolidationModal) loadUTXOs() {
+	cm.utxos = nil
+	cm.consolidation = nil
+	account := cm.accountDropdown.SelectedAccount()
+	if account == nil {
+		return
+	}
+
+	utxos, err := cm.wallet.UnspentOutputs(account.Number)
+	if err != nil {
+		cm.errText = err.Error()
+		return
+	}
+	utxos = sharedW.UnfrozenOutputs(utxos)
+	sort.SliceStable(utxos, func(i, j int) bool {
+		return utxos[i].Amount.ToInt() < utxos[j].Amount.ToInt()
+	})
+
+	for _, utxo := range utxos {
+		checkbox := cm.Theme.CheckBox(new(widget.Bool), "")
+		checkbox.Size = 20
+		cm.utxos = append(cm.utxos, &consolidationUTXO{UnspentOutput: utxo, checkbox: checkbox})
+	}
+	cm.prepareConsolidation()
+}
+
+func (cm *consolidationModal) selectedUTXOs() []*sharedW.UnspentOutput {
+	selected := make([]*sharedW.UnspentOutput, 0)
+	for _, utxo := range cm.utxos {
+		if utxo.checkbox.CheckBox.Value {
+			selected = append(selected, utxo.UnspentOutput)
+		}
+	}
+	return selected
+}
+
+// prepareConsolidation authors the consolidation of the selected outputs at
+// the selected fee rate.
+func (cm *consolidationModal) prepareConsolidation() {
+	cm.consolidation = nil
+	cm.errText = ""
+	if cm.feeRateDropdown == nil {
+		return
+	}
+
+	utxos := cm.selectedUTXOs()
+	if len(utxos) < 2 {
+		cm.errText = values.String(values.StrSelectTwoUTXOs)
+		return
+	}
+
+	feeRate := cm.feeRates[cm.feeRateDropdown.SelectedIndex()].Feerate.ToInt()
+	laterFeeRate := cm.feeRates[0].Feerate.ToInt()
+	account := cm.accountDropdown.SelectedAccount().Number
+	consolidation, err := sharedW.PrepareConsolidation(cm.wallet, account, utxos, feeRate, laterFeeRate)
+	if err != nil {
+		cm.errText = values.TranslateErr(err.Error())
+		return
+	}
+	cm.consolidation = consolidation
+}
+
+func (cm *consolidationModal) setLoading(loading bool) {
+	cm.isSending = loading
+	cm.Modal.SetDisabled(loading)
+}
+
+func (cm *consolidationModal) broadcast() {
+	password := cm.passwordEditor.Editor.Text()
+	if password == "" || cm.consolidation == nil || cm.isSending {
+		return
+	}
+
+	cm.setLoading(true)
+	go func() {
+		defer cm.setLoading(false)
+		if _, err := cm.wallet.Broadcast(password, ""); err != nil {
+			cm.passwordEditor.SetError(values.TranslateErr(err.Error()))
+			return
+		}
+
+		successModal := modal.NewSuccessModal(cm.Load, values.String(values.StrUTXOsConsolidated), modal.DefaultClickFunc())
+		cm.ParentWindow().ShowModal(successModal)
+		cm.Dismiss()
+	}()
+}
+
+func (cm *consolidationModal) Handle(gtx C) {
+	cm.accountDropdown.Handle(gtx)
+
+	if cm.feeRateDropdown != nil && cm.feeRateDropdown.Changed(gtx) {
+		cm.prepareConsolidation()
+	}
+
+	for _, utxo := range cm.utxos {
+		if utxo.checkbox.CheckBox.Update(gtx) {
+			cm.prepareConsolidation()
+		}
+	}
+
+	for {
+		event, ok := cm.passwordEditor.Editor.Update(gtx)
+		if !ok {
+			break
+		}
+		if _, ok := event.(widget.SubmitEvent); ok {
+			cm.broadcast()
+		}
+	}
+
+	cm.consolidateBtn.SetEnabled(cm.consolidation != nil && cm.passwordEditor.Editor.Text() != "")
+	if cm.consolidateBtn.Clicked(gtx) {
+		cm.broadcast()
+	}
+
+	if cm.cancelBtn.Clicked(gtx) && !cm.isSending {
+		cm.Dismiss()
+	}
+}
+
+func (cm *consolidationModal) Layout(gtx C) D {
+	textSize14 := values.TextSizeTransform(cm.IsMobileView(), values.TextSize14)
+	textSize20 := values.TextSizeTransform(cm.IsMobileView(), values.TextSize20)
+	return cm.Modal.Layout(gtx, []layout.Widget{
+		func(gtx C) D {
+			title := cm.Theme.Label(textSize20, values.String(values.StrConsolidateUTXOs))
+			title.Font.Weight = font.SemiBold
+			return title.Layout(gtx)
+		},
+		func(gtx C) D {
+			info := cm.Theme.Label(textSize14, values.String(values.StrConsolidationInfo))
+			info.Color = cm.Theme.Color.GrayText2
+			return info.Layout(gtx)
+		},
+		func(gtx C) D {
+			return cm.accountDropdown.Layout(gtx, values.String(values.StrAccount))
+		},
+		func(gtx C) D {
+			if cm.feeRateDropdown == nil {
+				if !cm.fetchingRates {
+					return D{}
+				}
+				return layout.Center.Layout(gtx, material.Loader(cm.Theme.Base).Layout)
+			}
+			return cm.feeRateDropdown.Layout(gtx)
+		},
+		cm.utxosLayout,
+		cm.summaryLayout,
+		cm.passwordEditor.Layout,
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				if cm.isSending {
+					return layout.Inset{Top: unit.Dp(7)}.Layout(gtx, material.Loader(cm.Theme.Base).Layout)
+				}
+				return layout.Flex{}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, cm.cancelBtn.Layout)
+					}),
+					layout.Rigid(cm.consolidateBtn.Layout),
+				)
+			})
+		},
+	})
+}
+
+func (cm *consolidationModal) utxosLayout(gtx C) D {
+	textSize14 := values.TextSizeTransform(cm.IsMobileView(), values.TextSize14)
+	if len(cm.utxos) == 0 {
+		noUTXOs := cm.Theme.Label(textSize14, values.String(values.StrNoUTXOs))
+		noUTXOs.Color = cm.Theme.Color.GrayText2
+		return noUTXOs.Layout(gtx)
+	}
+
+	cm.Modal.ShowScrollbar(true)
+	rows := make([]layout.FlexChild, len(cm.utxos))
+	for i, utxo := range cm.utxos {
+		utxo := utxo
+		rows[i] = layout.Rigid(func(gtx C) D {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(utxo.checkbox.Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, cm.Theme.Label(textSize14, utxo.Amount.String()).Layout)
+				}),
+				layout.Flexed(1, func(gtx C) D {
+					address := cm.Theme.Label(textSize14, utxo.Address)
+					address.Color = cm.Theme.Color.GrayText2
+					address.MaxLines = 1
+					return layout.E.Layout(gtx, address.Layout)
+				}),
+			)
+		})
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+}
+
+func (cm *consolidationModal) summaryLayout(gtx C) D {
+	textSize14 := values.TextSizeTransform(cm.IsMobileView(), values.TextSize14)
+	if cm.consolidation == nil {
+		if cm.errText == "" {
+			return D{}
+		}
+		errLabel := cm.Theme.Label(textSize14, cm.errText)
+		errLabel.Color = cm.Theme.Color.Danger
+		return errLabel.Layout(gtx)
+	}
+
+	row := func(title, value string, valueColor color.NRGBA) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					lbl := cm.Theme.Label(textSize14, title)
+					lbl.Color = cm.Theme.Color.GrayText2
+					return lbl.Layout(gtx)
+				}),
+				layout.Flexed(1, func(gtx C) D {
+					lbl := cm.Theme.Label(textSize14, value)
+					lbl.Color = valueColor
+					return layout.E.Layout(gtx, lbl.Layout)
+				}),
+			)
+		})
+	}
+
+	c := cm.consolidation
+	savings := c.Savings()
+	savingsColor := cm.Theme.Color.Success
+	if savings < 0 {
+		savingsColor = cm.Theme.Color.Danger
+	}
+	laterFeeRate := cm.wallet.ToAmount(c.LaterFeeRate).String() + "/kvB"
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		row(values.String(values.StrConsolidationFee), cm.wallet.ToAmount(c.Fee).String(), cm.Theme.Color.Text),
+		row(values.String(values.StrTxSize), strconv.Itoa(c.EstimatedSize)+" bytes", cm.Theme.Color.Text),
+		row(values.StringF(values.StrFeeSavings, laterFeeRate), cm.wallet.ToAmount(savings).String(), savingsColor),
+	)
+}
@@ -0,0 +1,317 @@
+package wallet
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	pageutils "github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const PeersPageID = "Peers"
+
+// peersRefreshInterval is how often the peers are queried while the page is
+// displayed.
+const peersRefreshInterval = 2 * time.Second
+
+// peerActions are the buttons of a peer, kept across refreshes so clicks
+// aren't lost.
+type peerActions struct {
+	disconnect cryptomaterial.Button
+	ban        cryptomaterial.Button
+}
+
+// PeersPage shows the peers the wallet is connected to, refreshed live, and
+// lets the user disconnect or ban them and connect to a peer temporarily.
+type PeersPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet sharedW.Asset
+
+	mu          sync.Mutex
+	peers       []sharedW.PeerInfo
+	peersErr    error
+	refreshing  bool
+	lastRefresh time.Time
+
+	actions       map[string]*peerActions
+	addPeerBtn    cryptomaterial.Button
+	scrollbarList *widget.List
+	backButton    cryptomaterial.IconButton
+}
+
+func NewPeersPage(l *load.Load, wallet sharedW.Asset) *PeersPage {
+	pg := &PeersPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(PeersPageID),
+		wallet:           wallet,
+		actions:          make(map[string]*peerActions),
+		addPeerBtn:       l.Theme.OutlineButton(values.String(values.StrAddPeer)),
+		scrollbarList: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+	}
+	pg.backButton = components.GetBackButton(l)
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *PeersPage) OnNavigatedTo() {
+	pg.refresh()
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window.
+// Part of the load.Page interface.
+func (pg *PeersPage) OnNavigatedFrom() {}
+
+// canManagePeers returns true if the wallet syncs with P2P peers, the node or
+// server of a remote backend can't be managed.
+func (pg *PeersPage) canManagePeers() bool {
+	return pg.wallet.ChainBackend() == sharedW.SPVBackend
+}
+
+// canAddPeer returns true if the wallet can connect to a peer without
+// restarting the sync, the DCR SPV syncer can't.
+func (pg *PeersPage) canAddPeer() bool {
+	return pg.canManagePeers() && pg.wallet.GetAssetType() != libutils.DCRWalletAsset
+}
+
+// refresh queries the peers in the background, the chain service may take a
+// moment to answer.
+func (pg *PeersPage) refresh() {
+	pg.mu.Lock()
+	if pg.refreshing {
+		pg.mu.Unlock()
+		return
+	}
+	pg.refreshing = true
+	pg.mu.Unlock()
+
+	go func() {
+		peers, err := pg.wallet.PeerInfoRaw()
+
+		pg.mu.Lock()
+		pg.peers, pg.peersErr = peers, err
+		pg.refreshing = false
+		pg.lastRefresh = time.Now()
+		pg.mu.Unlock()
+		pg.ParentWindow().Reload()
+	}()
+}
+
+func (pg *PeersPage) peerActions(addr string) *peerActions {
+	actions, ok := pg.actions[addr]
+	if !ok {
+		actions = &peerActions{
+			disconnect: pg.Theme.OutlineButton(values.String(values.StrDisconnect)),
+			ban:        pg.Theme.DangerButton(values.String(values.StrBanPeer)),
+		}
+		for _, btn := range []*cryptomaterial.Button{&actions.disconnect, &actions.ban} {
+			btn.TextSize = values.TextSize12
+			btn.Inset = layout.UniformInset(values.MarginPadding6)
+		}
+		pg.actions[addr] = actions
+	}
+	return actions
+}
+
+// runPeerAction runs the action off the UI goroutine and reports its error.
+func (pg *PeersPage) runPeerAction(action func() error) {
+	go func() {
+		if err := action(); err != nil {
+			errModal := modal.NewErrorModal(pg.Load, values.TranslateErr(err.Error()), modal.DefaultClickFunc())
+			pg.ParentWindow().ShowModal(errModal)
+			return
+		}
+		pg.refresh()
+	}()
+}
+
+func (pg *PeersPage) showAddPeerModal() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrIPAddress)).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(addr string, tim *modal.TextInputModal) bool {
+			if err := pg.wallet.AddPeer(addr); err != nil {
+				tim.SetError(values.TranslateErr(err.Error()))
+				return false
+			}
+			pg.refresh()
+			return true
+		})
+	textModal.Title(values.String(values.StrAddPeer)).
+		SetPositiveButtonText(values.String(values.StrConfirm)).
+		SetNegativeButtonText(values.String(values.StrCancel))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *PeersPage) HandleUserInteractions(gtx C) {
+	pg.mu.Lock()
+	stale := time.Since(pg.lastRefresh) >= peersRefreshInterval
+	pg.mu.Unlock()
+	if stale {
+		pg.refresh()
+	}
+
+	if pg.addPeerBtn.Clicked(gtx) {
+		pg.showAddPeerModal()
+	}
+
+	for addr, actions := range pg.actions {
+		addr := addr
+		if actions.disconnect.Clicked(gtx) {
+			pg.runPeerAction(func() error { return pg.wallet.DisconnectPeer(addr) })
+		}
+		if actions.ban.Clicked(gtx) {
+			pg.runPeerAction(func() error { return pg.wallet.BanPeer(addr) })
+		}
+	}
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *PeersPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrPeerDiagnostics),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: pg.layoutPeers,
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	// Redraw to refresh the peers while the page is displayed.
+	gtx.Execute(op.InvalidateCmd{At: time.Now().Add(peersRefreshInterval)})
+	if pg.IsMobileView() {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return container(gtx)
+}
+
+func (pg *PeersPage) layoutPeers(gtx C) D {
+	pg.mu.Lock()
+	peers, peersErr := pg.peers, pg.peersErr
+	pg.mu.Unlock()
+
+	connected := make(map[string]bool, len(peers))
+	for _, peer := range peers {
+		connected[peer.Addr] = true
+	}
+	for addr := range pg.actions {
+		if !connected[addr] {
+			delete(pg.actions, addr)
+		}
+	}
+
+	var items []layout.Widget
+	if pg.canAddPeer() {
+		items = append(items, func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, pg.addPeerBtn.Layout)
+		})
+	}
+	switch {
+	case peersErr != nil:
+		items = append(items, pg.Theme.Body2(values.TranslateErr(peersErr.Error())).Layout)
+	case len(peers) == 0:
+		items = append(items, pg.Theme.Body2(values.String(values.StrNoPeers)).Layout)
+	}
+	for i := range peers {
+		peer := peers[i]
+		items = append(items, func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+				return pg.layoutPeer(gtx, &peer)
+			})
+		})
+	}
+
+	return pg.Theme.List(pg.scrollbarList).Layout(gtx, len(items), func(gtx C, i int) D {
+		return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, items[i])
+	})
+}
+
+func (pg *PeersPage) layoutPeer(gtx C, peer *sharedW.PeerInfo) D {
+	item := func(title, value string) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+				l := pg.Theme.Body2(title)
+				r := pg.Theme.Body2(value)
+				r.Color = pg.Theme.Color.GrayText2
+				return components.EndToEndRow(gtx, l.Layout, r.Layout)
+			})
+		})
+	}
+
+	ping := "-"
+	if peer.PingMicros > 0 {
+		ping = (time.Duration(peer.PingMicros) * time.Microsecond).Round(time.Millisecond).String()
+	}
+
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			addr := pg.Theme.Body1(peer.Addr)
+			addr.Font.Weight = font.SemiBold
+			return addr.Layout(gtx)
+		}),
+		item(values.String(values.StrUserAgent), peer.SubVer),
+		item(values.String(values.StrServices), peer.Services),
+		item(values.String(values.StrPing), ping),
+		item(values.String(values.StrBytesSentReceived), fmt.Sprintf("%s / %s", pageutils.FormatBytes(peer.BytesSent), pageutils.FormatBytes(peer.BytesRecv))),
+		item(values.String(values.StrBestBlocks), fmt.Sprintf("%d", peer.BestHeight)),
+		item(values.String(values.StrBanScore), fmt.Sprintf("%d", peer.BanScore)),
+	}
+	if pg.canManagePeers() {
+		actions := pg.peerActions(peer.Addr)
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return layout.E.Layout(gtx, func(gtx C) D {
+					return layout.Flex{}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, actions.disconnect.Layout)
+						}),
+						layout.Rigid(actions.ban.Layout),
+					)
+				})
+			})
+		}))
+	}
+
+	card := pg.Theme.Card()
+	card.Color = pg.Theme.Color.Surface
+	return card.Layout(gtx, func(gtx C) D {
+		return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		})
+	})
+}
@@ -0,0 +1,253 @@
+package wallet
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// rescanDateLayout is the format of the start date typed in the rescan
+// modal.
+const rescanDateLayout = "2006-01-02"
+
+type rescanAccount struct {
+	number   int32
+	checkbox cryptomaterial.CheckBoxStyle
+}
+
+// rescanModal rescans the blockchain from a date or block height, optionally
+// for a subset of the accounts and with an extended gap limit.
+type rescanModal struct {
+	*load.Load
+	*cryptomaterial.Modal
+
+	wallet sharedW.Asset
+
+	startEditor    cryptomaterial.Editor
+	gapLimitEditor cryptomaterial.Editor
+	accounts       []*rescanAccount
+
+	pending    *sharedW.RescanOptions // options being estimated
+	opts       *sharedW.RescanOptions
+	estimate   *sharedW.RescanEstimate
+	estimating bool
+	errText    string
+
+	cancelBtn cryptomaterial.Button
+	rescanBtn cryptomaterial.Button
+}
+
+func newRescanModal(l *load.Load, wallet sharedW.Asset) *rescanModal {
+	rm := &rescanModal{
+		Load:      l,
+		Modal:     l.Theme.ModalFloatTitle("rescan_modal", l.IsMobileView(), nil),
+		wallet:    wallet,
+		cancelBtn: l.Theme.OutlineButton(values.String(values.StrCancel)),
+		rescanBtn: l.Theme.Button(values.String(values.StrRescan)),
+	}
+
+	rm.startEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrRescanStart))
+	rm.startEditor.Editor.SingleLine = true
+	rm.gapLimitEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrRescanGapLimit))
+	rm.gapLimitEditor.Editor.SingleLine = true
+
+	// dcrwallet always rescans all the accounts.
+	if wallet.GetAssetType() != libutils.DCRWalletAsset {
+		if accounts, err := wallet.GetAccountsRaw(); err == nil {
+			for _, account := range accounts.Accounts {
+				checkbox := l.Theme.CheckBox(new(widget.Bool), account.Name)
+				checkbox.CheckBox.Value = true
+				rm.accounts = append(rm.accounts, &rescanAccount{number: account.Number, checkbox: checkbox})
+			}
+		}
+	}
+
+	return rm
+}
+
+func (rm *rescanModal) OnResume() {
+	rm.updateEstimate()
+}
+
+func (rm *rescanModal) OnDismiss() {}
+
+// parseOptions reads the rescan options from the inputs. The string returned
+// with nil options describes the invalid input.
+func (rm *rescanModal) parseOptions() (*sharedW.RescanOptions, string) {
+	opts := new(sharedW.RescanOptions)
+	if start := strings.TrimSpace(rm.startEditor.Editor.Text()); start != "" {
+		if height, err := strconv.ParseInt(start, 10, 32); err == nil {
+			opts.StartHeight = int32(height)
+		} else if date, err := time.ParseInLocation(rescanDateLayout, start, time.Local); err == nil {
+			opts.StartDate = date
+		} else {
+			return nil, values.String(values.StrInvalidRescanStart)
+		}
+	}
+
+	if gapLimit := strings.TrimSpace(rm.gapLimitEditor.Editor.Text()); gapLimit != "" {
+		val, err := strconv.ParseUint(gapLimit, 10, 32)
+		if err != nil || val < 1 || val > sharedW.MaxRescanGapLimit {
+			return nil, values.String(values.StrGapLimitInputErr)
+		}
+		opts.GapLimit = uint32(val)
+	}
+
+	allAccounts := true
+	for _, account := range rm.accounts {
+		if account.checkbox.CheckBox.Value {
+			opts.Accounts = append(opts.Accounts, account.number)
+		} else {
+			allAccounts = false
+		}
+	}
+	if allAccounts {
+		opts.Accounts = nil
+	} else if len(opts.Accounts) == 0 {
+		return nil, values.String(values.StrNoRescanAccounts)
+	}
+	return opts, ""
+}
+
+// updateEstimate estimates the rescan of the options entered. Looking up the
+// start block from a date may fetch block headers, so it doesn't run on the
+// UI goroutine.
+func (rm *rescanModal) updateEstimate() {
+	rm.opts, rm.estimate = nil, nil
+	opts, errText := rm.parseOptions()
+	rm.pending, rm.errText = opts, errText
+	if opts == nil {
+		return
+	}
+
+	rm.estimating = true
+	go func() {
+		estimate, err := rm.wallet.EstimateRescan(opts)
+		if rm.pending != opts {
+			return // the inputs changed meanwhile
+		}
+		rm.estimating = false
+		if err != nil {
+			rm.errText = values.TranslateErr(err.Error())
+		} else {
+			rm.opts, rm.estimate = opts, estimate
+		}
+		rm.ParentWindow().Reload()
+	}()
+}
+
+func (rm *rescanModal) rescan() {
+	if rm.opts == nil {
+		return
+	}
+
+	if err := rm.wallet.RescanWithOptions(rm.opts); err != nil {
+		rm.errText = values.TranslateErr(err.Error())
+		return
+	}
+	rm.Dismiss()
+}
+
+func (rm *rescanModal) Handle(gtx C) {
+	changed := false
+	for _, editor := range []*widget.Editor{rm.startEditor.Editor, rm.gapLimitEditor.Editor} {
+		for {
+			event, ok := editor.Update(gtx)
+			if !ok {
+				break
+			}
+			if _, ok := event.(widget.ChangeEvent); ok {
+				changed = true
+			}
+		}
+	}
+	for _, account := range rm.accounts {
+		if account.checkbox.CheckBox.Update(gtx) {
+			changed = true
+		}
+	}
+	if changed {
+		rm.updateEstimate()
+	}
+
+	rm.rescanBtn.SetEnabled(rm.opts != nil && !rm.estimating)
+	if rm.rescanBtn.Clicked(gtx) {
+		rm.rescan()
+	}
+
+	if rm.cancelBtn.Clicked(gtx) {
+		rm.Dismiss()
+	}
+}
+
+func (rm *rescanModal) Layout(gtx C) D {
+	textSize14 := values.TextSizeTransform(rm.IsMobileView(), values.TextSize14)
+	textSize20 := values.TextSizeTransform(rm.IsMobileView(), values.TextSize20)
+	widgets := []layout.Widget{
+		func(gtx C) D {
+			title := rm.Theme.Label(textSize20, values.String(values.StrRescanBlockchain))
+			title.Font.Weight = font.SemiBold
+			return title.Layout(gtx)
+		},
+		func(gtx C) D {
+			info := rm.Theme.Label(textSize14, values.String(values.StrRescanInfo)+". "+values.String(values.StrRescanOptionsInfo))
+			info.Color = rm.Theme.Color.GrayText2
+			return info.Layout(gtx)
+		},
+		rm.startEditor.Layout,
+		rm.gapLimitEditor.Layout,
+	}
+
+	if len(rm.accounts) > 0 {
+		widgets = append(widgets, func(gtx C) D {
+			rows := []layout.FlexChild{
+				layout.Rigid(rm.Theme.Label(textSize14, values.String(values.StrRescanAccounts)).Layout),
+			}
+			for _, account := range rm.accounts {
+				rows = append(rows, layout.Rigid(account.checkbox.Layout))
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+		})
+	}
+
+	widgets = append(widgets,
+		func(gtx C) D {
+			var lbl cryptomaterial.Label
+			switch {
+			case rm.errText != "":
+				lbl = rm.Theme.Label(textSize14, rm.errText)
+				lbl.Color = rm.Theme.Color.Danger
+			case rm.estimate != nil:
+				e := rm.estimate
+				lbl = rm.Theme.Label(textSize14, values.StringF(values.StrRescanEstimate, e.Blocks, e.StartHeight,
+					e.StartTime.Format(rescanDateLayout), utils.TimeFormat(int(e.Duration.Seconds()), true)))
+				lbl.Color = rm.Theme.Color.GrayText2
+			default:
+				return D{}
+			}
+			return lbl.Layout(gtx)
+		},
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, rm.cancelBtn.Layout)
+					}),
+					layout.Rigid(rm.rescanBtn.Layout),
+				)
+			})
+		},
+	)
+	return rm.Modal.Layout(gtx, widgets)
+}
@@ -757,6 +757,25 @@ func (swmp *SingleWalletMasterPage) listenForNotifications() {
 		return
 	}
 
+	if dcrWallet, ok := swmp.selectedWallet.(*dcr.Asset); ok {
+		soloVoterListener := &dcr.SoloVoterNotificationListener{
+			OnSoloVoterStopped: func(_ int, err error) {
+				if err != nil {
+					swmp.Toast.NotifyError(values.StringF(values.StrSoloVoterStopped, err), true)
+				}
+				swmp.ParentWindow().Reload()
+			},
+			OnWalletKeptUnlocked: func(_ int) {
+				swmp.Toast.NotifyError(values.StringF(values.StrWalletKeptUnlocked, swmp.selectedWallet.GetWalletName()), true)
+			},
+		}
+		err = dcrWallet.AddSoloVoterNotificationListener(soloVoterListener, MainPageID)
+		if err != nil {
+			log.Errorf("Error adding solo voter notification listener: %v", err)
+			return
+		}
+	}
+
 	if swmp.isGovernanceAPIAllowed() {
 		proposalSyncCallback := func(propName string, status libutils.ProposalStatus) {
 			// Post desktop notification for all events except the synced event.
@@ -778,6 +797,9 @@ func (swmp *SingleWalletMasterPage) listenForNotifications() {
 func (swmp *SingleWalletMasterPage) stopNtfnListeners() {
 	swmp.selectedWallet.RemoveSyncProgressListener(MainPageID)
 	swmp.selectedWallet.RemoveTxAndBlockNotificationListener(MainPageID)
+	if dcrWallet, ok := swmp.selectedWallet.(*dcr.Asset); ok {
+		dcrWallet.RemoveSoloVoterNotificationListener(MainPageID)
+	}
 	swmp.AssetsManager.Politeia.RemoveSyncCallback(MainPageID)
 }
 
@@ -23,6 +23,7 @@ import (
 	"github.com/crypto-power/cryptopower/ui/page/security"
 	"github.com/crypto-power/cryptopower/ui/page/seedbackup"
 	s "github.com/crypto-power/cryptopower/ui/page/settings"
+	"github.com/crypto-power/cryptopower/ui/preference"
 	"github.com/crypto-power/cryptopower/ui/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
@@ -55,9 +56,12 @@ type SettingsPage struct {
 
 	changePass, viewSeed, rescan               *cryptomaterial.Clickable
 	changeAccount, checklog, checkStats        *cryptomaterial.Clickable
+	checkPeers                                 *cryptomaterial.Clickable
 	changeWalletName, addAccount, deleteWallet *cryptomaterial.Clickable
 	verifyMessage, validateAddr, signMessage   *cryptomaterial.Clickable
 	updateConnectToPeer, setGapLimit           *cryptomaterial.Clickable
+	coinSelection, dustThreshold, consolidate  *cryptomaterial.Clickable
+	chainBackend                               *cryptomaterial.Clickable
 
 	backButton cryptomaterial.IconButton
 	infoButton cryptomaterial.IconButton
@@ -65,6 +69,9 @@ type SettingsPage struct {
 	spendUnconfirmed  *cryptomaterial.Switch
 	spendUnmixedFunds *cryptomaterial.Switch
 	connectToPeer     *cryptomaterial.Switch
+	autoFreezeDust    *cryptomaterial.Switch
+	soloVoting        *cryptomaterial.Switch
+	soloVoter         *cryptomaterial.Switch
 
 	walletCallbackFunc func()
 
@@ -83,6 +90,7 @@ func NewSettingsPage(l *load.Load, wallet sharedW.Asset, walletCallbackFunc func
 		changeAccount:       l.Theme.NewClickable(false),
 		checklog:            l.Theme.NewClickable(false),
 		checkStats:          l.Theme.NewClickable(false),
+		checkPeers:          l.Theme.NewClickable(false),
 		changeWalletName:    l.Theme.NewClickable(false),
 		addAccount:          l.Theme.NewClickable(false),
 		deleteWallet:        l.Theme.NewClickable(false),
@@ -90,10 +98,17 @@ func NewSettingsPage(l *load.Load, wallet sharedW.Asset, walletCallbackFunc func
 		validateAddr:        l.Theme.NewClickable(false),
 		signMessage:         l.Theme.NewClickable(false),
 		updateConnectToPeer: l.Theme.NewClickable(false),
+		coinSelection:       l.Theme.NewClickable(false),
+		dustThreshold:       l.Theme.NewClickable(false),
+		consolidate:         l.Theme.NewClickable(false),
+		chainBackend:        l.Theme.NewClickable(false),
 
 		spendUnconfirmed:  l.Theme.Switch(),
 		spendUnmixedFunds: l.Theme.Switch(),
 		connectToPeer:     l.Theme.Switch(),
+		autoFreezeDust:    l.Theme.Switch(),
+		soloVoting:        l.Theme.Switch(),
+		soloVoter:         l.Theme.Switch(),
 
 		pageContainer: &widget.List{
 			List: layout.List{Axis: layout.Vertical},
@@ -114,6 +129,11 @@ func NewSettingsPage(l *load.Load, wallet sharedW.Asset, walletCallbackFunc func
 func (pg *SettingsPage) OnNavigatedTo() {
 	pg.spendUnconfirmed.SetChecked(pg.readBool(sharedW.SpendUnconfirmedConfigKey))
 	pg.spendUnmixedFunds.SetChecked(pg.readBool(sharedW.SpendUnmixedFundsKey))
+	pg.autoFreezeDust.SetChecked(pg.wallet.AutoFreezeDust())
+	if dcrWallet, ok := pg.wallet.(*dcr.Asset); ok {
+		pg.soloVoting.SetChecked(dcrWallet.IsSoloVotingEnabled())
+		pg.soloVoter.SetChecked(dcrWallet.IsSoloVoterRunning())
+	}
 
 	pg.loadPeerAddress()
 
@@ -201,6 +221,62 @@ func (pg *SettingsPage) generalSection() layout.Widget {
 				return D{}
 			}),
 			layout.Rigid(func(gtx C) D {
+				if pg.wallet.IsWatchingOnlyWallet() {
+					return D{}
+				}
+				coinSelectionRow := clickableRowData{
+					title:     values.String(values.StrCoinSelectionStrategy),
+					clickable: pg.coinSelection,
+					labelText: coinSelectionStrategyName(pg.wallet.DefaultCoinSelectionStrategy()),
+				}
+				return pg.clickableRow(gtx, coinSelectionRow)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.wallet.IsWatchingOnlyWallet() {
+					return D{}
+				}
+				dustThresholdRow := clickableRowData{
+					title:     values.String(values.StrDustThreshold),
+					clickable: pg.dustThreshold,
+					labelText: pg.wallet.ToAmount(pg.wallet.DustThreshold()).String(),
+				}
+				return pg.clickableRow(gtx, dustThresholdRow)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.wallet.IsWatchingOnlyWallet() {
+					return D{}
+				}
+				return pg.subSectionSwitch(values.String(values.StrAutoFreezeDust), pg.autoFreezeDust)(gtx)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.wallet.IsWatchingOnlyWallet() || !canConsolidate(pg.wallet) {
+					return D{}
+				}
+				return pg.sectionDimension(gtx, pg.consolidate, values.String(values.StrConsolidateUTXOs))
+			}),
+			layout.Rigid(func(gtx C) D {
+				chainBackendRow := clickableRowData{
+					title:     values.String(values.StrChainBackend),
+					clickable: pg.chainBackend,
+					labelText: values.String(values.StrSPV),
+				}
+				switch pg.wallet.ChainBackend() {
+				case sharedW.RPCBackend:
+					chainBackendRow.labelText = pg.wallet.RPCConfig().Host
+				case sharedW.ElectrumBackend:
+					chainBackendRow.labelText = values.String(values.StrElectrum)
+					if server := pg.wallet.ElectrumConfig().Server; server != "" {
+						chainBackendRow.labelText = server
+					}
+				}
+				return pg.clickableRow(gtx, chainBackendRow)
+			}),
+			layout.Rigid(pg.soloVotingSection),
+			layout.Rigid(func(gtx C) D {
+				if pg.wallet.ChainBackend() != sharedW.SPVBackend {
+					// The full node or Electrum server is the only peer of the wallet.
+					return D{}
+				}
 				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 					layout.Rigid(pg.subSectionSwitch(values.String(values.StrConnectToSpecificPeer), pg.connectToPeer)),
 					layout.Rigid(func(gtx C) D {
@@ -225,6 +301,38 @@ func (pg *SettingsPage) generalSection() layout.Widget {
 	}
 }
 
+// soloVotingSection lays out the solo voting settings of DCR wallets, with a
+// warning while the solo voter keeps the wallet unlocked.
+func (pg *SettingsPage) soloVotingSection(gtx C) D {
+	dcrWallet, ok := pg.wallet.(*dcr.Asset)
+	if !ok || dcrWallet.IsWatchingOnlyWallet() {
+		return D{}
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(pg.subSectionSwitch(values.String(values.StrSoloVoting), pg.soloVoting)),
+		layout.Rigid(func(gtx C) D {
+			if !dcrWallet.IsSoloVotingEnabled() {
+				return D{}
+			}
+			if !dcrWallet.SoloVotingActive() {
+				lbl := pg.Theme.Label(values.TextSizeTransform(pg.IsMobileView(), values.TextSize14), values.String(values.StrSoloVotingPending))
+				lbl.Color = pg.Theme.Color.GrayText2
+				return layout.Inset{Bottom: values.MarginPadding15}.Layout(gtx, lbl.Layout)
+			}
+			return pg.subSectionSwitch(values.String(values.StrSoloVoter), pg.soloVoter)(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if !dcrWallet.IsSoloVoterRunning() {
+				return D{}
+			}
+			lbl := pg.Theme.Label(values.TextSizeTransform(pg.IsMobileView(), values.TextSize14), values.String(values.StrSoloVoterRunning))
+			lbl.Color = pg.Theme.Color.Danger
+			return layout.Inset{Bottom: values.MarginPadding15}.Layout(gtx, lbl.Layout)
+		}),
+	)
+}
+
 func (pg *SettingsPage) debug() layout.Widget {
 	dim := func(gtx C) D {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
@@ -237,6 +345,7 @@ func (pg *SettingsPage) debug() layout.Widget {
 			}),
 			layout.Rigid(pg.sectionContent(pg.checklog, values.String(values.StrCheckWalletLog))),
 			layout.Rigid(pg.sectionContent(pg.checkStats, values.String(values.StrCheckStatistics))),
+			layout.Rigid(pg.sectionContent(pg.checkPeers, values.String(values.StrPeerDiagnostics))),
 		)
 	}
 	return func(gtx C) D {
@@ -609,6 +718,16 @@ func validatePeerAddressStr(addrs string) (string, bool) {
 	return strings.Trim(addrStr, ";"), true
 }
 
+// coinSelectionStrategyName returns the translated name of the strategy.
+func coinSelectionStrategyName(strategy sharedW.CoinSelectionStrategy) string {
+	for _, option := range preference.CoinSelectionOptions {
+		if option.Key == string(strategy) {
+			return values.String(option.Value)
+		}
+	}
+	return string(strategy)
+}
+
 func (pg *SettingsPage) clickableRow(gtx C, row clickableRowData) D {
 	return row.clickable.Layout(gtx, func(gtx C) D {
 		return pg.subSection(gtx, row.title, func(gtx C) D {
@@ -666,28 +785,7 @@ func (pg *SettingsPage) HandleUserInteractions(gtx C) {
 	}
 
 	if pg.rescan.Clicked(gtx) {
-		go func() {
-			info := modal.NewCustomModal(pg.Load).
-				Title(values.String(values.StrRescanBlockchain)).
-				Body(values.String(values.StrRescanInfo)).
-				SetNegativeButtonText(values.String(values.StrCancel)).
-				PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.Surface).
-				SetPositiveButtonText(values.String(values.StrRescan)).
-				SetPositiveButtonCallback(func(_ bool, im *modal.InfoModal) bool {
-					err := pg.wallet.RescanBlocks()
-					if err != nil {
-						errorModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
-						pg.ParentWindow().ShowModal(errorModal)
-						im.Dismiss()
-						return false
-					}
-
-					im.Dismiss()
-					return true
-				})
-
-			pg.ParentWindow().ShowModal(info)
-		}()
+		pg.ParentWindow().ShowModal(newRescanModal(pg.Load, pg.wallet))
 	}
 
 	if pg.setGapLimit.Clicked(gtx) {
@@ -765,6 +863,48 @@ func (pg *SettingsPage) HandleUserInteractions(gtx C) {
 		pg.showSPVPeerDialog()
 	}
 
+	if pg.chainBackend.Clicked(gtx) {
+		pg.ParentWindow().ShowModal(newChainBackendModal(pg.Load, pg.wallet, pg.ParentWindow().Reload))
+	}
+
+	if pg.soloVoting.Changed(gtx) {
+		pg.soloVotingChanged()
+	}
+
+	if pg.soloVoter.Changed(gtx) {
+		pg.soloVoterChanged()
+	}
+
+	if pg.coinSelection.Clicked(gtx) {
+		strategyModal := preference.NewListPreference(pg.Load, "", string(pg.wallet.DefaultCoinSelectionStrategy()), preference.CoinSelectionOptions).
+			Title(values.String(values.StrCoinSelectionStrategy)).
+			UpdateValues(func(val string) {
+				if err := pg.wallet.SetDefaultCoinSelectionStrategy(sharedW.CoinSelectionStrategy(val)); err != nil {
+					pg.Toast.NotifyError(err.Error())
+				}
+			})
+		pg.ParentWindow().ShowModal(strategyModal)
+	}
+
+	if pg.dustThreshold.Clicked(gtx) {
+		pg.dustThresholdModal()
+	}
+
+	if pg.autoFreezeDust.Changed(gtx) {
+		pg.wallet.SetAutoFreezeDust(pg.autoFreezeDust.IsChecked())
+		if pg.autoFreezeDust.IsChecked() {
+			go func() {
+				if _, err := sharedW.FreezeDust(pg.wallet); err != nil {
+					pg.Toast.NotifyError(err.Error())
+				}
+			}()
+		}
+	}
+
+	if pg.consolidate.Clicked(gtx) {
+		pg.ParentWindow().ShowModal(newConsolidationModal(pg.Load, pg.wallet))
+	}
+
 	if pg.verifyMessage.Clicked(gtx) {
 		pg.ParentNavigator().Display(security.NewVerifyMessagePage(pg.Load, pg.wallet))
 	}
@@ -785,6 +925,10 @@ func (pg *SettingsPage) HandleUserInteractions(gtx C) {
 		pg.ParentNavigator().Display(s.NewStatPage(pg.Load, pg.wallet))
 	}
 
+	if pg.checkPeers.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewPeersPage(pg.Load, pg.wallet))
+	}
+
 	for pg.addAccount.Clicked(gtx) {
 		newPasswordModal := modal.NewCreatePasswordModal(pg.Load).
 			Title(values.String(values.StrCreateNewAccount)).
@@ -811,6 +955,101 @@ func (pg *SettingsPage) HandleUserInteractions(gtx C) {
 	}
 }
 
+// soloVotingChanged saves the solo voting setting, warning the user of the
+// conditions for tickets to be voted before solo voting is enabled.
+func (pg *SettingsPage) soloVotingChanged() {
+	dcrWallet := pg.wallet.(*dcr.Asset)
+	if !pg.soloVoting.IsChecked() {
+		dcrWallet.SetSoloVotingEnabled(false)
+		return
+	}
+
+	warningModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrSoloVoting)).
+		Body(values.String(values.StrSoloVotingWarning)).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetNegativeButtonCallback(func() {
+			pg.soloVoting.SetChecked(false)
+		}).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger).
+		SetPositiveButtonText(values.String(values.StrConfirm)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			dcrWallet.SetSoloVotingEnabled(true)
+			return true
+		})
+	pg.ParentWindow().ShowModal(warningModal)
+}
+
+// soloVoterChanged starts the solo voter with the spending password of the
+// wallet, or stops it.
+func (pg *SettingsPage) soloVoterChanged() {
+	dcrWallet := pg.wallet.(*dcr.Asset)
+	if !pg.soloVoter.IsChecked() {
+		if err := dcrWallet.StopSoloVoter(); err != nil {
+			pg.Toast.NotifyError(err.Error())
+		}
+		return
+	}
+
+	if !dcrWallet.SupportsSoloVoting() {
+		pg.soloVoter.SetChecked(false)
+		pg.Toast.NotifyError(libutils.ErrSoloVotingNotSupported.Error())
+		return
+	}
+
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrSoloVoter)).
+		SetDescription(values.String(values.StrSoloVoterRunning)).
+		PasswordHint(values.String(values.StrSpendingPassword)).
+		SetNegativeButtonCallback(func() {
+			pg.soloVoter.SetChecked(false)
+		}).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			if err := dcrWallet.StartSoloVoter(password); err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+func (pg *SettingsPage) dustThresholdModal() {
+	threshold := strconv.FormatInt(pg.wallet.DustThreshold(), 10)
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.StringF(values.StrDustThresholdHint, atomUnit(pg.wallet.GetAssetType()))).
+		SetText(threshold).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		SetPositiveButtonCallback(func(threshold string, tm *modal.TextInputModal) bool {
+			val, err := strconv.ParseInt(strings.TrimSpace(threshold), 10, 64)
+			if err == nil {
+				err = pg.wallet.SetDustThreshold(val)
+			}
+			if err != nil {
+				tm.SetError(values.String(values.StrInvalidDustThreshold))
+				return false
+			}
+			return true
+		})
+	textModal.Title(values.String(values.StrDustThreshold)).
+		SetPositiveButtonText(values.String(values.StrSave))
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+// atomUnit returns the name of the smallest unit of the asset.
+func atomUnit(assetType libutils.AssetType) string {
+	switch assetType {
+	case libutils.BTCWalletAsset:
+		return "sats"
+	case libutils.LTCWalletAsset:
+		return "litoshi"
+	default:
+		return "atoms"
+	}
+}
+
 func (pg *SettingsPage) gapLimitModal() {
 	walGapLim := pg.wallet.ReadStringConfigValueForKey(load.GapLimitConfigKey, "20")
 	textModal := modal.NewTextInputModal(pg.Load).
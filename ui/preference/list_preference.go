@@ -61,6 +61,30 @@ var (
 		{Key: libutils.LogLevelError, Value: values.StrLogLevelError},
 		{Key: libutils.LogLevelCritical, Value: values.StrLogLevelCritical},
 	}
+
+	// CoinSelectionOptions are the selectable coin selection strategies.
+	CoinSelectionOptions = []ItemPreference{
+		{Key: string(sharedW.CoinSelectionLargestFirst), Value: values.StrLargestFirst},
+		{Key: string(sharedW.CoinSelectionOldestFirst), Value: values.StrOldestFirst},
+		{Key: string(sharedW.CoinSelectionBranchAndBound), Value: values.StrAvoidChange},
+		{Key: string(sharedW.CoinSelectionPrivacy), Value: values.StrPrivacyFirst},
+	}
+
+	// SyncHoursOptions are the selectable sync hours, keyed by the start and
+	// end hour.
+	SyncHoursOptions = []ItemPreference{
+		{Key: "0-0", Value: values.StrAnyTime},
+		{Key: "22-6", Value: values.StrSyncHoursOvernight},
+		{Key: "0-6", Value: values.StrSyncHoursEarlyMorning},
+	}
+
+	// ConcurrentSyncsOptions are the selectable numbers of wallets syncing at
+	// once, 0 for all of them.
+	ConcurrentSyncsOptions = []ItemPreference{
+		{Key: "0", Value: values.StrAllWallets},
+		{Key: "1", Value: values.StrOneAtATime},
+		{Key: "2", Value: values.StrTwoAtATime},
+	}
 )
 
 type ListPreferenceModal struct {
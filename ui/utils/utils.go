@@ -164,3 +164,17 @@ func USDMarketFromAsset(asset utils.AssetType) (values.Market, error) {
 		return values.UnknownMarket, fmt.Errorf("unsupported asset type: %s", asset)
 	}
 }
+
+// FormatBytes formats a size in bytes with a binary unit.
+func FormatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
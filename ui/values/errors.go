@@ -23,6 +23,12 @@ func TranslateErr(errStr string) string {
 	case utils.ErrInsufficientBalance:
 		return String(StrInsufficientFund)
 
+	case utils.ErrSyncOutsideHours.Error():
+		return String(StrSyncOutsideHours)
+
+	case utils.ErrSyncMeteredConnection.Error():
+		return String(StrSyncMeteredConnection)
+
 	default:
 		if strings.Contains(errStr, "strconv.ParseFloat") {
 			return String((StrInvalidAmount))
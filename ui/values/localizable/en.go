@@ -118,6 +118,7 @@ const EN = `
 "confirmtoCreateAccs" = "Confirm to create needed accounts"
 "confirmToMixAcc" = "Confirm to mix account"
 "confirmToRemove" = "Confirm to remove"
+"confirmToSave" = "Confirm to save"
 "confirmToSetMixer" = "Confirm to set mixer accounts"
 "confirmToShowSeed" = "Confirm to show seed"
 "confirmToSign" = "Confirm to sign"
@@ -949,4 +950,119 @@ const EN = `
 "rateKucoinWarning" = "*Some countries are restricted on Kucoin and may not be able to fetch rate."
 "restrictDetail" = "Restriction Detail"
 "rateUnavailable" = "The rate unavailable this time, please reset it later in settings."
+"proposalComments" = "Comments (%d)"
+"noComments" = "No comments yet"
+"commentAuthor" = "author"
+"commentDeleted" = "This comment was deleted"
+"commentScore" = "%d points"
+"authorProposals" = "%d proposals, %d approved"
+"useSeedPassphrase" = "Use a seed passphrase (advanced)"
+"seedPassphrase" = "Seed passphrase"
+"seedPassphraseInfo" = "Only enter a passphrase if the seed was protected with an extra BIP-39 passphrase. A different passphrase restores a different wallet."
+"taprootAccount" = "Taproot account (bc1p addresses)"
+"scanLegacyKeyScopes" = "Scan other wallets' derivation paths"
+"scanLegacyKeyScopesInfo" = "Also looks for funds on the legacy (BIP-44) and nested segwit (BIP-49) derivation paths used by other wallets, including the Litecoin coin type variants. Paths with funds are added as accounts once the wallet is synced."
+"backupAsSeedShares" = "Back up as Shamir shares (SLIP-39)"
+"seedShares" = "Seed shares"
+"seedSharesInfo" = "The seed is split into shares so that no single copy can restore the wallet. Any shares up to the required number restore it, store each share in a different place."
+"sharesRequired" = "Shares required to restore"
+"totalShares" = "Total shares"
+"useShareGroups" = "Use share groups"
+"shareGroups" = "Groups, e.g. 2-of-3, 3-of-5"
+"groupsRequired" = "Groups required to restore"
+"invalidShareGroups" = "Invalid share configuration, at most 16 shares and groups are allowed and the required count cannot exceed the total"
+"generateShares" = "Generate shares"
+"shareXofY" = "Share %d of %d"
+"shareGroupX" = "group %d"
+"wroteShare" = "I have written down this share"
+"enterShareToVerify" = "Enter the share you wrote down"
+"shareMismatch" = "The share does not match, check the words and their order"
+"restoreFromShares" = "Restore from Shamir shares (SLIP-39)"
+"enterSeedShares" = "Enter one share per line"
+"addressBook" = "Address book"
+"contactName" = "Contact name"
+"saveContact" = "Save contact"
+"noContacts" = "No saved contacts"
+"editTxLabel" = "Edit transaction label"
+"minAmount" = "Min amount"
+"maxAmount" = "Max amount"
+"fromDate" = "From (YYYY-MM-DD)"
+"toDate" = "To (YYYY-MM-DD)"
+"anyStatus" = "Any status"
+"confirming" = "Confirming"
+"invalidDate" = "Invalid date"
+"editUTXOLabel" = "Edit UTXO label"
+"coinSelectionStrategy" = "Coin selection strategy"
+"largestFirst" = "Largest first"
+"oldestFirst" = "Oldest first"
+"avoidChange" = "Avoid change"
+"privacyFirst" = "Keep addresses apart"
+"dustThreshold" = "Dust threshold"
+"dustThresholdHint" = "Threshold (%s)"
+"invalidDustThreshold" = "Enter a whole amount of 0 or more, 0 disables dust detection"
+"autoFreezeDust" = "Freeze dust automatically"
+"dust" = "Dust"
+"dustReceived" = "%s received %d tiny unsolicited outputs that may be a dust attack, avoid spending them with your other funds"
+"dustFrozen" = "%s received %d tiny unsolicited outputs that may be a dust attack, they were frozen"
+"consolidateUTXOs" = "Consolidate UTXOs"
+"consolidationInfo" = "Merge small outputs into one output of the same account while fees are low, spending them one by one later costs more."
+"consolidationFee" = "Consolidation fee"
+"feeSavings" = "Savings versus spending them at %s"
+"selectTwoUTXOs" = "Select at least two outputs"
+"consolidate" = "Consolidate"
+"utxosConsolidated" = "Outputs consolidated"
+"chainBackend" = "Chain backend"
+"spv" = "SPV"
+"syncWithFullNode" = "Sync with my full node"
+"chainBackendInfo" = "Sync the wallet with a full node you run instead of peers of the P2P network. The node must be synced, with its RPC server enabled."
+"rpcHost" = "RPC host"
+"rpcUser" = "RPC username"
+"rpcPassword" = "RPC password"
+"rpcCertPath" = "RPC TLS certificate path"
+"chainBackendUpdated" = "Chain backend updated"
+"unlockRPCPasswordInfo" = "You need to unlock your %s wallet (%s) to decrypt the password of your full node."
+"enterRPCPasswordInfo" = "Your %s wallet (%s) is watching only and doesn't save the password of your full node, enter it to sync."
+"electrum" = "Electrum"
+"syncWithElectrum" = "Sync with an Electrum server"
+"electrumServer" = "Electrum server (ssl://host:port)"
+"electrumCertFingerprint" = "TLS certificate sha256 fingerprint (optional)"
+"electrumServerInfo" = "Leave the server empty to use one of the default public servers."
+"peerDiagnostics" = "Peer diagnostics"
+"addPeer" = "Add peer"
+"banPeer" = "Ban"
+"noPeers" = "Not connected to any peer"
+"services" = "Services"
+"ping" = "Ping"
+"bytesSentReceived" = "Sent / received"
+"banScore" = "Ban score"
+"syncHours" = "Sync hours"
+"anyTime" = "Any time"
+"syncHoursOvernight" = "Overnight (22:00 - 06:00)"
+"syncHoursEarlyMorning" = "Early morning (00:00 - 06:00)"
+"concurrentSyncs" = "Wallets syncing at once"
+"oneAtATime" = "One at a time"
+"twoAtATime" = "Two at a time"
+"pauseSyncOnMetered" = "Pause sync on metered connections"
+"syncPending" = "Waiting to sync"
+"syncOutsideHours" = "Sync paused outside of the sync hours"
+"syncMeteredConnection" = "Sync paused on a metered connection"
+"dataUsage" = "Data usage"
+"dataUsageValue" = "%s received, %s sent"
+"rescanStart" = "Start date (YYYY-MM-DD) or block height"
+"rescanGapLimit" = "Gap limit (optional)"
+"rescanAccounts" = "Accounts to rescan"
+"rescanOptionsInfo" = "Rescan from the date the wallet was first used to find missing transactions faster. Set a gap limit larger than 20 if the wallet used many addresses without receiving funds to them."
+"rescanEstimate" = "Rescans %d blocks from block %d (%s), about %s."
+"invalidRescanStart" = "Enter a date as YYYY-MM-DD or a block height"
+"noRescanAccounts" = "Select at least one account to rescan"
+"proposalVoteReminder" = "Voting on %s ends in %d blocks, %s has %d tickets that can still vote"
+"soloVoting" = "Solo voting without a VSP"
+"soloVotingWarning" = "Tickets bought without a VSP are only voted while this wallet is online, unlocked and synced with your own dcrd node through an RPC chain backend. A ticket that misses its vote is revoked and its reward is lost. Solo voting applies the next time the wallet is opened."
+"soloVotingPending" = "Reopen the wallet to apply"
+"soloVoter" = "Solo voter"
+"soloVoterRunning" = "The solo voter keeps this wallet unlocked to sign votes. Stop the voter to lock the wallet."
+"soloVoterStopped" = "Solo voter stopped: %v"
+"walletKeptUnlocked" = "%s is still unlocked: the solo voter is running"
+"anyOutcome" = "Any outcome"
+"undecided" = "Undecided"
 `
@@ -230,6 +230,7 @@ const (
 	StrConfirmToCreateAccs                   = "confirmtoCreateAccs"
 	StrConfirmToMixAccount                   = "confirmToMixAcc"
 	StrConfirmToRemove                       = "confirmToRemove"
+	StrConfirmToSave                         = "confirmToSave"
 	StrConfirmToSetMixer                     = "confirmToSetMixer"
 	StrConfirmToSign                         = "confirmToSign"
 	StrConfirmToVerifySeed                   = "confirmToVerifySeed"
@@ -1058,4 +1059,119 @@ const (
 	StrRateKucoinWarning                     = "rateKucoinWarning"
 	StrRestrictedDetail                      = "restrictDetail"
 	StrRateUnavailable                       = "rateUnavailable"
+	StrProposalComments                      = "proposalComments"
+	StrNoComments                            = "noComments"
+	StrCommentAuthor                         = "commentAuthor"
+	StrCommentDeleted                        = "commentDeleted"
+	StrCommentScore                          = "commentScore"
+	StrAuthorProposals                       = "authorProposals"
+	StrProposalVoteReminder                  = "proposalVoteReminder"
+	StrUseSeedPassphrase                     = "useSeedPassphrase"
+	StrSeedPassphrase                        = "seedPassphrase"
+	StrSeedPassphraseInfo                    = "seedPassphraseInfo"
+	StrTaprootAccount                        = "taprootAccount"
+	StrScanLegacyKeyScopes                   = "scanLegacyKeyScopes"
+	StrScanLegacyKeyScopesInfo               = "scanLegacyKeyScopesInfo"
+	StrBackupAsSeedShares                    = "backupAsSeedShares"
+	StrSeedShares                            = "seedShares"
+	StrSeedSharesInfo                        = "seedSharesInfo"
+	StrSharesRequired                        = "sharesRequired"
+	StrTotalShares                           = "totalShares"
+	StrUseShareGroups                        = "useShareGroups"
+	StrShareGroups                           = "shareGroups"
+	StrGroupsRequired                        = "groupsRequired"
+	StrInvalidShareGroups                    = "invalidShareGroups"
+	StrGenerateShares                        = "generateShares"
+	StrShareXofY                             = "shareXofY"
+	StrShareGroupX                           = "shareGroupX"
+	StrWroteShare                            = "wroteShare"
+	StrEnterShareToVerify                    = "enterShareToVerify"
+	StrShareMismatch                         = "shareMismatch"
+	StrRestoreFromShares                     = "restoreFromShares"
+	StrEnterSeedShares                       = "enterSeedShares"
+	StrAddressBook                           = "addressBook"
+	StrContactName                           = "contactName"
+	StrSaveContact                           = "saveContact"
+	StrNoContacts                            = "noContacts"
+	StrEditTxLabel                           = "editTxLabel"
+	StrMinAmount                             = "minAmount"
+	StrMaxAmount                             = "maxAmount"
+	StrFromDate                              = "fromDate"
+	StrToDate                                = "toDate"
+	StrAnyStatus                             = "anyStatus"
+	StrConfirming                            = "confirming"
+	StrInvalidDate                           = "invalidDate"
+	StrEditUTXOLabel                         = "editUTXOLabel"
+	StrCoinSelectionStrategy                 = "coinSelectionStrategy"
+	StrLargestFirst                          = "largestFirst"
+	StrOldestFirst                           = "oldestFirst"
+	StrAvoidChange                           = "avoidChange"
+	StrPrivacyFirst                          = "privacyFirst"
+	StrDustThreshold                         = "dustThreshold"
+	StrDustThresholdHint                     = "dustThresholdHint"
+	StrInvalidDustThreshold                  = "invalidDustThreshold"
+	StrAutoFreezeDust                        = "autoFreezeDust"
+	StrDust                                  = "dust"
+	StrDustReceived                          = "dustReceived"
+	StrDustFrozen                            = "dustFrozen"
+	StrConsolidateUTXOs                      = "consolidateUTXOs"
+	StrConsolidationInfo                     = "consolidationInfo"
+	StrConsolidationFee                      = "consolidationFee"
+	StrFeeSavings                            = "feeSavings"
+	StrSelectTwoUTXOs                        = "selectTwoUTXOs"
+	StrConsolidate                           = "consolidate"
+	StrUTXOsConsolidated                     = "utxosConsolidated"
+	StrChainBackend                          = "chainBackend"
+	StrSPV                                   = "spv"
+	StrSyncWithFullNode                      = "syncWithFullNode"
+	StrChainBackendInfo                      = "chainBackendInfo"
+	StrRPCHost                               = "rpcHost"
+	StrRPCUser                               = "rpcUser"
+	StrRPCPassword                           = "rpcPassword"
+	StrRPCCertPath                           = "rpcCertPath"
+	StrChainBackendUpdated                   = "chainBackendUpdated"
+	StrUnlockRPCPasswordInfo                 = "unlockRPCPasswordInfo"
+	StrEnterRPCPasswordInfo                  = "enterRPCPasswordInfo"
+	StrElectrum                              = "electrum"
+	StrSyncWithElectrum                      = "syncWithElectrum"
+	StrElectrumServer                        = "electrumServer"
+	StrElectrumCertFingerprint               = "electrumCertFingerprint"
+	StrElectrumServerInfo                    = "electrumServerInfo"
+	StrPeerDiagnostics                       = "peerDiagnostics"
+	StrAddPeer                               = "addPeer"
+	StrBanPeer                               = "banPeer"
+	StrNoPeers                               = "noPeers"
+	StrServices                              = "services"
+	StrPing                                  = "ping"
+	StrBytesSentReceived                     = "bytesSentReceived"
+	StrBanScore                              = "banScore"
+	StrSyncHours                             = "syncHours"
+	StrAnyTime                               = "anyTime"
+	StrSyncHoursOvernight                    = "syncHoursOvernight"
+	StrSyncHoursEarlyMorning                 = "syncHoursEarlyMorning"
+	StrConcurrentSyncs                       = "concurrentSyncs"
+	StrOneAtATime                            = "oneAtATime"
+	StrTwoAtATime                            = "twoAtATime"
+	StrPauseSyncOnMetered                    = "pauseSyncOnMetered"
+	StrSyncPending                           = "syncPending"
+	StrSyncOutsideHours                      = "syncOutsideHours"
+	StrSyncMeteredConnection                 = "syncMeteredConnection"
+	StrDataUsage                             = "dataUsage"
+	StrDataUsageValue                        = "dataUsageValue"
+	StrRescanStart                           = "rescanStart"
+	StrRescanGapLimit                        = "rescanGapLimit"
+	StrRescanAccounts                        = "rescanAccounts"
+	StrRescanOptionsInfo                     = "rescanOptionsInfo"
+	StrRescanEstimate                        = "rescanEstimate"
+	StrInvalidRescanStart                    = "invalidRescanStart"
+	StrNoRescanAccounts                      = "noRescanAccounts"
+	StrSoloVoting                            = "soloVoting"
+	StrSoloVotingWarning                     = "soloVotingWarning"
+	StrSoloVotingPending                     = "soloVotingPending"
+	StrSoloVoter                             = "soloVoter"
+	StrSoloVoterRunning                      = "soloVoterRunning"
+	StrSoloVoterStopped                      = "soloVoterStopped"
+	StrWalletKeptUnlocked                    = "walletKeptUnlocked"
+	StrAnyOutcome                            = "anyOutcome"
+	StrUndecided                             = "undecided"
 )